@@ -0,0 +1,26 @@
+package v1
+
+type CreateAPIKeyRequest struct {
+	Name     string `json:"nome" validate:"required,max=100"`
+	Role     string `json:"papel" validate:"required,oneof=admin operator seller readonly"`
+	SellerID string `json:"vendedor_id" validate:"required_if=Role seller,omitempty,uuid"`
+}
+
+type APIKeyResponse struct {
+	ID         *string `json:"id"`
+	Name       *string `json:"nome"`
+	Role       *string `json:"papel"`
+	SellerID   *string `json:"vendedor_id"`
+	Prefix     *string `json:"prefixo"`
+	Key        *string `json:"chave,omitempty"`
+	CreatedBy  *string `json:"criado_por"`
+	CreatedAt  *string `json:"criado_em"`
+	LastUsedAt *string `json:"ultimo_uso_em"`
+	RevokedAt  *string `json:"revogada_em"`
+}
+
+type TokenResponse struct {
+	Token     *string `json:"token"`
+	TokenType *string `json:"tipo"`
+	ExpiresAt *string `json:"expira_em"`
+}
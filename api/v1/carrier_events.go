@@ -0,0 +1,37 @@
+package v1
+
+import "time"
+
+type CarrierEventRequest struct {
+	EventID      string    `json:"evento_id" validate:"required,max=100"`
+	Code         string    `json:"codigo" validate:"required,max=50"`
+	TrackingCode string    `json:"codigo_rastreio" validate:"required,max=13"`
+	OccurredAt   time.Time `json:"ocorrido_em" validate:"required"`
+	Description  string    `json:"descricao" validate:"max=500"`
+	Location     string    `json:"local" validate:"max=255"`
+}
+
+type CarrierEventResponse struct {
+	ID             *string `json:"id"`
+	PackageID      *string `json:"pacote_id"`
+	Duplicate      *bool   `json:"duplicado"`
+	MappedStatus   *string `json:"status_mapeado"`
+	PreviousStatus *string `json:"status_anterior"`
+	Status         *string `json:"status_atual"`
+}
+
+type CarrierEventMappingResponse struct {
+	Code        *string `json:"codigo"`
+	Status      *string `json:"status"`
+	Description *string `json:"descricao"`
+}
+
+type SaveCarrierEventMappingRequest struct {
+	Status      string `json:"status" validate:"omitempty,oneof=coletado enviado entregue extraviado"`
+	Description string `json:"descricao" validate:"required,max=255"`
+}
+
+type CarrierTokenResponse struct {
+	CarrierID *string `json:"transportadora_id"`
+	Token     *string `json:"token"`
+}
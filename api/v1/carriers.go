@@ -0,0 +1,104 @@
+package v1
+
+type CarrierRequest struct {
+	Name               string   `json:"nome" validate:"required,max=255"`
+	TrackingPrefix     string   `json:"prefixo_rastreio" validate:"required,len=2,alpha,uppercase,ne=OL"`
+	MaxWeightKg        *float64 `json:"peso_maximo_kg" validate:"omitempty,gt=0"`
+	MaxSideCm          *float64 `json:"lado_maximo_cm" validate:"omitempty,gt=0"`
+	MaxDimensionsSumCm *float64 `json:"soma_dimensoes_maxima_cm" validate:"omitempty,gt=0"`
+	CutoffTime         string   `json:"horario_corte" validate:"omitempty,datetime=15:04"`
+}
+
+type CarrierDetailResponse struct {
+	CarrierResponse
+	Coverage []CarrierCoverageResponse `json:"cobertura"`
+}
+
+type CreateCarrierCoverageRequest struct {
+	RegionID              string   `json:"regiao_id" validate:"required,uuid"`
+	OriginRegionID        string   `json:"regiao_origem_id" validate:"omitempty,uuid"`
+	EstimatedDeliveryDays int32    `json:"prazo_estimado_dias" validate:"required,gt=0"`
+	PricePerKg            float64  `json:"preco_por_kg" validate:"required,gt=0"`
+	MinCharge             float64  `json:"frete_minimo" validate:"gte=0"`
+	ExcessPricePerKg      *float64 `json:"excedente_por_kg" validate:"omitempty,gte=0"`
+	CubingDivisor         int32    `json:"divisor_cubagem" validate:"omitempty,gt=0"`
+	ValidFrom             string   `json:"valido_de" validate:"omitempty,date_or_time"`
+}
+
+type UpdateCarrierCoverageRequest struct {
+	EstimatedDeliveryDays int32    `json:"prazo_estimado_dias" validate:"required,gt=0"`
+	PricePerKg            float64  `json:"preco_por_kg" validate:"required,gt=0"`
+	MinCharge             float64  `json:"frete_minimo" validate:"gte=0"`
+	ExcessPricePerKg      *float64 `json:"excedente_por_kg" validate:"omitempty,gte=0"`
+	CubingDivisor         int32    `json:"divisor_cubagem" validate:"omitempty,gt=0"`
+	ValidFrom             string   `json:"valido_de" validate:"omitempty,date_or_time"`
+}
+
+type CarrierCoverageResponse struct {
+	ID                    *string `json:"id"`
+	RegionID              *string `json:"regiao_id"`
+	RegionName            *string `json:"regiao"`
+	OriginRegionID        *string `json:"regiao_origem_id"`
+	OriginRegionName      *string `json:"regiao_origem"`
+	EstimatedDeliveryDays *int32  `json:"prazo_estimado_dias"`
+	PricePerKg            *string `json:"preco_por_kg"`
+	MinCharge             *string `json:"frete_minimo"`
+	ExcessPricePerKg      *string `json:"excedente_por_kg"`
+	CubingDivisor         *int32  `json:"divisor_cubagem"`
+	ValidFrom             *string `json:"valido_de"`
+	ValidTo               *string `json:"valido_ate"`
+}
+
+type CarrierRatesQuery struct {
+	At string `form:"at" validate:"omitempty,date_or_time"`
+}
+
+type ImportCarrierRatesQuery struct {
+	DryRun    bool   `form:"simular"`
+	ValidFrom string `form:"valido_de" validate:"omitempty,date_or_time"`
+}
+
+type CarrierRateRow struct {
+	Region                string   `validate:"required_without=State,omitempty,max=50"`
+	State                 string   `validate:"omitempty,len=2,brazilian_state"`
+	MinWeightKg           *float64 `validate:"omitempty,gte=0"`
+	MaxWeightKg           float64  `validate:"required,gt=0"`
+	Price                 float64  `validate:"gte=0"`
+	EstimatedDeliveryDays int32    `validate:"required,gt=0"`
+	MinCharge             *float64 `validate:"omitempty,gte=0"`
+	ExcessPricePerKg      *float64 `validate:"omitempty,gte=0"`
+}
+
+type ImportCarrierRatesResponse struct {
+	DryRun  *bool                       `json:"simulacao"`
+	Applied *bool                       `json:"aplicada"`
+	Changes []CarrierRateChangeResponse `json:"alteracoes"`
+	Errors  []CarrierRateErrorResponse  `json:"erros"`
+}
+
+type CarrierRateErrorResponse struct {
+	Line   *int     `json:"linha"`
+	Errors []string `json:"erros"`
+}
+
+type CarrierRateChangeResponse struct {
+	RegionID   *string                   `json:"regiao_id"`
+	RegionName *string                   `json:"regiao"`
+	Status     *string                   `json:"status"`
+	Current    *CarrierRateSheetResponse `json:"atual"`
+	Proposed   *CarrierRateSheetResponse `json:"nova"`
+	VersionID  *string                   `json:"versao_id"`
+}
+
+type CarrierRateSheetResponse struct {
+	EstimatedDeliveryDays *int32                    `json:"prazo_estimado_dias"`
+	MinCharge             *float64                  `json:"frete_minimo"`
+	ExcessPricePerKg      *float64                  `json:"excedente_por_kg"`
+	Tiers                 []CarrierRateTierResponse `json:"faixas"`
+}
+
+type CarrierRateTierResponse struct {
+	MinWeightKg *float64 `json:"peso_min_kg"`
+	MaxWeightKg *float64 `json:"peso_max_kg"`
+	Price       *float64 `json:"preco"`
+}
@@ -1,50 +1,162 @@
 package v1
 
 type PackageResponse struct {
-	ID                *string  `json:"id"`
-	TrackingCode      *string  `json:"codigo_rastreio"`
-	Product           *string  `json:"produto"`
-	WeightKg          *float64 `json:"peso_kg"`
-	DestinationState  *string  `json:"estado_destino"`
-	Status            *string  `json:"status"`
-	HiredCarrierID    *string  `json:"transportadora_id"`
-	HiredPrice        *string  `json:"preco_contratado"`
-	HiredDeliveryDays *int32   `json:"prazo_contratado_dias"`
-	CreatedAt         *string  `json:"criado_em"`
-	UpdatedAt         *string  `json:"atualizado_em"`
+	ID                *string           `json:"id"`
+	TrackingCode      *string           `json:"codigo_rastreio"`
+	Product           *string           `json:"produto"`
+	WeightKg          *float64          `json:"peso_kg"`
+	DestinationState  *string           `json:"estado_destino"`
+	Status            *string           `json:"status"`
+	HiredCarrierID    *string           `json:"transportadora_id"`
+	HiredPrice        *string           `json:"preco_contratado"`
+	HiredDeliveryDays *int32            `json:"prazo_contratado_dias"`
+	HeightCm          *float64          `json:"altura_cm"`
+	WidthCm           *float64          `json:"largura_cm"`
+	LengthCm          *float64          `json:"comprimento_cm"`
+	Category          *string           `json:"categoria"`
+	HiredAt           *string           `json:"contratado_em"`
+	EstimatedDelivery *string           `json:"data_estimada_entrega"`
+	OriginWarehouseID *string           `json:"armazem_origem_id"`
+	DestinationCEP    *string           `json:"cep_destino"`
+	DestinationCity   *string           `json:"cidade_destino"`
+	SellerID          *string           `json:"vendedor_id"`
+	Recipient         *AddressResponse  `json:"destinatario"`
+	Sender            *AddressResponse  `json:"remetente"`
+	Version           *int32            `json:"versao"`
+	Deletion          *DeletionResponse `json:"exclusao"`
+	CreatedAt         *string           `json:"criado_em"`
+	UpdatedAt         *string           `json:"atualizado_em"`
+}
+
+// DeletionResponse is only set on deleted packages, listed with excluidos=true.
+type DeletionResponse struct {
+	DeletedAt *string `json:"excluido_em"`
+	DeletedBy *string `json:"excluido_por"`
+	Reason    *string `json:"motivo"`
+}
+
+type AddressResponse struct {
+	Name         *string `json:"nome"`
+	Document     *string `json:"documento"`
+	Phone        *string `json:"telefone"`
+	Street       *string `json:"logradouro"`
+	Number       *string `json:"numero"`
+	Complement   *string `json:"complemento"`
+	Neighborhood *string `json:"bairro"`
+	City         *string `json:"cidade"`
+	State        *string `json:"estado"`
+	CEP          *string `json:"cep"`
 }
 
 type CreatePackageRequest struct {
-	Product          string  `json:"produto" validate:"required"`
-	WeightKg         float64 `json:"peso_kg" validate:"required,gt=0"`
-	DestinationState string  `json:"estado_destino" validate:"required,len=2,brazilian_state"`
+	Product          string          `json:"produto" validate:"required"`
+	WeightKg         float64         `json:"peso_kg" validate:"required,gt=0"`
+	DestinationState string          `json:"estado_destino" validate:"required_without_all=DestinationCEP Recipient,omitempty,len=2,brazilian_state"`
+	DestinationCEP   string          `json:"cep_destino" validate:"omitempty,cep"`
+	HeightCm         float64         `json:"altura_cm" validate:"omitempty,gt=0,required_with=WidthCm LengthCm"`
+	WidthCm          float64         `json:"largura_cm" validate:"omitempty,gt=0,required_with=HeightCm LengthCm"`
+	LengthCm         float64         `json:"comprimento_cm" validate:"omitempty,gt=0,required_with=HeightCm WidthCm"`
+	Category         string          `json:"categoria" validate:"omitempty,oneof=geral fragil liquido bateria"`
+	OriginWarehouse  string          `json:"armazem_origem_id" validate:"omitempty,uuid"`
+	SellerID         string          `json:"vendedor_id" validate:"omitempty,uuid"`
+	Recipient        *AddressRequest `json:"destinatario"`
+	Sender           *AddressRequest `json:"remetente"`
+}
+
+// AddressRequest.Document is a CPF or CNPJ; Number accepts "S/N".
+type AddressRequest struct {
+	Name         string `json:"nome" validate:"required,max=255"`
+	Document     string `json:"documento" validate:"required,cpf_cnpj"`
+	Phone        string `json:"telefone" validate:"omitempty,br_phone"`
+	Street       string `json:"logradouro" validate:"required,max=255"`
+	Number       string `json:"numero" validate:"required,max=20"`
+	Complement   string `json:"complemento" validate:"max=100"`
+	Neighborhood string `json:"bairro" validate:"required,max=100"`
+	City         string `json:"cidade" validate:"required,max=100"`
+	State        string `json:"estado" validate:"required,len=2,brazilian_state"`
+	CEP          string `json:"cep" validate:"required,cep"`
+}
+
+type ListPackagesQuery struct {
+	Status           string `form:"status" validate:"omitempty,oneof=criado esperando_coleta coletado enviado entregue extraviado"`
+	DestinationState string `form:"estado_destino" validate:"omitempty,len=2,brazilian_state"`
+	HiredCarrierID   string `form:"transportadora_id" validate:"omitempty,uuid"`
+	CreatedFrom      string `form:"criado_de" validate:"omitempty,datetime=2006-01-02"`
+	CreatedTo        string `form:"criado_ate" validate:"omitempty,datetime=2006-01-02"`
+	Search           string `form:"busca" validate:"omitempty,max=255"`
+	SortBy           string `form:"ordenar_por" validate:"omitempty,oneof=criado_em peso_kg"`
+	Order            string `form:"ordem" validate:"omitempty,oneof=asc desc"`
+	Cursor           string `form:"cursor"`
+	Limit            int32  `form:"limite" validate:"omitempty,min=1,max=100"`
+	Deleted          bool   `form:"excluidos"`
+}
+
+type DeletePackageQuery struct {
+	Reason string `form:"motivo" validate:"max=500"`
 }
 
 type UpdatePackageStatusRequest struct {
 	Status string `json:"status" validate:"required,oneof=criado esperando_coleta coletado enviado entregue extraviado"`
+	Reason string `json:"motivo" validate:"max=500"`
+}
+
+type PackageStatusEventResponse struct {
+	ID         *string `json:"id"`
+	FromStatus *string `json:"status_anterior"`
+	ToStatus   *string `json:"status_novo"`
+	Actor      *string `json:"ator"`
+	Reason     *string `json:"motivo"`
+	CreatedAt  *string `json:"criado_em"`
 }
 
 type HireCarrierRequest struct {
-	CarrierID    string `json:"transportadora_id" validate:"required,uuid"`
-	Price        string `json:"preco" validate:"required"`
-	DeliveryDays int32  `json:"prazo_dias" validate:"required,gt=0"`
+	QuoteID string `json:"cotacao_id" validate:"required,uuid"`
 }
 
 type QuoteResponse struct {
+	ID                    *string  `json:"id"`
+	ExpiresAt             *string  `json:"expira_em"`
+	CarrierID             *string  `json:"transportadora_id"`
 	CarrierName           *string  `json:"transportadora"`
+	BillableWeightKg      *float64 `json:"peso_taxado_kg"`
 	EstimatedPrice        *float64 `json:"preco_estimado"`
 	EstimatedDeliveryDays *int32   `json:"prazo_estimado_dias"`
+	EstimatedDeliveryDate *string  `json:"data_estimada_entrega"`
+	CEPSurcharge          *float64 `json:"sobretaxa_cep"`
+}
+
+type QuotesResponse struct {
+	Quotes      []QuoteResponse              `json:"cotacoes"`
+	Unavailable []UnavailableCarrierResponse `json:"transportadoras_indisponiveis"`
+}
+
+type UnavailableCarrierResponse struct {
+	CarrierID   *string  `json:"transportadora_id"`
+	CarrierName *string  `json:"transportadora"`
+	Reasons     []string `json:"motivos"`
 }
 
 type GetQuotesQuery struct {
-	StateCode string  `form:"estado_destino" validate:"required,len=2,brazilian_state"`
+	StateCode string  `form:"estado_destino" validate:"required_without=CEP,omitempty,len=2,brazilian_state"`
+	CEP       string  `form:"cep_destino" validate:"omitempty,cep"`
 	WeightKg  float64 `form:"peso_kg" validate:"required,gt=0"`
+	HeightCm  float64 `form:"altura_cm" validate:"omitempty,gt=0,required_with=WidthCm LengthCm"`
+	WidthCm   float64 `form:"largura_cm" validate:"omitempty,gt=0,required_with=HeightCm LengthCm"`
+	LengthCm  float64 `form:"comprimento_cm" validate:"omitempty,gt=0,required_with=HeightCm WidthCm"`
+	Category  string  `form:"categoria" validate:"omitempty,oneof=geral fragil liquido bateria"`
+	Origin    string  `form:"armazem_origem_id" validate:"omitempty,uuid"`
+	SellerID  string  `form:"vendedor_id" validate:"omitempty,uuid"`
 }
 
 type CarrierResponse struct {
-	ID        *string `json:"id"`
-	Name      *string `json:"nome"`
-	CreatedAt *string `json:"criado_em"`
+	ID                 *string  `json:"id"`
+	Name               *string  `json:"nome"`
+	TrackingPrefix     *string  `json:"prefixo_rastreio"`
+	MaxWeightKg        *float64 `json:"peso_maximo_kg"`
+	MaxSideCm          *float64 `json:"lado_maximo_cm"`
+	MaxDimensionsSumCm *float64 `json:"soma_dimensoes_maxima_cm"`
+	CutoffTime         *string  `json:"horario_corte"`
+	CreatedAt          *string  `json:"criado_em"`
 }
 
 type StateResponse struct {
@@ -52,3 +164,45 @@ type StateResponse struct {
 	Name       *string `json:"nome"`
 	RegionName *string `json:"nome_regiao"`
 }
+
+type RegionResponse struct {
+	ID   *string `json:"id"`
+	Name *string `json:"nome"`
+}
+
+type WarehouseResponse struct {
+	ID        *string `json:"id"`
+	Name      *string `json:"nome"`
+	StateCode *string `json:"estado"`
+	CreatedAt *string `json:"criado_em"`
+}
+
+type CreateWarehouseRequest struct {
+	Name      string `json:"nome" validate:"required,max=255"`
+	StateCode string `json:"estado" validate:"required,len=2,brazilian_state"`
+}
+
+type ImportPackagesQuery struct {
+	Format   string `form:"formato" validate:"omitempty,oneof=csv ndjson"`
+	Mode     string `form:"modo" validate:"omitempty,oneof=parcial tudo_ou_nada"`
+	SellerID string `form:"vendedor_id" validate:"omitempty,uuid"`
+}
+
+type ImportPackagesResponse struct {
+	Mode     *string             `json:"modo"`
+	Total    *int                `json:"total"`
+	Imported *int                `json:"importados"`
+	Failed   *int                `json:"com_erro"`
+	Rows     []ImportRowResponse `json:"linhas"`
+}
+
+type ImportRowResponse struct {
+	Line      *int     `json:"linha"`
+	Status    *string  `json:"status"`
+	PackageID *string  `json:"pacote_id"`
+	Errors    []string `json:"erros"`
+}
+
+type LabelQuery struct {
+	Format string `form:"format" validate:"omitempty,oneof=pdf zpl"`
+}
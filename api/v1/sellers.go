@@ -0,0 +1,25 @@
+package v1
+
+type CreateSellerRequest struct {
+	Name string `json:"nome" validate:"required,max=255"`
+}
+
+type SellerResponse struct {
+	ID        *string `json:"id"`
+	Name      *string `json:"nome"`
+	CreatedAt *string `json:"criado_em"`
+}
+
+type SaveSellerRateRequest struct {
+	PricePerKg    *float64 `json:"preco_por_kg" validate:"omitempty,gt=0"`
+	MarkupPercent float64  `json:"acrescimo_percentual" validate:"gt=-100,lte=1000"`
+}
+
+type SellerRateResponse struct {
+	SellerID      *string `json:"vendedor_id"`
+	CarrierID     *string `json:"transportadora_id"`
+	PricePerKg    *string `json:"preco_por_kg"`
+	MarkupPercent *string `json:"acrescimo_percentual"`
+	CreatedAt     *string `json:"criado_em"`
+	UpdatedAt     *string `json:"atualizado_em"`
+}
@@ -0,0 +1,19 @@
+package v1
+
+type TrackingResponse struct {
+	TrackingCode      *string                 `json:"codigo_rastreio"`
+	Status            *string                 `json:"status"`
+	CarrierName       *string                 `json:"transportadora"`
+	DestinationState  *string                 `json:"estado_destino"`
+	DestinationCity   *string                 `json:"cidade_destino"`
+	EstimatedDelivery *string                 `json:"data_estimada_entrega"`
+	Events            []TrackingEventResponse `json:"eventos"`
+}
+
+type TrackingEventResponse struct {
+	Type        *string `json:"tipo"`
+	Status      *string `json:"status"`
+	Description *string `json:"descricao"`
+	Location    *string `json:"local"`
+	OccurredAt  *string `json:"ocorrido_em"`
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"errors"
 	"net/http"
+	"reflect"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -16,6 +17,14 @@ type Response struct {
 	Data    interface{} `json:"data"`
 }
 
+type PaginatedResponse struct {
+	Code       int         `json:"code"`
+	Message    string      `json:"message"`
+	Data       interface{} `json:"data"`
+	NextCursor *string     `json:"next_cursor"`
+	Total      int64       `json:"total"`
+}
+
 func HandleSuccess(ctx *gin.Context, data interface{}) {
 	if data == nil {
 		data = map[string]interface{}{}
@@ -28,6 +37,22 @@ func HandleSuccess(ctx *gin.Context, data interface{}) {
 	ctx.JSON(http.StatusOK, resp)
 }
 
+func HandlePaginated(ctx *gin.Context, data interface{}, nextCursor string, total int64) {
+	if data == nil {
+		data = []interface{}{}
+	}
+	resp := PaginatedResponse{
+		Code:    0,
+		Message: "success",
+		Data:    data,
+		Total:   total,
+	}
+	if nextCursor != "" {
+		resp.NextCursor = &nextCursor
+	}
+	ctx.JSON(http.StatusOK, resp)
+}
+
 func HandleCreated(ctx *gin.Context, data interface{}) {
 	if data == nil {
 		data = map[string]interface{}{}
@@ -56,34 +81,36 @@ func HandleBadRequest(ctx *gin.Context, message string) {
 	HandleError(ctx, http.StatusBadRequest, message, nil)
 }
 
+func HandleUnauthorized(ctx *gin.Context, message string) {
+	HandleError(ctx, http.StatusUnauthorized, message, nil)
+}
+
+func HandleForbidden(ctx *gin.Context, message string) {
+	HandleError(ctx, http.StatusForbidden, message, nil)
+}
+
 func HandleNotFound(ctx *gin.Context, message string) {
 	HandleError(ctx, http.StatusNotFound, message, nil)
 }
 
+func HandleConflict(ctx *gin.Context, message string) {
+	HandleError(ctx, http.StatusConflict, message, nil)
+}
+
+func HandlePreconditionFailed(ctx *gin.Context, message string) {
+	HandleError(ctx, http.StatusPreconditionFailed, message, nil)
+}
+
+func HandleUnprocessableEntity(ctx *gin.Context, message string) {
+	HandleError(ctx, http.StatusUnprocessableEntity, message, nil)
+}
+
 func HandleInternalError(ctx *gin.Context, message string) {
 	HandleError(ctx, http.StatusInternalServerError, message, nil)
 }
 
 func HandleValidationError(ctx *gin.Context, err error) {
-	var validationErrors validator.ValidationErrors
-	if errors.As(err, &validationErrors) {
-		var messages []string
-		for _, ve := range validationErrors {
-			switch ve.Tag() {
-			case "required":
-				messages = append(messages, ve.Field()+" é obrigatório")
-			case "gt":
-				messages = append(messages, ve.Field()+" deve ser maior que "+ve.Param())
-			case "len":
-				messages = append(messages, ve.Field()+" deve ter "+ve.Param()+" caracteres")
-			case "brazilian_state":
-				messages = append(messages, ve.Field()+" deve ser um estado brasileiro válido (SC, PR, RS, SP, RJ, MG, GO)")
-			case "uuid":
-				messages = append(messages, ve.Field()+" deve ser um UUID válido")
-			default:
-				messages = append(messages, ve.Field()+" é inválido")
-			}
-		}
+	if messages := ValidationMessages(err); messages != nil {
 		HandleError(ctx, http.StatusBadRequest, strings.Join(messages, ", "), nil)
 		return
 	}
@@ -91,6 +118,61 @@ func HandleValidationError(ctx *gin.Context, err error) {
 	HandleError(ctx, http.StatusBadRequest, err.Error(), nil)
 }
 
+// ValidationMessages returns nil when err is not a validation error.
+func ValidationMessages(err error) []string {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return nil
+	}
+
+	var messages []string
+	for _, ve := range validationErrors {
+		switch ve.Tag() {
+		case "required":
+			messages = append(messages, ve.Field()+" é obrigatório")
+		case "required_with":
+			messages = append(messages, ve.Field()+" é obrigatório quando "+ve.Param()+" é informado")
+		case "gt":
+			messages = append(messages, ve.Field()+" deve ser maior que "+ve.Param())
+		case "len":
+			messages = append(messages, ve.Field()+" deve ter "+ve.Param()+" caracteres")
+		case "brazilian_state":
+			messages = append(messages, ve.Field()+" deve ser um estado brasileiro válido (SC, PR, RS, SP, RJ, MG, GO)")
+		case "cep":
+			messages = append(messages, ve.Field()+" deve ser um CEP válido (00000-000)")
+		case "required_without":
+			messages = append(messages, ve.Field()+" é obrigatório quando "+ve.Param()+" não é informado")
+		case "required_without_all":
+			messages = append(messages, ve.Field()+" é obrigatório quando nenhum de "+ve.Param()+" é informado")
+		case "cpf_cnpj":
+			messages = append(messages, ve.Field()+" deve ser um CPF ou CNPJ válido")
+		case "br_phone":
+			messages = append(messages, ve.Field()+" deve ser um telefone válido com DDD")
+		case "uuid":
+			messages = append(messages, ve.Field()+" deve ser um UUID válido")
+		case "min":
+			if ve.Kind() == reflect.String {
+				messages = append(messages, ve.Field()+" deve ter no mínimo "+ve.Param()+" caracteres")
+			} else {
+				messages = append(messages, ve.Field()+" deve ser no mínimo "+ve.Param())
+			}
+		case "max":
+			if ve.Kind() == reflect.String {
+				messages = append(messages, ve.Field()+" deve ter no máximo "+ve.Param()+" caracteres")
+			} else {
+				messages = append(messages, ve.Field()+" deve ser no máximo "+ve.Param())
+			}
+		case "oneof":
+			messages = append(messages, ve.Field()+" deve ser um dos valores: "+ve.Param())
+		case "datetime":
+			messages = append(messages, ve.Field()+" deve estar no formato "+ve.Param())
+		default:
+			messages = append(messages, ve.Field()+" é inválido")
+		}
+	}
+	return messages
+}
+
 func HandleDatabaseError(ctx *gin.Context, err error, message string) {
 	if errors.Is(err, sql.ErrNoRows) {
 		HandleNotFound(ctx, message)
@@ -0,0 +1,36 @@
+package v1
+
+import "encoding/json"
+
+type WebhookResponse struct {
+	ID         *string  `json:"id"`
+	URL        *string  `json:"url"`
+	EventTypes []string `json:"eventos"`
+	Secret     *string  `json:"segredo,omitempty"`
+	CreatedAt  *string  `json:"criado_em"`
+}
+
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,http_url,max=2048"`
+	Secret     string   `json:"segredo" validate:"omitempty,min=16,max=255"`
+	EventTypes []string `json:"eventos" validate:"required,min=1,dive,oneof=package.created package.status_changed package.carrier_hired package.deleted package.restored"`
+}
+
+type ListWebhookDeliveriesQuery struct {
+	Limit int32 `form:"limite" validate:"omitempty,min=1,max=100"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID                 *string         `json:"id"`
+	SubscriptionID     *string         `json:"assinatura_id"`
+	EventID            *string         `json:"evento_id"`
+	EventType          *string         `json:"evento"`
+	Status             *string         `json:"status"`
+	Attempts           *int32          `json:"tentativas"`
+	NextAttemptAt      *string         `json:"proxima_tentativa_em"`
+	LastResponseStatus *int32          `json:"ultimo_status_http"`
+	LastError          *string         `json:"ultimo_erro"`
+	DeliveredAt        *string         `json:"entregue_em"`
+	CreatedAt          *string         `json:"criado_em"`
+	Payload            json.RawMessage `json:"payload" swaggertype:"object"`
+}
@@ -27,7 +27,7 @@ func main() {
 
 	log.Print("connection is repository establish")
 
-	store := repository.New(conn.DB())
+	store := repository.NewStore(conn.DB().DB)
 
 	logger, err := zap.NewProduction()
 	if err != nil {
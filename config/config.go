@@ -2,18 +2,50 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
+const DefaultQuoteTTL = 30 * time.Minute
+
+const DefaultLabelSenderName = "Olist"
+
+const (
+	DefaultWebhookPollInterval = 5 * time.Second
+	DefaultWebhookTimeout      = 10 * time.Second
+	DefaultWebhookMaxAttempts  = 8
+)
+
+const DefaultAuthTokenTTL = time.Hour
+
+const DefaultIdempotencyTTL = 24 * time.Hour
+
 type Config struct {
-	DBSource          string `mapstructure:"DB_SOURCE"`
-	HTTPServerAddress string `mapstructure:"HTTP_SERVER_ADDRESS"`
+	DBSource            string        `mapstructure:"DB_SOURCE"`
+	HTTPServerAddress   string        `mapstructure:"HTTP_SERVER_ADDRESS"`
+	QuoteTTL            time.Duration `mapstructure:"QUOTE_TTL"`
+	LabelSenderName     string        `mapstructure:"LABEL_SENDER_NAME"`
+	WebhookPollInterval time.Duration `mapstructure:"WEBHOOK_POLL_INTERVAL"`
+	WebhookTimeout      time.Duration `mapstructure:"WEBHOOK_TIMEOUT"`
+	WebhookMaxAttempts  int           `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+	AuthJWTSecret       string        `mapstructure:"AUTH_JWT_SECRET"`
+	AuthTokenTTL        time.Duration `mapstructure:"AUTH_TOKEN_TTL"`
+	AuthBootstrapKey    string        `mapstructure:"AUTH_BOOTSTRAP_KEY"`
+	IdempotencyTTL      time.Duration `mapstructure:"IDEMPOTENCY_TTL"`
 }
 
 func LoadConfig(path string) (config Config, err error) {
 	// Set defaults
 	config.HTTPServerAddress = "0.0.0.0:8080"
+	config.QuoteTTL = DefaultQuoteTTL
+	config.LabelSenderName = DefaultLabelSenderName
+	config.WebhookPollInterval = DefaultWebhookPollInterval
+	config.WebhookTimeout = DefaultWebhookTimeout
+	config.WebhookMaxAttempts = DefaultWebhookMaxAttempts
+	config.AuthTokenTTL = DefaultAuthTokenTTL
+	config.IdempotencyTTL = DefaultIdempotencyTTL
 
 	viper.AddConfigPath(path)
 	viper.SetConfigType("env")
@@ -30,6 +62,54 @@ func LoadConfig(path string) (config Config, err error) {
 		config.DBSource = dbSource
 	}
 
+	if quoteTTL := os.Getenv("QUOTE_TTL"); quoteTTL != "" {
+		if ttl, err := time.ParseDuration(quoteTTL); err == nil {
+			config.QuoteTTL = ttl
+		}
+	}
+
+	if senderName := os.Getenv("LABEL_SENDER_NAME"); senderName != "" {
+		config.LabelSenderName = senderName
+	}
+
+	if interval := os.Getenv("WEBHOOK_POLL_INTERVAL"); interval != "" {
+		if d, err := time.ParseDuration(interval); err == nil {
+			config.WebhookPollInterval = d
+		}
+	}
+
+	if timeout := os.Getenv("WEBHOOK_TIMEOUT"); timeout != "" {
+		if d, err := time.ParseDuration(timeout); err == nil {
+			config.WebhookTimeout = d
+		}
+	}
+
+	if maxAttempts := os.Getenv("WEBHOOK_MAX_ATTEMPTS"); maxAttempts != "" {
+		if n, err := strconv.Atoi(maxAttempts); err == nil && n > 0 {
+			config.WebhookMaxAttempts = n
+		}
+	}
+
+	if secret := os.Getenv("AUTH_JWT_SECRET"); secret != "" {
+		config.AuthJWTSecret = secret
+	}
+
+	if ttl := os.Getenv("AUTH_TOKEN_TTL"); ttl != "" {
+		if d, err := time.ParseDuration(ttl); err == nil {
+			config.AuthTokenTTL = d
+		}
+	}
+
+	if bootstrapKey := os.Getenv("AUTH_BOOTSTRAP_KEY"); bootstrapKey != "" {
+		config.AuthBootstrapKey = bootstrapKey
+	}
+
+	if ttl := os.Getenv("IDEMPOTENCY_TTL"); ttl != "" {
+		if d, err := time.ParseDuration(ttl); err == nil && d > 0 {
+			config.IdempotencyTTL = d
+		}
+	}
+
 	if port := os.Getenv("PORT"); port != "" {
 		config.HTTPServerAddress = "0.0.0.0:" + port
 	}
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_package_status_events_package;
+
+DROP TABLE IF EXISTS package_status_events;
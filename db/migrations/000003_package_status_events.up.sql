@@ -0,0 +1,13 @@
+-- Table Package Status Events
+CREATE TABLE package_status_events (
+                                       id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                       package_id UUID NOT NULL,
+                                       from_status VARCHAR(50) NOT NULL,
+                                       to_status VARCHAR(50) NOT NULL,
+                                       actor VARCHAR(255) NOT NULL,
+                                       reason TEXT,
+                                       created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                       CONSTRAINT fk_package FOREIGN KEY (package_id) REFERENCES packages(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_package_status_events_package ON package_status_events(package_id, created_at);
@@ -0,0 +1,7 @@
+ALTER TABLE carrier_regions
+    DROP COLUMN IF EXISTS cubing_divisor;
+
+ALTER TABLE packages
+    DROP COLUMN IF EXISTS height_cm,
+    DROP COLUMN IF EXISTS width_cm,
+    DROP COLUMN IF EXISTS length_cm;
@@ -0,0 +1,14 @@
+-- Package dimensions (cm), used to compute the volumetric weight
+ALTER TABLE packages
+    ADD COLUMN height_cm FLOAT CHECK (height_cm > 0),
+    ADD COLUMN width_cm FLOAT CHECK (width_cm > 0),
+    ADD COLUMN length_cm FLOAT CHECK (length_cm > 0);
+
+-- Cubing divisor per carrier region: volumetric weight (kg) = height x width x length (cm) / divisor
+ALTER TABLE carrier_regions
+    ADD COLUMN cubing_divisor INT NOT NULL DEFAULT 6000 CHECK (cubing_divisor > 0);
+
+-- RotaFácil uses the road freight divisor
+UPDATE carrier_regions
+SET cubing_divisor = 5000
+WHERE carrier_id = '660e8400-e29b-41d4-a716-446655440002';
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS carrier_rate_tiers;
+
+ALTER TABLE carrier_regions
+    DROP COLUMN IF EXISTS excess_price_per_kg,
+    DROP COLUMN IF EXISTS min_charge;
@@ -0,0 +1,40 @@
+-- Excess price per kg above the last weight band and minimum charge per shipment
+ALTER TABLE carrier_regions
+    ADD COLUMN excess_price_per_kg DECIMAL(10,2) CHECK (excess_price_per_kg >= 0),
+    ADD COLUMN min_charge DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (min_charge >= 0);
+
+-- Table Carrier Rate Tiers: fixed price per weight band (min_weight_kg, max_weight_kg]
+CREATE TABLE carrier_rate_tiers (
+                                    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                    carrier_region_id UUID NOT NULL,
+                                    min_weight_kg DECIMAL(10,3) NOT NULL CHECK (min_weight_kg >= 0),
+                                    max_weight_kg DECIMAL(10,3) NOT NULL,
+                                    price DECIMAL(10,2) NOT NULL CHECK (price >= 0),
+                                    created_at TIMESTAMP DEFAULT NOW(),
+                                    CONSTRAINT fk_carrier_region FOREIGN KEY (carrier_region_id) REFERENCES carrier_regions(id) ON DELETE CASCADE,
+                                    CONSTRAINT check_weight_band CHECK (max_weight_kg > min_weight_kg),
+                                    UNIQUE(carrier_region_id, min_weight_kg)
+);
+
+CREATE INDEX idx_carrier_rate_tiers_region ON carrier_rate_tiers(carrier_region_id, max_weight_kg);
+
+-- Moventra publishes its rates by weight band
+UPDATE carrier_regions
+SET excess_price_per_kg = CASE region_id
+                              WHEN '550e8400-e29b-41d4-a716-446655440003' THEN 6.50
+                              ELSE 8.50
+    END,
+    min_charge = 15.00
+WHERE carrier_id = '660e8400-e29b-41d4-a716-446655440003';
+
+INSERT INTO carrier_rate_tiers (carrier_region_id, min_weight_kg, max_weight_kg, price)
+SELECT cr.id, t.min_weight_kg, t.max_weight_kg,
+       CASE cr.region_id
+           WHEN '550e8400-e29b-41d4-a716-446655440003' THEN t.price_center_west
+           ELSE t.price_northeast
+           END
+FROM carrier_regions cr
+         CROSS JOIN (VALUES (0, 1, 18.90, 22.90),
+                            (1, 5, 39.90, 49.90),
+                            (5, 30, 149.90, 189.90)) AS t(min_weight_kg, max_weight_kg, price_center_west, price_northeast)
+WHERE cr.carrier_id = '660e8400-e29b-41d4-a716-446655440003';
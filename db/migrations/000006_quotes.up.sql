@@ -0,0 +1,16 @@
+-- Table Quotes: persisted quotes that a package can be hired with until they expire
+CREATE TABLE quotes (
+                        id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                        package_id UUID NOT NULL,
+                        carrier_id UUID NOT NULL,
+                        price DECIMAL(10,2) NOT NULL,
+                        estimated_delivery_days INT NOT NULL,
+                        billable_weight_kg FLOAT NOT NULL,
+                        expires_at TIMESTAMP NOT NULL,
+                        used_at TIMESTAMP,
+                        created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                        CONSTRAINT fk_package FOREIGN KEY (package_id) REFERENCES packages(id) ON DELETE CASCADE,
+                        CONSTRAINT fk_carrier FOREIGN KEY (carrier_id) REFERENCES carriers(id) ON DELETE CASCADE
+);
+
+CREATE INDEX idx_quotes_package ON quotes(package_id, created_at);
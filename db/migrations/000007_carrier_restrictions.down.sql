@@ -0,0 +1,10 @@
+DROP TABLE IF EXISTS carrier_restricted_categories;
+
+ALTER TABLE packages
+    DROP CONSTRAINT IF EXISTS check_product_category,
+    DROP COLUMN IF EXISTS product_category;
+
+ALTER TABLE carriers
+    DROP COLUMN IF EXISTS max_weight_kg,
+    DROP COLUMN IF EXISTS max_side_cm,
+    DROP COLUMN IF EXISTS max_dimensions_sum_cm;
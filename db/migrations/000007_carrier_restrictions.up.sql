@@ -0,0 +1,34 @@
+-- Carrier capabilities: NULL means no limit
+ALTER TABLE carriers
+    ADD COLUMN max_weight_kg FLOAT CHECK (max_weight_kg > 0),
+    ADD COLUMN max_side_cm FLOAT CHECK (max_side_cm > 0),
+    ADD COLUMN max_dimensions_sum_cm FLOAT CHECK (max_dimensions_sum_cm > 0);
+
+-- Product category of the package
+ALTER TABLE packages
+    ADD COLUMN product_category VARCHAR(50) NOT NULL DEFAULT 'geral',
+    ADD CONSTRAINT check_product_category CHECK (product_category IN ('geral', 'fragil', 'liquido', 'bateria'));
+
+-- Table Carrier Restricted Categories: product categories a carrier refuses to ship
+CREATE TABLE carrier_restricted_categories (
+                                               carrier_id UUID NOT NULL,
+                                               category VARCHAR(50) NOT NULL,
+                                               created_at TIMESTAMP DEFAULT NOW(),
+                                               PRIMARY KEY (carrier_id, category),
+                                               CONSTRAINT fk_carrier FOREIGN KEY (carrier_id) REFERENCES carriers(id) ON DELETE CASCADE,
+                                               CONSTRAINT check_category CHECK (category IN ('geral', 'fragil', 'liquido', 'bateria'))
+);
+
+UPDATE carriers SET max_weight_kg = 30, max_side_cm = 100, max_dimensions_sum_cm = 200
+WHERE id = '660e8400-e29b-41d4-a716-446655440001';
+
+UPDATE carriers SET max_weight_kg = 50, max_side_cm = 150, max_dimensions_sum_cm = 300
+WHERE id = '660e8400-e29b-41d4-a716-446655440002';
+
+UPDATE carriers SET max_weight_kg = 30, max_side_cm = 105, max_dimensions_sum_cm = 200
+WHERE id = '660e8400-e29b-41d4-a716-446655440003';
+
+INSERT INTO carrier_restricted_categories (carrier_id, category) VALUES
+                                                                     ('660e8400-e29b-41d4-a716-446655440001', 'bateria'),
+                                                                     ('660e8400-e29b-41d4-a716-446655440001', 'liquido'),
+                                                                     ('660e8400-e29b-41d4-a716-446655440003', 'fragil');
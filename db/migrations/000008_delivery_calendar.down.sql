@@ -0,0 +1,8 @@
+ALTER TABLE packages
+    DROP COLUMN IF EXISTS hired_at,
+    DROP COLUMN IF EXISTS estimated_delivery_date;
+
+ALTER TABLE carriers
+    DROP COLUMN IF EXISTS cutoff_time;
+
+DROP TABLE IF EXISTS holidays;
@@ -0,0 +1,46 @@
+-- Table Holidays: national (state_code NULL) and state holidays; recurring ones repeat every year on the same day
+CREATE TABLE holidays (
+                          id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                          name VARCHAR(100) NOT NULL,
+                          holiday_date DATE NOT NULL,
+                          recurring BOOLEAN NOT NULL DEFAULT TRUE,
+                          state_code CHAR(2),
+                          created_at TIMESTAMP DEFAULT NOW(),
+                          CONSTRAINT fk_state FOREIGN KEY (state_code) REFERENCES states(code)
+);
+
+CREATE INDEX idx_holidays_state ON holidays(state_code);
+
+-- Carrier cut-off time: orders hired after it are dispatched on the next business day
+ALTER TABLE carriers
+    ADD COLUMN cutoff_time TIME NOT NULL DEFAULT '14:00';
+
+-- Hire time and promised delivery date
+ALTER TABLE packages
+    ADD COLUMN hired_at TIMESTAMP,
+    ADD COLUMN estimated_delivery_date DATE;
+
+UPDATE carriers SET cutoff_time = '16:00' WHERE id = '660e8400-e29b-41d4-a716-446655440001';
+UPDATE carriers SET cutoff_time = '12:00' WHERE id = '660e8400-e29b-41d4-a716-446655440002';
+
+-- Fixed national holidays (Carnaval, Sexta-feira Santa and Corpus Christi are computed from Easter)
+INSERT INTO holidays (name, holiday_date) VALUES
+                                              ('Confraternização Universal', '2000-01-01'),
+                                              ('Tiradentes', '2000-04-21'),
+                                              ('Dia do Trabalho', '2000-05-01'),
+                                              ('Independência do Brasil', '2000-09-07'),
+                                              ('Nossa Senhora Aparecida', '2000-10-12'),
+                                              ('Finados', '2000-11-02'),
+                                              ('Proclamação da República', '2000-11-15'),
+                                              ('Dia Nacional de Zumbi e da Consciência Negra', '2000-11-20'),
+                                              ('Natal', '2000-12-25');
+
+-- State holidays
+INSERT INTO holidays (name, holiday_date, state_code) VALUES
+                                                          ('Revolução Constitucionalista', '2000-07-09', 'SP'),
+                                                          ('Dia de São Jorge', '2000-04-23', 'RJ'),
+                                                          ('Revolução Farroupilha', '2000-09-20', 'RS'),
+                                                          ('Emancipação Política do Paraná', '2000-12-19', 'PR'),
+                                                          ('Data Magna de Santa Catarina', '2000-08-11', 'SC'),
+                                                          ('Independência da Bahia', '2000-07-02', 'BA'),
+                                                          ('Revolução Pernambucana', '2000-03-06', 'PE');
@@ -0,0 +1,12 @@
+DELETE FROM carrier_regions WHERE origin_region_id IS NOT NULL;
+
+DROP INDEX IF EXISTS uq_carrier_regions_lane;
+
+ALTER TABLE carrier_regions
+    DROP COLUMN IF EXISTS origin_region_id,
+    ADD CONSTRAINT carrier_regions_carrier_id_region_id_key UNIQUE (carrier_id, region_id);
+
+ALTER TABLE packages
+    DROP COLUMN IF EXISTS origin_warehouse_id;
+
+DROP TABLE IF EXISTS warehouses;
@@ -0,0 +1,35 @@
+-- Table Warehouses: distribution centers packages ship from
+CREATE TABLE warehouses (
+                            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                            name VARCHAR(255) NOT NULL,
+                            state_code CHAR(2) NOT NULL,
+                            created_at TIMESTAMP DEFAULT NOW(),
+                            CONSTRAINT fk_warehouse_state FOREIGN KEY (state_code) REFERENCES states(code)
+);
+
+ALTER TABLE packages
+    ADD COLUMN origin_warehouse_id UUID,
+    ADD CONSTRAINT fk_origin_warehouse FOREIGN KEY (origin_warehouse_id) REFERENCES warehouses(id);
+
+CREATE INDEX idx_packages_origin_warehouse ON packages(origin_warehouse_id);
+
+-- Rates are keyed on origin region x destination region; a NULL origin applies to any origin
+ALTER TABLE carrier_regions
+    ADD COLUMN origin_region_id UUID,
+    ADD CONSTRAINT fk_origin_region FOREIGN KEY (origin_region_id) REFERENCES regions(id),
+    DROP CONSTRAINT carrier_regions_carrier_id_region_id_key;
+
+CREATE UNIQUE INDEX uq_carrier_regions_lane ON carrier_regions(
+    carrier_id,
+    COALESCE(origin_region_id, '00000000-0000-0000-0000-000000000000'),
+    region_id
+);
+
+INSERT INTO warehouses (id, name, state_code) VALUES
+                                                  ('aa0e8400-e29b-41d4-a716-446655440001', 'CD Cajamar', 'SP'),
+                                                  ('aa0e8400-e29b-41d4-a716-446655440002', 'CD Recife', 'PE');
+
+-- Lanes out of the Nordeste: RotaFácil is cheaper and faster, Nebulix only serves the Nordeste from there
+INSERT INTO carrier_regions (carrier_id, origin_region_id, region_id, estimated_delivery_days, price_per_kg) VALUES
+                                                                                                               ('660e8400-e29b-41d4-a716-446655440002', '550e8400-e29b-41d4-a716-446655440004', '550e8400-e29b-41d4-a716-446655440004', 5, 5.10),
+                                                                                                               ('660e8400-e29b-41d4-a716-446655440001', '550e8400-e29b-41d4-a716-446655440004', '550e8400-e29b-41d4-a716-446655440004', 3, 6.40);
@@ -0,0 +1,6 @@
+ALTER TABLE packages
+    DROP COLUMN IF EXISTS destination_cep,
+    DROP COLUMN IF EXISTS destination_city;
+
+DROP TABLE IF EXISTS carrier_cep_rules;
+DROP TABLE IF EXISTS cep_ranges;
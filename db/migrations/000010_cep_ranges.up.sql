@@ -0,0 +1,90 @@
+-- Table CEP Ranges: resolves destination state and city; narrower ranges take precedence
+CREATE TABLE cep_ranges (
+                            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                            cep_start CHAR(8) NOT NULL,
+                            cep_end CHAR(8) NOT NULL,
+                            state_code CHAR(2) NOT NULL,
+                            city VARCHAR(100),
+                            is_capital BOOLEAN NOT NULL DEFAULT FALSE,
+                            created_at TIMESTAMP DEFAULT NOW(),
+                            CONSTRAINT fk_cep_state FOREIGN KEY (state_code) REFERENCES states(code),
+                            CONSTRAINT check_cep_range CHECK (cep_start <= cep_end)
+);
+
+CREATE INDEX idx_cep_ranges_lookup ON cep_ranges(cep_start, cep_end);
+
+-- Table Carrier CEP Rules: coverage and surcharges by CEP range (capital vs. interior, remote areas)
+CREATE TABLE carrier_cep_rules (
+                                   id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                   carrier_id UUID NOT NULL,
+                                   cep_start CHAR(8) NOT NULL,
+                                   cep_end CHAR(8) NOT NULL,
+                                   serviceable BOOLEAN NOT NULL DEFAULT TRUE,
+                                   surcharge DECIMAL(10,2) NOT NULL DEFAULT 0 CHECK (surcharge >= 0),
+                                   extra_delivery_days INT NOT NULL DEFAULT 0 CHECK (extra_delivery_days >= 0),
+                                   description VARCHAR(100),
+                                   created_at TIMESTAMP DEFAULT NOW(),
+                                   CONSTRAINT fk_carrier FOREIGN KEY (carrier_id) REFERENCES carriers(id) ON DELETE CASCADE,
+                                   CONSTRAINT check_cep_range CHECK (cep_start <= cep_end)
+);
+
+CREATE INDEX idx_carrier_cep_rules_lookup ON carrier_cep_rules(cep_start, cep_end);
+
+ALTER TABLE packages
+    ADD COLUMN destination_cep CHAR(8),
+    ADD COLUMN destination_city VARCHAR(100);
+
+INSERT INTO cep_ranges (cep_start, cep_end, state_code) VALUES
+                                                            ('01000000', '19999999', 'SP'),
+                                                            ('20000000', '28999999', 'RJ'),
+                                                            ('29000000', '29999999', 'ES'),
+                                                            ('30000000', '39999999', 'MG'),
+                                                            ('40000000', '48999999', 'BA'),
+                                                            ('49000000', '49999999', 'SE'),
+                                                            ('50000000', '56999999', 'PE'),
+                                                            ('57000000', '57999999', 'AL'),
+                                                            ('58000000', '58999999', 'PB'),
+                                                            ('59000000', '59999999', 'RN'),
+                                                            ('60000000', '63999999', 'CE'),
+                                                            ('64000000', '64999999', 'PI'),
+                                                            ('65000000', '65999999', 'MA'),
+                                                            ('66000000', '68899999', 'PA'),
+                                                            ('68900000', '68999999', 'AP'),
+                                                            ('69000000', '69299999', 'AM'),
+                                                            ('69300000', '69399999', 'RR'),
+                                                            ('69400000', '69899999', 'AM'),
+                                                            ('69900000', '69999999', 'AC'),
+                                                            ('70000000', '72799999', 'DF'),
+                                                            ('72800000', '72999999', 'GO'),
+                                                            ('73000000', '73699999', 'DF'),
+                                                            ('73700000', '76799999', 'GO'),
+                                                            ('76800000', '76999999', 'RO'),
+                                                            ('77000000', '77999999', 'TO'),
+                                                            ('78000000', '78899999', 'MT'),
+                                                            ('79000000', '79999999', 'MS'),
+                                                            ('80000000', '87999999', 'PR'),
+                                                            ('88000000', '89999999', 'SC'),
+                                                            ('90000000', '99999999', 'RS');
+
+INSERT INTO cep_ranges (cep_start, cep_end, state_code, city, is_capital) VALUES
+                                                                              ('01000000', '05999999', 'SP', 'São Paulo', TRUE),
+                                                                              ('08000000', '08499999', 'SP', 'São Paulo', TRUE),
+                                                                              ('20000000', '23799999', 'RJ', 'Rio de Janeiro', TRUE),
+                                                                              ('30000000', '31999999', 'MG', 'Belo Horizonte', TRUE),
+                                                                              ('40000000', '42599999', 'BA', 'Salvador', TRUE),
+                                                                              ('50000000', '52999999', 'PE', 'Recife', TRUE),
+                                                                              ('60000000', '61599999', 'CE', 'Fortaleza', TRUE),
+                                                                              ('70000000', '72799999', 'DF', 'Brasília', TRUE),
+                                                                              ('80000000', '82999999', 'PR', 'Curitiba', TRUE),
+                                                                              ('88000000', '88099999', 'SC', 'Florianópolis', TRUE),
+                                                                              ('90000000', '91999999', 'RS', 'Porto Alegre', TRUE),
+                                                                              ('53990000', '53990999', 'PE', 'Fernando de Noronha', FALSE);
+
+INSERT INTO carrier_cep_rules (carrier_id, cep_start, cep_end, serviceable, surcharge, extra_delivery_days, description) VALUES
+-- Nebulix: interior de SP com taxa e um dia a mais
+('660e8400-e29b-41d4-a716-446655440001', '06000000', '19999999', TRUE, 3.50, 1, 'Interior de SP'),
+-- RotaFácil: interior do RJ com taxa; não atende Fernando de Noronha
+('660e8400-e29b-41d4-a716-446655440002', '23800000', '28999999', TRUE, 2.00, 0, 'Interior do RJ'),
+('660e8400-e29b-41d4-a716-446655440002', '53990000', '53990999', FALSE, 0, 0, 'Fernando de Noronha'),
+-- Moventra: área remota em Fernando de Noronha
+('660e8400-e29b-41d4-a716-446655440003', '53990000', '53990999', TRUE, 45.00, 5, 'Área remota - Fernando de Noronha');
@@ -0,0 +1,20 @@
+DROP FUNCTION IF EXISTS next_tracking_serial(TEXT);
+
+DO $$
+DECLARE
+    sequence_name TEXT;
+BEGIN
+    FOR sequence_name IN SELECT sequencename FROM pg_sequences WHERE sequencename LIKE 'tracking\_serial\_%' LOOP
+        EXECUTE format('DROP SEQUENCE %I', sequence_name);
+    END LOOP;
+END;
+$$;
+
+ALTER TABLE carriers DROP COLUMN IF EXISTS tracking_prefix;
+
+ALTER TABLE packages DROP CONSTRAINT IF EXISTS packages_tracking_code_key;
+CREATE INDEX IF NOT EXISTS idx_packages_tracking_code ON packages(tracking_code);
+
+-- S10 codes (13 characters) do not fit the original column
+UPDATE packages SET tracking_code = NULL WHERE length(tracking_code) > 11;
+ALTER TABLE packages ALTER COLUMN tracking_code TYPE VARCHAR(11);
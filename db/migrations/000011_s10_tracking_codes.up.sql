@@ -0,0 +1,64 @@
+-- Duplicate codes would break the unique constraint below; fail up front and name them
+DO $$
+DECLARE
+    duplicates TEXT;
+BEGIN
+    SELECT string_agg(format('%s (packages %s)', tracking_code, package_ids), '; ')
+    INTO duplicates
+    FROM (
+        SELECT tracking_code, string_agg(id::TEXT, ', ' ORDER BY created_at) AS package_ids
+        FROM packages
+        WHERE tracking_code IS NOT NULL
+        GROUP BY tracking_code
+        HAVING count(*) > 1
+    ) dup;
+
+    IF duplicates IS NOT NULL THEN
+        RAISE EXCEPTION 'duplicate tracking codes: %', duplicates;
+    END IF;
+END;
+$$;
+
+-- UPU S10 codes such as NB000000014BR; legacy BR + 8 digit codes stay valid for lookups
+ALTER TABLE packages ALTER COLUMN tracking_code TYPE VARCHAR(13);
+
+DROP INDEX IF EXISTS idx_packages_tracking_code;
+ALTER TABLE packages ADD CONSTRAINT packages_tracking_code_key UNIQUE (tracking_code);
+
+ALTER TABLE carriers ADD COLUMN tracking_prefix CHAR(2);
+
+UPDATE carriers SET tracking_prefix = 'NB' WHERE id = '660e8400-e29b-41d4-a716-446655440001';
+UPDATE carriers SET tracking_prefix = 'RF' WHERE id = '660e8400-e29b-41d4-a716-446655440002';
+UPDATE carriers SET tracking_prefix = 'MV' WHERE id = '660e8400-e29b-41d4-a716-446655440003';
+UPDATE carriers SET tracking_prefix = 'OL' WHERE tracking_prefix IS NULL;
+
+ALTER TABLE carriers
+    ALTER COLUMN tracking_prefix SET NOT NULL,
+    ADD CONSTRAINT chk_carriers_tracking_prefix CHECK (tracking_prefix ~ '^[A-Z]{2}$');
+
+-- Next serial of a prefix; each prefix has its own sequence, created on first use
+CREATE FUNCTION next_tracking_serial(prefix TEXT) RETURNS BIGINT AS $$
+DECLARE
+    sequence_name TEXT := 'tracking_serial_' || lower(prefix);
+BEGIN
+    IF prefix !~ '^[A-Z]{2}$' THEN
+        RAISE EXCEPTION 'invalid tracking prefix: %', prefix;
+    END IF;
+
+    IF to_regclass(sequence_name) IS NULL THEN
+        BEGIN
+            EXECUTE format('CREATE SEQUENCE %I MINVALUE 1 MAXVALUE 99999999 NO CYCLE', sequence_name);
+        EXCEPTION WHEN duplicate_table OR unique_violation THEN
+            -- created concurrently by another transaction
+            NULL;
+        END;
+    END IF;
+
+    RETURN nextval(sequence_name);
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE SEQUENCE tracking_serial_nb MINVALUE 1 MAXVALUE 99999999 NO CYCLE;
+CREATE SEQUENCE tracking_serial_rf MINVALUE 1 MAXVALUE 99999999 NO CYCLE;
+CREATE SEQUENCE tracking_serial_mv MINVALUE 1 MAXVALUE 99999999 NO CYCLE;
+CREATE SEQUENCE tracking_serial_ol MINVALUE 1 MAXVALUE 99999999 NO CYCLE;
@@ -0,0 +1,30 @@
+-- Table Webhook Subscriptions: endpoints notified about package lifecycle events
+CREATE TABLE webhook_subscriptions (
+                                       id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                       url TEXT NOT NULL,
+                                       secret TEXT NOT NULL,
+                                       event_types TEXT[] NOT NULL,
+                                       created_at TIMESTAMP DEFAULT NOW(),
+                                       CONSTRAINT chk_webhook_event_types CHECK (cardinality(event_types) > 0)
+);
+
+-- Table Webhook Deliveries: one row per event and subscription, also used as the retry queue
+CREATE TABLE webhook_deliveries (
+                                    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                    subscription_id UUID NOT NULL,
+                                    event_id UUID NOT NULL,
+                                    event_type VARCHAR(50) NOT NULL,
+                                    payload JSONB NOT NULL,
+                                    status VARCHAR(20) NOT NULL DEFAULT 'pendente',
+                                    attempts INTEGER NOT NULL DEFAULT 0,
+                                    next_attempt_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                    last_response_status INTEGER,
+                                    last_error TEXT,
+                                    delivered_at TIMESTAMP,
+                                    created_at TIMESTAMP DEFAULT NOW(),
+                                    CONSTRAINT fk_webhook_subscription FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+                                    CONSTRAINT chk_webhook_delivery_status CHECK (status IN ('pendente', 'entregue', 'falhou'))
+);
+
+CREATE INDEX idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pendente';
+CREATE INDEX idx_webhook_deliveries_subscription ON webhook_deliveries(subscription_id, created_at DESC);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS carrier_tracking_events;
+
+DROP TABLE IF EXISTS carrier_event_mappings;
+
+DROP TABLE IF EXISTS carrier_api_tokens;
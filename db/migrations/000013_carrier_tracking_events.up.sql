@@ -0,0 +1,62 @@
+-- Table Carrier API Tokens: only the SHA-256 of each carrier's token is stored
+CREATE TABLE carrier_api_tokens (
+                                    carrier_id UUID PRIMARY KEY,
+                                    token_hash CHAR(64) NOT NULL,
+                                    created_at TIMESTAMP DEFAULT NOW(),
+                                    CONSTRAINT fk_carrier_api_token_carrier FOREIGN KEY (carrier_id) REFERENCES carriers(id) ON DELETE CASCADE
+);
+
+-- Table Carrier Event Mappings: carrier scan codes to package status; NULL status marks informational scans
+CREATE TABLE carrier_event_mappings (
+                                        carrier_id UUID NOT NULL,
+                                        carrier_code VARCHAR(50) NOT NULL,
+                                        status VARCHAR(50),
+                                        description VARCHAR(255) NOT NULL,
+                                        created_at TIMESTAMP DEFAULT NOW(),
+                                        PRIMARY KEY (carrier_id, carrier_code),
+                                        CONSTRAINT fk_carrier_event_mapping_carrier FOREIGN KEY (carrier_id) REFERENCES carriers(id) ON DELETE CASCADE,
+                                        CONSTRAINT check_carrier_event_mapping_status CHECK (status IN ('coletado', 'enviado', 'entregue', 'extraviado'))
+);
+
+-- Table Carrier Tracking Events: raw scans pushed by carriers, deduplicated by the carrier's own event id
+CREATE TABLE carrier_tracking_events (
+                                         id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                         carrier_id UUID NOT NULL,
+                                         package_id UUID NOT NULL,
+                                         carrier_event_id VARCHAR(100) NOT NULL,
+                                         carrier_code VARCHAR(50) NOT NULL,
+                                         mapped_status VARCHAR(50),
+                                         description TEXT,
+                                         location VARCHAR(255),
+                                         occurred_at TIMESTAMP NOT NULL,
+                                         raw JSONB NOT NULL,
+                                         received_at TIMESTAMP DEFAULT NOW(),
+                                         CONSTRAINT fk_carrier_tracking_event_carrier FOREIGN KEY (carrier_id) REFERENCES carriers(id),
+                                         CONSTRAINT fk_carrier_tracking_event_package FOREIGN KEY (package_id) REFERENCES packages(id) ON DELETE CASCADE,
+                                         CONSTRAINT uq_carrier_tracking_event UNIQUE (carrier_id, carrier_event_id)
+);
+
+CREATE INDEX idx_carrier_tracking_events_package ON carrier_tracking_events(package_id, occurred_at);
+
+INSERT INTO carrier_event_mappings (carrier_id, carrier_code, status, description) VALUES
+-- Nebulix
+('660e8400-e29b-41d4-a716-446655440001', 'COL', 'coletado', 'Objeto coletado'),
+('660e8400-e29b-41d4-a716-446655440001', 'TRA', 'enviado', 'Objeto em trânsito'),
+('660e8400-e29b-41d4-a716-446655440001', 'SAI', 'enviado', 'Objeto saiu para entrega'),
+('660e8400-e29b-41d4-a716-446655440001', 'TNE', NULL, 'Tentativa de entrega sem sucesso'),
+('660e8400-e29b-41d4-a716-446655440001', 'ENT', 'entregue', 'Objeto entregue'),
+('660e8400-e29b-41d4-a716-446655440001', 'EXT', 'extraviado', 'Objeto extraviado'),
+-- RotaFácil
+('660e8400-e29b-41d4-a716-446655440002', 'PICKED_UP', 'coletado', 'Objeto coletado'),
+('660e8400-e29b-41d4-a716-446655440002', 'IN_TRANSIT', 'enviado', 'Objeto em trânsito'),
+('660e8400-e29b-41d4-a716-446655440002', 'OUT_FOR_DELIVERY', 'enviado', 'Objeto saiu para entrega'),
+('660e8400-e29b-41d4-a716-446655440002', 'DELIVERY_FAILED', NULL, 'Tentativa de entrega sem sucesso'),
+('660e8400-e29b-41d4-a716-446655440002', 'DELIVERED', 'entregue', 'Objeto entregue'),
+('660e8400-e29b-41d4-a716-446655440002', 'LOST', 'extraviado', 'Objeto extraviado'),
+-- Moventra
+('660e8400-e29b-41d4-a716-446655440003', '10', 'coletado', 'Objeto coletado'),
+('660e8400-e29b-41d4-a716-446655440003', '20', 'enviado', 'Objeto em trânsito'),
+('660e8400-e29b-41d4-a716-446655440003', '30', 'enviado', 'Objeto saiu para entrega'),
+('660e8400-e29b-41d4-a716-446655440003', '41', NULL, 'Tentativa de entrega sem sucesso'),
+('660e8400-e29b-41d4-a716-446655440003', '40', 'entregue', 'Objeto entregue'),
+('660e8400-e29b-41d4-a716-446655440003', '90', 'extraviado', 'Objeto extraviado');
@@ -0,0 +1,13 @@
+-- Table API Keys: only the SHA-256 of each key is stored; key_prefix identifies the key in listings
+CREATE TABLE api_keys (
+                          id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                          name VARCHAR(100) NOT NULL,
+                          role VARCHAR(20) NOT NULL,
+                          key_prefix VARCHAR(16) NOT NULL,
+                          key_hash CHAR(64) NOT NULL UNIQUE,
+                          created_by VARCHAR(255) NOT NULL,
+                          created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                          last_used_at TIMESTAMP,
+                          revoked_at TIMESTAMP,
+                          CONSTRAINT check_api_key_role CHECK (role IN ('admin', 'operator', 'seller', 'readonly'))
+);
@@ -0,0 +1,11 @@
+DROP TABLE IF EXISTS seller_carrier_rates;
+
+ALTER TABLE api_keys
+    DROP CONSTRAINT IF EXISTS check_api_key_seller,
+    DROP COLUMN IF EXISTS seller_id;
+
+DROP INDEX IF EXISTS idx_packages_seller;
+
+ALTER TABLE packages DROP COLUMN IF EXISTS seller_id;
+
+DROP TABLE IF EXISTS sellers;
@@ -0,0 +1,41 @@
+-- Table Sellers: marketplace stores; every package belongs to one
+CREATE TABLE sellers (
+                         id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                         name VARCHAR(255) NOT NULL,
+                         created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Packages created before multi-tenancy belong to the default store
+INSERT INTO sellers (id, name) VALUES
+    ('880e8400-e29b-41d4-a716-446655440001', 'Loja Olist');
+
+ALTER TABLE packages
+    ADD COLUMN seller_id UUID,
+    ADD CONSTRAINT fk_package_seller FOREIGN KEY (seller_id) REFERENCES sellers(id);
+
+UPDATE packages SET seller_id = '880e8400-e29b-41d4-a716-446655440001';
+
+ALTER TABLE packages ALTER COLUMN seller_id SET NOT NULL;
+
+CREATE INDEX idx_packages_seller ON packages(seller_id, created_at);
+
+-- API keys of the seller role act only on that seller's packages
+ALTER TABLE api_keys
+    ADD COLUMN seller_id UUID,
+    ADD CONSTRAINT fk_api_key_seller FOREIGN KEY (seller_id) REFERENCES sellers(id) ON DELETE CASCADE,
+    ADD CONSTRAINT check_api_key_seller CHECK (role <> 'seller' OR seller_id IS NOT NULL);
+
+-- Table Seller Carrier Rates: negotiated price per kg and/or markup (negative for discounts) per seller and carrier
+CREATE TABLE seller_carrier_rates (
+                                      seller_id UUID NOT NULL,
+                                      carrier_id UUID NOT NULL,
+                                      price_per_kg DECIMAL(10,2),
+                                      markup_percent DECIMAL(6,2) NOT NULL DEFAULT 0,
+                                      created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                      updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                      PRIMARY KEY (seller_id, carrier_id),
+                                      CONSTRAINT fk_seller_rate_seller FOREIGN KEY (seller_id) REFERENCES sellers(id) ON DELETE CASCADE,
+                                      CONSTRAINT fk_seller_rate_carrier FOREIGN KEY (carrier_id) REFERENCES carriers(id) ON DELETE CASCADE,
+                                      CONSTRAINT check_seller_rate_price CHECK (price_per_kg IS NULL OR price_per_kg > 0),
+                                      CONSTRAINT check_seller_rate_markup CHECK (markup_percent > -100)
+);
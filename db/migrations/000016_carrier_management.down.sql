@@ -0,0 +1,7 @@
+ALTER TABLE carrier_regions
+    DROP CONSTRAINT IF EXISTS check_carrier_region_delivery_days,
+    DROP CONSTRAINT IF EXISTS check_carrier_region_price;
+
+DROP INDEX IF EXISTS uq_carriers_tracking_prefix;
+
+ALTER TABLE carriers DROP COLUMN IF EXISTS deleted_at;
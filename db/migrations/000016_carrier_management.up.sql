@@ -0,0 +1,10 @@
+-- Deleted carriers leave listings and quotes but stay referenced by the packages they already shipped
+ALTER TABLE carriers ADD COLUMN deleted_at TIMESTAMP;
+
+-- The tracking prefix identifies the carrier in the S10 code; OL is shared by packages without a carrier
+CREATE UNIQUE INDEX uq_carriers_tracking_prefix ON carriers(tracking_prefix)
+    WHERE deleted_at IS NULL AND tracking_prefix <> 'OL';
+
+ALTER TABLE carrier_regions
+    ADD CONSTRAINT check_carrier_region_delivery_days CHECK (estimated_delivery_days > 0),
+    ADD CONSTRAINT check_carrier_region_price CHECK (price_per_kg >= 0);
@@ -0,0 +1,17 @@
+-- Keep only the version in effect now; ended lanes and scheduled versions are dropped
+DELETE FROM carrier_regions
+WHERE valid_from > NOW()
+   OR valid_to <= NOW();
+
+DROP INDEX IF EXISTS uq_carrier_regions_lane_version;
+
+CREATE UNIQUE INDEX uq_carrier_regions_lane ON carrier_regions(
+    carrier_id,
+    COALESCE(origin_region_id, '00000000-0000-0000-0000-000000000000'),
+    region_id
+);
+
+ALTER TABLE carrier_regions
+    DROP CONSTRAINT IF EXISTS check_carrier_region_validity,
+    DROP COLUMN IF EXISTS valid_to,
+    DROP COLUMN IF EXISTS valid_from;
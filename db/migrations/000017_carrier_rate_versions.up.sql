@@ -0,0 +1,21 @@
+-- Each carrier_regions row is a rate version valid from valid_from until valid_to (NULL = open-ended)
+ALTER TABLE carrier_regions
+    ADD COLUMN valid_from TIMESTAMP,
+    ADD COLUMN valid_to TIMESTAMP;
+
+UPDATE carrier_regions SET valid_from = COALESCE(created_at, NOW());
+
+ALTER TABLE carrier_regions
+    ALTER COLUMN valid_from SET NOT NULL,
+    ALTER COLUMN valid_from SET DEFAULT NOW(),
+    ADD CONSTRAINT check_carrier_region_validity CHECK (valid_to IS NULL OR valid_to > valid_from);
+
+-- A lane now holds one row per version; two versions cannot start at the same time
+DROP INDEX IF EXISTS uq_carrier_regions_lane;
+
+CREATE UNIQUE INDEX uq_carrier_regions_lane_version ON carrier_regions(
+    carrier_id,
+    COALESCE(origin_region_id, '00000000-0000-0000-0000-000000000000'),
+    region_id,
+    valid_from
+);
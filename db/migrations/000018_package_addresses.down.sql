@@ -0,0 +1,18 @@
+ALTER TABLE packages
+    DROP COLUMN IF EXISTS sender_cep,
+    DROP COLUMN IF EXISTS sender_state,
+    DROP COLUMN IF EXISTS sender_city,
+    DROP COLUMN IF EXISTS sender_neighborhood,
+    DROP COLUMN IF EXISTS sender_complement,
+    DROP COLUMN IF EXISTS sender_number,
+    DROP COLUMN IF EXISTS sender_street,
+    DROP COLUMN IF EXISTS sender_phone,
+    DROP COLUMN IF EXISTS sender_document,
+    DROP COLUMN IF EXISTS sender_name,
+    DROP COLUMN IF EXISTS recipient_neighborhood,
+    DROP COLUMN IF EXISTS recipient_complement,
+    DROP COLUMN IF EXISTS recipient_number,
+    DROP COLUMN IF EXISTS recipient_street,
+    DROP COLUMN IF EXISTS recipient_phone,
+    DROP COLUMN IF EXISTS recipient_document,
+    DROP COLUMN IF EXISTS recipient_name;
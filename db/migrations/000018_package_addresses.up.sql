@@ -0,0 +1,22 @@
+-- Recipient address; state, CEP and city are the package's destination_* columns
+ALTER TABLE packages
+    ADD COLUMN recipient_name VARCHAR(255),
+    ADD COLUMN recipient_document VARCHAR(14),
+    ADD COLUMN recipient_phone VARCHAR(11),
+    ADD COLUMN recipient_street VARCHAR(255),
+    ADD COLUMN recipient_number VARCHAR(20),
+    ADD COLUMN recipient_complement VARCHAR(100),
+    ADD COLUMN recipient_neighborhood VARCHAR(100);
+
+-- Sender address; without it the label shows the origin warehouse
+ALTER TABLE packages
+    ADD COLUMN sender_name VARCHAR(255),
+    ADD COLUMN sender_document VARCHAR(14),
+    ADD COLUMN sender_phone VARCHAR(11),
+    ADD COLUMN sender_street VARCHAR(255),
+    ADD COLUMN sender_number VARCHAR(20),
+    ADD COLUMN sender_complement VARCHAR(100),
+    ADD COLUMN sender_neighborhood VARCHAR(100),
+    ADD COLUMN sender_city VARCHAR(100),
+    ADD COLUMN sender_state CHAR(2) REFERENCES states(code),
+    ADD COLUMN sender_cep CHAR(8);
@@ -0,0 +1,13 @@
+-- Table Idempotency Keys: a NULL status_code means the first request is still in progress
+CREATE TABLE idempotency_keys (
+                                  principal VARCHAR(255) NOT NULL,
+                                  idempotency_key VARCHAR(255) NOT NULL,
+                                  route VARCHAR(500) NOT NULL,
+                                  request_hash CHAR(64) NOT NULL,
+                                  status_code INTEGER,
+                                  content_type VARCHAR(255),
+                                  response_body BYTEA,
+                                  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+                                  expires_at TIMESTAMP NOT NULL,
+                                  PRIMARY KEY (principal, idempotency_key, route)
+);
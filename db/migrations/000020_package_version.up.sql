@@ -0,0 +1,3 @@
+-- Optimistic concurrency: every update increments the version, exposed to clients as the package ETag
+ALTER TABLE packages
+    ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,6 @@
+DELETE FROM packages WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE packages
+    DROP COLUMN IF EXISTS deleted_reason,
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
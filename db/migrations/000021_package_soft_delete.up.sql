@@ -0,0 +1,5 @@
+-- Soft delete: deleted packages keep their history and tracking code and can be restored
+ALTER TABLE packages
+    ADD COLUMN deleted_at TIMESTAMP,
+    ADD COLUMN deleted_by VARCHAR(255),
+    ADD COLUMN deleted_reason TEXT;
@@ -0,0 +1 @@
+-- Issued tracking codes may already be printed on labels, so they are kept
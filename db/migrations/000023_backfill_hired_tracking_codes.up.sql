@@ -0,0 +1,32 @@
+-- Issue S10 codes, as pkg/tracking does, to packages hired before codes were issued at hire
+CREATE FUNCTION s10_tracking_code(prefix TEXT, serial BIGINT) RETURNS TEXT AS $$
+DECLARE
+    digits      TEXT  := lpad(serial::TEXT, 8, '0');
+    weights     INT[] := ARRAY[8, 6, 4, 2, 3, 5, 9, 7];
+    total       INT   := 0;
+    check_digit INT;
+BEGIN
+    FOR i IN 1..8 LOOP
+        total := total + substr(digits, i, 1)::INT * weights[i];
+    END LOOP;
+
+    check_digit := 11 - total % 11;
+    IF check_digit = 10 THEN
+        check_digit := 0;
+    ELSIF check_digit = 11 THEN
+        check_digit := 5;
+    END IF;
+
+    RETURN prefix || digits || check_digit || 'BR';
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+UPDATE packages p
+SET tracking_code = s10_tracking_code(c.tracking_prefix, next_tracking_serial(c.tracking_prefix)),
+    version       = p.version + 1,
+    updated_at    = NOW()
+FROM carriers c
+WHERE c.id = p.hired_carrier_id
+  AND p.tracking_code IS NULL;
+
+DROP FUNCTION s10_tracking_code(TEXT, BIGINT);
@@ -0,0 +1,2 @@
+-- Headers of the first response that replays must return, such as ETag and Location
+ALTER TABLE idempotency_keys ADD COLUMN response_headers JSONB NOT NULL DEFAULT '{}';
@@ -0,0 +1,26 @@
+-- name: CreateApiKey :one
+INSERT INTO api_keys (name, role, key_prefix, key_hash, created_by, seller_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, role, key_prefix, key_hash, created_by, created_at, last_used_at, revoked_at, seller_id;
+
+-- name: GetActiveApiKeyByHash :one
+SELECT id, name, role, key_prefix, key_hash, created_by, created_at, last_used_at, revoked_at, seller_id
+FROM api_keys
+WHERE key_hash = $1 AND revoked_at IS NULL;
+
+-- name: ListApiKeys :many
+SELECT id, name, role, key_prefix, key_hash, created_by, created_at, last_used_at, revoked_at, seller_id
+FROM api_keys
+ORDER BY created_at DESC;
+
+-- name: RevokeApiKey :one
+UPDATE api_keys
+SET revoked_at = COALESCE(revoked_at, NOW())
+WHERE id = $1
+RETURNING id, name, role, key_prefix, key_hash, created_by, created_at, last_used_at, revoked_at, seller_id;
+
+-- name: TouchApiKey :exec
+-- Writes at most once a minute instead of on every request
+UPDATE api_keys
+SET last_used_at = NOW()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute');
@@ -0,0 +1,5 @@
+-- name: ListCarrierCepRules :many
+SELECT id, carrier_id, cep_start, cep_end, serviceable, surcharge, extra_delivery_days, description, created_at
+FROM carrier_cep_rules
+WHERE @cep::CHAR(8) BETWEEN cep_start AND cep_end
+ORDER BY carrier_id, cep_end::INT - cep_start::INT;
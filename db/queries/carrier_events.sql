@@ -0,0 +1,54 @@
+-- name: GetCarrierApiTokenHash :one
+SELECT token_hash
+FROM carrier_api_tokens
+WHERE carrier_id = $1;
+
+-- name: UpsertCarrierApiToken :exec
+INSERT INTO carrier_api_tokens (carrier_id, token_hash)
+VALUES ($1, $2)
+ON CONFLICT (carrier_id) DO UPDATE
+SET token_hash = EXCLUDED.token_hash,
+    created_at = NOW();
+
+-- name: GetCarrierEventMapping :one
+SELECT carrier_id, carrier_code, status, description, created_at
+FROM carrier_event_mappings
+WHERE carrier_id = $1 AND carrier_code = $2;
+
+-- name: ListCarrierEventMappings :many
+SELECT carrier_id, carrier_code, status, description, created_at
+FROM carrier_event_mappings
+WHERE carrier_id = $1
+ORDER BY carrier_code;
+
+-- name: UpsertCarrierEventMapping :one
+INSERT INTO carrier_event_mappings (carrier_id, carrier_code, status, description)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (carrier_id, carrier_code) DO UPDATE
+SET status = EXCLUDED.status,
+    description = EXCLUDED.description
+RETURNING carrier_id, carrier_code, status, description, created_at;
+
+-- name: CreateCarrierTrackingEvent :one
+-- No row returned means the event is a duplicate
+INSERT INTO carrier_tracking_events (
+    carrier_id, package_id, carrier_event_id, carrier_code, mapped_status, description, location, occurred_at, raw
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+ON CONFLICT (carrier_id, carrier_event_id) DO NOTHING
+RETURNING id, carrier_id, package_id, carrier_event_id, carrier_code, mapped_status, description, location,
+    occurred_at, raw, received_at;
+
+-- name: GetCarrierTrackingEvent :one
+SELECT id, carrier_id, package_id, carrier_event_id, carrier_code, mapped_status, description, location,
+    occurred_at, raw, received_at
+FROM carrier_tracking_events
+WHERE carrier_id = $1 AND carrier_event_id = $2;
+
+-- name: ListCarrierTrackingEventsByPackage :many
+SELECT id, carrier_id, package_id, carrier_event_id, carrier_code, mapped_status, description, location,
+    occurred_at, raw, received_at
+FROM carrier_tracking_events
+WHERE package_id = $1
+ORDER BY occurred_at, received_at;
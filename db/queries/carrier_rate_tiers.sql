@@ -0,0 +1,26 @@
+-- name: ListCarrierRateTiersByState :many
+SELECT
+    t.id,
+    t.carrier_region_id,
+    t.min_weight_kg,
+    t.max_weight_kg,
+    t.price,
+    t.created_at
+FROM carrier_rate_tiers t
+         JOIN carrier_regions cr ON cr.id = t.carrier_region_id
+         JOIN states s ON s.region_id = cr.region_id
+WHERE s.code = @state_code
+ORDER BY t.carrier_region_id, t.max_weight_kg;
+
+-- name: ListCarrierRateTiersByCarrier :many
+SELECT
+    t.id,
+    t.carrier_region_id,
+    t.min_weight_kg,
+    t.max_weight_kg,
+    t.price,
+    t.created_at
+FROM carrier_rate_tiers t
+         JOIN carrier_regions cr ON cr.id = t.carrier_region_id
+WHERE cr.carrier_id = $1
+ORDER BY t.carrier_region_id, t.max_weight_kg;
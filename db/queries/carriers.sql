@@ -1,22 +1,203 @@
 -- name: ListCarriers :many
-SELECT id, name, created_at
+SELECT id, name, created_at, max_weight_kg, max_side_cm, max_dimensions_sum_cm, cutoff_time, tracking_prefix, deleted_at
 FROM carriers
+WHERE deleted_at IS NULL
 ORDER BY name;
 
+-- name: CreateCarrier :one
+INSERT INTO carriers (name, tracking_prefix, max_weight_kg, max_side_cm, max_dimensions_sum_cm, cutoff_time)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, created_at, max_weight_kg, max_side_cm, max_dimensions_sum_cm, cutoff_time, tracking_prefix, deleted_at;
+
+-- name: UpdateCarrier :one
+UPDATE carriers
+SET name = $2,
+    tracking_prefix = $3,
+    max_weight_kg = $4,
+    max_side_cm = $5,
+    max_dimensions_sum_cm = $6,
+    cutoff_time = $7
+WHERE id = $1
+  AND deleted_at IS NULL
+RETURNING id, name, created_at, max_weight_kg, max_side_cm, max_dimensions_sum_cm, cutoff_time, tracking_prefix, deleted_at;
+
+-- name: DeleteCarrier :execrows
+-- Soft delete, refused while the carrier has packages in progress
+UPDATE carriers
+SET deleted_at = NOW()
+WHERE carriers.id = $1
+  AND carriers.deleted_at IS NULL
+  AND NOT EXISTS (
+      SELECT 1
+      FROM packages p
+      WHERE p.hired_carrier_id = $1
+        AND p.status NOT IN ('entregue', 'extraviado')
+  );
+
 -- name: GetCarrierRegions :many
+-- Only the rate version in effect now
 SELECT
     cr.id,
     cr.carrier_id,
     cr.region_id,
+    cr.origin_region_id,
     cr.estimated_delivery_days,
     cr.price_per_kg,
-    r.name as region_name
+    r.name as region_name,
+    o.name as origin_region_name,
+    cr.min_charge,
+    cr.excess_price_per_kg,
+    cr.cubing_divisor,
+    cr.valid_from,
+    cr.valid_to
 FROM carrier_regions cr
          JOIN regions r ON cr.region_id = r.id
-WHERE cr.carrier_id = $1;
+         LEFT JOIN regions o ON cr.origin_region_id = o.id
+WHERE cr.carrier_id = $1
+  AND cr.valid_from <= NOW()
+  AND (cr.valid_to IS NULL OR cr.valid_to > NOW())
+ORDER BY r.name, o.name NULLS FIRST;
+
+-- name: ListCarrierRates :many
+-- Without a date lists every version, including scheduled ones
+SELECT
+    cr.id,
+    cr.carrier_id,
+    cr.region_id,
+    cr.origin_region_id,
+    cr.estimated_delivery_days,
+    cr.price_per_kg,
+    r.name as region_name,
+    o.name as origin_region_name,
+    cr.min_charge,
+    cr.excess_price_per_kg,
+    cr.cubing_divisor,
+    cr.valid_from,
+    cr.valid_to
+FROM carrier_regions cr
+         JOIN regions r ON cr.region_id = r.id
+         LEFT JOIN regions o ON cr.origin_region_id = o.id
+WHERE cr.carrier_id = @carrier_id
+  AND (sqlc.narg('at')::TIMESTAMP IS NULL
+       OR (cr.valid_from <= sqlc.narg('at') AND (cr.valid_to IS NULL OR cr.valid_to > sqlc.narg('at'))))
+ORDER BY r.name, o.name NULLS FIRST, cr.valid_from;
+
+-- name: GetCarrierRegionById :one
+SELECT id, carrier_id, region_id, estimated_delivery_days, price_per_kg, created_at, cubing_divisor, excess_price_per_kg, min_charge, origin_region_id, valid_from, valid_to
+FROM carrier_regions
+WHERE id = $1
+  AND carrier_id = $2;
+
+-- name: CreateCarrierRegion :one
+-- Ends the version in effect at valid_from and inherits its weight tiers
+WITH version_start AS (
+    SELECT COALESCE(sqlc.narg('valid_from')::TIMESTAMP, NOW()::TIMESTAMP) AS valid_from
+), previous AS (
+    UPDATE carrier_regions cr
+    SET valid_to = vs.valid_from
+    FROM version_start vs
+    WHERE cr.carrier_id = @carrier_id
+      AND cr.region_id = @region_id
+      AND cr.origin_region_id IS NOT DISTINCT FROM sqlc.narg('origin_region_id')
+      AND cr.valid_from < vs.valid_from
+      AND (cr.valid_to IS NULL OR cr.valid_to > vs.valid_from)
+    RETURNING cr.id
+), version AS (
+    INSERT INTO carrier_regions (carrier_id, region_id, origin_region_id, estimated_delivery_days, price_per_kg, min_charge, excess_price_per_kg, cubing_divisor, valid_from, valid_to)
+    VALUES (@carrier_id, @region_id, sqlc.narg('origin_region_id'), @estimated_delivery_days, @price_per_kg, @min_charge, sqlc.narg('excess_price_per_kg'), @cubing_divisor,
+            (SELECT valid_from FROM version_start),
+            (SELECT MIN(n.valid_from)
+             FROM carrier_regions n
+             WHERE n.carrier_id = @carrier_id
+               AND n.region_id = @region_id
+               AND n.origin_region_id IS NOT DISTINCT FROM sqlc.narg('origin_region_id')
+               AND n.valid_from > (SELECT valid_from FROM version_start)))
+    RETURNING id, carrier_id, region_id, estimated_delivery_days, price_per_kg, created_at, cubing_divisor, excess_price_per_kg, min_charge, origin_region_id, valid_from, valid_to
+), tiers AS (
+    INSERT INTO carrier_rate_tiers (carrier_region_id, min_weight_kg, max_weight_kg, price)
+    SELECT version.id, t.min_weight_kg, t.max_weight_kg, t.price
+    FROM carrier_rate_tiers t
+             JOIN previous p ON p.id = t.carrier_region_id
+             CROSS JOIN version
+)
+SELECT id, carrier_id, region_id, estimated_delivery_days, price_per_kg, created_at, cubing_divisor, excess_price_per_kg, min_charge, origin_region_id, valid_from, valid_to
+FROM version;
+
+-- name: ImportCarrierRates :many
+-- Adds a version per lane, of any origin, ending the version in effect at valid_from
+WITH version_start AS (
+    SELECT COALESCE(sqlc.narg('valid_from')::TIMESTAMP, NOW()::TIMESTAMP) AS valid_from
+), lanes AS (
+    SELECT (e.item->>'region_id')::UUID AS region_id,
+           (e.item->>'estimated_delivery_days')::INT AS estimated_delivery_days,
+           (e.item->>'price_per_kg')::DECIMAL(10,2) AS price_per_kg,
+           (e.item->>'min_charge')::DECIMAL(10,2) AS min_charge,
+           (e.item->>'excess_price_per_kg')::DECIMAL(10,2) AS excess_price_per_kg,
+           (e.item->>'cubing_divisor')::INT AS cubing_divisor,
+           e.item->'tiers' AS tiers
+    FROM jsonb_array_elements(@lanes::JSONB) AS e(item)
+), previous AS (
+    UPDATE carrier_regions cr
+    SET valid_to = vs.valid_from
+    FROM version_start vs, lanes l
+    WHERE cr.carrier_id = @carrier_id
+      AND cr.region_id = l.region_id
+      AND cr.origin_region_id IS NULL
+      AND cr.valid_from < vs.valid_from
+      AND (cr.valid_to IS NULL OR cr.valid_to > vs.valid_from)
+), versions AS (
+    INSERT INTO carrier_regions (carrier_id, region_id, estimated_delivery_days, price_per_kg, min_charge, excess_price_per_kg, cubing_divisor, valid_from, valid_to)
+    SELECT @carrier_id, l.region_id, l.estimated_delivery_days, l.price_per_kg, l.min_charge, l.excess_price_per_kg, l.cubing_divisor, vs.valid_from,
+           (SELECT MIN(n.valid_from)
+            FROM carrier_regions n
+            WHERE n.carrier_id = @carrier_id
+              AND n.region_id = l.region_id
+              AND n.origin_region_id IS NULL
+              AND n.valid_from > vs.valid_from)
+    FROM lanes l
+             CROSS JOIN version_start vs
+    RETURNING id, region_id
+), tiers AS (
+    INSERT INTO carrier_rate_tiers (carrier_region_id, min_weight_kg, max_weight_kg, price)
+    SELECT v.id, (t.item->>'min_weight_kg')::DECIMAL(10,3), (t.item->>'max_weight_kg')::DECIMAL(10,3), (t.item->>'price')::DECIMAL(10,2)
+    FROM versions v
+             JOIN lanes l ON l.region_id = v.region_id
+             CROSS JOIN jsonb_array_elements(l.tiers) AS t(item)
+)
+SELECT id, region_id
+FROM versions;
+
+-- name: EndCarrierRegion :exec
+-- Drops scheduled versions and ends the current one, which stays in history
+WITH lane AS (
+    SELECT carrier_id, region_id, origin_region_id
+    FROM carrier_regions
+    WHERE carrier_regions.id = $1
+      AND carrier_regions.carrier_id = $2
+), scheduled AS (
+    DELETE FROM carrier_regions cr
+    USING lane
+    WHERE cr.carrier_id = lane.carrier_id
+      AND cr.region_id = lane.region_id
+      AND cr.origin_region_id IS NOT DISTINCT FROM lane.origin_region_id
+      AND cr.valid_from > NOW()
+)
+UPDATE carrier_regions cr
+SET valid_to = NOW()
+FROM lane
+WHERE cr.carrier_id = lane.carrier_id
+  AND cr.region_id = lane.region_id
+  AND cr.origin_region_id IS NOT DISTINCT FROM lane.origin_region_id
+  AND cr.valid_from <= NOW()
+  AND (cr.valid_to IS NULL OR cr.valid_to > NOW());
 
 -- name: GetRegionByState :one
 SELECT r.id, r.name
 FROM regions r
          JOIN states s ON s.region_id = r.id
-WHERE s.code = $1;
\ No newline at end of file
+WHERE s.code = $1;
+
+-- name: ListCarrierRestrictedCategories :many
+SELECT carrier_id, category, created_at
+FROM carrier_restricted_categories
+ORDER BY carrier_id, category;
@@ -0,0 +1,6 @@
+-- name: GetCepRange :one
+SELECT id, cep_start, cep_end, state_code, city, is_capital, created_at
+FROM cep_ranges
+WHERE @cep::CHAR(8) BETWEEN cep_start AND cep_end
+ORDER BY cep_end::INT - cep_start::INT
+LIMIT 1;
@@ -0,0 +1,5 @@
+-- name: ListHolidaysForState :many
+SELECT id, name, holiday_date, recurring, state_code, created_at
+FROM holidays
+WHERE state_code IS NULL OR state_code = @state_code
+ORDER BY holiday_date;
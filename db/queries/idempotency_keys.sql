@@ -0,0 +1,48 @@
+-- name: ReserveIdempotencyKey :one
+-- Takes over expired keys or ones stuck in progress; otherwise returns no row
+INSERT INTO idempotency_keys (principal, idempotency_key, route, request_hash, expires_at)
+VALUES (@principal, @idempotency_key, @route, @request_hash, NOW() + make_interval(secs => @ttl_seconds::float8))
+ON CONFLICT (principal, idempotency_key, route) DO UPDATE
+SET request_hash = EXCLUDED.request_hash,
+    status_code = NULL,
+    content_type = NULL,
+    response_body = NULL,
+    response_headers = '{}',
+    created_at = NOW(),
+    expires_at = EXCLUDED.expires_at
+WHERE idempotency_keys.expires_at <= NOW()
+   OR (idempotency_keys.status_code IS NULL
+       AND idempotency_keys.request_hash = EXCLUDED.request_hash
+       AND idempotency_keys.created_at < NOW() - make_interval(secs => @lock_timeout_seconds::float8))
+RETURNING principal, idempotency_key, route, request_hash, status_code, content_type, response_body, created_at, expires_at, response_headers;
+
+-- name: GetIdempotencyKey :one
+SELECT principal, idempotency_key, route, request_hash, status_code, content_type, response_body, created_at, expires_at, response_headers
+FROM idempotency_keys
+WHERE principal = @principal
+  AND idempotency_key = @idempotency_key
+  AND route = @route;
+
+-- name: CompleteIdempotencyKey :exec
+UPDATE idempotency_keys
+SET status_code = @status_code,
+    content_type = @content_type,
+    response_body = @response_body,
+    response_headers = @response_headers
+WHERE principal = @principal
+  AND idempotency_key = @idempotency_key
+  AND route = @route
+  AND request_hash = @request_hash;
+
+-- name: ReleaseIdempotencyKey :exec
+DELETE FROM idempotency_keys
+WHERE principal = @principal
+  AND idempotency_key = @idempotency_key
+  AND route = @route
+  AND request_hash = @request_hash
+  AND status_code IS NULL;
+
+
+-- name: DeleteExpiredIdempotencyKeys :execrows
+DELETE FROM idempotency_keys
+WHERE expires_at <= NOW();
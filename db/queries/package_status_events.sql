@@ -0,0 +1,10 @@
+-- name: CreatePackageStatusEvent :one
+INSERT INTO package_status_events (package_id, from_status, to_status, actor, reason)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, package_id, from_status, to_status, actor, reason, created_at;
+
+-- name: ListPackageStatusEvents :many
+SELECT id, package_id, from_status, to_status, actor, reason, created_at
+FROM package_status_events
+WHERE package_id = $1
+ORDER BY created_at;
@@ -1,40 +1,95 @@
 -- name: CreatePackage :one
-INSERT INTO packages (tracking_code, product, weight_kg, destination_state, status)
-VALUES ($1, $2, $3, $4, 'criado')
-RETURNING id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at;
+INSERT INTO packages (tracking_code, product, weight_kg, destination_state, status, height_cm, width_cm, length_cm, product_category, origin_warehouse_id, destination_cep, destination_city, seller_id,
+                      recipient_name, recipient_document, recipient_phone, recipient_street, recipient_number, recipient_complement, recipient_neighborhood, sender_name, sender_document, sender_phone, sender_street, sender_number, sender_complement, sender_neighborhood, sender_city, sender_state, sender_cep)
+VALUES (@tracking_code, @product, @weight_kg, @destination_state, 'criado', @height_cm, @width_cm, @length_cm, COALESCE(sqlc.narg('product_category')::TEXT, 'geral'), @origin_warehouse_id, @destination_cep, @destination_city, @seller_id,
+        @recipient_name, @recipient_document, @recipient_phone, @recipient_street, @recipient_number, @recipient_complement, @recipient_neighborhood, @sender_name, @sender_document, @sender_phone, @sender_street, @sender_number, @sender_complement, @sender_neighborhood, @sender_city, @sender_state, @sender_cep)
+RETURNING id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at, height_cm, width_cm, length_cm, product_category, hired_at, estimated_delivery_date, origin_warehouse_id, destination_cep, destination_city, seller_id, recipient_name, recipient_document, recipient_phone, recipient_street, recipient_number, recipient_complement, recipient_neighborhood, sender_name, sender_document, sender_phone, sender_street, sender_number, sender_complement, sender_neighborhood, sender_city, sender_state, sender_cep, version, deleted_at, deleted_by, deleted_reason;
 
 -- name: GetPackageById :one
-SELECT id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at
+SELECT id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at, height_cm, width_cm, length_cm, product_category, hired_at, estimated_delivery_date, origin_warehouse_id, destination_cep, destination_city, seller_id, recipient_name, recipient_document, recipient_phone, recipient_street, recipient_number, recipient_complement, recipient_neighborhood, sender_name, sender_document, sender_phone, sender_street, sender_number, sender_complement, sender_neighborhood, sender_city, sender_state, sender_cep, version, deleted_at, deleted_by, deleted_reason
 FROM packages
-WHERE id = $1;
+WHERE id = @id
+  AND (sqlc.narg('seller_id')::UUID IS NULL OR seller_id = sqlc.narg('seller_id'))
+  AND (@include_deleted::BOOLEAN OR deleted_at IS NULL);
 
 -- name: GetPackageByTrackingCode :one
-SELECT id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at
-FROM packages
-WHERE tracking_code = $1;
-
--- name: ListPackages :many
-SELECT id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at
+SELECT id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at, height_cm, width_cm, length_cm, product_category, hired_at, estimated_delivery_date, origin_warehouse_id, destination_cep, destination_city, seller_id, recipient_name, recipient_document, recipient_phone, recipient_street, recipient_number, recipient_complement, recipient_neighborhood, sender_name, sender_document, sender_phone, sender_street, sender_number, sender_complement, sender_neighborhood, sender_city, sender_state, sender_cep, version, deleted_at, deleted_by, deleted_reason
 FROM packages
-ORDER BY created_at DESC;
+WHERE tracking_code = @tracking_code
+  AND (sqlc.narg('seller_id')::UUID IS NULL OR seller_id = sqlc.narg('seller_id'))
+  AND deleted_at IS NULL;
 
--- name: UpdatePackageStatus :exec
+-- name: UpdatePackageStatus :execrows
+-- Zero rows means the package changed since it was read
 UPDATE packages
-SET status = $2, updated_at = NOW()
-WHERE id = $1;
+SET status = $2, version = version + 1, updated_at = NOW()
+WHERE id = $1
+  AND version = $3;
 
--- name: HireCarrier :exec
+-- name: HireCarrier :execrows
 UPDATE packages
 SET hired_carrier_id = $2,
     hired_price = $3,
     hired_delivery_days = $4,
+    hired_at = NOW(),
+    estimated_delivery_date = $5,
     status = 'esperando_coleta',
+    tracking_code = COALESCE(tracking_code, $7),
+    version = version + 1,
     updated_at = NOW()
-WHERE id = $1;
+WHERE id = $1
+  AND version = $6;
 
--- name: DeletePackage :exec
-DELETE FROM packages
-WHERE id = $1;
+-- name: CreatePackagesBatch :many
+INSERT INTO packages (product, weight_kg, destination_state, status, height_cm, width_cm, length_cm, product_category, origin_warehouse_id, destination_cep, destination_city, seller_id,
+                      recipient_name, recipient_document, recipient_phone, recipient_street, recipient_number, recipient_complement, recipient_neighborhood, sender_name, sender_document, sender_phone, sender_street, sender_number, sender_complement, sender_neighborhood, sender_city, sender_state, sender_cep)
+SELECT e.item->>'product',
+       (e.item->>'weight_kg')::FLOAT,
+       e.item->>'destination_state',
+       'criado',
+       (e.item->>'height_cm')::FLOAT,
+       (e.item->>'width_cm')::FLOAT,
+       (e.item->>'length_cm')::FLOAT,
+       COALESCE(e.item->>'product_category', 'geral'),
+       (e.item->>'origin_warehouse_id')::UUID,
+       e.item->>'destination_cep',
+       e.item->>'destination_city',
+       @seller_id::UUID,
+       e.item->>'recipient_name',
+       e.item->>'recipient_document',
+       e.item->>'recipient_phone',
+       e.item->>'recipient_street',
+       e.item->>'recipient_number',
+       e.item->>'recipient_complement',
+       e.item->>'recipient_neighborhood',
+       e.item->>'sender_name',
+       e.item->>'sender_document',
+       e.item->>'sender_phone',
+       e.item->>'sender_street',
+       e.item->>'sender_number',
+       e.item->>'sender_complement',
+       e.item->>'sender_neighborhood',
+       e.item->>'sender_city',
+       e.item->>'sender_state',
+       e.item->>'sender_cep'
+FROM jsonb_array_elements(@packages::JSONB) WITH ORDINALITY AS e(item, position)
+ORDER BY e.position
+RETURNING id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at, height_cm, width_cm, length_cm, product_category, hired_at, estimated_delivery_date, origin_warehouse_id, destination_cep, destination_city, seller_id, recipient_name, recipient_document, recipient_phone, recipient_street, recipient_number, recipient_complement, recipient_neighborhood, sender_name, sender_document, sender_phone, sender_street, sender_number, sender_complement, sender_neighborhood, sender_city, sender_state, sender_cep, version, deleted_at, deleted_by, deleted_reason;
+
+-- name: DeletePackage :execrows
+-- Soft delete; history and tracking code are kept
+UPDATE packages
+SET deleted_at = NOW(), deleted_by = $3, deleted_reason = $4, version = version + 1, updated_at = NOW()
+WHERE id = $1
+  AND version = $2
+  AND deleted_at IS NULL;
+
+-- name: RestorePackage :execrows
+UPDATE packages
+SET deleted_at = NULL, deleted_by = NULL, deleted_reason = NULL, version = version + 1, updated_at = NOW()
+WHERE id = $1
+  AND version = $2
+  AND deleted_at IS NOT NULL;
 
 -- name: TrackingCodeExists :one
 SELECT EXISTS(
@@ -43,21 +98,77 @@ SELECT EXISTS(
 );
 
 -- name: GetQuotesForPackage :many
-SELECT
-    c.name as carier,
-    (cr.price_per_kg * @weight_kg::DECIMAL)::FLOAT as estimated_price,
-    cr.estimated_delivery_days
+SELECT DISTINCT ON (c.id)
+    c.id as carrier_id,
+    c.name as carrier_name,
+    cr.id as carrier_region_id,
+    cr.price_per_kg,
+    cr.excess_price_per_kg,
+    cr.min_charge,
+    cr.cubing_divisor,
+    cr.estimated_delivery_days,
+    c.max_weight_kg,
+    c.max_side_cm,
+    c.max_dimensions_sum_cm,
+    c.cutoff_time
 FROM carriers c
          JOIN carrier_regions cr ON c.id = cr.carrier_id
          JOIN states s ON s.region_id = cr.region_id
-WHERE s.code = @state_code;
+WHERE s.code = @state_code
+  AND c.deleted_at IS NULL
+  AND cr.valid_from <= NOW()
+  AND (cr.valid_to IS NULL OR cr.valid_to > NOW())
+  AND (cr.origin_region_id IS NULL
+       OR cr.origin_region_id = (SELECT o.region_id FROM states o WHERE o.code = sqlc.narg('origin_state')))
+-- A lane-specific table wins over the any-origin one
+ORDER BY c.id, cr.origin_region_id NULLS LAST;
 
 -- name: GetCarrierById :one
-SELECT id, name, created_at
+SELECT id, name, created_at, max_weight_kg, max_side_cm, max_dimensions_sum_cm, cutoff_time, tracking_prefix, deleted_at
 FROM carriers
 WHERE id = $1;
 
--- name: UpdatePackageStatusWithTracking :exec
+-- name: UpdatePackageStatusWithTracking :execrows
 UPDATE packages
-SET status = $2, tracking_code = $3, updated_at = NOW()
-WHERE id = $1;
\ No newline at end of file
+SET status = $2, tracking_code = $3, version = version + 1, updated_at = NOW()
+WHERE id = $1
+  AND version = $4;
+
+-- name: ListPackagesPage :many
+SELECT id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at, height_cm, width_cm, length_cm, product_category, hired_at, estimated_delivery_date, origin_warehouse_id, destination_cep, destination_city, seller_id, recipient_name, recipient_document, recipient_phone, recipient_street, recipient_number, recipient_complement, recipient_neighborhood, sender_name, sender_document, sender_phone, sender_street, sender_number, sender_complement, sender_neighborhood, sender_city, sender_state, sender_cep, version, deleted_at, deleted_by, deleted_reason
+FROM packages
+WHERE (sqlc.narg('status')::VARCHAR IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('destination_state')::CHAR(2) IS NULL OR destination_state = sqlc.narg('destination_state'))
+  AND (sqlc.narg('hired_carrier_id')::UUID IS NULL OR hired_carrier_id = sqlc.narg('hired_carrier_id'))
+  AND (sqlc.narg('created_from')::TIMESTAMP IS NULL OR created_at >= sqlc.narg('created_from'))
+  AND (sqlc.narg('created_to')::TIMESTAMP IS NULL OR created_at < sqlc.narg('created_to'))
+  AND (sqlc.narg('search')::TEXT IS NULL OR position(lower(sqlc.narg('search')) IN lower(product)) > 0)
+  AND (
+      sqlc.narg('cursor_id')::UUID IS NULL
+      OR (@sort_by::TEXT = 'created_at' AND @sort_desc::BOOLEAN AND (created_at, id) < (sqlc.narg('cursor_created_at')::TIMESTAMP, sqlc.narg('cursor_id')))
+      OR (@sort_by = 'created_at' AND NOT @sort_desc AND (created_at, id) > (sqlc.narg('cursor_created_at'), sqlc.narg('cursor_id')))
+      OR (@sort_by = 'weight_kg' AND @sort_desc AND (weight_kg, id) < (sqlc.narg('cursor_weight_kg')::FLOAT, sqlc.narg('cursor_id')))
+      OR (@sort_by = 'weight_kg' AND NOT @sort_desc AND (weight_kg, id) > (sqlc.narg('cursor_weight_kg'), sqlc.narg('cursor_id')))
+  )
+  AND (sqlc.narg('seller_id')::UUID IS NULL OR seller_id = sqlc.narg('seller_id'))
+  AND (deleted_at IS NOT NULL) = @deleted::BOOLEAN
+ORDER BY
+    CASE WHEN @sort_by = 'created_at' AND @sort_desc THEN created_at END DESC,
+    CASE WHEN @sort_by = 'created_at' AND NOT @sort_desc THEN created_at END ASC,
+    CASE WHEN @sort_by = 'weight_kg' AND @sort_desc THEN weight_kg END DESC,
+    CASE WHEN @sort_by = 'weight_kg' AND NOT @sort_desc THEN weight_kg END ASC,
+    CASE WHEN @sort_desc THEN id END DESC,
+    CASE WHEN NOT @sort_desc THEN id END ASC
+LIMIT @page_limit;
+
+-- name: CountPackages :one
+SELECT COUNT(*)
+FROM packages
+WHERE (sqlc.narg('status')::VARCHAR IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('destination_state')::CHAR(2) IS NULL OR destination_state = sqlc.narg('destination_state'))
+  AND (sqlc.narg('hired_carrier_id')::UUID IS NULL OR hired_carrier_id = sqlc.narg('hired_carrier_id'))
+  AND (sqlc.narg('created_from')::TIMESTAMP IS NULL OR created_at >= sqlc.narg('created_from'))
+  AND (sqlc.narg('created_to')::TIMESTAMP IS NULL OR created_at < sqlc.narg('created_to'))
+  AND (sqlc.narg('search')::TEXT IS NULL OR position(lower(sqlc.narg('search')) IN lower(product)) > 0)
+  AND (sqlc.narg('seller_id')::UUID IS NULL OR seller_id = sqlc.narg('seller_id'))
+  AND (deleted_at IS NOT NULL) = @deleted::BOOLEAN;
@@ -0,0 +1,17 @@
+-- name: CreateQuote :one
+INSERT INTO quotes (package_id, carrier_id, price, estimated_delivery_days, billable_weight_kg, expires_at)
+VALUES (@package_id, @carrier_id, @price, @estimated_delivery_days, @billable_weight_kg,
+        NOW() + make_interval(secs => @ttl_seconds::float8))
+RETURNING id, package_id, carrier_id, price, estimated_delivery_days, billable_weight_kg, expires_at, used_at, created_at;
+
+-- name: GetQuoteById :one
+SELECT id, package_id, carrier_id, price, estimated_delivery_days, billable_weight_kg, expires_at, used_at, created_at
+FROM quotes
+WHERE id = $1;
+
+-- name: MarkQuoteUsed :execrows
+UPDATE quotes
+SET used_at = NOW()
+WHERE id = $1
+  AND used_at IS NULL
+  AND expires_at > NOW();
@@ -0,0 +1,29 @@
+-- name: CreateSeller :one
+INSERT INTO sellers (name)
+VALUES ($1)
+RETURNING id, name, created_at;
+
+-- name: GetSellerById :one
+SELECT id, name, created_at
+FROM sellers
+WHERE id = $1;
+
+-- name: ListSellers :many
+SELECT id, name, created_at
+FROM sellers
+ORDER BY name;
+
+-- name: ListSellerCarrierRates :many
+SELECT seller_id, carrier_id, price_per_kg, markup_percent, created_at, updated_at
+FROM seller_carrier_rates
+WHERE seller_id = $1
+ORDER BY carrier_id;
+
+-- name: UpsertSellerCarrierRate :one
+INSERT INTO seller_carrier_rates (seller_id, carrier_id, price_per_kg, markup_percent)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (seller_id, carrier_id) DO UPDATE
+SET price_per_kg = EXCLUDED.price_per_kg,
+    markup_percent = EXCLUDED.markup_percent,
+    updated_at = NOW()
+RETURNING seller_id, carrier_id, price_per_kg, markup_percent, created_at, updated_at;
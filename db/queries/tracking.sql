@@ -0,0 +1,2 @@
+-- name: NextTrackingSerial :one
+SELECT next_tracking_serial(@prefix::TEXT)::BIGINT AS serial;
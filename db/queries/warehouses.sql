@@ -0,0 +1,14 @@
+-- name: CreateWarehouse :one
+INSERT INTO warehouses (name, state_code)
+VALUES ($1, $2)
+RETURNING id, name, state_code, created_at;
+
+-- name: GetWarehouseById :one
+SELECT id, name, state_code, created_at
+FROM warehouses
+WHERE id = $1;
+
+-- name: ListWarehouses :many
+SELECT id, name, state_code, created_at
+FROM warehouses
+ORDER BY name;
@@ -0,0 +1,77 @@
+-- name: CreateWebhookSubscription :one
+INSERT INTO webhook_subscriptions (url, secret, event_types)
+VALUES ($1, $2, $3)
+RETURNING id, url, secret, event_types, created_at;
+
+-- name: DeleteWebhookSubscription :execrows
+DELETE FROM webhook_subscriptions
+WHERE id = $1;
+
+-- name: ListWebhookSubscriptions :many
+SELECT id, url, secret, event_types, created_at
+FROM webhook_subscriptions
+ORDER BY created_at;
+
+-- name: ListWebhookSubscriptionsForEvent :many
+SELECT id, url, secret, event_types, created_at
+FROM webhook_subscriptions
+WHERE @event_type::text = ANY(event_types)
+ORDER BY created_at;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+VALUES ($1, $2, $3, $4)
+RETURNING id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+    last_response_status, last_error, delivered_at, created_at;
+
+-- name: GetWebhookDeliveryById :one
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+    last_response_status, last_error, delivered_at, created_at
+FROM webhook_deliveries
+WHERE id = $1;
+
+-- name: ListWebhookDeliveries :many
+SELECT id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+    last_response_status, last_error, delivered_at, created_at
+FROM webhook_deliveries
+WHERE subscription_id = $1
+ORDER BY created_at DESC
+LIMIT $2;
+
+-- name: ClaimDueWebhookDeliveries :many
+-- Claimed deliveries are retried once the 5-minute claim expires
+UPDATE webhook_deliveries d
+SET next_attempt_at = NOW() + INTERVAL '5 minutes'
+FROM webhook_subscriptions s
+WHERE s.id = d.subscription_id
+  AND d.id IN (
+      SELECT id
+      FROM webhook_deliveries
+      WHERE status = 'pendente' AND next_attempt_at <= NOW()
+      ORDER BY next_attempt_at
+      LIMIT $1
+      FOR UPDATE SKIP LOCKED
+  )
+RETURNING d.id, d.event_id, d.event_type, d.payload, d.attempts, s.url, s.secret;
+
+-- name: RecordWebhookDeliveryAttempt :exec
+UPDATE webhook_deliveries
+SET status = @status,
+    attempts = @attempts,
+    next_attempt_at = NOW() + make_interval(secs => @retry_after_seconds::float8),
+    last_response_status = @last_response_status,
+    last_error = @last_error,
+    delivered_at = CASE WHEN @status::text = 'entregue' THEN NOW() END
+WHERE id = @id;
+
+-- name: ReplayWebhookDelivery :one
+UPDATE webhook_deliveries
+SET status = 'pendente',
+    attempts = 0,
+    next_attempt_at = NOW(),
+    last_response_status = NULL,
+    last_error = NULL,
+    delivered_at = NULL
+WHERE id = $1
+RETURNING id, subscription_id, event_id, event_type, payload, status, attempts, next_attempt_at,
+    last_response_status, last_error, delivered_at, created_at;
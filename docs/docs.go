@@ -1,4 +1,5 @@
-// Package docs Code generated by swaggo/swag. DO NOT EDIT
+// Code generated by swaggo/swag. DO NOT EDIT.
+
 package docs
 
 import "github.com/swaggo/swag"
@@ -9,25 +10,36 @@ const docTemplate = `{
     "info": {
         "description": "{{escape .Description}}",
         "title": "{{.Title}}",
-        "contact": {},
+        "termsOfService": "http://swagger.io/terms/",
+        "contact": {
+            "name": "API Support",
+            "url": "http://www.swagger.io/support",
+            "email": "support@olist.com"
+        },
+        "license": {
+            "name": "Apache 2.0",
+            "url": "http://www.apache.org/licenses/LICENSE-2.0.html"
+        },
         "version": "{{.Version}}"
     },
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/carriers": {
+        "/admin/api-keys": {
             "get": {
-                "description": "Get all available carriers",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "List issued API keys, including revoked ones; keys themselves are never returned",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "carriers"
+                    "auth"
                 ],
-                "summary": "List all carriers",
+                "summary": "List API keys",
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -42,7 +54,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/v1.CarrierResponse"
+                                                "$ref": "#/definitions/v1.APIKeyResponse"
                                             }
                                         }
                                     }
@@ -50,48 +62,16 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/v1.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/packages": {
-            "get": {
-                "description": "Get all packages with pagination support",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "packages"
-                ],
-                "summary": "List all packages",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/v1.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/v1.PackageResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/v1.Response"
                         }
                     },
                     "500": {
@@ -103,7 +83,12 @@ const docTemplate = `{
                 }
             },
             "post": {
-                "description": "Create a new package for shipping",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue a new API key with the given role; the key is only returned in this response. Seller keys require vendedor_id and only see that seller's packages",
                 "consumes": [
                     "application/json"
                 ],
@@ -111,17 +96,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "packages"
+                    "auth"
                 ],
-                "summary": "Create a new package",
+                "summary": "Issue an API key",
                 "parameters": [
                     {
-                        "description": "Package data",
+                        "description": "API key data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.CreatePackageRequest"
+                            "$ref": "#/definitions/v1.CreateAPIKeyRequest"
                         }
                     }
                 ],
@@ -137,7 +122,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.PackageResponse"
+                                            "$ref": "#/definitions/v1.APIKeyResponse"
                                         }
                                     }
                                 }
@@ -150,6 +135,30 @@ const docTemplate = `{
                             "$ref": "#/definitions/v1.Response"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -159,24 +168,26 @@ const docTemplate = `{
                 }
             }
         },
-        "/packages/tracking/{tracking_code}": {
-            "get": {
-                "description": "Get package details by tracking code",
-                "consumes": [
-                    "application/json"
+        "/admin/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Revoke an API key; requests using it are rejected immediately",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "packages"
+                    "auth"
                 ],
-                "summary": "Get package by tracking code",
+                "summary": "Revoke an API key",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tracking Code",
-                        "name": "tracking_code",
+                        "description": "API key ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -193,15 +204,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.PackageResponse"
+                                            "$ref": "#/definitions/v1.APIKeyResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/v1.Response"
                         }
@@ -211,13 +228,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/v1.Response"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
                     }
                 }
             }
         },
-        "/packages/{id}": {
-            "get": {
-                "description": "Get package details by package ID",
+        "/admin/carriers/{id}/event-mappings/{code}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Map a carrier scan code to a package status; omit status to make the code informational only",
                 "consumes": [
                     "application/json"
                 ],
@@ -225,16 +253,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "packages"
+                    "admin"
                 ],
-                "summary": "Get package by ID",
+                "summary": "Create or update a carrier event mapping",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Package ID",
+                        "description": "Carrier ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Carrier event code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Mapping",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.SaveCarrierEventMappingRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -249,7 +293,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/v1.PackageResponse"
+                                            "$ref": "#/definitions/v1.CarrierEventMappingResponse"
                                         }
                                     }
                                 }
@@ -267,11 +311,24 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/v1.Response"
                         }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
                     }
                 }
-            },
-            "delete": {
-                "description": "Delete a package by ID",
+            }
+        },
+        "/admin/carriers/{id}/token": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a new token for the carrier events endpoint; the previous token stops working and the new one is only shown in this response",
                 "consumes": [
                     "application/json"
                 ],
@@ -279,13 +336,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "packages"
+                    "admin"
                 ],
-                "summary": "Delete a package",
+                "summary": "Rotate a carrier API token",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Package ID",
+                        "description": "Carrier ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -295,11 +352,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/v1.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.CarrierTokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/v1.Response"
                         }
@@ -313,46 +382,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/packages/{id}/hire": {
-            "post": {
-                "description": "Hire a carrier to deliver the package",
-                "consumes": [
-                    "application/json"
+        "/admin/sellers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "List the marketplace sellers that own packages",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "packages"
-                ],
-                "summary": "Hire carrier for package",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Package ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Carrier hire data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/v1.HireCarrierRequest"
-                        }
-                    }
+                    "sellers"
                 ],
+                "summary": "List sellers",
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.SellerResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/v1.Response"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/v1.Response"
                         }
@@ -364,11 +438,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/packages/{id}/status": {
-            "patch": {
-                "description": "Update the status of a package",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a marketplace seller; packages and seller API keys are bound to it",
                 "consumes": [
                     "application/json"
                 ],
@@ -376,30 +453,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "packages"
+                    "sellers"
                 ],
-                "summary": "Update package status",
+                "summary": "Create a seller",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Package ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Status data",
+                        "description": "Seller data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/v1.UpdatePackageStatusRequest"
+                            "$ref": "#/definitions/v1.CreateSellerRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.SellerResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
                     },
                     "400": {
                         "description": "Bad Request",
@@ -407,6 +492,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/v1.Response"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -416,32 +513,27 @@ const docTemplate = `{
                 }
             }
         },
-        "/quotes": {
+        "/admin/sellers/{id}/rates": {
             "get": {
-                "description": "Get shipping quotes for a package based on destination state and weight",
-                "consumes": [
-                    "application/json"
-                ],
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the negotiated price per kg and markup of a seller for each carrier",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "quotes"
+                    "sellers"
                 ],
-                "summary": "Get shipping quotes",
+                "summary": "List seller negotiated rates",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Destination state code",
-                        "name": "estado_destino",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "number",
-                        "description": "Package weight in kg",
-                        "name": "peso_kg",
-                        "in": "query",
+                        "description": "Seller ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -459,7 +551,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/v1.QuoteResponse"
+                                                "$ref": "#/definitions/v1.SellerRateResponse"
                                             }
                                         }
                                     }
@@ -467,12 +559,278 @@ const docTemplate = `{
                             ]
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/sellers/{id}/rates/{carrier_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create or replace the seller's condition for a carrier: preco_por_kg replaces the carrier's per-kg price and weight tiers (minimum charge still applies) and acrescimo_percentual is applied to the freight (negative for a discount)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "sellers"
+                ],
+                "summary": "Save a seller negotiated rate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Seller ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Carrier ID",
+                        "name": "carrier_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Negotiated rate",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.SaveSellerRateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.SellerRateResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/v1.Response"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/deliveries/{id}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Put a delivery back in the queue with its attempts reset, whether it failed or was already delivered",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Replay a webhook delivery",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.WebhookDeliveryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/token": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Exchange the current credential (API key or JWT) for a short-lived JWT with the same subject and role",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Issue a JWT",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.TokenResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "501": {
+                        "description": "Not Implemented",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/carriers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all available carriers",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "List all carriers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.CarrierResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -480,60 +838,3302 @@ const docTemplate = `{
                         }
                     }
                 }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a carrier; it only shows up in quotes after its route coverage is added",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "Create a carrier",
+                "parameters": [
+                    {
+                        "description": "Carrier data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.CarrierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.CarrierResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/carriers/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a carrier with its limits and the routes it covers, with delivery days and prices",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "Get a carrier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Carrier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.CarrierDetailResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the carrier data; omitted limits are removed and an omitted cutoff time resets to 14:00",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "Update a carrier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Carrier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Carrier data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.CarrierRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.CarrierResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove the carrier from listings and quotes; packages it already shipped keep their history. Carriers with packages in progress cannot be deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "Delete a carrier",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Carrier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/carriers/{id}/coverage": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a rate version for a destination region served by the carrier, with delivery days and prices; without regiao_origem_id the route applies to any origin. Without valido_de the version is in effect from now on; a future valido_de schedules it and the current version stays in effect until then",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "Add a carrier route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Carrier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Route",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.CreateCarrierCoverageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.CarrierCoverageResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/carriers/{id}/coverage/{coverage_id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a new rate version for the route of the given version; the previous one is kept so past prices can still be explained. Without valido_de it is in effect from now on, a future valido_de schedules it. To change the regions, remove the route and add it again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "Update a carrier route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Carrier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Route ID",
+                        "name": "coverage_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Route",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UpdateCarrierCoverageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.CarrierCoverageResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "End a route now; the carrier stops being quoted for it, scheduled versions are discarded and past versions stay in the rate history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "Remove a carrier route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Carrier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Route ID",
+                        "name": "coverage_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/carriers/{id}/event-mappings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get how each carrier scan code maps to a package status; codes without status are informational",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "List carrier event mappings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Carrier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.CarrierEventMappingResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/carriers/{id}/events": {
+            "post": {
+                "description": "Endpoint for carriers to push scan events. Authenticated with the carrier token (Authorization: Bearer). The carrier code is mapped to a package status and the package is moved forward; repeated evento_id values are acknowledged without being stored again",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "Receive a carrier tracking event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Carrier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Scan event",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.CarrierEventRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Duplicate event",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.CarrierEventResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.CarrierEventResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/carriers/{id}/rates": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the rate versions of the carrier routes with their validity. With at, only the versions in effect at that moment (a date alone means midnight); without it, every version, including ended and scheduled ones",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "List carrier rate versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Carrier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Moment (YYYY-MM-DD or RFC 3339)",
+                        "name": "at",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.CarrierCoverageResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/carriers/{id}/rates/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload the carrier rate sheet as CSV, one weight band per row: regiao (name or id) or estado, peso_max_kg, preco and prazo_dias; optional peso_min_kg (defaults to the previous band), frete_minimo and excedente_por_kg (required for new routes). Each route is compared with its current rates for any origin; with simular=true only the diff is returned, otherwise new and changed routes get a new rate version atomically, from valido_de or now. Any invalid row rejects the whole sheet.",
+                "consumes": [
+                    "text/csv",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "carriers"
+                ],
+                "summary": "Import a carrier rate sheet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Carrier ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only compare with the current rates",
+                        "name": "simular",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of the new versions (YYYY-MM-DD or RFC 3339)",
+                        "name": "valido_de",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Rate sheet (multipart)",
+                        "name": "arquivo",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ImportCarrierRatesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ImportCarrierRatesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/packages": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List packages with filters, sorting and cursor-based pagination",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "List packages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Package status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Destination state code",
+                        "name": "estado_destino",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Hired carrier ID",
+                        "name": "transportadora_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created from (YYYY-MM-DD)",
+                        "name": "criado_de",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Created until, inclusive (YYYY-MM-DD)",
+                        "name": "criado_ate",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product text search",
+                        "name": "busca",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field (criado_em, peso_kg)",
+                        "name": "ordenar_por",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (asc, desc)",
+                        "name": "ordem",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor returned by the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (max 100)",
+                        "name": "limite",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "List only deleted packages",
+                        "name": "excluidos",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.PackageResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new package for shipping. With a recipient (destinatario), the destination state and CEP come from its address; CPF/CNPJ check digits and phone are validated for both addresses.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Create a new package",
+                "parameters": [
+                    {
+                        "description": "Package data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.CreatePackageRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the first response for retries with the same key and body",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PackageResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/packages/import": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bulk create packages from a CSV (header with the same fields as the create request) or NDJSON file. Rows are validated with the create rules; valid rows are inserted atomically. In \"tudo_ou_nada\" mode any invalid row rejects the whole file.",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Import packages",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File format (csv, ndjson); inferred from Content-Type or file extension",
+                        "name": "formato",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Import mode (parcial, tudo_ou_nada)",
+                        "name": "modo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Seller that owns the packages (required without a seller-scoped credential)",
+                        "name": "vendedor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "file",
+                        "description": "File to import (multipart)",
+                        "name": "arquivo",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ImportPackagesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.ImportPackagesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/packages/tracking/{tracking_code}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get package details by tracking code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Get package by tracking code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tracking Code",
+                        "name": "tracking_code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy; responds 304 while the package is unchanged",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PackageResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/packages/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get package details by package ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Get package by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Package ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy; responds 304 while the package is unchanged",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PackageResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft delete a package by ID; it can be restored later. Shipped packages cannot be deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Delete a package",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Package ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deletion reason",
+                        "name": "motivo",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the package version being deleted",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/packages/{id}/hire": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hire a carrier to deliver the package using a saved, unexpired quote of that package",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Hire carrier for package",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Package ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Carrier hire data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.HireCarrierRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Replays the first response for retries with the same key and body",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the package version being changed",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/packages/{id}/history": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the timeline of status transitions of a package",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Get package status history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Package ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.PackageStatusEventResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/packages/{id}/label": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Render the shipping label of a package with a hired carrier (sender, recipient, carrier, Code128 tracking barcode and QR code). The tracking code is assigned when the carrier is hired; this endpoint never changes the package.",
+                "produces": [
+                    "application/pdf",
+                    "text/plain"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Get shipping label",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Package ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "pdf",
+                        "description": "Label format (pdf, zpl)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/packages/{id}/quotes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Compute and save shipping quotes for the package; the returned quote id is used to hire the carrier before it expires",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Create package quotes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Package ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.QuotesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/packages/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Bring back a soft-deleted package with the status it had when it was deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Restore a deleted package",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Package ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the package version being restored",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.PackageResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/packages/{id}/status": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the status of a package",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Update package status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Package ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Status data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.UpdatePackageStatusRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the package version being changed",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/quotes": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get shipping quotes for a package based on destination state, weight and optional dimensions (billable weight)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "quotes"
+                ],
+                "summary": "Get shipping quotes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Destination state code (required without cep_destino)",
+                        "name": "estado_destino",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Destination CEP (00000-000)",
+                        "name": "cep_destino",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Package weight in kg",
+                        "name": "peso_kg",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "Package height in cm",
+                        "name": "altura_cm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Package width in cm",
+                        "name": "largura_cm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Package length in cm",
+                        "name": "comprimento_cm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Product category (geral, fragil, liquido, bateria)",
+                        "name": "categoria",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Origin warehouse ID",
+                        "name": "armazem_origem_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Seller whose negotiated rates apply (ignored for seller-scoped credentials)",
+                        "name": "vendedor_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.QuotesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/regions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the regions used by carrier coverage",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "states"
+                ],
+                "summary": "List all regions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.RegionResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/states": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all Brazilian states with their regions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "states"
+                ],
+                "summary": "List all states",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.StateResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/tracking/{tracking_code}": {
+            "get": {
+                "description": "Public tracking view of a package: current status, carrier, estimated delivery date and the chronological list of status changes and carrier scans. Prices and internal IDs are not exposed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "tracking"
+                ],
+                "summary": "Public package tracking",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tracking Code",
+                        "name": "tracking_code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.TrackingResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/warehouses": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all origin warehouses (distribution centers)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "List all warehouses",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.WarehouseResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register an origin warehouse (distribution center)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "warehouses"
+                ],
+                "summary": "Create a warehouse",
+                "parameters": [
+                    {
+                        "description": "Warehouse data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.CreateWarehouseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.WarehouseResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get all webhook subscriptions; secrets are never returned",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook subscriptions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.WebhookResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Subscribe a URL to package lifecycle events. Deliveries are signed with HMAC-SHA256 using the secret, which is generated when omitted and only returned here",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Create a webhook subscription",
+                "parameters": [
+                    {
+                        "description": "Subscription data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/v1.CreateWebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/v1.WebhookResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a webhook subscription and its delivery history",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "Delete a webhook subscription",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the most recent deliveries of a webhook subscription, with attempts and last error",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of deliveries (default 50)",
+                        "name": "limite",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/v1.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/v1.WebhookDeliveryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/v1.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "v1.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "chave": {
+                    "type": "string"
+                },
+                "criado_em": {
+                    "type": "string"
+                },
+                "criado_por": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "papel": {
+                    "type": "string"
+                },
+                "prefixo": {
+                    "type": "string"
+                },
+                "revogada_em": {
+                    "type": "string"
+                },
+                "ultimo_uso_em": {
+                    "type": "string"
+                },
+                "vendedor_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.AddressRequest": {
+            "type": "object",
+            "required": [
+                "bairro",
+                "cep",
+                "cidade",
+                "documento",
+                "estado",
+                "logradouro",
+                "nome",
+                "numero"
+            ],
+            "properties": {
+                "bairro": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "cep": {
+                    "type": "string"
+                },
+                "cidade": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "complemento": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "documento": {
+                    "type": "string"
+                },
+                "estado": {
+                    "type": "string"
+                },
+                "logradouro": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "numero": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "telefone": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.AddressResponse": {
+            "type": "object",
+            "properties": {
+                "bairro": {
+                    "type": "string"
+                },
+                "cep": {
+                    "type": "string"
+                },
+                "cidade": {
+                    "type": "string"
+                },
+                "complemento": {
+                    "type": "string"
+                },
+                "documento": {
+                    "type": "string"
+                },
+                "estado": {
+                    "type": "string"
+                },
+                "logradouro": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "numero": {
+                    "type": "string"
+                },
+                "telefone": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.CarrierCoverageResponse": {
+            "type": "object",
+            "properties": {
+                "divisor_cubagem": {
+                    "type": "integer"
+                },
+                "excedente_por_kg": {
+                    "type": "string"
+                },
+                "frete_minimo": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "prazo_estimado_dias": {
+                    "type": "integer"
+                },
+                "preco_por_kg": {
+                    "type": "string"
+                },
+                "regiao": {
+                    "type": "string"
+                },
+                "regiao_id": {
+                    "type": "string"
+                },
+                "regiao_origem": {
+                    "type": "string"
+                },
+                "regiao_origem_id": {
+                    "type": "string"
+                },
+                "valido_ate": {
+                    "type": "string"
+                },
+                "valido_de": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.CarrierDetailResponse": {
+            "type": "object",
+            "properties": {
+                "cobertura": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.CarrierCoverageResponse"
+                    }
+                },
+                "criado_em": {
+                    "type": "string"
+                },
+                "horario_corte": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lado_maximo_cm": {
+                    "type": "number"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "peso_maximo_kg": {
+                    "type": "number"
+                },
+                "prefixo_rastreio": {
+                    "type": "string"
+                },
+                "soma_dimensoes_maxima_cm": {
+                    "type": "number"
+                }
+            }
+        },
+        "v1.CarrierEventMappingResponse": {
+            "type": "object",
+            "properties": {
+                "codigo": {
+                    "type": "string"
+                },
+                "descricao": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.CarrierEventRequest": {
+            "type": "object",
+            "required": [
+                "codigo",
+                "codigo_rastreio",
+                "evento_id",
+                "ocorrido_em"
+            ],
+            "properties": {
+                "codigo": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "codigo_rastreio": {
+                    "type": "string",
+                    "maxLength": 13
+                },
+                "descricao": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "evento_id": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "local": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "ocorrido_em": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.CarrierEventResponse": {
+            "type": "object",
+            "properties": {
+                "duplicado": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "pacote_id": {
+                    "type": "string"
+                },
+                "status_anterior": {
+                    "type": "string"
+                },
+                "status_atual": {
+                    "type": "string"
+                },
+                "status_mapeado": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.CarrierRateChangeResponse": {
+            "type": "object",
+            "properties": {
+                "atual": {
+                    "$ref": "#/definitions/v1.CarrierRateSheetResponse"
+                },
+                "nova": {
+                    "$ref": "#/definitions/v1.CarrierRateSheetResponse"
+                },
+                "regiao": {
+                    "type": "string"
+                },
+                "regiao_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "versao_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.CarrierRateErrorResponse": {
+            "type": "object",
+            "properties": {
+                "erros": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "linha": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.CarrierRateSheetResponse": {
+            "type": "object",
+            "properties": {
+                "excedente_por_kg": {
+                    "type": "number"
+                },
+                "faixas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.CarrierRateTierResponse"
+                    }
+                },
+                "frete_minimo": {
+                    "type": "number"
+                },
+                "prazo_estimado_dias": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.CarrierRateTierResponse": {
+            "type": "object",
+            "properties": {
+                "peso_max_kg": {
+                    "type": "number"
+                },
+                "peso_min_kg": {
+                    "type": "number"
+                },
+                "preco": {
+                    "type": "number"
+                }
+            }
+        },
+        "v1.CarrierRequest": {
+            "type": "object",
+            "required": [
+                "nome",
+                "prefixo_rastreio"
+            ],
+            "properties": {
+                "horario_corte": {
+                    "type": "string"
+                },
+                "lado_maximo_cm": {
+                    "type": "number"
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "peso_maximo_kg": {
+                    "type": "number"
+                },
+                "prefixo_rastreio": {
+                    "type": "string"
+                },
+                "soma_dimensoes_maxima_cm": {
+                    "type": "number"
+                }
+            }
+        },
+        "v1.CarrierResponse": {
+            "type": "object",
+            "properties": {
+                "criado_em": {
+                    "type": "string"
+                },
+                "horario_corte": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "lado_maximo_cm": {
+                    "type": "number"
+                },
+                "nome": {
+                    "type": "string"
+                },
+                "peso_maximo_kg": {
+                    "type": "number"
+                },
+                "prefixo_rastreio": {
+                    "type": "string"
+                },
+                "soma_dimensoes_maxima_cm": {
+                    "type": "number"
+                }
+            }
+        },
+        "v1.CarrierTokenResponse": {
+            "type": "object",
+            "properties": {
+                "token": {
+                    "type": "string"
+                },
+                "transportadora_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "nome",
+                "papel"
+            ],
+            "properties": {
+                "nome": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "papel": {
+                    "type": "string",
+                    "enum": [
+                        "admin",
+                        "operator",
+                        "seller",
+                        "readonly"
+                    ]
+                },
+                "vendedor_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.CreateCarrierCoverageRequest": {
+            "type": "object",
+            "required": [
+                "prazo_estimado_dias",
+                "preco_por_kg",
+                "regiao_id"
+            ],
+            "properties": {
+                "divisor_cubagem": {
+                    "type": "integer"
+                },
+                "excedente_por_kg": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "frete_minimo": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "prazo_estimado_dias": {
+                    "type": "integer"
+                },
+                "preco_por_kg": {
+                    "type": "number"
+                },
+                "regiao_id": {
+                    "type": "string"
+                },
+                "regiao_origem_id": {
+                    "type": "string"
+                },
+                "valido_de": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.CreatePackageRequest": {
+            "type": "object",
+            "required": [
+                "peso_kg",
+                "produto"
+            ],
+            "properties": {
+                "altura_cm": {
+                    "type": "number"
+                },
+                "armazem_origem_id": {
+                    "type": "string"
+                },
+                "categoria": {
+                    "type": "string",
+                    "enum": [
+                        "geral",
+                        "fragil",
+                        "liquido",
+                        "bateria"
+                    ]
+                },
+                "cep_destino": {
+                    "type": "string"
+                },
+                "comprimento_cm": {
+                    "type": "number"
+                },
+                "destinatario": {
+                    "$ref": "#/definitions/v1.AddressRequest"
+                },
+                "estado_destino": {
+                    "type": "string"
+                },
+                "largura_cm": {
+                    "type": "number"
+                },
+                "peso_kg": {
+                    "type": "number"
+                },
+                "produto": {
+                    "type": "string"
+                },
+                "remetente": {
+                    "$ref": "#/definitions/v1.AddressRequest"
+                },
+                "vendedor_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.CreateSellerRequest": {
+            "type": "object",
+            "required": [
+                "nome"
+            ],
+            "properties": {
+                "nome": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "v1.CreateWarehouseRequest": {
+            "type": "object",
+            "required": [
+                "estado",
+                "nome"
+            ],
+            "properties": {
+                "estado": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "v1.CreateWebhookRequest": {
+            "type": "object",
+            "required": [
+                "eventos",
+                "url"
+            ],
+            "properties": {
+                "eventos": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "segredo": {
+                    "type": "string",
+                    "maxLength": 255,
+                    "minLength": 16
+                },
+                "url": {
+                    "type": "string",
+                    "maxLength": 2048
+                }
+            }
+        },
+        "v1.DeletionResponse": {
+            "type": "object",
+            "properties": {
+                "excluido_em": {
+                    "type": "string"
+                },
+                "excluido_por": {
+                    "type": "string"
+                },
+                "motivo": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.HireCarrierRequest": {
+            "type": "object",
+            "required": [
+                "cotacao_id"
+            ],
+            "properties": {
+                "cotacao_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.ImportCarrierRatesResponse": {
+            "type": "object",
+            "properties": {
+                "alteracoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.CarrierRateChangeResponse"
+                    }
+                },
+                "aplicada": {
+                    "type": "boolean"
+                },
+                "erros": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.CarrierRateErrorResponse"
+                    }
+                },
+                "simulacao": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "v1.ImportPackagesResponse": {
+            "type": "object",
+            "properties": {
+                "com_erro": {
+                    "type": "integer"
+                },
+                "importados": {
+                    "type": "integer"
+                },
+                "linhas": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.ImportRowResponse"
+                    }
+                },
+                "modo": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.ImportRowResponse": {
+            "type": "object",
+            "properties": {
+                "erros": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "linha": {
+                    "type": "integer"
+                },
+                "pacote_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.PackageResponse": {
+            "type": "object",
+            "properties": {
+                "altura_cm": {
+                    "type": "number"
+                },
+                "armazem_origem_id": {
+                    "type": "string"
+                },
+                "atualizado_em": {
+                    "type": "string"
+                },
+                "categoria": {
+                    "type": "string"
+                },
+                "cep_destino": {
+                    "type": "string"
+                },
+                "cidade_destino": {
+                    "type": "string"
+                },
+                "codigo_rastreio": {
+                    "type": "string"
+                },
+                "comprimento_cm": {
+                    "type": "number"
+                },
+                "contratado_em": {
+                    "type": "string"
+                },
+                "criado_em": {
+                    "type": "string"
+                },
+                "data_estimada_entrega": {
+                    "type": "string"
+                },
+                "destinatario": {
+                    "$ref": "#/definitions/v1.AddressResponse"
+                },
+                "estado_destino": {
+                    "type": "string"
+                },
+                "exclusao": {
+                    "$ref": "#/definitions/v1.DeletionResponse"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "largura_cm": {
+                    "type": "number"
+                },
+                "peso_kg": {
+                    "type": "number"
+                },
+                "prazo_contratado_dias": {
+                    "type": "integer"
+                },
+                "preco_contratado": {
+                    "type": "string"
+                },
+                "produto": {
+                    "type": "string"
+                },
+                "remetente": {
+                    "$ref": "#/definitions/v1.AddressResponse"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transportadora_id": {
+                    "type": "string"
+                },
+                "vendedor_id": {
+                    "type": "string"
+                },
+                "versao": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.PackageStatusEventResponse": {
+            "type": "object",
+            "properties": {
+                "ator": {
+                    "type": "string"
+                },
+                "criado_em": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "motivo": {
+                    "type": "string"
+                },
+                "status_anterior": {
+                    "type": "string"
+                },
+                "status_novo": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.PaginatedResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "integer"
+                },
+                "data": {},
+                "message": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.QuoteResponse": {
+            "type": "object",
+            "properties": {
+                "data_estimada_entrega": {
+                    "type": "string"
+                },
+                "expira_em": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "peso_taxado_kg": {
+                    "type": "number"
+                },
+                "prazo_estimado_dias": {
+                    "type": "integer"
+                },
+                "preco_estimado": {
+                    "type": "number"
+                },
+                "sobretaxa_cep": {
+                    "type": "number"
+                },
+                "transportadora": {
+                    "type": "string"
+                },
+                "transportadora_id": {
+                    "type": "string"
+                }
             }
         },
-        "/states": {
-            "get": {
-                "description": "Get all Brazilian states with their regions",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "states"
-                ],
-                "summary": "List all states",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/v1.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/v1.StateResponse"
-                                            }
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "$ref": "#/definitions/v1.Response"
-                        }
+        "v1.QuotesResponse": {
+            "type": "object",
+            "properties": {
+                "cotacoes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.QuoteResponse"
+                    }
+                },
+                "transportadoras_indisponiveis": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.UnavailableCarrierResponse"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "v1.CarrierResponse": {
+        },
+        "v1.RegionResponse": {
             "type": "object",
             "properties": {
-                "criado_em": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "string"
                 },
@@ -542,118 +4142,207 @@ const docTemplate = `{
                 }
             }
         },
-        "v1.CreatePackageRequest": {
+        "v1.Response": {
             "type": "object",
-            "required": [
-                "estado_destino",
-                "peso_kg",
-                "produto"
-            ],
             "properties": {
-                "estado_destino": {
-                    "type": "string"
-                },
-                "peso_kg": {
-                    "type": "number"
+                "code": {
+                    "type": "integer"
                 },
-                "produto": {
+                "data": {},
+                "message": {
                     "type": "string"
                 }
             }
         },
-        "v1.HireCarrierRequest": {
+        "v1.SaveCarrierEventMappingRequest": {
             "type": "object",
             "required": [
-                "prazo_dias",
-                "preco",
-                "transportadora_id"
+                "descricao"
             ],
             "properties": {
-                "prazo_dias": {
-                    "type": "integer"
+                "descricao": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "preco": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "coletado",
+                        "enviado",
+                        "entregue",
+                        "extraviado"
+                    ]
+                }
+            }
+        },
+        "v1.SaveSellerRateRequest": {
+            "type": "object",
+            "properties": {
+                "acrescimo_percentual": {
+                    "type": "number",
+                    "maximum": 1000
+                },
+                "preco_por_kg": {
+                    "type": "number"
+                }
+            }
+        },
+        "v1.SellerRateResponse": {
+            "type": "object",
+            "properties": {
+                "acrescimo_percentual": {
+                    "type": "string"
+                },
+                "atualizado_em": {
+                    "type": "string"
+                },
+                "criado_em": {
+                    "type": "string"
+                },
+                "preco_por_kg": {
                     "type": "string"
                 },
                 "transportadora_id": {
                     "type": "string"
+                },
+                "vendedor_id": {
+                    "type": "string"
                 }
             }
         },
-        "v1.PackageResponse": {
+        "v1.SellerResponse": {
             "type": "object",
             "properties": {
-                "atualizado_em": {
+                "criado_em": {
                     "type": "string"
                 },
-                "codigo_rastreio": {
+                "id": {
                     "type": "string"
                 },
-                "criado_em": {
+                "nome": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.StateResponse": {
+            "type": "object",
+            "properties": {
+                "codigo": {
                     "type": "string"
                 },
-                "estado_destino": {
+                "nome": {
                     "type": "string"
                 },
-                "id": {
+                "nome_regiao": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.TokenResponse": {
+            "type": "object",
+            "properties": {
+                "expira_em": {
                     "type": "string"
                 },
-                "peso_kg": {
-                    "type": "number"
+                "tipo": {
+                    "type": "string"
                 },
-                "prazo_contratado_dias": {
-                    "type": "integer"
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.TrackingEventResponse": {
+            "type": "object",
+            "properties": {
+                "descricao": {
+                    "type": "string"
                 },
-                "preco_contratado": {
+                "local": {
                     "type": "string"
                 },
-                "produto": {
+                "ocorrido_em": {
                     "type": "string"
                 },
                 "status": {
                     "type": "string"
                 },
-                "transportadora_id": {
+                "tipo": {
                     "type": "string"
                 }
             }
         },
-        "v1.QuoteResponse": {
+        "v1.TrackingResponse": {
             "type": "object",
             "properties": {
-                "prazo_estimado_dias": {
-                    "type": "integer"
+                "cidade_destino": {
+                    "type": "string"
                 },
-                "preco_estimado": {
-                    "type": "number"
+                "codigo_rastreio": {
+                    "type": "string"
+                },
+                "data_estimada_entrega": {
+                    "type": "string"
+                },
+                "estado_destino": {
+                    "type": "string"
+                },
+                "eventos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/v1.TrackingEventResponse"
+                    }
+                },
+                "status": {
+                    "type": "string"
                 },
                 "transportadora": {
                     "type": "string"
                 }
             }
         },
-        "v1.Response": {
+        "v1.UnavailableCarrierResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "type": "integer"
+                "motivos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "data": {},
-                "message": {
+                "transportadora": {
+                    "type": "string"
+                },
+                "transportadora_id": {
                     "type": "string"
                 }
             }
         },
-        "v1.StateResponse": {
+        "v1.UpdateCarrierCoverageRequest": {
             "type": "object",
+            "required": [
+                "prazo_estimado_dias",
+                "preco_por_kg"
+            ],
             "properties": {
-                "codigo": {
-                    "type": "string"
+                "divisor_cubagem": {
+                    "type": "integer"
                 },
-                "nome": {
-                    "type": "string"
+                "excedente_por_kg": {
+                    "type": "number",
+                    "minimum": 0
                 },
-                "nome_regiao": {
+                "frete_minimo": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "prazo_estimado_dias": {
+                    "type": "integer"
+                },
+                "preco_por_kg": {
+                    "type": "number"
+                },
+                "valido_de": {
                     "type": "string"
                 }
             }
@@ -664,6 +4353,10 @@ const docTemplate = `{
                 "status"
             ],
             "properties": {
+                "motivo": {
+                    "type": "string",
+                    "maxLength": 500
+                },
                 "status": {
                     "type": "string",
                     "enum": [
@@ -676,18 +4369,107 @@ const docTemplate = `{
                     ]
                 }
             }
+        },
+        "v1.WarehouseResponse": {
+            "type": "object",
+            "properties": {
+                "criado_em": {
+                    "type": "string"
+                },
+                "estado": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "nome": {
+                    "type": "string"
+                }
+            }
+        },
+        "v1.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "assinatura_id": {
+                    "type": "string"
+                },
+                "criado_em": {
+                    "type": "string"
+                },
+                "entregue_em": {
+                    "type": "string"
+                },
+                "evento": {
+                    "type": "string"
+                },
+                "evento_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "payload": {
+                    "type": "object"
+                },
+                "proxima_tentativa_em": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "tentativas": {
+                    "type": "integer"
+                },
+                "ultimo_erro": {
+                    "type": "string"
+                },
+                "ultimo_status_http": {
+                    "type": "integer"
+                }
+            }
+        },
+        "v1.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "criado_em": {
+                    "type": "string"
+                },
+                "eventos": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "segredo": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "\"Bearer \" seguido de uma API key (olk_...) ou de um JWT",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
         }
     }
 }`
 
 // SwaggerInfo holds exported Swagger Info so clients can modify it
 var SwaggerInfo = &swag.Spec{
-	Version:          "",
-	Host:             "",
-	BasePath:         "",
+	Version:          "1.0",
+	Host:             "localhost:8080",
+	BasePath:         "/api/v1",
 	Schemes:          []string{},
-	Title:            "",
-	Description:      "",
+	Title:            "Olist Shipping API",
+	Description:      "API para gerenciamento de entregas da Olist",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
 }
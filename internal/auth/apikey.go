@@ -0,0 +1,33 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// APIKeyPrefix tells API keys apart from JWTs in the Authorization header.
+const APIKeyPrefix = "olk_"
+
+// displayPrefixLen characters are stored in clear to identify the key in listings.
+const displayPrefixLen = len(APIKeyPrefix) + 8
+
+func GenerateAPIKey() (key, displayPrefix string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("generate api key: %v", err)
+	}
+	key = APIKeyPrefix + hex.EncodeToString(buf)
+	return key, key[:displayPrefixLen], nil
+}
+
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func IsAPIKey(credential string) bool {
+	return strings.HasPrefix(credential, APIKeyPrefix)
+}
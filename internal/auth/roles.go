@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var ErrUnauthenticated = errors.New("missing or invalid credentials")
+
+const (
+	RoleAdmin    = "admin"
+	RoleOperator = "operator"
+	RoleSeller   = "seller"
+	RoleReadonly = "readonly"
+)
+
+var Roles = []string{RoleAdmin, RoleOperator, RoleSeller, RoleReadonly}
+
+const (
+	MethodAPIKey = "api_key"
+	MethodJWT    = "jwt"
+)
+
+// Principal.SellerID, when set, restricts access to that seller's packages.
+type Principal struct {
+	Subject  string
+	Role     string
+	Method   string
+	SellerID uuid.NullUUID
+	APIKeyID uuid.NullUUID
+}
+
+// Scope uses the API key id because key names can repeat.
+func (p Principal) Scope() string {
+	if p.APIKeyID.Valid {
+		return "apikey:" + p.APIKeyID.UUID.String()
+	}
+	scope := p.Method + ":" + p.Subject
+	if p.SellerID.Valid {
+		scope += "@" + p.SellerID.UUID.String()
+	}
+	return scope
+}
+
+func ValidRole(role string) bool {
+	for _, r := range Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func (p Principal) HasRole(roles ...string) bool {
+	for _, role := range roles {
+		if p.Role == role {
+			return true
+		}
+	}
+	return false
+}
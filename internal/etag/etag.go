@@ -0,0 +1,54 @@
+package etag
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidIfMatch = errors.New("If-Match must be a single ETag returned by the API or *")
+
+func Format(version int32) string {
+	return `"` + strconv.FormatInt(int64(version), 10) + `"`
+}
+
+// ParseIfMatch returns zero, meaning any version, for an empty header or "*"; weak ETags are rejected.
+func ParseIfMatch(header string) (int32, error) {
+	value := strings.TrimSpace(header)
+	if value == "" || value == "*" {
+		return 0, nil
+	}
+
+	version, ok := parse(value)
+	if !ok {
+		return 0, ErrInvalidIfMatch
+	}
+	return version, nil
+}
+
+// NoneMatch uses weak comparison, so W/"3" matches "3".
+func NoneMatch(header, current string) bool {
+	if strings.TrimSpace(header) == "" {
+		return false
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if tag == "*" || tag == current {
+			return true
+		}
+	}
+	return false
+}
+
+func parse(tag string) (int32, bool) {
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+
+	version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 32)
+	if err != nil || version <= 0 {
+		return 0, false
+	}
+	return int32(version), true
+}
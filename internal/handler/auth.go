@@ -0,0 +1,222 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github/moura95/olist-shipping-api/api/v1"
+	"github/moura95/olist-shipping-api/config"
+	"github/moura95/olist-shipping-api/internal/middleware"
+	"github/moura95/olist-shipping-api/internal/repository"
+	"github/moura95/olist-shipping-api/internal/service"
+	customValidator "github/moura95/olist-shipping-api/pkg/validator"
+	"go.uber.org/zap"
+)
+
+type AuthHandler struct {
+	authService *service.AuthService
+	config      *config.Config
+	logger      *zap.SugaredLogger
+	validate    *validator.Validate
+}
+
+func NewAuthHandler(authService *service.AuthService, cfg *config.Config, logger *zap.SugaredLogger) *AuthHandler {
+	validate := validator.New()
+	customValidator.SetupCustomValidators(validate)
+	return &AuthHandler{
+		authService: authService,
+		config:      cfg,
+		logger:      logger,
+		validate:    validate,
+	}
+}
+
+// Token godoc
+// @Summary      Issue a JWT
+// @Description  Exchange the current credential (API key or JWT) for a short-lived JWT with the same subject and role
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  v1.Response{data=v1.TokenResponse}
+// @Failure      401  {object}  v1.Response
+// @Failure      501  {object}  v1.Response
+// @Router       /auth/token [post]
+func (h *AuthHandler) Token(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("issue token started")
+
+	principal, ok := middleware.GetPrincipalFromContext(ctx)
+	if !ok {
+		v1.HandleUnauthorized(ctx, "Authentication is required")
+		return
+	}
+
+	token, expiresAt, err := h.authService.IssueToken(*principal)
+	if err != nil {
+		logger.Errorw("issue token failed", "error", err)
+		if errors.Is(err, service.ErrJWTDisabled) {
+			v1.HandleError(ctx, http.StatusNotImplemented, err.Error(), nil)
+			return
+		}
+		v1.HandleInternalError(ctx, fmt.Errorf("issue token: %v", err).Error())
+		return
+	}
+
+	tokenType := "Bearer"
+	expires := expiresAt.UTC().Format(time.RFC3339)
+
+	logger.Infow("issue token completed", "expires_at", expires)
+	v1.HandleSuccess(ctx, v1.TokenResponse{
+		Token:     &token,
+		TokenType: &tokenType,
+		ExpiresAt: &expires,
+	})
+}
+
+// ListAPIKeys godoc
+// @Summary      List API keys
+// @Description  List issued API keys, including revoked ones; keys themselves are never returned
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  v1.Response{data=[]v1.APIKeyResponse}
+// @Failure      401  {object}  v1.Response
+// @Failure      403  {object}  v1.Response
+// @Failure      500  {object}  v1.Response
+// @Router       /admin/api-keys [get]
+func (h *AuthHandler) ListAPIKeys(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("list api keys started")
+
+	keys, err := h.authService.ListAPIKeys(ctx)
+	if err != nil {
+		logger.Errorw("list api keys failed", "error", err)
+		v1.HandleInternalError(ctx, fmt.Errorf("list api keys: %v", err).Error())
+		return
+	}
+
+	resp := []v1.APIKeyResponse{}
+	for _, key := range keys {
+		resp = append(resp, newAPIKeyResponse(key, ""))
+	}
+
+	logger.Infow("list api keys completed", "count", len(resp))
+	v1.HandleSuccess(ctx, resp)
+}
+
+// CreateAPIKey godoc
+// @Summary      Issue an API key
+// @Description  Issue a new API key with the given role; the key is only returned in this response. Seller keys require vendedor_id and only see that seller's packages
+// @Tags         auth
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      v1.CreateAPIKeyRequest  true  "API key data"
+// @Success      201      {object}  v1.Response{data=v1.APIKeyResponse}
+// @Failure      400      {object}  v1.Response
+// @Failure      401      {object}  v1.Response
+// @Failure      403      {object}  v1.Response
+// @Failure      404      {object}  v1.Response
+// @Failure      422      {object}  v1.Response
+// @Failure      500      {object}  v1.Response
+// @Router       /admin/api-keys [post]
+func (h *AuthHandler) CreateAPIKey(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("create api key started")
+
+	var req v1.CreateAPIKeyRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Errorw("bind json failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	apiKey, key, err := h.authService.CreateAPIKey(ctx, service.CreateAPIKeyInput{
+		Name:      req.Name,
+		Role:      req.Role,
+		SellerID:  req.SellerID,
+		CreatedBy: actorFromContext(ctx),
+	})
+	if err != nil {
+		logger.Errorw("create api key failed", "error", err)
+		handleServiceError(ctx, fmt.Errorf("create api key: %w", err))
+		return
+	}
+
+	logger.Infow("create api key completed", "id", apiKey.ID, "role", apiKey.Role)
+	v1.HandleCreated(ctx, newAPIKeyResponse(*apiKey, key))
+}
+
+// RevokeAPIKey godoc
+// @Summary      Revoke an API key
+// @Description  Revoke an API key; requests using it are rejected immediately
+// @Tags         auth
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "API key ID"
+// @Success      200  {object}  v1.Response{data=v1.APIKeyResponse}
+// @Failure      401  {object}  v1.Response
+// @Failure      403  {object}  v1.Response
+// @Failure      404  {object}  v1.Response
+// @Failure      500  {object}  v1.Response
+// @Router       /admin/api-keys/{id} [delete]
+func (h *AuthHandler) RevokeAPIKey(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("revoke api key started")
+
+	id := ctx.Param("id")
+	apiKey, err := h.authService.RevokeAPIKey(ctx, id)
+	if err != nil {
+		logger.Errorw("revoke api key failed", "error", err, "id", id)
+		handleServiceError(ctx, fmt.Errorf("revoke api key: %w", err))
+		return
+	}
+
+	logger.Infow("revoke api key completed", "id", id)
+	v1.HandleSuccess(ctx, newAPIKeyResponse(*apiKey, ""))
+}
+
+func newAPIKeyResponse(apiKey repository.ApiKey, key string) v1.APIKeyResponse {
+	id := apiKey.ID.String()
+	createdAt := apiKey.CreatedAt.Format(time.RFC3339)
+
+	var plainKey, sellerID, lastUsedAt, revokedAt *string
+	if key != "" {
+		plainKey = &key
+	}
+	if apiKey.SellerID.Valid {
+		formatted := apiKey.SellerID.UUID.String()
+		sellerID = &formatted
+	}
+	if apiKey.LastUsedAt.Valid {
+		formatted := apiKey.LastUsedAt.Time.Format(time.RFC3339)
+		lastUsedAt = &formatted
+	}
+	if apiKey.RevokedAt.Valid {
+		formatted := apiKey.RevokedAt.Time.Format(time.RFC3339)
+		revokedAt = &formatted
+	}
+
+	return v1.APIKeyResponse{
+		ID:         &id,
+		Name:       &apiKey.Name,
+		Role:       &apiKey.Role,
+		SellerID:   sellerID,
+		Prefix:     &apiKey.KeyPrefix,
+		Key:        plainKey,
+		CreatedBy:  &apiKey.CreatedBy,
+		CreatedAt:  &createdAt,
+		LastUsedAt: lastUsedAt,
+		RevokedAt:  revokedAt,
+	}
+}
@@ -1,7 +1,11 @@
 package handler
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -9,7 +13,9 @@ import (
 	"github/moura95/olist-shipping-api/api/v1"
 	"github/moura95/olist-shipping-api/config"
 	"github/moura95/olist-shipping-api/internal/middleware"
+	"github/moura95/olist-shipping-api/internal/repository"
 	"github/moura95/olist-shipping-api/internal/service"
+	"github/moura95/olist-shipping-api/internal/util"
 	customValidator "github/moura95/olist-shipping-api/pkg/validator"
 	"go.uber.org/zap"
 )
@@ -38,6 +44,7 @@ func NewCarrierHandler(packageService *service.PackageService, cfg *config.Confi
 // @Tags         carriers
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Success      200  {object}  v1.Response{data=[]v1.CarrierResponse}
 // @Failure      500  {object}  v1.Response
 // @Router       /carriers [get]
@@ -54,20 +61,631 @@ func (h *CarrierHandler) List(ctx *gin.Context) {
 
 	var resp []v1.CarrierResponse
 	for _, carrier := range carriers {
-		var createdAt *string
-		if carrier.CreatedAt.Valid {
-			formatted := carrier.CreatedAt.Time.Format(time.RFC3339)
-			createdAt = &formatted
-		}
-
-		carrierID := carrier.ID.String()
-		resp = append(resp, v1.CarrierResponse{
-			ID:        &carrierID,
-			Name:      &carrier.Name,
-			CreatedAt: createdAt,
-		})
+		resp = append(resp, newCarrierResponse(carrier))
 	}
 
 	logger.Infow("list carriers completed", "count", len(resp))
 	v1.HandleSuccess(ctx, resp)
 }
+
+// Get godoc
+// @Summary      Get a carrier
+// @Description  Get a carrier with its limits and the routes it covers, with delivery days and prices
+// @Tags         carriers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Carrier ID"
+// @Success      200  {object}  v1.Response{data=v1.CarrierDetailResponse}
+// @Failure      404  {object}  v1.Response
+// @Failure      500  {object}  v1.Response
+// @Router       /carriers/{id} [get]
+func (h *CarrierHandler) Get(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("get carrier started")
+
+	id := ctx.Param("id")
+	detail, err := h.packageService.GetCarrier(ctx, id)
+	if err != nil {
+		logger.Errorw("get carrier failed", "error", err, "id", id)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	coverage := []v1.CarrierCoverageResponse{}
+	for _, region := range detail.Coverage {
+		coverage = append(coverage, newCarrierCoverageResponse(repository.ListCarrierRatesRow(region)))
+	}
+
+	logger.Infow("get carrier completed", "id", id, "routes", len(coverage))
+	v1.HandleSuccess(ctx, v1.CarrierDetailResponse{
+		CarrierResponse: newCarrierResponse(detail.Carrier),
+		Coverage:        coverage,
+	})
+}
+
+// Create godoc
+// @Summary      Create a carrier
+// @Description  Register a carrier; it only shows up in quotes after its route coverage is added
+// @Tags         carriers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      v1.CarrierRequest  true  "Carrier data"
+// @Success      201      {object}  v1.Response{data=v1.CarrierResponse}
+// @Failure      400      {object}  v1.Response
+// @Failure      409      {object}  v1.Response
+// @Failure      500      {object}  v1.Response
+// @Router       /carriers [post]
+func (h *CarrierHandler) Create(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("create carrier started")
+
+	var req v1.CarrierRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Errorw("bind json failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	carrier, err := h.packageService.CreateCarrier(ctx, newCarrierInput(req))
+	if err != nil {
+		logger.Errorw("create carrier failed", "error", err)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	logger.Infow("create carrier completed", "id", carrier.ID)
+	v1.HandleCreated(ctx, newCarrierResponse(*carrier))
+}
+
+// Update godoc
+// @Summary      Update a carrier
+// @Description  Replace the carrier data; omitted limits are removed and an omitted cutoff time resets to 14:00
+// @Tags         carriers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string             true  "Carrier ID"
+// @Param        request  body      v1.CarrierRequest  true  "Carrier data"
+// @Success      200      {object}  v1.Response{data=v1.CarrierResponse}
+// @Failure      400      {object}  v1.Response
+// @Failure      404      {object}  v1.Response
+// @Failure      409      {object}  v1.Response
+// @Failure      500      {object}  v1.Response
+// @Router       /carriers/{id} [put]
+func (h *CarrierHandler) Update(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("update carrier started")
+
+	var req v1.CarrierRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Errorw("bind json failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	id := ctx.Param("id")
+	carrier, err := h.packageService.UpdateCarrier(ctx, id, newCarrierInput(req))
+	if err != nil {
+		logger.Errorw("update carrier failed", "error", err, "id", id)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	logger.Infow("update carrier completed", "id", id)
+	v1.HandleSuccess(ctx, newCarrierResponse(*carrier))
+}
+
+// Delete godoc
+// @Summary      Delete a carrier
+// @Description  Remove the carrier from listings and quotes; packages it already shipped keep their history. Carriers with packages in progress cannot be deleted
+// @Tags         carriers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Carrier ID"
+// @Success      200  {object}  v1.Response
+// @Failure      404  {object}  v1.Response
+// @Failure      409  {object}  v1.Response
+// @Failure      500  {object}  v1.Response
+// @Router       /carriers/{id} [delete]
+func (h *CarrierHandler) Delete(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("delete carrier started")
+
+	id := ctx.Param("id")
+	if err := h.packageService.DeleteCarrier(ctx, id); err != nil {
+		logger.Errorw("delete carrier failed", "error", err, "id", id)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	logger.Infow("delete carrier completed", "id", id)
+	v1.HandleSuccess(ctx, "Carrier deleted successfully")
+}
+
+// AddCoverage godoc
+// @Summary      Add a carrier route
+// @Description  Add a rate version for a destination region served by the carrier, with delivery days and prices; without regiao_origem_id the route applies to any origin. Without valido_de the version is in effect from now on; a future valido_de schedules it and the current version stays in effect until then
+// @Tags         carriers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                           true  "Carrier ID"
+// @Param        request  body      v1.CreateCarrierCoverageRequest  true  "Route"
+// @Success      201      {object}  v1.Response{data=v1.CarrierCoverageResponse}
+// @Failure      400      {object}  v1.Response
+// @Failure      404      {object}  v1.Response
+// @Failure      409      {object}  v1.Response
+// @Failure      422      {object}  v1.Response
+// @Failure      500      {object}  v1.Response
+// @Router       /carriers/{id}/coverage [post]
+func (h *CarrierHandler) AddCoverage(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("add carrier coverage started")
+
+	var req v1.CreateCarrierCoverageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Errorw("bind json failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	var validFrom *time.Time
+	if req.ValidFrom != "" {
+		parsed, _ := customValidator.ParseDateOrTime(req.ValidFrom)
+		validFrom = &parsed
+	}
+	carrierID := ctx.Param("id")
+	region, err := h.packageService.AddCarrierCoverage(ctx, carrierID, service.CarrierCoverageInput{
+		RegionID:              req.RegionID,
+		OriginRegionID:        req.OriginRegionID,
+		EstimatedDeliveryDays: req.EstimatedDeliveryDays,
+		PricePerKg:            req.PricePerKg,
+		MinCharge:             req.MinCharge,
+		ExcessPricePerKg:      req.ExcessPricePerKg,
+		CubingDivisor:         req.CubingDivisor,
+		ValidFrom:             validFrom,
+	})
+	if err != nil {
+		logger.Errorw("add carrier coverage failed", "error", err, "carrier_id", carrierID)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	logger.Infow("add carrier coverage completed", "carrier_id", carrierID, "id", region.ID)
+	v1.HandleCreated(ctx, newCarrierCoverageResponse(*region))
+}
+
+// UpdateCoverage godoc
+// @Summary      Update a carrier route
+// @Description  Add a new rate version for the route of the given version; the previous one is kept so past prices can still be explained. Without valido_de it is in effect from now on, a future valido_de schedules it. To change the regions, remove the route and add it again
+// @Tags         carriers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id           path      string                           true  "Carrier ID"
+// @Param        coverage_id  path      string                           true  "Route ID"
+// @Param        request      body      v1.UpdateCarrierCoverageRequest  true  "Route"
+// @Success      200          {object}  v1.Response{data=v1.CarrierCoverageResponse}
+// @Failure      400          {object}  v1.Response
+// @Failure      404          {object}  v1.Response
+// @Failure      409          {object}  v1.Response
+// @Failure      422          {object}  v1.Response
+// @Failure      500          {object}  v1.Response
+// @Router       /carriers/{id}/coverage/{coverage_id} [put]
+func (h *CarrierHandler) UpdateCoverage(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("update carrier coverage started")
+
+	var req v1.UpdateCarrierCoverageRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Errorw("bind json failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	var validFrom *time.Time
+	if req.ValidFrom != "" {
+		parsed, _ := customValidator.ParseDateOrTime(req.ValidFrom)
+		validFrom = &parsed
+	}
+	carrierID, coverageID := ctx.Param("id"), ctx.Param("coverage_id")
+	region, err := h.packageService.UpdateCarrierCoverage(ctx, carrierID, coverageID, service.CarrierCoverageInput{
+		EstimatedDeliveryDays: req.EstimatedDeliveryDays,
+		PricePerKg:            req.PricePerKg,
+		MinCharge:             req.MinCharge,
+		ExcessPricePerKg:      req.ExcessPricePerKg,
+		CubingDivisor:         req.CubingDivisor,
+		ValidFrom:             validFrom,
+	})
+	if err != nil {
+		logger.Errorw("update carrier coverage failed", "error", err, "carrier_id", carrierID, "id", coverageID)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	logger.Infow("update carrier coverage completed", "carrier_id", carrierID, "id", coverageID)
+	v1.HandleSuccess(ctx, newCarrierCoverageResponse(*region))
+}
+
+// DeleteCoverage godoc
+// @Summary      Remove a carrier route
+// @Description  End a route now; the carrier stops being quoted for it, scheduled versions are discarded and past versions stay in the rate history
+// @Tags         carriers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id           path      string  true  "Carrier ID"
+// @Param        coverage_id  path      string  true  "Route ID"
+// @Success      200          {object}  v1.Response
+// @Failure      404          {object}  v1.Response
+// @Failure      500          {object}  v1.Response
+// @Router       /carriers/{id}/coverage/{coverage_id} [delete]
+func (h *CarrierHandler) DeleteCoverage(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("delete carrier coverage started")
+
+	carrierID, coverageID := ctx.Param("id"), ctx.Param("coverage_id")
+	if err := h.packageService.DeleteCarrierCoverage(ctx, carrierID, coverageID); err != nil {
+		logger.Errorw("delete carrier coverage failed", "error", err, "carrier_id", carrierID, "id", coverageID)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	logger.Infow("delete carrier coverage completed", "carrier_id", carrierID, "id", coverageID)
+	v1.HandleSuccess(ctx, "Carrier coverage deleted successfully")
+}
+
+// Rates godoc
+// @Summary      List carrier rate versions
+// @Description  List the rate versions of the carrier routes with their validity. With at, only the versions in effect at that moment (a date alone means midnight); without it, every version, including ended and scheduled ones
+// @Tags         carriers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true   "Carrier ID"
+// @Param        at   query     string  false  "Moment (YYYY-MM-DD or RFC 3339)"
+// @Success      200  {object}  v1.Response{data=[]v1.CarrierCoverageResponse}
+// @Failure      400  {object}  v1.Response
+// @Failure      404  {object}  v1.Response
+// @Failure      500  {object}  v1.Response
+// @Router       /carriers/{id}/rates [get]
+func (h *CarrierHandler) Rates(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("list carrier rates started")
+
+	var query v1.CarrierRatesQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		logger.Errorw("bind query failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	var at *time.Time
+	if query.At != "" {
+		parsed, _ := customValidator.ParseDateOrTime(query.At)
+		at = &parsed
+	}
+
+	id := ctx.Param("id")
+	rates, err := h.packageService.GetCarrierRates(ctx, id, at)
+	if err != nil {
+		logger.Errorw("list carrier rates failed", "error", err, "id", id)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	resp := []v1.CarrierCoverageResponse{}
+	for _, rate := range rates {
+		resp = append(resp, newCarrierCoverageResponse(rate))
+	}
+
+	logger.Infow("list carrier rates completed", "id", id, "count", len(resp))
+	v1.HandleSuccess(ctx, resp)
+}
+
+const maxCarrierEventBytes = 64 << 10
+
+// Events godoc
+// @Summary      Receive a carrier tracking event
+// @Description  Endpoint for carriers to push scan events. Authenticated with the carrier token (Authorization: Bearer). The carrier code is mapped to a package status and the package is moved forward; repeated evento_id values are acknowledged without being stored again
+// @Tags         carriers
+// @Accept       json
+// @Produce      json
+// @Param        id       path      string                  true  "Carrier ID"
+// @Param        request  body      v1.CarrierEventRequest  true  "Scan event"
+// @Success      200      {object}  v1.Response{data=v1.CarrierEventResponse}  "Duplicate event"
+// @Success      201      {object}  v1.Response{data=v1.CarrierEventResponse}
+// @Failure      400      {object}  v1.Response
+// @Failure      401      {object}  v1.Response
+// @Failure      404      {object}  v1.Response
+// @Failure      409      {object}  v1.Response
+// @Failure      422      {object}  v1.Response
+// @Failure      500      {object}  v1.Response
+// @Router       /carriers/{id}/events [post]
+func (h *CarrierHandler) Events(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("carrier event started")
+
+	carrierID := ctx.Param("id")
+	token := strings.TrimSpace(strings.TrimPrefix(ctx.GetHeader("Authorization"), "Bearer "))
+	carrier, err := h.packageService.AuthenticateCarrier(ctx, carrierID, token)
+	if err != nil {
+		logger.Errorw("carrier authentication failed", "error", err, "carrier_id", carrierID)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	raw, err := io.ReadAll(http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxCarrierEventBytes))
+	if err != nil {
+		logger.Errorw("read body failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid request body")
+		return
+	}
+
+	var req v1.CarrierEventRequest
+	if err := json.Unmarshal(raw, &req); err != nil {
+		logger.Errorw("bind json failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	result, err := h.packageService.IngestCarrierEvent(ctx, *carrier, service.CarrierEventInput{
+		EventID:      req.EventID,
+		Code:         req.Code,
+		TrackingCode: req.TrackingCode,
+		OccurredAt:   req.OccurredAt,
+		Description:  req.Description,
+		Location:     req.Location,
+		Raw:          raw,
+	})
+	if err != nil {
+		logger.Errorw("carrier event failed", "error", err, "carrier_id", carrierID, "event_id", req.EventID)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	eventID := result.EventID.String()
+	packageID := result.PackageID.String()
+	resp := v1.CarrierEventResponse{
+		ID:             &eventID,
+		PackageID:      &packageID,
+		Duplicate:      &result.Duplicate,
+		PreviousStatus: &result.PreviousStatus,
+		Status:         &result.Status,
+	}
+	if result.MappedStatus != "" {
+		resp.MappedStatus = &result.MappedStatus
+	}
+
+	logger.Infow("carrier event completed", "carrier_id", carrierID, "event_id", req.EventID,
+		"duplicate", result.Duplicate, "status", result.Status)
+	if result.Duplicate {
+		v1.HandleSuccess(ctx, resp)
+		return
+	}
+	v1.HandleCreated(ctx, resp)
+}
+
+// EventMappings godoc
+// @Summary      List carrier event mappings
+// @Description  Get how each carrier scan code maps to a package status; codes without status are informational
+// @Tags         carriers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Carrier ID"
+// @Success      200  {object}  v1.Response{data=[]v1.CarrierEventMappingResponse}
+// @Failure      404  {object}  v1.Response
+// @Failure      500  {object}  v1.Response
+// @Router       /carriers/{id}/event-mappings [get]
+func (h *CarrierHandler) EventMappings(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("list carrier event mappings started")
+
+	carrierID := ctx.Param("id")
+	mappings, err := h.packageService.GetCarrierEventMappings(ctx, carrierID)
+	if err != nil {
+		logger.Errorw("list carrier event mappings failed", "error", err, "carrier_id", carrierID)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	resp := []v1.CarrierEventMappingResponse{}
+	for _, mapping := range mappings {
+		resp = append(resp, newCarrierEventMappingResponse(mapping))
+	}
+
+	logger.Infow("list carrier event mappings completed", "carrier_id", carrierID, "count", len(resp))
+	v1.HandleSuccess(ctx, resp)
+}
+
+// SaveEventMapping godoc
+// @Summary      Create or update a carrier event mapping
+// @Description  Map a carrier scan code to a package status; omit status to make the code informational only
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id       path      string                             true  "Carrier ID"
+// @Param        code     path      string                             true  "Carrier event code"
+// @Param        request  body      v1.SaveCarrierEventMappingRequest  true  "Mapping"
+// @Success      200      {object}  v1.Response{data=v1.CarrierEventMappingResponse}
+// @Failure      400      {object}  v1.Response
+// @Failure      404      {object}  v1.Response
+// @Failure      500      {object}  v1.Response
+// @Router       /admin/carriers/{id}/event-mappings/{code} [put]
+func (h *CarrierHandler) SaveEventMapping(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("save carrier event mapping started")
+
+	var req v1.SaveCarrierEventMappingRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Errorw("bind json failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	carrierID := ctx.Param("id")
+	code := ctx.Param("code")
+	mapping, err := h.packageService.SaveCarrierEventMapping(ctx, carrierID, service.CarrierEventMappingInput{
+		Code:        code,
+		Status:      req.Status,
+		Description: req.Description,
+	})
+	if err != nil {
+		logger.Errorw("save carrier event mapping failed", "error", err, "carrier_id", carrierID, "code", code)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	logger.Infow("save carrier event mapping completed", "carrier_id", carrierID, "code", code)
+	v1.HandleSuccess(ctx, newCarrierEventMappingResponse(*mapping))
+}
+
+// RotateToken godoc
+// @Summary      Rotate a carrier API token
+// @Description  Generate a new token for the carrier events endpoint; the previous token stops working and the new one is only shown in this response
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Carrier ID"
+// @Success      200  {object}  v1.Response{data=v1.CarrierTokenResponse}
+// @Failure      404  {object}  v1.Response
+// @Failure      500  {object}  v1.Response
+// @Router       /admin/carriers/{id}/token [post]
+func (h *CarrierHandler) RotateToken(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("rotate carrier token started")
+
+	carrierID := ctx.Param("id")
+	token, err := h.packageService.RotateCarrierToken(ctx, carrierID)
+	if err != nil {
+		logger.Errorw("rotate carrier token failed", "error", err, "carrier_id", carrierID)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	logger.Infow("rotate carrier token completed", "carrier_id", carrierID)
+	v1.HandleSuccess(ctx, v1.CarrierTokenResponse{
+		CarrierID: &carrierID,
+		Token:     &token,
+	})
+}
+
+func newCarrierInput(req v1.CarrierRequest) service.CarrierInput {
+	return service.CarrierInput{
+		Name:               req.Name,
+		TrackingPrefix:     req.TrackingPrefix,
+		MaxWeightKg:        req.MaxWeightKg,
+		MaxSideCm:          req.MaxSideCm,
+		MaxDimensionsSumCm: req.MaxDimensionsSumCm,
+		CutoffTime:         req.CutoffTime,
+	}
+}
+
+func newCarrierResponse(carrier repository.Carrier) v1.CarrierResponse {
+	var createdAt *string
+	if carrier.CreatedAt.Valid {
+		formatted := carrier.CreatedAt.Time.Format(time.RFC3339)
+		createdAt = &formatted
+	}
+
+	carrierID := carrier.ID.String()
+	cutoffTime := carrier.CutoffTime.Format("15:04")
+	return v1.CarrierResponse{
+		ID:                 &carrierID,
+		Name:               &carrier.Name,
+		TrackingPrefix:     &carrier.TrackingPrefix,
+		MaxWeightKg:        util.NullFloat64ToPtr(carrier.MaxWeightKg),
+		MaxSideCm:          util.NullFloat64ToPtr(carrier.MaxSideCm),
+		MaxDimensionsSumCm: util.NullFloat64ToPtr(carrier.MaxDimensionsSumCm),
+		CutoffTime:         &cutoffTime,
+		CreatedAt:          createdAt,
+	}
+}
+
+func newCarrierCoverageResponse(region repository.ListCarrierRatesRow) v1.CarrierCoverageResponse {
+	id := region.ID.String()
+	regionID := region.RegionID.String()
+	var originRegionID *string
+	if region.OriginRegionID.Valid {
+		formatted := region.OriginRegionID.UUID.String()
+		originRegionID = &formatted
+	}
+
+	validFrom := region.ValidFrom.Format(time.RFC3339)
+	var validTo *string
+	if region.ValidTo.Valid {
+		formatted := region.ValidTo.Time.Format(time.RFC3339)
+		validTo = &formatted
+	}
+
+	return v1.CarrierCoverageResponse{
+		ID:                    &id,
+		RegionID:              &regionID,
+		RegionName:            &region.RegionName,
+		OriginRegionID:        originRegionID,
+		OriginRegionName:      util.NullStringToPtr(region.OriginRegionName),
+		EstimatedDeliveryDays: &region.EstimatedDeliveryDays,
+		PricePerKg:            &region.PricePerKg,
+		MinCharge:             &region.MinCharge,
+		ExcessPricePerKg:      util.NullStringToPtr(region.ExcessPricePerKg),
+		CubingDivisor:         &region.CubingDivisor,
+		ValidFrom:             &validFrom,
+		ValidTo:               validTo,
+	}
+}
+
+func newCarrierEventMappingResponse(mapping repository.CarrierEventMapping) v1.CarrierEventMappingResponse {
+	return v1.CarrierEventMappingResponse{
+		Code:        &mapping.CarrierCode,
+		Status:      util.NullStringToPtr(mapping.Status),
+		Description: &mapping.Description,
+	}
+}
@@ -0,0 +1,282 @@
+package handler
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/api/v1"
+	"github/moura95/olist-shipping-api/internal/middleware"
+	"github/moura95/olist-shipping-api/internal/service"
+	customValidator "github/moura95/olist-shipping-api/pkg/validator"
+)
+
+// ImportRates godoc
+// @Summary      Import a carrier rate sheet
+// @Description  Upload the carrier rate sheet as CSV, one weight band per row: regiao (name or id) or estado, peso_max_kg, preco and prazo_dias; optional peso_min_kg (defaults to the previous band), frete_minimo and excedente_por_kg (required for new routes). Each route is compared with its current rates for any origin; with simular=true only the diff is returned, otherwise new and changed routes get a new rate version atomically, from valido_de or now. Any invalid row rejects the whole sheet.
+// @Tags         carriers
+// @Accept       text/csv
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id         path      string  true   "Carrier ID"
+// @Param        simular    query     bool    false  "Only compare with the current rates"
+// @Param        valido_de  query     string  false  "Start of the new versions (YYYY-MM-DD or RFC 3339)"
+// @Param        arquivo    formData  file    false  "Rate sheet (multipart)"
+// @Success      200        {object}  v1.Response{data=v1.ImportCarrierRatesResponse}
+// @Failure      400        {object}  v1.Response
+// @Failure      404        {object}  v1.Response
+// @Failure      409        {object}  v1.Response
+// @Failure      422        {object}  v1.Response{data=v1.ImportCarrierRatesResponse}
+// @Failure      500        {object}  v1.Response
+// @Router       /carriers/{id}/rates/import [post]
+func (h *CarrierHandler) ImportRates(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("import carrier rates started")
+
+	var query v1.ImportCarrierRatesQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		logger.Errorw("bind query failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportBytes)
+	body, _, err := importBody(ctx, importFormatCSV)
+	if err != nil {
+		logger.Errorw("read rate sheet failed", "error", err)
+		v1.HandleBadRequest(ctx, err.Error())
+		return
+	}
+	defer body.Close()
+
+	rows, err := h.parseRateSheet(body)
+	if err != nil {
+		logger.Errorw("parse rate sheet failed", "error", err)
+		v1.HandleBadRequest(ctx, err.Error())
+		return
+	}
+
+	var validFrom *time.Time
+	if query.ValidFrom != "" {
+		parsed, _ := customValidator.ParseDateOrTime(query.ValidFrom)
+		validFrom = &parsed
+	}
+
+	id := ctx.Param("id")
+	report, err := h.packageService.ImportCarrierRates(ctx, id, rows, validFrom, query.DryRun)
+	if err != nil {
+		logger.Errorw("import carrier rates failed", "error", err, "id", id)
+		handleServiceError(ctx, fmt.Errorf("import carrier rates: %w", err))
+		return
+	}
+
+	resp := newImportCarrierRatesResponse(report)
+
+	if report.Rejected() {
+		logger.Infow("import carrier rates rejected", "id", id, "total", len(rows), "failed", len(report.Errors))
+		v1.HandleError(ctx, http.StatusUnprocessableEntity, "import rejected: rows with errors", resp)
+		return
+	}
+
+	logger.Infow("import carrier rates completed", "id", id, "total", len(rows), "routes", len(report.Changes), "dry_run", report.DryRun)
+	v1.HandleSuccess(ctx, resp)
+}
+
+var rateSheetColumns = map[string]bool{
+	"regiao":           true,
+	"estado":           true,
+	"peso_min_kg":      true,
+	"peso_max_kg":      true,
+	"preco":            true,
+	"prazo_dias":       true,
+	"frete_minimo":     true,
+	"excedente_por_kg": true,
+}
+
+// parseRateSheet uses the same separator and decimal comma rules as the package import.
+func (h *CarrierHandler) parseRateSheet(body io.Reader) ([]service.RateImportRow, error) {
+	csvReader, columns, semicolon, err := newImportCSVReader(body, rateSheetColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	_, hasRegion := columns["regiao"]
+	_, hasState := columns["estado"]
+	if !hasRegion && !hasState {
+		return nil, fmt.Errorf("coluna obrigatória ausente: regiao ou estado")
+	}
+	for _, required := range []string{"peso_max_kg", "preco", "prazo_dias"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("coluna obrigatória ausente: %s", required)
+		}
+	}
+
+	var rows []service.RateImportRow
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := csvReader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rows = append(rows, service.RateImportRow{Line: parseErr.StartLine, Errors: []string{parseErr.Err.Error()}})
+				continue
+			}
+			return nil, fmt.Errorf("read csv: %v", err)
+		}
+
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		var parseErrors []string
+		optionalNumber := func(column string) *float64 {
+			raw := value(column)
+			if raw == "" {
+				return nil
+			}
+			if semicolon {
+				raw = strings.ReplaceAll(raw, ",", ".")
+			}
+			n, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				parseErrors = append(parseErrors, column+" deve ser numérico")
+			}
+			return &n
+		}
+		number := func(column string) float64 {
+			if n := optionalNumber(column); n != nil {
+				return *n
+			}
+			return 0
+		}
+
+		var deliveryDays int64
+		if raw := value("prazo_dias"); raw != "" {
+			deliveryDays, err = strconv.ParseInt(raw, 10, 32)
+			if err != nil {
+				parseErrors = append(parseErrors, "prazo_dias deve ser um número inteiro")
+			}
+		}
+
+		row := v1.CarrierRateRow{
+			Region:                value("regiao"),
+			State:                 strings.ToUpper(value("estado")),
+			MinWeightKg:           optionalNumber("peso_min_kg"),
+			MaxWeightKg:           number("peso_max_kg"),
+			Price:                 number("preco"),
+			EstimatedDeliveryDays: int32(deliveryDays),
+			MinCharge:             optionalNumber("frete_minimo"),
+			ExcessPricePerKg:      optionalNumber("excedente_por_kg"),
+		}
+		rows = append(rows, h.newRateImportRow(line, row, parseErrors))
+	}
+
+	return rows, nil
+}
+
+func (h *CarrierHandler) newRateImportRow(line int, row v1.CarrierRateRow, parseErrors []string) service.RateImportRow {
+	result := service.RateImportRow{
+		Line:                  line,
+		Region:                row.Region,
+		State:                 row.State,
+		MinWeightKg:           row.MinWeightKg,
+		MaxWeightKg:           row.MaxWeightKg,
+		Price:                 row.Price,
+		EstimatedDeliveryDays: row.EstimatedDeliveryDays,
+		MinCharge:             row.MinCharge,
+		ExcessPricePerKg:      row.ExcessPricePerKg,
+		Errors:                parseErrors,
+	}
+
+	if len(result.Errors) == 0 {
+		if err := h.validate.Struct(row); err != nil {
+			if messages := v1.ValidationMessages(err); messages != nil {
+				result.Errors = messages
+			} else {
+				result.Errors = []string{err.Error()}
+			}
+		}
+	}
+
+	return result
+}
+
+func newImportCarrierRatesResponse(report *service.RateImportReport) v1.ImportCarrierRatesResponse {
+	resp := v1.ImportCarrierRatesResponse{
+		DryRun:  &report.DryRun,
+		Applied: &report.Applied,
+		Changes: make([]v1.CarrierRateChangeResponse, 0, len(report.Changes)),
+		Errors:  make([]v1.CarrierRateErrorResponse, 0, len(report.Errors)),
+	}
+
+	for _, change := range report.Changes {
+		regionID := change.RegionID.String()
+		regionName := change.RegionName
+		status := change.Status
+		var current *v1.CarrierRateSheetResponse
+		if change.Current != nil {
+			current = newCarrierRateSheetResponse(*change.Current)
+		}
+		var versionID *string
+		if change.VersionID != uuid.Nil {
+			id := change.VersionID.String()
+			versionID = &id
+		}
+		resp.Changes = append(resp.Changes, v1.CarrierRateChangeResponse{
+			RegionID:   &regionID,
+			RegionName: &regionName,
+			Status:     &status,
+			Current:    current,
+			Proposed:   newCarrierRateSheetResponse(change.Proposed),
+			VersionID:  versionID,
+		})
+	}
+
+	for _, rowError := range report.Errors {
+		line := rowError.Line
+		resp.Errors = append(resp.Errors, v1.CarrierRateErrorResponse{
+			Line:   &line,
+			Errors: rowError.Errors,
+		})
+	}
+
+	return resp
+}
+
+func newCarrierRateSheetResponse(sheet service.RateSheet) *v1.CarrierRateSheetResponse {
+	resp := &v1.CarrierRateSheetResponse{
+		EstimatedDeliveryDays: &sheet.EstimatedDeliveryDays,
+		MinCharge:             &sheet.MinCharge,
+		ExcessPricePerKg:      &sheet.ExcessPricePerKg,
+		Tiers:                 make([]v1.CarrierRateTierResponse, 0, len(sheet.Tiers)),
+	}
+	for _, tier := range sheet.Tiers {
+		tier := tier
+		resp.Tiers = append(resp.Tiers, v1.CarrierRateTierResponse{
+			MinWeightKg: &tier.MinWeightKg,
+			MaxWeightKg: &tier.MaxWeightKg,
+			Price:       &tier.Price,
+		})
+	}
+	return resp
+}
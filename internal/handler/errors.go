@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github/moura95/olist-shipping-api/api/v1"
+	"github/moura95/olist-shipping-api/internal/service"
+)
+
+// serviceErrorStatus maps each service error to its HTTP status; anything else is a 500.
+var serviceErrorStatus = []struct {
+	err    error
+	status int
+}{
+	{service.ErrPackageNotFound, http.StatusNotFound},
+	{service.ErrQuoteNotFound, http.StatusNotFound},
+	{service.ErrWebhookNotFound, http.StatusNotFound},
+	{service.ErrWebhookDeliveryNotFound, http.StatusNotFound},
+	{service.ErrCarrierNotFound, http.StatusNotFound},
+	{service.ErrAPIKeyNotFound, http.StatusNotFound},
+	{service.ErrSellerNotFound, http.StatusNotFound},
+	{service.ErrCoverageNotFound, http.StatusNotFound},
+
+	{service.ErrCarrierUnauthorized, http.StatusUnauthorized},
+
+	{service.ErrInvalidStatusTransition, http.StatusConflict},
+	{service.ErrPackageModified, http.StatusConflict},
+	{service.ErrQuoteExpired, http.StatusConflict},
+	{service.ErrQuoteAlreadyUsed, http.StatusConflict},
+	{service.ErrPackageNotHired, http.StatusConflict},
+	{service.ErrTrackingCodeMissing, http.StatusConflict},
+	{service.ErrCarrierInUse, http.StatusConflict},
+	{service.ErrTrackingPrefixInUse, http.StatusConflict},
+	{service.ErrRateVersionExists, http.StatusConflict},
+	{service.ErrPackageAlreadyShipped, http.StatusConflict},
+	{service.ErrPackageNotDeleted, http.StatusConflict},
+
+	{service.ErrVersionMismatch, http.StatusPreconditionFailed},
+
+	{service.ErrCarrierRestricted, http.StatusUnprocessableEntity},
+	{service.ErrWarehouseNotFound, http.StatusUnprocessableEntity},
+	{service.ErrCEPNotFound, http.StatusUnprocessableEntity},
+	{service.ErrCEPStateMismatch, http.StatusUnprocessableEntity},
+	{service.ErrRecipientMismatch, http.StatusUnprocessableEntity},
+	{service.ErrUnknownCarrierEvent, http.StatusUnprocessableEntity},
+	{service.ErrSellerRequired, http.StatusUnprocessableEntity},
+	{service.ErrRegionNotFound, http.StatusUnprocessableEntity},
+	{service.ErrRetroactiveRate, http.StatusUnprocessableEntity},
+
+	{service.ErrInvalidListFilter, http.StatusBadRequest},
+	{service.ErrInvalidImport, http.StatusBadRequest},
+	{service.ErrInvalidTrackingCode, http.StatusBadRequest},
+}
+
+// handleServiceError responds with the status mapped to err in serviceErrorStatus.
+func handleServiceError(ctx *gin.Context, err error) {
+	for _, mapping := range serviceErrorStatus {
+		if errors.Is(err, mapping.err) {
+			v1.HandleError(ctx, mapping.status, err.Error(), nil)
+			return
+		}
+	}
+	v1.HandleInternalError(ctx, err.Error())
+}
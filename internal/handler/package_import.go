@@ -0,0 +1,371 @@
+package handler
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/api/v1"
+	"github/moura95/olist-shipping-api/internal/middleware"
+	"github/moura95/olist-shipping-api/internal/service"
+)
+
+const maxImportBytes = 5 << 20
+
+const (
+	importFormatCSV    = "csv"
+	importFormatNDJSON = "ndjson"
+)
+
+var errUnknownImportFormat = errors.New("formato não reconhecido: use formato=csv ou formato=ndjson")
+
+// Import godoc
+// @Summary      Import packages
+// @Description  Bulk create packages from a CSV (header with the same fields as the create request) or NDJSON file. Rows are validated with the create rules; valid rows are inserted atomically. In "tudo_ou_nada" mode any invalid row rejects the whole file.
+// @Tags         packages
+// @Accept       text/csv
+// @Accept       application/x-ndjson
+// @Accept       multipart/form-data
+// @Produce      json
+// @Security     BearerAuth
+// @Param        formato  query     string  false  "File format (csv, ndjson); inferred from Content-Type or file extension"
+// @Param        modo     query     string  false  "Import mode (parcial, tudo_ou_nada)"
+// @Param        vendedor_id  query  string  false  "Seller that owns the packages (required without a seller-scoped credential)"
+// @Param        arquivo  formData  file    false  "File to import (multipart)"
+// @Success      200      {object}  v1.Response{data=v1.ImportPackagesResponse}
+// @Failure      400      {object}  v1.Response
+// @Failure      422      {object}  v1.Response{data=v1.ImportPackagesResponse}
+// @Failure      500      {object}  v1.Response
+// @Router       /packages/import [post]
+func (h *PackageHandler) Import(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("import packages started")
+
+	var query v1.ImportPackagesQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		logger.Errorw("bind query failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxImportBytes)
+	body, format, err := importBody(ctx, query.Format)
+	if err != nil {
+		logger.Errorw("read import file failed", "error", err)
+		v1.HandleBadRequest(ctx, err.Error())
+		return
+	}
+	defer body.Close()
+
+	var rows []service.ImportRow
+	switch format {
+	case importFormatCSV:
+		rows, err = h.parseImportCSV(body)
+	case importFormatNDJSON:
+		rows, err = h.parseImportNDJSON(body)
+	}
+	if err != nil {
+		logger.Errorw("parse import file failed", "error", err, "format", format)
+		v1.HandleBadRequest(ctx, err.Error())
+		return
+	}
+
+	mode := service.ImportMode(query.Mode)
+	if mode == "" {
+		mode = service.ImportModePartial
+	}
+
+	report, err := h.packageService.ImportPackages(ctx, sellerForRequest(ctx, query.SellerID), rows, mode)
+	if err != nil {
+		logger.Errorw("import packages failed", "error", err)
+		handleServiceError(ctx, fmt.Errorf("import packages: %w", err))
+		return
+	}
+
+	resp := newImportPackagesResponse(report)
+
+	if report.Rejected() {
+		logger.Infow("import packages rejected", "format", format, "total", len(rows), "failed", report.Failed)
+		v1.HandleError(ctx, http.StatusUnprocessableEntity, "import rejected: rows with errors", resp)
+		return
+	}
+
+	logger.Infow("import packages completed", "format", format, "total", len(rows), "imported", report.Imported, "failed", report.Failed)
+	v1.HandleSuccess(ctx, resp)
+}
+
+// importBody reads the "arquivo" multipart field or the raw request body.
+func importBody(ctx *gin.Context, format string) (io.ReadCloser, string, error) {
+	contentType, _, _ := mime.ParseMediaType(ctx.GetHeader("Content-Type"))
+
+	if contentType == "multipart/form-data" {
+		fileHeader, err := ctx.FormFile("arquivo")
+		if err != nil {
+			return nil, "", fmt.Errorf("arquivo é obrigatório")
+		}
+		if format == "" {
+			format = formatFromExtension(fileHeader.Filename)
+		}
+		if format == "" {
+			return nil, "", errUnknownImportFormat
+		}
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, "", fmt.Errorf("open import file: %v", err)
+		}
+		return file, format, nil
+	}
+
+	if format == "" {
+		format = formatFromContentType(contentType)
+	}
+	if format == "" {
+		return nil, "", errUnknownImportFormat
+	}
+	return ctx.Request.Body, format, nil
+}
+
+func formatFromExtension(filename string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return importFormatCSV
+	case ".ndjson", ".jsonl":
+		return importFormatNDJSON
+	}
+	return ""
+}
+
+func formatFromContentType(contentType string) string {
+	switch contentType {
+	case "text/csv", "application/csv":
+		return importFormatCSV
+	case "application/x-ndjson", "application/ndjson", "application/jsonl":
+		return importFormatNDJSON
+	}
+	return ""
+}
+
+func (h *PackageHandler) parseImportCSV(body io.Reader) ([]service.ImportRow, error) {
+	csvReader, columns, semicolon, err := newImportCSVReader(body, importColumns)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, required := range []string{"produto", "peso_kg"} {
+		if _, ok := columns[required]; !ok {
+			return nil, fmt.Errorf("coluna obrigatória ausente: %s", required)
+		}
+	}
+
+	var rows []service.ImportRow
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		line, _ := csvReader.FieldPos(0)
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				rows = append(rows, service.ImportRow{Line: parseErr.StartLine, Errors: []string{parseErr.Err.Error()}})
+				continue
+			}
+			return nil, fmt.Errorf("read csv: %v", err)
+		}
+
+		value := func(column string) string {
+			if i, ok := columns[column]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		var parseErrors []string
+		number := func(column string) float64 {
+			raw := value(column)
+			if raw == "" {
+				return 0
+			}
+			if semicolon {
+				raw = strings.ReplaceAll(raw, ",", ".")
+			}
+			n, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				parseErrors = append(parseErrors, column+" deve ser numérico")
+			}
+			return n
+		}
+
+		req := v1.CreatePackageRequest{
+			Product:          value("produto"),
+			WeightKg:         number("peso_kg"),
+			DestinationState: strings.ToUpper(value("estado_destino")),
+			DestinationCEP:   value("cep_destino"),
+			HeightCm:         number("altura_cm"),
+			WidthCm:          number("largura_cm"),
+			LengthCm:         number("comprimento_cm"),
+			Category:         value("categoria"),
+			OriginWarehouse:  value("armazem_origem_id"),
+		}
+		rows = append(rows, h.newImportRow(line, req, parseErrors))
+	}
+
+	return rows, nil
+}
+
+// newImportCSVReader accepts commas, or semicolons with decimal commas as in Brazilian spreadsheets.
+func newImportCSVReader(body io.Reader, allowed map[string]bool) (*csv.Reader, map[string]int, bool, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, nil, false, fmt.Errorf("read csv: %v", err)
+	}
+
+	firstLine := data
+	if end := bytes.IndexByte(firstLine, '\n'); end >= 0 {
+		firstLine = firstLine[:end]
+	}
+
+	csvReader := csv.NewReader(bytes.NewReader(data))
+	csvReader.FieldsPerRecord = -1
+	csvReader.TrimLeadingSpace = true
+	semicolon := bytes.Count(firstLine, []byte(";")) > bytes.Count(firstLine, []byte(","))
+	if semicolon {
+		csvReader.Comma = ';'
+	}
+
+	header, err := csvReader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil, false, fmt.Errorf("arquivo vazio")
+		}
+		return nil, nil, false, fmt.Errorf("read csv header: %v", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\uFEFF")))
+		if !allowed[name] {
+			return nil, nil, false, fmt.Errorf("coluna desconhecida: %s", name)
+		}
+		columns[name] = i
+	}
+
+	return csvReader, columns, semicolon, nil
+}
+
+// parseImportNDJSON skips blank lines.
+func (h *PackageHandler) parseImportNDJSON(body io.Reader) ([]service.ImportRow, error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 64*1024), maxImportBytes)
+
+	var rows []service.ImportRow
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		var req v1.CreatePackageRequest
+		if err := json.Unmarshal(text, &req); err != nil {
+			rows = append(rows, service.ImportRow{Line: line, Errors: []string{"JSON inválido: " + err.Error()}})
+			continue
+		}
+		rows = append(rows, h.newImportRow(line, req, nil))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read ndjson: %v", err)
+	}
+
+	return rows, nil
+}
+
+// importColumns match the JSON field names of CreatePackageRequest.
+var importColumns = map[string]bool{
+	"produto":           true,
+	"peso_kg":           true,
+	"estado_destino":    true,
+	"cep_destino":       true,
+	"altura_cm":         true,
+	"largura_cm":        true,
+	"comprimento_cm":    true,
+	"categoria":         true,
+	"armazem_origem_id": true,
+}
+
+func (h *PackageHandler) newImportRow(line int, req v1.CreatePackageRequest, parseErrors []string) service.ImportRow {
+	row := service.ImportRow{Line: line, Errors: parseErrors}
+	if len(row.Errors) == 0 {
+		if err := h.validate.Struct(req); err != nil {
+			if messages := v1.ValidationMessages(err); messages != nil {
+				row.Errors = messages
+			} else {
+				row.Errors = []string{err.Error()}
+			}
+		}
+	}
+
+	row.Input = service.CreatePackageInput{
+		Product:           req.Product,
+		WeightKg:          req.WeightKg,
+		DestinationState:  req.DestinationState,
+		Dimensions:        newDimensions(req.HeightCm, req.WidthCm, req.LengthCm),
+		Category:          req.Category,
+		OriginWarehouseID: req.OriginWarehouse,
+		DestinationCEP:    req.DestinationCEP,
+		Recipient:         newAddress(req.Recipient),
+		Sender:            newAddress(req.Sender),
+	}
+	return row
+}
+
+func newImportPackagesResponse(report *service.ImportReport) v1.ImportPackagesResponse {
+	mode := string(report.Mode)
+	total := len(report.Rows)
+	resp := v1.ImportPackagesResponse{
+		Mode:     &mode,
+		Total:    &total,
+		Imported: &report.Imported,
+		Failed:   &report.Failed,
+		Rows:     make([]v1.ImportRowResponse, 0, len(report.Rows)),
+	}
+
+	for _, row := range report.Rows {
+		line := row.Line
+		status := row.Status
+		var packageID *string
+		if row.PackageID != uuid.Nil {
+			id := row.PackageID.String()
+			packageID = &id
+		}
+		errs := row.Errors
+		if errs == nil {
+			errs = []string{}
+		}
+		resp.Rows = append(resp.Rows, v1.ImportRowResponse{
+			Line:      &line,
+			Status:    &status,
+			PackageID: packageID,
+			Errors:    errs,
+		})
+	}
+	return resp
+}
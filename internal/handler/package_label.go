@@ -0,0 +1,79 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github/moura95/olist-shipping-api/api/v1"
+	"github/moura95/olist-shipping-api/internal/middleware"
+	"github/moura95/olist-shipping-api/pkg/label"
+)
+
+// Label godoc
+// @Summary      Get shipping label
+// @Description  Render the shipping label of a package with a hired carrier (sender, recipient, carrier, Code128 tracking barcode and QR code). The tracking code is assigned when the carrier is hired; this endpoint never changes the package.
+// @Tags         packages
+// @Produce      application/pdf
+// @Produce      text/plain
+// @Security     BearerAuth
+// @Param        id      path      string  true   "Package ID"
+// @Param        format  query     string  false  "Label format (pdf, zpl)" default(pdf)
+// @Success      200     {file}    file
+// @Failure      400     {object}  v1.Response
+// @Failure      404     {object}  v1.Response
+// @Failure      409     {object}  v1.Response
+// @Failure      500     {object}  v1.Response
+// @Router       /packages/{id}/label [get]
+func (h *PackageHandler) Label(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("get package label started")
+
+	id := ctx.Param("id")
+	if id == "" {
+		logger.Errorw("package id is required")
+		v1.HandleBadRequest(ctx, "Package ID is required")
+		return
+	}
+
+	var query v1.LabelQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		logger.Errorw("bind query failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	format := query.Format
+	if format == "" {
+		format = label.FormatPDF
+	}
+
+	shippingLabel, err := h.packageService.GetLabel(ctx, id, sellerFromContext(ctx))
+	if err != nil {
+		logger.Errorw("get package label failed", "error", err, "id", id)
+		handleServiceError(ctx, fmt.Errorf("get package label: %w", err))
+		return
+	}
+
+	render, contentType := label.PDF, "application/pdf"
+	if format == label.FormatZPL {
+		render, contentType = label.ZPL, "text/plain; charset=utf-8"
+	}
+
+	body, err := render(*shippingLabel)
+	if err != nil {
+		logger.Errorw("render package label failed", "error", err, "id", id, "format", format)
+		v1.HandleInternalError(ctx, err.Error())
+		return
+	}
+
+	ctx.Header("Content-Disposition", fmt.Sprintf(`inline; filename="etiqueta-%s.%s"`, shippingLabel.TrackingCode, format))
+	logger.Infow("get package label completed", "id", id, "format", format, "tracking_code", shippingLabel.TrackingCode)
+	ctx.Data(http.StatusOK, contentType, body)
+}
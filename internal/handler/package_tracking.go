@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github/moura95/olist-shipping-api/api/v1"
+	"github/moura95/olist-shipping-api/internal/middleware"
+	"github/moura95/olist-shipping-api/internal/service"
+	"github/moura95/olist-shipping-api/internal/util"
+)
+
+// Tracking godoc
+// @Summary      Public package tracking
+// @Description  Public tracking view of a package: current status, carrier, estimated delivery date and the chronological list of status changes and carrier scans. Prices and internal IDs are not exposed.
+// @Tags         tracking
+// @Produce      json
+// @Param        tracking_code  path      string  true  "Tracking Code"
+// @Success      200            {object}  v1.Response{data=v1.TrackingResponse}
+// @Failure      400            {object}  v1.Response
+// @Failure      404            {object}  v1.Response
+// @Failure      500            {object}  v1.Response
+// @Router       /tracking/{tracking_code} [get]
+func (h *PackageHandler) Tracking(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("get tracking timeline started")
+
+	trackingCode := ctx.Param("tracking_code")
+	if trackingCode == "" {
+		logger.Errorw("tracking code is required")
+		v1.HandleBadRequest(ctx, "Tracking code is required")
+		return
+	}
+
+	timeline, err := h.packageService.GetTrackingTimeline(ctx, trackingCode)
+	if err != nil {
+		logger.Errorw("get tracking timeline failed", "error", err, "tracking_code", trackingCode)
+		handleServiceError(ctx, fmt.Errorf("get tracking timeline: %w", err))
+		return
+	}
+
+	response := newTrackingResponse(*timeline)
+
+	// Short cache for storefront reloads without delaying updates
+	ctx.Header("Cache-Control", "public, max-age=60")
+
+	logger.Infow("get tracking timeline completed", "tracking_code", trackingCode, "events", len(response.Events))
+	v1.HandleSuccess(ctx, response)
+}
+
+func newTrackingResponse(timeline service.TrackingTimeline) v1.TrackingResponse {
+	pkg := timeline.Package
+
+	var carrierName, estimatedDelivery *string
+	if timeline.CarrierName != "" {
+		carrierName = &timeline.CarrierName
+	}
+	if pkg.EstimatedDeliveryDate.Valid {
+		formatted := pkg.EstimatedDeliveryDate.Time.Format(time.DateOnly)
+		estimatedDelivery = &formatted
+	}
+
+	events := []v1.TrackingEventResponse{}
+	for _, event := range timeline.Events {
+		occurredAt := event.OccurredAt.Format(time.RFC3339)
+		response := v1.TrackingEventResponse{
+			Type:        &event.Type,
+			Description: &event.Description,
+			OccurredAt:  &occurredAt,
+		}
+		if event.Status != "" {
+			response.Status = &event.Status
+		}
+		if event.Location != "" {
+			response.Location = &event.Location
+		}
+		events = append(events, response)
+	}
+
+	return v1.TrackingResponse{
+		TrackingCode:      &pkg.TrackingCode.String,
+		Status:            &pkg.Status,
+		CarrierName:       carrierName,
+		DestinationState:  &pkg.DestinationState,
+		DestinationCity:   util.NullStringToPtr(pkg.DestinationCity),
+		EstimatedDelivery: estimatedDelivery,
+		Events:            events,
+	}
+}
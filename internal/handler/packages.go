@@ -1,15 +1,19 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"github/moura95/olist-shipping-api/api/v1"
 	"github/moura95/olist-shipping-api/config"
+	"github/moura95/olist-shipping-api/internal/etag"
 	"github/moura95/olist-shipping-api/internal/middleware"
+	"github/moura95/olist-shipping-api/internal/repository"
 	"github/moura95/olist-shipping-api/internal/service"
 	"github/moura95/olist-shipping-api/internal/util"
 	customValidator "github/moura95/olist-shipping-api/pkg/validator"
@@ -35,61 +39,83 @@ func NewPackageHandler(packageService *service.PackageService, cfg *config.Confi
 }
 
 // List godoc
-// @Summary      List all packages
-// @Description  Get all packages with pagination support
+// @Summary      List packages
+// @Description  List packages with filters, sorting and cursor-based pagination
 // @Tags         packages
 // @Accept       json
 // @Produce      json
-// @Success      200  {object}  v1.Response{data=[]v1.PackageResponse}
+// @Security     BearerAuth
+// @Param        status             query     string  false  "Package status"
+// @Param        estado_destino     query     string  false  "Destination state code"
+// @Param        transportadora_id  query     string  false  "Hired carrier ID"
+// @Param        criado_de          query     string  false  "Created from (YYYY-MM-DD)"
+// @Param        criado_ate         query     string  false  "Created until, inclusive (YYYY-MM-DD)"
+// @Param        busca              query     string  false  "Product text search"
+// @Param        ordenar_por        query     string  false  "Sort field (criado_em, peso_kg)"
+// @Param        ordem              query     string  false  "Sort order (asc, desc)"
+// @Param        cursor             query     string  false  "Cursor returned by the previous page"
+// @Param        limite             query     int     false  "Page size (max 100)"
+// @Param        excluidos          query     bool    false  "List only deleted packages"
+// @Success      200  {object}  v1.PaginatedResponse{data=[]v1.PackageResponse}
+// @Failure      400  {object}  v1.Response
 // @Failure      500  {object}  v1.Response
 // @Router       /packages [get]
 func (h *PackageHandler) List(ctx *gin.Context) {
 	logger := middleware.GetLoggerFromContext(ctx)
 	logger.Info("list packages started")
 
-	packages, err := h.packageService.GetAll(ctx)
-	if err != nil {
-		logger.Errorw("list packages failed", "error", err)
-		v1.HandleInternalError(ctx, fmt.Errorf("list packages: %v", err).Error())
+	var query v1.ListPackagesQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		logger.Errorw("bind query failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid query parameters")
 		return
 	}
 
-	var resp []v1.PackageResponse
-	for _, pkg := range packages {
-		var createdAt, updatedAt *string
-		if pkg.CreatedAt.Valid {
-			formatted := pkg.CreatedAt.Time.Format(time.RFC3339)
-			createdAt = &formatted
-		}
-		if pkg.UpdatedAt.Valid {
-			formatted := pkg.UpdatedAt.Time.Format(time.RFC3339)
-			updatedAt = &formatted
-		}
+	if err := h.validate.Struct(query); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
 
-		pkgID := pkg.ID.String()
-		var hiredCarrierID *string
-		if pkg.HiredCarrierID.Valid {
-			carrierID := pkg.HiredCarrierID.UUID.String()
-			hiredCarrierID = &carrierID
-		}
+	filter := service.PackageListFilter{
+		Status:           query.Status,
+		DestinationState: query.DestinationState,
+		HiredCarrierID:   query.HiredCarrierID,
+		Search:           query.Search,
+		SortBy:           service.SortByCreatedAt,
+		SortDesc:         query.Order != "asc",
+		Cursor:           query.Cursor,
+		Limit:            query.Limit,
+		SellerID:         sellerFromContext(ctx),
+		Deleted:          query.Deleted,
+	}
+	if query.SortBy == "peso_kg" {
+		filter.SortBy = service.SortByWeightKg
+	}
+	if query.CreatedFrom != "" {
+		createdFrom, _ := time.Parse(time.DateOnly, query.CreatedFrom)
+		filter.CreatedFrom = &createdFrom
+	}
+	if query.CreatedTo != "" {
+		createdTo, _ := time.Parse(time.DateOnly, query.CreatedTo)
+		createdTo = createdTo.AddDate(0, 0, 1)
+		filter.CreatedTo = &createdTo
+	}
 
-		resp = append(resp, v1.PackageResponse{
-			ID:                &pkgID,
-			TrackingCode:      util.NullStringToPtr(pkg.TrackingCode),
-			Product:           &pkg.Product,
-			WeightKg:          &pkg.WeightKg,
-			DestinationState:  &pkg.DestinationState,
-			Status:            &pkg.Status,
-			HiredCarrierID:    hiredCarrierID,
-			HiredPrice:        util.NullStringToPtr(pkg.HiredPrice),
-			HiredDeliveryDays: util.NullInt32ToPtr(pkg.HiredDeliveryDays),
-			CreatedAt:         createdAt,
-			UpdatedAt:         updatedAt,
-		})
+	page, err := h.packageService.List(ctx, filter)
+	if err != nil {
+		logger.Errorw("list packages failed", "error", err)
+		handleServiceError(ctx, fmt.Errorf("list packages: %w", err))
+		return
 	}
 
-	logger.Infow("list packages completed", "count", len(resp))
-	v1.HandleSuccess(ctx, resp)
+	resp := []v1.PackageResponse{}
+	for _, pkg := range page.Packages {
+		resp = append(resp, newPackageResponse(pkg))
+	}
+
+	logger.Infow("list packages completed", "count", len(resp), "total", page.Total)
+	v1.HandlePaginated(ctx, resp, page.NextCursor, page.Total)
 }
 
 // GetByID godoc
@@ -98,10 +124,13 @@ func (h *PackageHandler) List(ctx *gin.Context) {
 // @Tags         packages
 // @Accept       json
 // @Produce      json
-// @Param        id   path      string  true  "Package ID"
-// @Success      200  {object}  v1.Response{data=v1.PackageResponse}
-// @Failure      400  {object}  v1.Response
-// @Failure      404  {object}  v1.Response
+// @Security     BearerAuth
+// @Param        id             path      string  true   "Package ID"
+// @Param        If-None-Match  header    string  false  "ETag of a cached copy; responds 304 while the package is unchanged"
+// @Success      200            {object}  v1.Response{data=v1.PackageResponse}
+// @Success      304            "Not Modified"
+// @Failure      400            {object}  v1.Response
+// @Failure      404            {object}  v1.Response
 // @Router       /packages/{id} [get]
 func (h *PackageHandler) GetByID(ctx *gin.Context) {
 	logger := middleware.GetLoggerFromContext(ctx)
@@ -114,43 +143,19 @@ func (h *PackageHandler) GetByID(ctx *gin.Context) {
 		return
 	}
 
-	pkg, err := h.packageService.GetByID(ctx, id)
+	pkg, err := h.packageService.GetByID(ctx, id, sellerFromContext(ctx))
 	if err != nil {
 		logger.Errorw("get package by id failed", "error", err, "id", id)
 		v1.HandleNotFound(ctx, fmt.Errorf("get package by id: %v", err).Error())
 		return
 	}
 
-	var createdAt, updatedAt *string
-	if pkg.CreatedAt.Valid {
-		formatted := pkg.CreatedAt.Time.Format(time.RFC3339)
-		createdAt = &formatted
-	}
-	if pkg.UpdatedAt.Valid {
-		formatted := pkg.UpdatedAt.Time.Format(time.RFC3339)
-		updatedAt = &formatted
-	}
-
-	pkgID := pkg.ID.String()
-	var hiredCarrierID *string
-	if pkg.HiredCarrierID.Valid {
-		carrierID := pkg.HiredCarrierID.UUID.String()
-		hiredCarrierID = &carrierID
+	if notModified(ctx, *pkg) {
+		logger.Infow("get package by id not modified", "id", id)
+		return
 	}
 
-	response := v1.PackageResponse{
-		ID:                &pkgID,
-		TrackingCode:      util.NullStringToPtr(pkg.TrackingCode),
-		Product:           &pkg.Product,
-		WeightKg:          &pkg.WeightKg,
-		DestinationState:  &pkg.DestinationState,
-		Status:            &pkg.Status,
-		HiredCarrierID:    hiredCarrierID,
-		HiredPrice:        util.NullStringToPtr(pkg.HiredPrice),
-		HiredDeliveryDays: util.NullInt32ToPtr(pkg.HiredDeliveryDays),
-		CreatedAt:         createdAt,
-		UpdatedAt:         updatedAt,
-	}
+	response := newPackageResponse(*pkg)
 
 	logger.Infow("get package by id completed", "id", id)
 	v1.HandleSuccess(ctx, response)
@@ -162,10 +167,13 @@ func (h *PackageHandler) GetByID(ctx *gin.Context) {
 // @Tags         packages
 // @Accept       json
 // @Produce      json
-// @Param        tracking_code  path      string  true  "Tracking Code"
-// @Success      200           {object}  v1.Response{data=v1.PackageResponse}
-// @Failure      400           {object}  v1.Response
-// @Failure      404           {object}  v1.Response
+// @Security     BearerAuth
+// @Param        tracking_code  path      string  true   "Tracking Code"
+// @Param        If-None-Match  header    string  false  "ETag of a cached copy; responds 304 while the package is unchanged"
+// @Success      200            {object}  v1.Response{data=v1.PackageResponse}
+// @Success      304            "Not Modified"
+// @Failure      400            {object}  v1.Response
+// @Failure      404            {object}  v1.Response
 // @Router       /packages/tracking/{tracking_code} [get]
 func (h *PackageHandler) GetByTrackingCode(ctx *gin.Context) {
 	logger := middleware.GetLoggerFromContext(ctx)
@@ -178,43 +186,23 @@ func (h *PackageHandler) GetByTrackingCode(ctx *gin.Context) {
 		return
 	}
 
-	pkg, err := h.packageService.GetByTrackingCode(ctx, trackingCode)
+	pkg, err := h.packageService.GetByTrackingCode(ctx, trackingCode, sellerFromContext(ctx))
 	if err != nil {
 		logger.Errorw("get package by tracking code failed", "error", err, "tracking_code", trackingCode)
+		if errors.Is(err, service.ErrInvalidTrackingCode) {
+			v1.HandleBadRequest(ctx, err.Error())
+			return
+		}
 		v1.HandleNotFound(ctx, fmt.Errorf("get package by tracking code: %v", err).Error())
 		return
 	}
 
-	var createdAt, updatedAt *string
-	if pkg.CreatedAt.Valid {
-		formatted := pkg.CreatedAt.Time.Format(time.RFC3339)
-		createdAt = &formatted
-	}
-	if pkg.UpdatedAt.Valid {
-		formatted := pkg.UpdatedAt.Time.Format(time.RFC3339)
-		updatedAt = &formatted
-	}
-
-	pkgID := pkg.ID.String()
-	var hiredCarrierID *string
-	if pkg.HiredCarrierID.Valid {
-		carrierID := pkg.HiredCarrierID.UUID.String()
-		hiredCarrierID = &carrierID
+	if notModified(ctx, *pkg) {
+		logger.Infow("get package by tracking code not modified", "tracking_code", trackingCode)
+		return
 	}
 
-	response := v1.PackageResponse{
-		ID:                &pkgID,
-		TrackingCode:      util.NullStringToPtr(pkg.TrackingCode),
-		Product:           &pkg.Product,
-		WeightKg:          &pkg.WeightKg,
-		DestinationState:  &pkg.DestinationState,
-		Status:            &pkg.Status,
-		HiredCarrierID:    hiredCarrierID,
-		HiredPrice:        util.NullStringToPtr(pkg.HiredPrice),
-		HiredDeliveryDays: util.NullInt32ToPtr(pkg.HiredDeliveryDays),
-		CreatedAt:         createdAt,
-		UpdatedAt:         updatedAt,
-	}
+	response := newPackageResponse(*pkg)
 
 	logger.Infow("get package by tracking code completed", "tracking_code", trackingCode)
 	v1.HandleSuccess(ctx, response)
@@ -222,14 +210,18 @@ func (h *PackageHandler) GetByTrackingCode(ctx *gin.Context) {
 
 // Create godoc
 // @Summary      Create a new package
-// @Description  Create a new package for shipping
+// @Description  Create a new package for shipping. With a recipient (destinatario), the destination state and CEP come from its address; CPF/CNPJ check digits and phone are validated for both addresses.
 // @Tags         packages
 // @Accept       json
 // @Produce      json
-// @Param        request  body      v1.CreatePackageRequest  true  "Package data"
-// @Success      201      {object}  v1.Response{data=v1.PackageResponse}
-// @Failure      400      {object}  v1.Response
-// @Failure      500      {object}  v1.Response
+// @Security     BearerAuth
+// @Param        request          body      v1.CreatePackageRequest  true   "Package data"
+// @Param        Idempotency-Key  header    string                   false  "Replays the first response for retries with the same key and body"
+// @Success      201              {object}  v1.Response{data=v1.PackageResponse}
+// @Failure      400              {object}  v1.Response
+// @Failure      409              {object}  v1.Response
+// @Failure      422              {object}  v1.Response
+// @Failure      500              {object}  v1.Response
 // @Router       /packages [post]
 func (h *PackageHandler) Create(ctx *gin.Context) {
 	logger := middleware.GetLoggerFromContext(ctx)
@@ -248,39 +240,30 @@ func (h *PackageHandler) Create(ctx *gin.Context) {
 		return
 	}
 
-	pkg, err := h.packageService.Create(ctx, req.Product, req.WeightKg, req.DestinationState)
+	input := service.CreatePackageInput{
+		Product:           req.Product,
+		WeightKg:          req.WeightKg,
+		DestinationState:  req.DestinationState,
+		Dimensions:        newDimensions(req.HeightCm, req.WidthCm, req.LengthCm),
+		Category:          req.Category,
+		OriginWarehouseID: req.OriginWarehouse,
+		DestinationCEP:    req.DestinationCEP,
+		SellerID:          sellerForRequest(ctx, req.SellerID),
+		Recipient:         newAddress(req.Recipient),
+		Sender:            newAddress(req.Sender),
+	}
+
+	pkg, err := h.packageService.Create(ctx, input)
 	if err != nil {
 		logger.Errorw("create package failed", "error", err)
-		v1.HandleInternalError(ctx, fmt.Errorf("create package: %v", err).Error())
+		handleServiceError(ctx, fmt.Errorf("create package: %w", err))
 		return
 	}
 
-	var createdAt, updatedAt *string
-	if pkg.CreatedAt.Valid {
-		formatted := pkg.CreatedAt.Time.Format(time.RFC3339)
-		createdAt = &formatted
-	}
-	if pkg.UpdatedAt.Valid {
-		formatted := pkg.UpdatedAt.Time.Format(time.RFC3339)
-		updatedAt = &formatted
-	}
-
-	pkgID := pkg.ID.String()
-	response := v1.PackageResponse{
-		ID:                &pkgID,
-		TrackingCode:      util.NullStringToPtr(pkg.TrackingCode),
-		Product:           &pkg.Product,
-		WeightKg:          &pkg.WeightKg,
-		DestinationState:  &pkg.DestinationState,
-		Status:            &pkg.Status,
-		HiredCarrierID:    nil,
-		HiredPrice:        util.NullStringToPtr(pkg.HiredPrice),
-		HiredDeliveryDays: util.NullInt32ToPtr(pkg.HiredDeliveryDays),
-		CreatedAt:         createdAt,
-		UpdatedAt:         updatedAt,
-	}
+	response := newPackageResponse(*pkg)
 
 	logger.Infow("create package completed", "id", pkg.ID, "tracking_code", pkg.TrackingCode)
+	ctx.Header("ETag", etag.Format(pkg.Version))
 	v1.HandleCreated(ctx, response)
 }
 
@@ -290,11 +273,16 @@ func (h *PackageHandler) Create(ctx *gin.Context) {
 // @Tags         packages
 // @Accept       json
 // @Produce      json
-// @Param        id       path  string                        true  "Package ID"
-// @Param        request  body  v1.UpdatePackageStatusRequest  true  "Status data"
-// @Success      204      "No Content"
-// @Failure      400      {object}  v1.Response
-// @Failure      500      {object}  v1.Response
+// @Security     BearerAuth
+// @Param        id        path    string                         true   "Package ID"
+// @Param        request   body    v1.UpdatePackageStatusRequest  true   "Status data"
+// @Param        If-Match  header  string                         false  "ETag of the package version being changed"
+// @Success      204       "No Content"
+// @Failure      400       {object}  v1.Response
+// @Failure      404       {object}  v1.Response
+// @Failure      409       {object}  v1.Response
+// @Failure      412       {object}  v1.Response
+// @Failure      500       {object}  v1.Response
 // @Router       /packages/{id}/status [patch]
 func (h *PackageHandler) UpdateStatus(ctx *gin.Context) {
 	logger := middleware.GetLoggerFromContext(ctx)
@@ -320,27 +308,46 @@ func (h *PackageHandler) UpdateStatus(ctx *gin.Context) {
 		return
 	}
 
-	err := h.packageService.UpdateStatus(ctx, id, req.Status)
+	version, ok := ifMatchVersion(ctx)
+	if !ok {
+		return
+	}
+
+	change := service.StatusChange{
+		Status:  req.Status,
+		Actor:   actorFromContext(ctx),
+		Reason:  req.Reason,
+		Version: version,
+	}
+
+	newVersion, err := h.packageService.UpdateStatus(ctx, id, sellerFromContext(ctx), change)
 	if err != nil {
 		logger.Errorw("update package status failed", "error", err, "id", id)
-		v1.HandleInternalError(ctx, fmt.Errorf("update package status: %v", err).Error())
+		handleServiceError(ctx, fmt.Errorf("update package status: %w", err))
 		return
 	}
 
 	logger.Infow("update package status completed", "id", id, "status", req.Status)
+	ctx.Header("ETag", etag.Format(newVersion))
 	ctx.Status(http.StatusNoContent)
 }
 
 // Delete godoc
 // @Summary      Delete a package
-// @Description  Delete a package by ID
+// @Description  Soft delete a package by ID; it can be restored later. Shipped packages cannot be deleted
 // @Tags         packages
 // @Accept       json
 // @Produce      json
-// @Param        id  path      string  true  "Package ID"
-// @Success      200  {object}  v1.Response
-// @Failure      400  {object}  v1.Response
-// @Failure      500  {object}  v1.Response
+// @Security     BearerAuth
+// @Param        id        path      string  true   "Package ID"
+// @Param        motivo    query     string  false  "Deletion reason"
+// @Param        If-Match  header    string  false  "ETag of the package version being deleted"
+// @Success      200       {object}  v1.Response
+// @Failure      400       {object}  v1.Response
+// @Failure      404       {object}  v1.Response
+// @Failure      409       {object}  v1.Response
+// @Failure      412       {object}  v1.Response
+// @Failure      500       {object}  v1.Response
 // @Router       /packages/{id} [delete]
 func (h *PackageHandler) Delete(ctx *gin.Context) {
 	logger := middleware.GetLoggerFromContext(ctx)
@@ -353,10 +360,32 @@ func (h *PackageHandler) Delete(ctx *gin.Context) {
 		return
 	}
 
-	err := h.packageService.Delete(ctx, id)
+	var query v1.DeletePackageQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		logger.Errorw("bind query failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	version, ok := ifMatchVersion(ctx)
+	if !ok {
+		return
+	}
+
+	err := h.packageService.Delete(ctx, id, sellerFromContext(ctx), service.PackageDeletion{
+		Actor:   actorFromContext(ctx),
+		Reason:  query.Reason,
+		Version: version,
+	})
 	if err != nil {
 		logger.Errorw("delete package failed", "error", err, "id", id)
-		v1.HandleInternalError(ctx, fmt.Errorf("delete package: %v", err).Error())
+		handleServiceError(ctx, fmt.Errorf("delete package: %w", err))
 		return
 	}
 
@@ -364,17 +393,68 @@ func (h *PackageHandler) Delete(ctx *gin.Context) {
 	v1.HandleSuccess(ctx, "Package deleted successfully")
 }
 
+// Restore godoc
+// @Summary      Restore a deleted package
+// @Description  Bring back a soft-deleted package with the status it had when it was deleted
+// @Tags         packages
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id        path      string  true   "Package ID"
+// @Param        If-Match  header    string  false  "ETag of the package version being restored"
+// @Success      200       {object}  v1.Response{data=v1.PackageResponse}
+// @Failure      400       {object}  v1.Response
+// @Failure      404       {object}  v1.Response
+// @Failure      409       {object}  v1.Response
+// @Failure      412       {object}  v1.Response
+// @Failure      500       {object}  v1.Response
+// @Router       /packages/{id}/restore [post]
+func (h *PackageHandler) Restore(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("restore package started")
+
+	id := ctx.Param("id")
+	if id == "" {
+		logger.Errorw("package id is required")
+		v1.HandleBadRequest(ctx, "Package ID is required")
+		return
+	}
+
+	version, ok := ifMatchVersion(ctx)
+	if !ok {
+		return
+	}
+
+	pkg, err := h.packageService.Restore(ctx, id, sellerFromContext(ctx), actorFromContext(ctx), version)
+	if err != nil {
+		logger.Errorw("restore package failed", "error", err, "id", id)
+		handleServiceError(ctx, fmt.Errorf("restore package: %w", err))
+		return
+	}
+
+	logger.Infow("restore package completed", "id", id)
+	ctx.Header("ETag", etag.Format(pkg.Version))
+	v1.HandleSuccess(ctx, newPackageResponse(*pkg))
+}
+
 // HireCarrier godoc
 // @Summary      Hire carrier for package
-// @Description  Hire a carrier to deliver the package
+// @Description  Hire a carrier to deliver the package using a saved, unexpired quote of that package
 // @Tags         packages
 // @Accept       json
 // @Produce      json
-// @Param        id       path  string                 true  "Package ID"
-// @Param        request  body  v1.HireCarrierRequest  true  "Carrier hire data"
-// @Success      200      {object}  v1.Response
-// @Failure      400      {object}  v1.Response
-// @Failure      500      {object}  v1.Response
+// @Security     BearerAuth
+// @Param        id               path      string                 true   "Package ID"
+// @Param        request          body      v1.HireCarrierRequest  true   "Carrier hire data"
+// @Param        Idempotency-Key  header    string                 false  "Replays the first response for retries with the same key and body"
+// @Param        If-Match         header    string                 false  "ETag of the package version being changed"
+// @Success      200              {object}  v1.Response
+// @Failure      400              {object}  v1.Response
+// @Failure      404              {object}  v1.Response
+// @Failure      409              {object}  v1.Response
+// @Failure      412              {object}  v1.Response
+// @Failure      422              {object}  v1.Response
+// @Failure      500              {object}  v1.Response
 // @Router       /packages/{id}/hire [post]
 func (h *PackageHandler) HireCarrier(ctx *gin.Context) {
 	logger := middleware.GetLoggerFromContext(ctx)
@@ -400,13 +480,288 @@ func (h *PackageHandler) HireCarrier(ctx *gin.Context) {
 		return
 	}
 
-	err := h.packageService.HireCarrier(ctx, id, req.CarrierID, req.Price, req.DeliveryDays)
+	version, ok := ifMatchVersion(ctx)
+	if !ok {
+		return
+	}
+
+	newVersion, err := h.packageService.HireCarrier(ctx, id, req.QuoteID, sellerFromContext(ctx), actorFromContext(ctx), version)
 	if err != nil {
-		logger.Errorw("hire carrier failed", "error", err, "id", id, "carrier_id", req.CarrierID)
-		v1.HandleInternalError(ctx, fmt.Errorf("hire carrier: %v", err).Error())
+		logger.Errorw("hire carrier failed", "error", err, "id", id, "quote_id", req.QuoteID)
+		handleServiceError(ctx, fmt.Errorf("hire carrier: %w", err))
 		return
 	}
 
-	logger.Infow("hire carrier completed", "id", id, "carrier_id", req.CarrierID)
+	logger.Infow("hire carrier completed", "id", id, "quote_id", req.QuoteID)
+	ctx.Header("ETag", etag.Format(newVersion))
 	v1.HandleSuccess(ctx, "Carrier hired successfully")
 }
+
+// CreateQuotes godoc
+// @Summary      Create package quotes
+// @Description  Compute and save shipping quotes for the package; the returned quote id is used to hire the carrier before it expires
+// @Tags         packages
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Package ID"
+// @Success      201  {object}  v1.Response{data=v1.QuotesResponse}
+// @Failure      400  {object}  v1.Response
+// @Failure      404  {object}  v1.Response
+// @Failure      409  {object}  v1.Response
+// @Failure      500  {object}  v1.Response
+// @Router       /packages/{id}/quotes [post]
+func (h *PackageHandler) CreateQuotes(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("create package quotes started")
+
+	id := ctx.Param("id")
+	if id == "" {
+		logger.Errorw("package id is required")
+		v1.HandleBadRequest(ctx, "Package ID is required")
+		return
+	}
+
+	result, err := h.packageService.CreatePackageQuotes(ctx, id, sellerFromContext(ctx))
+	if err != nil {
+		logger.Errorw("create package quotes failed", "error", err, "id", id)
+		handleServiceError(ctx, fmt.Errorf("create package quotes: %w", err))
+		return
+	}
+
+	resp := newQuotesResponse(result)
+
+	logger.Infow("create package quotes completed", "id", id, "quotes_count", len(resp.Quotes), "unavailable_count", len(resp.Unavailable))
+	v1.HandleCreated(ctx, resp)
+}
+
+// History godoc
+// @Summary      Get package status history
+// @Description  Get the timeline of status transitions of a package
+// @Tags         packages
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Package ID"
+// @Success      200  {object}  v1.Response{data=[]v1.PackageStatusEventResponse}
+// @Failure      400  {object}  v1.Response
+// @Failure      404  {object}  v1.Response
+// @Failure      500  {object}  v1.Response
+// @Router       /packages/{id}/history [get]
+func (h *PackageHandler) History(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("get package history started")
+
+	id := ctx.Param("id")
+	if id == "" {
+		logger.Errorw("package id is required")
+		v1.HandleBadRequest(ctx, "Package ID is required")
+		return
+	}
+
+	events, err := h.packageService.GetStatusHistory(ctx, id, sellerFromContext(ctx))
+	if err != nil {
+		logger.Errorw("get package history failed", "error", err, "id", id)
+		handleServiceError(ctx, fmt.Errorf("get package history: %w", err))
+		return
+	}
+
+	resp := []v1.PackageStatusEventResponse{}
+	for _, event := range events {
+		eventID := event.ID.String()
+		createdAt := event.CreatedAt.Format(time.RFC3339)
+		resp = append(resp, v1.PackageStatusEventResponse{
+			ID:         &eventID,
+			FromStatus: &event.FromStatus,
+			ToStatus:   &event.ToStatus,
+			Actor:      &event.Actor,
+			Reason:     util.NullStringToPtr(event.Reason),
+			CreatedAt:  &createdAt,
+		})
+	}
+
+	logger.Infow("get package history completed", "id", id, "count", len(resp))
+	v1.HandleSuccess(ctx, resp)
+}
+
+func newPackageResponse(pkg repository.Package) v1.PackageResponse {
+	var createdAt, updatedAt *string
+	if pkg.CreatedAt.Valid {
+		formatted := pkg.CreatedAt.Time.Format(time.RFC3339)
+		createdAt = &formatted
+	}
+	if pkg.UpdatedAt.Valid {
+		formatted := pkg.UpdatedAt.Time.Format(time.RFC3339)
+		updatedAt = &formatted
+	}
+	var hiredAt, estimatedDelivery *string
+	if pkg.HiredAt.Valid {
+		formatted := pkg.HiredAt.Time.Format(time.RFC3339)
+		hiredAt = &formatted
+	}
+	if pkg.EstimatedDeliveryDate.Valid {
+		formatted := pkg.EstimatedDeliveryDate.Time.Format(time.DateOnly)
+		estimatedDelivery = &formatted
+	}
+
+	pkgID := pkg.ID.String()
+	sellerID := pkg.SellerID.String()
+	var hiredCarrierID, originWarehouseID *string
+	if pkg.HiredCarrierID.Valid {
+		carrierID := pkg.HiredCarrierID.UUID.String()
+		hiredCarrierID = &carrierID
+	}
+	if pkg.OriginWarehouseID.Valid {
+		warehouseID := pkg.OriginWarehouseID.UUID.String()
+		originWarehouseID = &warehouseID
+	}
+
+	return v1.PackageResponse{
+		ID:                &pkgID,
+		TrackingCode:      util.NullStringToPtr(pkg.TrackingCode),
+		Product:           &pkg.Product,
+		WeightKg:          &pkg.WeightKg,
+		DestinationState:  &pkg.DestinationState,
+		Status:            &pkg.Status,
+		HiredCarrierID:    hiredCarrierID,
+		HiredPrice:        util.NullStringToPtr(pkg.HiredPrice),
+		HiredDeliveryDays: util.NullInt32ToPtr(pkg.HiredDeliveryDays),
+		HeightCm:          util.NullFloat64ToPtr(pkg.HeightCm),
+		WidthCm:           util.NullFloat64ToPtr(pkg.WidthCm),
+		LengthCm:          util.NullFloat64ToPtr(pkg.LengthCm),
+		Category:          &pkg.ProductCategory,
+		HiredAt:           hiredAt,
+		EstimatedDelivery: estimatedDelivery,
+		OriginWarehouseID: originWarehouseID,
+		DestinationCEP:    util.NullStringToPtr(pkg.DestinationCep),
+		DestinationCity:   util.NullStringToPtr(pkg.DestinationCity),
+		SellerID:          &sellerID,
+		Recipient:         newRecipientResponse(pkg),
+		Sender:            newSenderResponse(pkg),
+		Version:           &pkg.Version,
+		Deletion:          newDeletionResponse(pkg),
+		CreatedAt:         createdAt,
+		UpdatedAt:         updatedAt,
+	}
+}
+
+func newAddress(req *v1.AddressRequest) *service.Address {
+	if req == nil {
+		return nil
+	}
+	return &service.Address{
+		Name:         req.Name,
+		Document:     req.Document,
+		Phone:        req.Phone,
+		Street:       req.Street,
+		Number:       req.Number,
+		Complement:   req.Complement,
+		Neighborhood: req.Neighborhood,
+		City:         req.City,
+		State:        req.State,
+		CEP:          req.CEP,
+	}
+}
+
+// newRecipientResponse takes state, CEP and city from the destination columns.
+func newRecipientResponse(pkg repository.Package) *v1.AddressResponse {
+	if !pkg.RecipientName.Valid {
+		return nil
+	}
+	return &v1.AddressResponse{
+		Name:         util.NullStringToPtr(pkg.RecipientName),
+		Document:     util.NullStringToPtr(pkg.RecipientDocument),
+		Phone:        util.NullStringToPtr(pkg.RecipientPhone),
+		Street:       util.NullStringToPtr(pkg.RecipientStreet),
+		Number:       util.NullStringToPtr(pkg.RecipientNumber),
+		Complement:   util.NullStringToPtr(pkg.RecipientComplement),
+		Neighborhood: util.NullStringToPtr(pkg.RecipientNeighborhood),
+		City:         util.NullStringToPtr(pkg.DestinationCity),
+		State:        &pkg.DestinationState,
+		CEP:          util.NullStringToPtr(pkg.DestinationCep),
+	}
+}
+
+func newSenderResponse(pkg repository.Package) *v1.AddressResponse {
+	if !pkg.SenderName.Valid {
+		return nil
+	}
+	return &v1.AddressResponse{
+		Name:         util.NullStringToPtr(pkg.SenderName),
+		Document:     util.NullStringToPtr(pkg.SenderDocument),
+		Phone:        util.NullStringToPtr(pkg.SenderPhone),
+		Street:       util.NullStringToPtr(pkg.SenderStreet),
+		Number:       util.NullStringToPtr(pkg.SenderNumber),
+		Complement:   util.NullStringToPtr(pkg.SenderComplement),
+		Neighborhood: util.NullStringToPtr(pkg.SenderNeighborhood),
+		City:         util.NullStringToPtr(pkg.SenderCity),
+		State:        util.NullStringToPtr(pkg.SenderState),
+		CEP:          util.NullStringToPtr(pkg.SenderCep),
+	}
+}
+
+func newDeletionResponse(pkg repository.Package) *v1.DeletionResponse {
+	if !pkg.DeletedAt.Valid {
+		return nil
+	}
+	deletedAt := pkg.DeletedAt.Time.Format(time.RFC3339)
+	return &v1.DeletionResponse{
+		DeletedAt: &deletedAt,
+		DeletedBy: util.NullStringToPtr(pkg.DeletedBy),
+		Reason:    util.NullStringToPtr(pkg.DeletedReason),
+	}
+}
+
+func newDimensions(heightCm, widthCm, lengthCm float64) *service.Dimensions {
+	if heightCm == 0 || widthCm == 0 || lengthCm == 0 {
+		return nil
+	}
+	return &service.Dimensions{HeightCm: heightCm, WidthCm: widthCm, LengthCm: lengthCm}
+}
+
+// ifMatchVersion responds 400 on an invalid If-Match header.
+func ifMatchVersion(ctx *gin.Context) (int32, bool) {
+	version, err := etag.ParseIfMatch(ctx.GetHeader("If-Match"))
+	if err != nil {
+		v1.HandleBadRequest(ctx, err.Error())
+		return 0, false
+	}
+	return version, true
+}
+
+// notModified always sets the ETag and responds 304 when If-None-Match already has it.
+func notModified(ctx *gin.Context, pkg repository.Package) bool {
+	current := etag.Format(pkg.Version)
+	ctx.Header("ETag", current)
+	if !etag.NoneMatch(ctx.GetHeader("If-None-Match"), current) {
+		return false
+	}
+	ctx.Status(http.StatusNotModified)
+	return true
+}
+
+// actorFromContext falls back to the X-Actor header when there is no principal.
+func actorFromContext(ctx *gin.Context) string {
+	if principal, ok := middleware.GetPrincipalFromContext(ctx); ok {
+		return principal.Subject
+	}
+	if actor := ctx.GetHeader("X-Actor"); actor != "" {
+		return actor
+	}
+	return "api"
+}
+
+func sellerFromContext(ctx *gin.Context) uuid.NullUUID {
+	if principal, ok := middleware.GetPrincipalFromContext(ctx); ok {
+		return principal.SellerID
+	}
+	return uuid.NullUUID{}
+}
+
+// sellerForRequest prefers the principal's seller over the requested one.
+func sellerForRequest(ctx *gin.Context, requested string) string {
+	if sellerID := sellerFromContext(ctx); sellerID.Valid {
+		return sellerID.UUID.String()
+	}
+	return requested
+}
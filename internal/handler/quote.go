@@ -2,9 +2,11 @@ package handler
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
 	"github/moura95/olist-shipping-api/api/v1"
 	"github/moura95/olist-shipping-api/config"
 	"github/moura95/olist-shipping-api/internal/middleware"
@@ -33,14 +35,23 @@ func NewQuoteHandler(packageService *service.PackageService, cfg *config.Config,
 
 // GetQuotes godoc
 // @Summary      Get shipping quotes
-// @Description  Get shipping quotes for a package based on destination state and weight
+// @Description  Get shipping quotes for a package based on destination state, weight and optional dimensions (billable weight)
 // @Tags         quotes
 // @Accept       json
 // @Produce      json
-// @Param        estado_destino  query     string   true  "Destination state code"
+// @Security     BearerAuth
+// @Param        estado_destino  query     string   false "Destination state code (required without cep_destino)"
+// @Param        cep_destino     query     string   false "Destination CEP (00000-000)"
 // @Param        peso_kg         query     number   true  "Package weight in kg"
-// @Success      200             {object}  v1.Response{data=[]v1.QuoteResponse}
+// @Param        altura_cm       query     number   false "Package height in cm"
+// @Param        largura_cm      query     number   false "Package width in cm"
+// @Param        comprimento_cm  query     number   false "Package length in cm"
+// @Param        categoria       query     string   false "Product category (geral, fragil, liquido, bateria)"
+// @Param        armazem_origem_id query   string   false "Origin warehouse ID"
+// @Param        vendedor_id     query     string   false "Seller whose negotiated rates apply (ignored for seller-scoped credentials)"
+// @Success      200             {object}  v1.Response{data=v1.QuotesResponse}
 // @Failure      400             {object}  v1.Response
+// @Failure      422             {object}  v1.Response
 // @Failure      500             {object}  v1.Response
 // @Router       /quotes [get]
 func (h *QuoteHandler) GetQuotes(ctx *gin.Context) {
@@ -60,22 +71,73 @@ func (h *QuoteHandler) GetQuotes(ctx *gin.Context) {
 		return
 	}
 
-	quotes, err := h.packageService.GetQuotes(ctx, query.StateCode, query.WeightKg)
+	params := service.QuoteParams{
+		StateCode:         query.StateCode,
+		DestinationCEP:    query.CEP,
+		OriginWarehouseID: query.Origin,
+		WeightKg:          query.WeightKg,
+		Dimensions:        newDimensions(query.HeightCm, query.WidthCm, query.LengthCm),
+		Category:          query.Category,
+		SellerID:          sellerFromContext(ctx),
+	}
+	if !params.SellerID.Valid && query.SellerID != "" {
+		params.SellerID = uuid.NullUUID{UUID: uuid.MustParse(query.SellerID), Valid: true}
+	}
+
+	result, err := h.packageService.GetQuotes(ctx, params)
 	if err != nil {
 		logger.Errorw("get quotes failed", "error", err)
-		v1.HandleInternalError(ctx, fmt.Errorf("get quotes: %v", err).Error())
+		handleServiceError(ctx, fmt.Errorf("get quotes: %w", err))
 		return
 	}
 
-	var resp []v1.QuoteResponse
-	for _, quote := range quotes {
-		resp = append(resp, v1.QuoteResponse{
-			CarrierName:           &quote.Carier,
-			EstimatedPrice:        &quote.EstimatedPrice,
-			EstimatedDeliveryDays: &quote.EstimatedDeliveryDays,
+	resp := newQuotesResponse(result)
+
+	logger.Infow("get quotes completed", "state_code", query.StateCode, "cep", query.CEP, "weight_kg", query.WeightKg, "quotes_count", len(resp.Quotes), "unavailable_count", len(resp.Unavailable))
+	v1.HandleSuccess(ctx, resp)
+}
+
+func newQuotesResponse(result *service.QuoteResult) v1.QuotesResponse {
+	resp := v1.QuotesResponse{
+		Quotes:      []v1.QuoteResponse{},
+		Unavailable: []v1.UnavailableCarrierResponse{},
+	}
+	for _, quote := range result.Quotes {
+		resp.Quotes = append(resp.Quotes, newQuoteResponse(quote))
+	}
+	for _, carrier := range result.Unavailable {
+		carrierID := carrier.CarrierID.String()
+		carrierName := carrier.CarrierName
+		resp.Unavailable = append(resp.Unavailable, v1.UnavailableCarrierResponse{
+			CarrierID:   &carrierID,
+			CarrierName: &carrierName,
+			Reasons:     carrier.Reasons,
 		})
 	}
+	return resp
+}
 
-	logger.Infow("get quotes completed", "state_code", query.StateCode, "weight_kg", query.WeightKg, "quotes_count", len(resp))
-	v1.HandleSuccess(ctx, resp)
+// Only saved quotes have an id and expiry.
+func newQuoteResponse(quote service.Quote) v1.QuoteResponse {
+	var id, expiresAt *string
+	if quote.ID != uuid.Nil {
+		quoteID := quote.ID.String()
+		id = &quoteID
+		formatted := quote.ExpiresAt.Format(time.RFC3339)
+		expiresAt = &formatted
+	}
+
+	carrierID := quote.CarrierID.String()
+	deliveryDate := quote.EstimatedDeliveryDate.Format(time.DateOnly)
+	return v1.QuoteResponse{
+		ID:                    id,
+		ExpiresAt:             expiresAt,
+		CarrierID:             &carrierID,
+		CarrierName:           &quote.CarrierName,
+		BillableWeightKg:      &quote.BillableWeightKg,
+		EstimatedPrice:        &quote.EstimatedPrice,
+		EstimatedDeliveryDays: &quote.EstimatedDeliveryDays,
+		EstimatedDeliveryDate: &deliveryDate,
+		CEPSurcharge:          &quote.CEPSurcharge,
+	}
 }
@@ -0,0 +1,216 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github/moura95/olist-shipping-api/api/v1"
+	"github/moura95/olist-shipping-api/config"
+	"github/moura95/olist-shipping-api/internal/middleware"
+	"github/moura95/olist-shipping-api/internal/repository"
+	"github/moura95/olist-shipping-api/internal/service"
+	"github/moura95/olist-shipping-api/internal/util"
+	customValidator "github/moura95/olist-shipping-api/pkg/validator"
+	"go.uber.org/zap"
+)
+
+type SellerHandler struct {
+	packageService *service.PackageService
+	config         *config.Config
+	logger         *zap.SugaredLogger
+	validate       *validator.Validate
+}
+
+func NewSellerHandler(packageService *service.PackageService, cfg *config.Config, logger *zap.SugaredLogger) *SellerHandler {
+	validate := validator.New()
+	customValidator.SetupCustomValidators(validate)
+	return &SellerHandler{
+		packageService: packageService,
+		config:         cfg,
+		logger:         logger,
+		validate:       validate,
+	}
+}
+
+// List godoc
+// @Summary      List sellers
+// @Description  List the marketplace sellers that own packages
+// @Tags         sellers
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  v1.Response{data=[]v1.SellerResponse}
+// @Failure      401  {object}  v1.Response
+// @Failure      403  {object}  v1.Response
+// @Failure      500  {object}  v1.Response
+// @Router       /admin/sellers [get]
+func (h *SellerHandler) List(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("list sellers started")
+
+	sellers, err := h.packageService.GetSellers(ctx)
+	if err != nil {
+		logger.Errorw("list sellers failed", "error", err)
+		v1.HandleInternalError(ctx, fmt.Errorf("list sellers: %v", err).Error())
+		return
+	}
+
+	resp := []v1.SellerResponse{}
+	for _, seller := range sellers {
+		resp = append(resp, newSellerResponse(seller))
+	}
+
+	logger.Infow("list sellers completed", "count", len(resp))
+	v1.HandleSuccess(ctx, resp)
+}
+
+// Create godoc
+// @Summary      Create a seller
+// @Description  Register a marketplace seller; packages and seller API keys are bound to it
+// @Tags         sellers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      v1.CreateSellerRequest  true  "Seller data"
+// @Success      201      {object}  v1.Response{data=v1.SellerResponse}
+// @Failure      400      {object}  v1.Response
+// @Failure      401      {object}  v1.Response
+// @Failure      403      {object}  v1.Response
+// @Failure      500      {object}  v1.Response
+// @Router       /admin/sellers [post]
+func (h *SellerHandler) Create(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("create seller started")
+
+	var req v1.CreateSellerRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Errorw("bind json failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	seller, err := h.packageService.CreateSeller(ctx, req.Name)
+	if err != nil {
+		logger.Errorw("create seller failed", "error", err)
+		v1.HandleInternalError(ctx, fmt.Errorf("create seller: %v", err).Error())
+		return
+	}
+
+	logger.Infow("create seller completed", "id", seller.ID)
+	v1.HandleCreated(ctx, newSellerResponse(*seller))
+}
+
+// Rates godoc
+// @Summary      List seller negotiated rates
+// @Description  List the negotiated price per kg and markup of a seller for each carrier
+// @Tags         sellers
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id   path      string  true  "Seller ID"
+// @Success      200  {object}  v1.Response{data=[]v1.SellerRateResponse}
+// @Failure      401  {object}  v1.Response
+// @Failure      403  {object}  v1.Response
+// @Failure      404  {object}  v1.Response
+// @Failure      500  {object}  v1.Response
+// @Router       /admin/sellers/{id}/rates [get]
+func (h *SellerHandler) Rates(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("list seller rates started")
+
+	id := ctx.Param("id")
+	rates, err := h.packageService.GetSellerRates(ctx, id)
+	if err != nil {
+		logger.Errorw("list seller rates failed", "error", err, "id", id)
+		handleServiceError(ctx, fmt.Errorf("list seller rates: %w", err))
+		return
+	}
+
+	resp := []v1.SellerRateResponse{}
+	for _, rate := range rates {
+		resp = append(resp, newSellerRateResponse(rate))
+	}
+
+	logger.Infow("list seller rates completed", "id", id, "count", len(resp))
+	v1.HandleSuccess(ctx, resp)
+}
+
+// SaveRate godoc
+// @Summary      Save a seller negotiated rate
+// @Description  Create or replace the seller's condition for a carrier: preco_por_kg replaces the carrier's per-kg price and weight tiers (minimum charge still applies) and acrescimo_percentual is applied to the freight (negative for a discount)
+// @Tags         sellers
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id          path      string                    true  "Seller ID"
+// @Param        carrier_id  path      string                    true  "Carrier ID"
+// @Param        request     body      v1.SaveSellerRateRequest  true  "Negotiated rate"
+// @Success      200         {object}  v1.Response{data=v1.SellerRateResponse}
+// @Failure      400         {object}  v1.Response
+// @Failure      401         {object}  v1.Response
+// @Failure      403         {object}  v1.Response
+// @Failure      404         {object}  v1.Response
+// @Failure      500         {object}  v1.Response
+// @Router       /admin/sellers/{id}/rates/{carrier_id} [put]
+func (h *SellerHandler) SaveRate(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("save seller rate started")
+
+	var req v1.SaveSellerRateRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Errorw("bind json failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	id, carrierID := ctx.Param("id"), ctx.Param("carrier_id")
+	rate, err := h.packageService.SaveSellerRate(ctx, id, carrierID, service.SaveSellerRateInput{
+		PricePerKg:    req.PricePerKg,
+		MarkupPercent: req.MarkupPercent,
+	})
+	if err != nil {
+		logger.Errorw("save seller rate failed", "error", err, "id", id, "carrier_id", carrierID)
+		handleServiceError(ctx, fmt.Errorf("save seller rate: %w", err))
+		return
+	}
+
+	logger.Infow("save seller rate completed", "id", id, "carrier_id", carrierID)
+	v1.HandleSuccess(ctx, newSellerRateResponse(*rate))
+}
+
+func newSellerResponse(seller repository.Seller) v1.SellerResponse {
+	id := seller.ID.String()
+	createdAt := seller.CreatedAt.Format(time.RFC3339)
+	return v1.SellerResponse{
+		ID:        &id,
+		Name:      &seller.Name,
+		CreatedAt: &createdAt,
+	}
+}
+
+func newSellerRateResponse(rate repository.SellerCarrierRate) v1.SellerRateResponse {
+	sellerID := rate.SellerID.String()
+	carrierID := rate.CarrierID.String()
+	createdAt := rate.CreatedAt.Format(time.RFC3339)
+	updatedAt := rate.UpdatedAt.Format(time.RFC3339)
+	return v1.SellerRateResponse{
+		SellerID:      &sellerID,
+		CarrierID:     &carrierID,
+		PricePerKg:    util.NullStringToPtr(rate.PricePerKg),
+		MarkupPercent: &rate.MarkupPercent,
+		CreatedAt:     &createdAt,
+		UpdatedAt:     &updatedAt,
+	}
+}
@@ -37,6 +37,7 @@ func NewStateHandler(packageService *service.PackageService, cfg *config.Config,
 // @Tags         states
 // @Accept       json
 // @Produce      json
+// @Security     BearerAuth
 // @Success      200  {object}  v1.Response{data=[]v1.StateResponse}
 // @Failure      500  {object}  v1.Response
 // @Router       /states [get]
@@ -63,3 +64,37 @@ func (h *StateHandler) List(ctx *gin.Context) {
 	logger.Infow("list states completed", "count", len(resp))
 	v1.HandleSuccess(ctx, resp)
 }
+
+// Regions godoc
+// @Summary      List all regions
+// @Description  Get the regions used by carrier coverage
+// @Tags         states
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  v1.Response{data=[]v1.RegionResponse}
+// @Failure      500  {object}  v1.Response
+// @Router       /regions [get]
+func (h *StateHandler) Regions(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("list regions started")
+
+	regions, err := h.packageService.GetRegions(ctx)
+	if err != nil {
+		logger.Errorw("list regions failed", "error", err)
+		v1.HandleInternalError(ctx, fmt.Errorf("list regions: %v", err).Error())
+		return
+	}
+
+	resp := []v1.RegionResponse{}
+	for _, region := range regions {
+		regionID := region.ID.String()
+		resp = append(resp, v1.RegionResponse{
+			ID:   &regionID,
+			Name: &region.Name,
+		})
+	}
+
+	logger.Infow("list regions completed", "count", len(resp))
+	v1.HandleSuccess(ctx, resp)
+}
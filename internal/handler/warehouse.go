@@ -0,0 +1,123 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github/moura95/olist-shipping-api/api/v1"
+	"github/moura95/olist-shipping-api/config"
+	"github/moura95/olist-shipping-api/internal/middleware"
+	"github/moura95/olist-shipping-api/internal/repository"
+	"github/moura95/olist-shipping-api/internal/service"
+	customValidator "github/moura95/olist-shipping-api/pkg/validator"
+	"go.uber.org/zap"
+)
+
+type WarehouseHandler struct {
+	packageService *service.PackageService
+	config         *config.Config
+	logger         *zap.SugaredLogger
+	validate       *validator.Validate
+}
+
+func NewWarehouseHandler(packageService *service.PackageService, cfg *config.Config, logger *zap.SugaredLogger) *WarehouseHandler {
+	validate := validator.New()
+	customValidator.SetupCustomValidators(validate)
+	return &WarehouseHandler{
+		packageService: packageService,
+		config:         cfg,
+		logger:         logger,
+		validate:       validate,
+	}
+}
+
+// List godoc
+// @Summary      List all warehouses
+// @Description  Get all origin warehouses (distribution centers)
+// @Tags         warehouses
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  v1.Response{data=[]v1.WarehouseResponse}
+// @Failure      500  {object}  v1.Response
+// @Router       /warehouses [get]
+func (h *WarehouseHandler) List(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("list warehouses started")
+
+	warehouses, err := h.packageService.GetWarehouses(ctx)
+	if err != nil {
+		logger.Errorw("list warehouses failed", "error", err)
+		v1.HandleInternalError(ctx, fmt.Errorf("list warehouses: %v", err).Error())
+		return
+	}
+
+	resp := []v1.WarehouseResponse{}
+	for _, warehouse := range warehouses {
+		resp = append(resp, newWarehouseResponse(warehouse))
+	}
+
+	logger.Infow("list warehouses completed", "count", len(resp))
+	v1.HandleSuccess(ctx, resp)
+}
+
+// Create godoc
+// @Summary      Create a warehouse
+// @Description  Register an origin warehouse (distribution center)
+// @Tags         warehouses
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      v1.CreateWarehouseRequest  true  "Warehouse data"
+// @Success      201      {object}  v1.Response{data=v1.WarehouseResponse}
+// @Failure      400      {object}  v1.Response
+// @Failure      500      {object}  v1.Response
+// @Router       /warehouses [post]
+func (h *WarehouseHandler) Create(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("create warehouse started")
+
+	var req v1.CreateWarehouseRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Errorw("bind json failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	warehouse, err := h.packageService.CreateWarehouse(ctx, service.CreateWarehouseInput{
+		Name:      req.Name,
+		StateCode: req.StateCode,
+	})
+	if err != nil {
+		logger.Errorw("create warehouse failed", "error", err)
+		v1.HandleInternalError(ctx, fmt.Errorf("create warehouse: %v", err).Error())
+		return
+	}
+
+	logger.Infow("create warehouse completed", "id", warehouse.ID)
+	v1.HandleCreated(ctx, newWarehouseResponse(*warehouse))
+}
+
+func newWarehouseResponse(warehouse repository.Warehouse) v1.WarehouseResponse {
+	var createdAt *string
+	if warehouse.CreatedAt.Valid {
+		formatted := warehouse.CreatedAt.Time.Format(time.RFC3339)
+		createdAt = &formatted
+	}
+
+	warehouseID := warehouse.ID.String()
+	return v1.WarehouseResponse{
+		ID:        &warehouseID,
+		Name:      &warehouse.Name,
+		StateCode: &warehouse.StateCode,
+		CreatedAt: createdAt,
+	}
+}
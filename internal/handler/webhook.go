@@ -0,0 +1,267 @@
+package handler
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github/moura95/olist-shipping-api/api/v1"
+	"github/moura95/olist-shipping-api/config"
+	"github/moura95/olist-shipping-api/internal/middleware"
+	"github/moura95/olist-shipping-api/internal/repository"
+	"github/moura95/olist-shipping-api/internal/service"
+	"github/moura95/olist-shipping-api/internal/util"
+	customValidator "github/moura95/olist-shipping-api/pkg/validator"
+	"go.uber.org/zap"
+)
+
+type WebhookHandler struct {
+	webhookService *service.WebhookService
+	config         *config.Config
+	logger         *zap.SugaredLogger
+	validate       *validator.Validate
+}
+
+func NewWebhookHandler(webhookService *service.WebhookService, cfg *config.Config, logger *zap.SugaredLogger) *WebhookHandler {
+	validate := validator.New()
+	customValidator.SetupCustomValidators(validate)
+	return &WebhookHandler{
+		webhookService: webhookService,
+		config:         cfg,
+		logger:         logger,
+		validate:       validate,
+	}
+}
+
+// List godoc
+// @Summary      List webhook subscriptions
+// @Description  Get all webhook subscriptions; secrets are never returned
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Success      200  {object}  v1.Response{data=[]v1.WebhookResponse}
+// @Failure      500  {object}  v1.Response
+// @Router       /webhooks [get]
+func (h *WebhookHandler) List(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("list webhooks started")
+
+	subscriptions, err := h.webhookService.ListSubscriptions(ctx)
+	if err != nil {
+		logger.Errorw("list webhooks failed", "error", err)
+		v1.HandleInternalError(ctx, fmt.Errorf("list webhooks: %v", err).Error())
+		return
+	}
+
+	resp := []v1.WebhookResponse{}
+	for _, subscription := range subscriptions {
+		resp = append(resp, newWebhookResponse(subscription, false))
+	}
+
+	logger.Infow("list webhooks completed", "count", len(resp))
+	v1.HandleSuccess(ctx, resp)
+}
+
+// Create godoc
+// @Summary      Create a webhook subscription
+// @Description  Subscribe a URL to package lifecycle events. Deliveries are signed with HMAC-SHA256 using the secret, which is generated when omitted and only returned here
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        request  body      v1.CreateWebhookRequest  true  "Subscription data"
+// @Success      201      {object}  v1.Response{data=v1.WebhookResponse}
+// @Failure      400      {object}  v1.Response
+// @Failure      500      {object}  v1.Response
+// @Router       /webhooks [post]
+func (h *WebhookHandler) Create(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("create webhook started")
+
+	var req v1.CreateWebhookRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		logger.Errorw("bind json failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid request body")
+		return
+	}
+
+	if err := h.validate.Struct(req); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(ctx, service.CreateWebhookInput{
+		URL:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+	})
+	if err != nil {
+		logger.Errorw("create webhook failed", "error", err)
+		v1.HandleInternalError(ctx, fmt.Errorf("create webhook: %v", err).Error())
+		return
+	}
+
+	logger.Infow("create webhook completed", "id", subscription.ID)
+	v1.HandleCreated(ctx, newWebhookResponse(*subscription, true))
+}
+
+// Delete godoc
+// @Summary      Delete a webhook subscription
+// @Description  Remove a webhook subscription and its delivery history
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      string  true  "Webhook ID"
+// @Success      200  {object}  v1.Response
+// @Failure      404  {object}  v1.Response
+// @Failure      500  {object}  v1.Response
+// @Router       /webhooks/{id} [delete]
+func (h *WebhookHandler) Delete(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("delete webhook started")
+
+	id := ctx.Param("id")
+	if err := h.webhookService.DeleteSubscription(ctx, id); err != nil {
+		logger.Errorw("delete webhook failed", "error", err, "id", id)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	logger.Infow("delete webhook completed", "id", id)
+	v1.HandleSuccess(ctx, "Webhook deleted successfully")
+}
+
+// Deliveries godoc
+// @Summary      List webhook deliveries
+// @Description  Get the most recent deliveries of a webhook subscription, with attempts and last error
+// @Tags         webhooks
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id      path      string  true   "Webhook ID"
+// @Param        limite  query     int     false  "Maximum number of deliveries (default 50)"
+// @Success      200     {object}  v1.Response{data=[]v1.WebhookDeliveryResponse}
+// @Failure      400     {object}  v1.Response
+// @Failure      404     {object}  v1.Response
+// @Failure      500     {object}  v1.Response
+// @Router       /webhooks/{id}/deliveries [get]
+func (h *WebhookHandler) Deliveries(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("list webhook deliveries started")
+
+	var query v1.ListWebhookDeliveriesQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		logger.Errorw("bind query failed", "error", err)
+		v1.HandleBadRequest(ctx, "Invalid query parameters")
+		return
+	}
+
+	if err := h.validate.Struct(query); err != nil {
+		logger.Errorw("validation failed", "error", err)
+		v1.HandleValidationError(ctx, err)
+		return
+	}
+
+	id := ctx.Param("id")
+	deliveries, err := h.webhookService.ListDeliveries(ctx, id, query.Limit)
+	if err != nil {
+		logger.Errorw("list webhook deliveries failed", "error", err, "id", id)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	resp := []v1.WebhookDeliveryResponse{}
+	for _, delivery := range deliveries {
+		resp = append(resp, newWebhookDeliveryResponse(delivery))
+	}
+
+	logger.Infow("list webhook deliveries completed", "id", id, "count", len(resp))
+	v1.HandleSuccess(ctx, resp)
+}
+
+// Replay godoc
+// @Summary      Replay a webhook delivery
+// @Description  Put a delivery back in the queue with its attempts reset, whether it failed or was already delivered
+// @Tags         admin
+// @Accept       json
+// @Produce      json
+// @Security     BearerAuth
+// @Param        id  path      string  true  "Delivery ID"
+// @Success      200  {object}  v1.Response{data=v1.WebhookDeliveryResponse}
+// @Failure      404  {object}  v1.Response
+// @Failure      500  {object}  v1.Response
+// @Router       /admin/webhooks/deliveries/{id}/replay [post]
+func (h *WebhookHandler) Replay(ctx *gin.Context) {
+	logger := middleware.GetLoggerFromContext(ctx)
+	logger.Info("replay webhook delivery started")
+
+	id := ctx.Param("id")
+	delivery, err := h.webhookService.ReplayDelivery(ctx, id)
+	if err != nil {
+		logger.Errorw("replay webhook delivery failed", "error", err, "id", id)
+		handleServiceError(ctx, err)
+		return
+	}
+
+	logger.Infow("replay webhook delivery completed", "id", id)
+	v1.HandleSuccess(ctx, newWebhookDeliveryResponse(*delivery))
+}
+
+// newWebhookResponse exposes the secret only when withSecret is set, i.e. on creation.
+func newWebhookResponse(subscription repository.WebhookSubscription, withSecret bool) v1.WebhookResponse {
+	var createdAt *string
+	if subscription.CreatedAt.Valid {
+		formatted := subscription.CreatedAt.Time.Format(time.RFC3339)
+		createdAt = &formatted
+	}
+
+	subscriptionID := subscription.ID.String()
+	resp := v1.WebhookResponse{
+		ID:         &subscriptionID,
+		URL:        &subscription.Url,
+		EventTypes: subscription.EventTypes,
+		CreatedAt:  createdAt,
+	}
+	if withSecret {
+		resp.Secret = &subscription.Secret
+	}
+	return resp
+}
+
+func newWebhookDeliveryResponse(delivery repository.WebhookDelivery) v1.WebhookDeliveryResponse {
+	var nextAttemptAt, deliveredAt, createdAt *string
+	if delivery.Status == service.DeliveryStatusPending {
+		formatted := delivery.NextAttemptAt.Format(time.RFC3339)
+		nextAttemptAt = &formatted
+	}
+	if delivery.DeliveredAt.Valid {
+		formatted := delivery.DeliveredAt.Time.Format(time.RFC3339)
+		deliveredAt = &formatted
+	}
+	if delivery.CreatedAt.Valid {
+		formatted := delivery.CreatedAt.Time.Format(time.RFC3339)
+		createdAt = &formatted
+	}
+
+	deliveryID := delivery.ID.String()
+	subscriptionID := delivery.SubscriptionID.String()
+	eventID := delivery.EventID.String()
+	return v1.WebhookDeliveryResponse{
+		ID:                 &deliveryID,
+		SubscriptionID:     &subscriptionID,
+		EventID:            &eventID,
+		EventType:          &delivery.EventType,
+		Status:             &delivery.Status,
+		Attempts:           &delivery.Attempts,
+		NextAttemptAt:      nextAttemptAt,
+		LastResponseStatus: util.NullInt32ToPtr(delivery.LastResponseStatus),
+		LastError:          util.NullStringToPtr(delivery.LastError),
+		DeliveredAt:        deliveredAt,
+		CreatedAt:          createdAt,
+		Payload:            delivery.Payload,
+	}
+}
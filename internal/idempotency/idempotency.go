@@ -0,0 +1,41 @@
+package idempotency
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+)
+
+const Header = "Idempotency-Key"
+
+const ReplayedHeader = "Idempotent-Replayed"
+
+const MaxKeyLength = 255
+
+// ReplayHeaders are stored with the first response and sent again on replays.
+var ReplayHeaders = []string{"ETag", "Location"}
+
+var (
+	ErrKeyReused  = errors.New("idempotency key already used with a different request body")
+	ErrInProgress = errors.New("a request with this idempotency key is still in progress")
+)
+
+// Key.RequestHash tells a retry apart from a reused key.
+type Key struct {
+	Principal   string
+	Key         string
+	Route       string
+	RequestHash string
+}
+
+type Response struct {
+	StatusCode  int
+	ContentType string
+	Headers     map[string]string
+	Body        []byte
+}
+
+func HashBody(body []byte) string {
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github/moura95/olist-shipping-api/api/v1"
+	"github/moura95/olist-shipping-api/internal/auth"
+)
+
+const principalKey = "principal"
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, credential string) (*auth.Principal, error)
+}
+
+// AuthMiddleware accepts Authorization: Bearer or X-API-Key.
+func AuthMiddleware(authenticator Authenticator) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		logger := GetLoggerFromContext(ctx)
+
+		principal, err := authenticator.Authenticate(ctx, credentialFromRequest(ctx))
+		if err != nil {
+			if errors.Is(err, auth.ErrUnauthenticated) {
+				logger.Infow("authentication failed", "error", err)
+				ctx.Header("WWW-Authenticate", `Bearer realm="api"`)
+				v1.HandleUnauthorized(ctx, auth.ErrUnauthenticated.Error())
+			} else {
+				logger.Errorw("authentication failed", "error", err)
+				v1.HandleInternalError(ctx, "authentication failed")
+			}
+			ctx.Abort()
+			return
+		}
+
+		ctx.Set(principalKey, principal)
+		ctx.Set("logger", logger.With("subject", principal.Subject, "role", principal.Role))
+		ctx.Next()
+	}
+}
+
+// RoleMiddleware must run after AuthMiddleware.
+func RoleMiddleware(roles ...string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		principal, ok := GetPrincipalFromContext(ctx)
+		if !ok {
+			v1.HandleUnauthorized(ctx, auth.ErrUnauthenticated.Error())
+			ctx.Abort()
+			return
+		}
+		if !principal.HasRole(roles...) {
+			GetLoggerFromContext(ctx).Infow("permission denied", "path", ctx.FullPath(), "method", ctx.Request.Method)
+			v1.HandleForbidden(ctx, "role "+principal.Role+" is not allowed to access this resource")
+			ctx.Abort()
+			return
+		}
+		ctx.Next()
+	}
+}
+
+func GetPrincipalFromContext(ctx *gin.Context) (*auth.Principal, bool) {
+	if value, exists := ctx.Get(principalKey); exists {
+		if principal, ok := value.(*auth.Principal); ok {
+			return principal, true
+		}
+	}
+	return nil, false
+}
+
+func credentialFromRequest(ctx *gin.Context) string {
+	if header := ctx.GetHeader("Authorization"); header != "" {
+		scheme, credential, found := strings.Cut(header, " ")
+		if found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(credential)
+		}
+		return ""
+	}
+	return strings.TrimSpace(ctx.GetHeader("X-API-Key"))
+}
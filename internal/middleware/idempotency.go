@@ -0,0 +1,139 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github/moura95/olist-shipping-api/api/v1"
+	"github/moura95/olist-shipping-api/internal/auth"
+	"github/moura95/olist-shipping-api/internal/idempotency"
+)
+
+type IdempotencyStore interface {
+	Begin(ctx context.Context, key idempotency.Key) (*idempotency.Response, error)
+	Complete(ctx context.Context, key idempotency.Key, response idempotency.Response) error
+	Release(ctx context.Context, key idempotency.Key) error
+}
+
+// IdempotencyMiddleware must run after AuthMiddleware; 5xx responses are not stored so they can be retried.
+func IdempotencyMiddleware(store IdempotencyStore) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		value := strings.TrimSpace(ctx.GetHeader(idempotency.Header))
+		if value == "" {
+			ctx.Next()
+			return
+		}
+
+		logger := GetLoggerFromContext(ctx)
+
+		if len(value) > idempotency.MaxKeyLength {
+			v1.HandleBadRequest(ctx, "Idempotency-Key must have at most 255 characters")
+			ctx.Abort()
+			return
+		}
+
+		principal, ok := GetPrincipalFromContext(ctx)
+		if !ok {
+			v1.HandleUnauthorized(ctx, auth.ErrUnauthenticated.Error())
+			ctx.Abort()
+			return
+		}
+
+		var body []byte
+		if ctx.Request.Body != nil {
+			var err error
+			body, err = io.ReadAll(ctx.Request.Body)
+			if err != nil {
+				logger.Errorw("read request body failed", "error", err)
+				v1.HandleBadRequest(ctx, "Invalid request body")
+				ctx.Abort()
+				return
+			}
+			ctx.Request.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		key := idempotency.Key{
+			Principal:   principal.Scope(),
+			Key:         value,
+			Route:       ctx.Request.Method + " " + ctx.Request.URL.Path,
+			RequestHash: idempotency.HashBody(body),
+		}
+
+		stored, err := store.Begin(ctx, key)
+		if err != nil {
+			switch {
+			case errors.Is(err, idempotency.ErrKeyReused):
+				logger.Infow("idempotency key reused", "key", key.Key, "route", key.Route)
+				v1.HandleUnprocessableEntity(ctx, idempotency.ErrKeyReused.Error())
+			case errors.Is(err, idempotency.ErrInProgress):
+				logger.Infow("idempotent request in progress", "key", key.Key, "route", key.Route)
+				v1.HandleConflict(ctx, idempotency.ErrInProgress.Error())
+			default:
+				logger.Errorw("begin idempotent request failed", "error", err)
+				v1.HandleInternalError(ctx, "idempotency check failed")
+			}
+			ctx.Abort()
+			return
+		}
+
+		if stored != nil {
+			logger.Infow("idempotent request replayed", "key", key.Key, "route", key.Route, "status", stored.StatusCode)
+			for name, value := range stored.Headers {
+				ctx.Header(name, value)
+			}
+			ctx.Header(idempotency.ReplayedHeader, "true")
+			ctx.Data(stored.StatusCode, stored.ContentType, stored.Body)
+			ctx.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: ctx.Writer}
+		ctx.Writer = recorder
+
+		ctx.Next()
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			if err := store.Release(context.WithoutCancel(ctx), key); err != nil {
+				logger.Errorw("release idempotency key failed", "error", err, "key", key.Key)
+			}
+			return
+		}
+
+		headers := make(map[string]string)
+		for _, name := range idempotency.ReplayHeaders {
+			if value := recorder.Header().Get(name); value != "" {
+				headers[name] = value
+			}
+		}
+
+		response := idempotency.Response{
+			StatusCode:  recorder.Status(),
+			ContentType: recorder.Header().Get("Content-Type"),
+			Headers:     headers,
+			Body:        recorder.body.Bytes(),
+		}
+		if err := store.Complete(context.WithoutCancel(ctx), key, response); err != nil {
+			logger.Errorw("complete idempotency key failed", "error", err, "key", key.Key)
+		}
+	}
+}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *responseRecorder) Write(data []byte) (int, error) {
+	r.body.Write(data)
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *responseRecorder) WriteString(data string) (int, error) {
+	r.body.WriteString(data)
+	return r.ResponseWriter.WriteString(data)
+}
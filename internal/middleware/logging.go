@@ -5,6 +5,7 @@ import (
 	"crypto/md5"
 	"fmt"
 	"io"
+	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -39,7 +40,7 @@ func RequestLogMiddleware(logger *zap.SugaredLogger) gin.HandlerFunc {
 			"query", ctx.Request.URL.RawQuery,
 			"user_agent", ctx.Request.UserAgent(),
 			"client_ip", ctx.ClientIP(),
-			"headers", ctx.Request.Header,
+			"headers", redactHeaders(ctx.Request.Header),
 			"body", requestBody,
 			"timestamp", startTime.Format(time.RFC3339),
 		)
@@ -51,6 +52,16 @@ func RequestLogMiddleware(logger *zap.SugaredLogger) gin.HandlerFunc {
 	}
 }
 
+func redactHeaders(headers http.Header) http.Header {
+	redacted := headers.Clone()
+	for _, name := range []string{"Authorization", "X-Api-Key"} {
+		if redacted.Get(name) != "" {
+			redacted.Set(name, "[REDACTED]")
+		}
+	}
+	return redacted
+}
+
 func generateTraceID() string {
 	// Gera UUID v4
 	uuid := uuid.New()
@@ -0,0 +1,153 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: api_keys.sql
+
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const createApiKey = `-- name: CreateApiKey :one
+INSERT INTO api_keys (name, role, key_prefix, key_hash, created_by, seller_id)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, role, key_prefix, key_hash, created_by, created_at, last_used_at, revoked_at, seller_id
+`
+
+type CreateApiKeyParams struct {
+	Name      string
+	Role      string
+	KeyPrefix string
+	KeyHash   string
+	CreatedBy string
+	SellerID  uuid.NullUUID
+}
+
+func (q *Queries) CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, createApiKey,
+		arg.Name,
+		arg.Role,
+		arg.KeyPrefix,
+		arg.KeyHash,
+		arg.CreatedBy,
+		arg.SellerID,
+	)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Role,
+		&i.KeyPrefix,
+		&i.KeyHash,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.SellerID,
+	)
+	return i, err
+}
+
+const getActiveApiKeyByHash = `-- name: GetActiveApiKeyByHash :one
+SELECT id, name, role, key_prefix, key_hash, created_by, created_at, last_used_at, revoked_at, seller_id
+FROM api_keys
+WHERE key_hash = $1 AND revoked_at IS NULL
+`
+
+func (q *Queries) GetActiveApiKeyByHash(ctx context.Context, keyHash string) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, getActiveApiKeyByHash, keyHash)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Role,
+		&i.KeyPrefix,
+		&i.KeyHash,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.SellerID,
+	)
+	return i, err
+}
+
+const listApiKeys = `-- name: ListApiKeys :many
+SELECT id, name, role, key_prefix, key_hash, created_by, created_at, last_used_at, revoked_at, seller_id
+FROM api_keys
+ORDER BY created_at DESC
+`
+
+func (q *Queries) ListApiKeys(ctx context.Context) ([]ApiKey, error) {
+	rows, err := q.db.QueryContext(ctx, listApiKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ApiKey{}
+	for rows.Next() {
+		var i ApiKey
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Role,
+			&i.KeyPrefix,
+			&i.KeyHash,
+			&i.CreatedBy,
+			&i.CreatedAt,
+			&i.LastUsedAt,
+			&i.RevokedAt,
+			&i.SellerID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const revokeApiKey = `-- name: RevokeApiKey :one
+UPDATE api_keys
+SET revoked_at = COALESCE(revoked_at, NOW())
+WHERE id = $1
+RETURNING id, name, role, key_prefix, key_hash, created_by, created_at, last_used_at, revoked_at, seller_id
+`
+
+func (q *Queries) RevokeApiKey(ctx context.Context, id uuid.UUID) (ApiKey, error) {
+	row := q.db.QueryRowContext(ctx, revokeApiKey, id)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Role,
+		&i.KeyPrefix,
+		&i.KeyHash,
+		&i.CreatedBy,
+		&i.CreatedAt,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.SellerID,
+	)
+	return i, err
+}
+
+const touchApiKey = `-- name: TouchApiKey :exec
+UPDATE api_keys
+SET last_used_at = NOW()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < NOW() - INTERVAL '1 minute')
+`
+
+// Writes at most once a minute instead of on every request
+func (q *Queries) TouchApiKey(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, touchApiKey, id)
+	return err
+}
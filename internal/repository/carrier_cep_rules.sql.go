@@ -0,0 +1,50 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: carrier_cep_rules.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const listCarrierCepRules = `-- name: ListCarrierCepRules :many
+SELECT id, carrier_id, cep_start, cep_end, serviceable, surcharge, extra_delivery_days, description, created_at
+FROM carrier_cep_rules
+WHERE $1::CHAR(8) BETWEEN cep_start AND cep_end
+ORDER BY carrier_id, cep_end::INT - cep_start::INT
+`
+
+func (q *Queries) ListCarrierCepRules(ctx context.Context, cep string) ([]CarrierCepRule, error) {
+	rows, err := q.db.QueryContext(ctx, listCarrierCepRules, cep)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CarrierCepRule{}
+	for rows.Next() {
+		var i CarrierCepRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.CarrierID,
+			&i.CepStart,
+			&i.CepEnd,
+			&i.Serviceable,
+			&i.Surcharge,
+			&i.ExtraDeliveryDays,
+			&i.Description,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
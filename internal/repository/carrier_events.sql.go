@@ -0,0 +1,267 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: carrier_events.sql
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const createCarrierTrackingEvent = `-- name: CreateCarrierTrackingEvent :one
+INSERT INTO carrier_tracking_events (
+    carrier_id, package_id, carrier_event_id, carrier_code, mapped_status, description, location, occurred_at, raw
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8, $9
+)
+ON CONFLICT (carrier_id, carrier_event_id) DO NOTHING
+RETURNING id, carrier_id, package_id, carrier_event_id, carrier_code, mapped_status, description, location,
+    occurred_at, raw, received_at
+`
+
+type CreateCarrierTrackingEventParams struct {
+	CarrierID      uuid.UUID
+	PackageID      uuid.UUID
+	CarrierEventID string
+	CarrierCode    string
+	MappedStatus   sql.NullString
+	Description    sql.NullString
+	Location       sql.NullString
+	OccurredAt     time.Time
+	Raw            json.RawMessage
+}
+
+// No row returned means the event is a duplicate
+func (q *Queries) CreateCarrierTrackingEvent(ctx context.Context, arg CreateCarrierTrackingEventParams) (CarrierTrackingEvent, error) {
+	row := q.db.QueryRowContext(ctx, createCarrierTrackingEvent,
+		arg.CarrierID,
+		arg.PackageID,
+		arg.CarrierEventID,
+		arg.CarrierCode,
+		arg.MappedStatus,
+		arg.Description,
+		arg.Location,
+		arg.OccurredAt,
+		arg.Raw,
+	)
+	var i CarrierTrackingEvent
+	err := row.Scan(
+		&i.ID,
+		&i.CarrierID,
+		&i.PackageID,
+		&i.CarrierEventID,
+		&i.CarrierCode,
+		&i.MappedStatus,
+		&i.Description,
+		&i.Location,
+		&i.OccurredAt,
+		&i.Raw,
+		&i.ReceivedAt,
+	)
+	return i, err
+}
+
+const getCarrierApiTokenHash = `-- name: GetCarrierApiTokenHash :one
+SELECT token_hash
+FROM carrier_api_tokens
+WHERE carrier_id = $1
+`
+
+func (q *Queries) GetCarrierApiTokenHash(ctx context.Context, carrierID uuid.UUID) (string, error) {
+	row := q.db.QueryRowContext(ctx, getCarrierApiTokenHash, carrierID)
+	var token_hash string
+	err := row.Scan(&token_hash)
+	return token_hash, err
+}
+
+const getCarrierEventMapping = `-- name: GetCarrierEventMapping :one
+SELECT carrier_id, carrier_code, status, description, created_at
+FROM carrier_event_mappings
+WHERE carrier_id = $1 AND carrier_code = $2
+`
+
+type GetCarrierEventMappingParams struct {
+	CarrierID   uuid.UUID
+	CarrierCode string
+}
+
+func (q *Queries) GetCarrierEventMapping(ctx context.Context, arg GetCarrierEventMappingParams) (CarrierEventMapping, error) {
+	row := q.db.QueryRowContext(ctx, getCarrierEventMapping, arg.CarrierID, arg.CarrierCode)
+	var i CarrierEventMapping
+	err := row.Scan(
+		&i.CarrierID,
+		&i.CarrierCode,
+		&i.Status,
+		&i.Description,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getCarrierTrackingEvent = `-- name: GetCarrierTrackingEvent :one
+SELECT id, carrier_id, package_id, carrier_event_id, carrier_code, mapped_status, description, location,
+    occurred_at, raw, received_at
+FROM carrier_tracking_events
+WHERE carrier_id = $1 AND carrier_event_id = $2
+`
+
+type GetCarrierTrackingEventParams struct {
+	CarrierID      uuid.UUID
+	CarrierEventID string
+}
+
+func (q *Queries) GetCarrierTrackingEvent(ctx context.Context, arg GetCarrierTrackingEventParams) (CarrierTrackingEvent, error) {
+	row := q.db.QueryRowContext(ctx, getCarrierTrackingEvent, arg.CarrierID, arg.CarrierEventID)
+	var i CarrierTrackingEvent
+	err := row.Scan(
+		&i.ID,
+		&i.CarrierID,
+		&i.PackageID,
+		&i.CarrierEventID,
+		&i.CarrierCode,
+		&i.MappedStatus,
+		&i.Description,
+		&i.Location,
+		&i.OccurredAt,
+		&i.Raw,
+		&i.ReceivedAt,
+	)
+	return i, err
+}
+
+const listCarrierEventMappings = `-- name: ListCarrierEventMappings :many
+SELECT carrier_id, carrier_code, status, description, created_at
+FROM carrier_event_mappings
+WHERE carrier_id = $1
+ORDER BY carrier_code
+`
+
+func (q *Queries) ListCarrierEventMappings(ctx context.Context, carrierID uuid.UUID) ([]CarrierEventMapping, error) {
+	rows, err := q.db.QueryContext(ctx, listCarrierEventMappings, carrierID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CarrierEventMapping{}
+	for rows.Next() {
+		var i CarrierEventMapping
+		if err := rows.Scan(
+			&i.CarrierID,
+			&i.CarrierCode,
+			&i.Status,
+			&i.Description,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCarrierTrackingEventsByPackage = `-- name: ListCarrierTrackingEventsByPackage :many
+SELECT id, carrier_id, package_id, carrier_event_id, carrier_code, mapped_status, description, location,
+    occurred_at, raw, received_at
+FROM carrier_tracking_events
+WHERE package_id = $1
+ORDER BY occurred_at, received_at
+`
+
+func (q *Queries) ListCarrierTrackingEventsByPackage(ctx context.Context, packageID uuid.UUID) ([]CarrierTrackingEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listCarrierTrackingEventsByPackage, packageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CarrierTrackingEvent{}
+	for rows.Next() {
+		var i CarrierTrackingEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.CarrierID,
+			&i.PackageID,
+			&i.CarrierEventID,
+			&i.CarrierCode,
+			&i.MappedStatus,
+			&i.Description,
+			&i.Location,
+			&i.OccurredAt,
+			&i.Raw,
+			&i.ReceivedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertCarrierApiToken = `-- name: UpsertCarrierApiToken :exec
+INSERT INTO carrier_api_tokens (carrier_id, token_hash)
+VALUES ($1, $2)
+ON CONFLICT (carrier_id) DO UPDATE
+SET token_hash = EXCLUDED.token_hash,
+    created_at = NOW()
+`
+
+type UpsertCarrierApiTokenParams struct {
+	CarrierID uuid.UUID
+	TokenHash string
+}
+
+func (q *Queries) UpsertCarrierApiToken(ctx context.Context, arg UpsertCarrierApiTokenParams) error {
+	_, err := q.db.ExecContext(ctx, upsertCarrierApiToken, arg.CarrierID, arg.TokenHash)
+	return err
+}
+
+const upsertCarrierEventMapping = `-- name: UpsertCarrierEventMapping :one
+INSERT INTO carrier_event_mappings (carrier_id, carrier_code, status, description)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (carrier_id, carrier_code) DO UPDATE
+SET status = EXCLUDED.status,
+    description = EXCLUDED.description
+RETURNING carrier_id, carrier_code, status, description, created_at
+`
+
+type UpsertCarrierEventMappingParams struct {
+	CarrierID   uuid.UUID
+	CarrierCode string
+	Status      sql.NullString
+	Description string
+}
+
+func (q *Queries) UpsertCarrierEventMapping(ctx context.Context, arg UpsertCarrierEventMappingParams) (CarrierEventMapping, error) {
+	row := q.db.QueryRowContext(ctx, upsertCarrierEventMapping,
+		arg.CarrierID,
+		arg.CarrierCode,
+		arg.Status,
+		arg.Description,
+	)
+	var i CarrierEventMapping
+	err := row.Scan(
+		&i.CarrierID,
+		&i.CarrierCode,
+		&i.Status,
+		&i.Description,
+		&i.CreatedAt,
+	)
+	return i, err
+}
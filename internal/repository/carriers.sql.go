@@ -7,32 +7,275 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const createCarrier = `-- name: CreateCarrier :one
+INSERT INTO carriers (name, tracking_prefix, max_weight_kg, max_side_cm, max_dimensions_sum_cm, cutoff_time)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, name, created_at, max_weight_kg, max_side_cm, max_dimensions_sum_cm, cutoff_time, tracking_prefix, deleted_at
+`
+
+type CreateCarrierParams struct {
+	Name               string
+	TrackingPrefix     string
+	MaxWeightKg        sql.NullFloat64
+	MaxSideCm          sql.NullFloat64
+	MaxDimensionsSumCm sql.NullFloat64
+	CutoffTime         time.Time
+}
+
+func (q *Queries) CreateCarrier(ctx context.Context, arg CreateCarrierParams) (Carrier, error) {
+	row := q.db.QueryRowContext(ctx, createCarrier,
+		arg.Name,
+		arg.TrackingPrefix,
+		arg.MaxWeightKg,
+		arg.MaxSideCm,
+		arg.MaxDimensionsSumCm,
+		arg.CutoffTime,
+	)
+	var i Carrier
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.MaxWeightKg,
+		&i.MaxSideCm,
+		&i.MaxDimensionsSumCm,
+		&i.CutoffTime,
+		&i.TrackingPrefix,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
+const createCarrierRegion = `-- name: CreateCarrierRegion :one
+WITH version_start AS (
+    SELECT COALESCE($1::TIMESTAMP, NOW()::TIMESTAMP) AS valid_from
+), previous AS (
+    UPDATE carrier_regions cr
+    SET valid_to = vs.valid_from
+    FROM version_start vs
+    WHERE cr.carrier_id = $2
+      AND cr.region_id = $3
+      AND cr.origin_region_id IS NOT DISTINCT FROM $4
+      AND cr.valid_from < vs.valid_from
+      AND (cr.valid_to IS NULL OR cr.valid_to > vs.valid_from)
+    RETURNING cr.id
+), version AS (
+    INSERT INTO carrier_regions (carrier_id, region_id, origin_region_id, estimated_delivery_days, price_per_kg, min_charge, excess_price_per_kg, cubing_divisor, valid_from, valid_to)
+    VALUES ($2, $3, $4, $5, $6, $7, $8, $9,
+            (SELECT valid_from FROM version_start),
+            (SELECT MIN(n.valid_from)
+             FROM carrier_regions n
+             WHERE n.carrier_id = $2
+               AND n.region_id = $3
+               AND n.origin_region_id IS NOT DISTINCT FROM $4
+               AND n.valid_from > (SELECT valid_from FROM version_start)))
+    RETURNING id, carrier_id, region_id, estimated_delivery_days, price_per_kg, created_at, cubing_divisor, excess_price_per_kg, min_charge, origin_region_id, valid_from, valid_to
+), tiers AS (
+    INSERT INTO carrier_rate_tiers (carrier_region_id, min_weight_kg, max_weight_kg, price)
+    SELECT version.id, t.min_weight_kg, t.max_weight_kg, t.price
+    FROM carrier_rate_tiers t
+             JOIN previous p ON p.id = t.carrier_region_id
+             CROSS JOIN version
+)
+SELECT id, carrier_id, region_id, estimated_delivery_days, price_per_kg, created_at, cubing_divisor, excess_price_per_kg, min_charge, origin_region_id, valid_from, valid_to
+FROM version
+`
+
+type CreateCarrierRegionParams struct {
+	ValidFrom             sql.NullTime
+	CarrierID             uuid.UUID
+	RegionID              uuid.UUID
+	OriginRegionID        uuid.NullUUID
+	EstimatedDeliveryDays int32
+	PricePerKg            string
+	MinCharge             string
+	ExcessPricePerKg      sql.NullString
+	CubingDivisor         int32
+}
+
+type CreateCarrierRegionRow struct {
+	ID                    uuid.UUID
+	CarrierID             uuid.UUID
+	RegionID              uuid.UUID
+	EstimatedDeliveryDays int32
+	PricePerKg            string
+	CreatedAt             sql.NullTime
+	CubingDivisor         int32
+	ExcessPricePerKg      sql.NullString
+	MinCharge             string
+	OriginRegionID        uuid.NullUUID
+	ValidFrom             time.Time
+	ValidTo               sql.NullTime
+}
+
+// Ends the version in effect at valid_from and inherits its weight tiers
+func (q *Queries) CreateCarrierRegion(ctx context.Context, arg CreateCarrierRegionParams) (CreateCarrierRegionRow, error) {
+	row := q.db.QueryRowContext(ctx, createCarrierRegion,
+		arg.ValidFrom,
+		arg.CarrierID,
+		arg.RegionID,
+		arg.OriginRegionID,
+		arg.EstimatedDeliveryDays,
+		arg.PricePerKg,
+		arg.MinCharge,
+		arg.ExcessPricePerKg,
+		arg.CubingDivisor,
+	)
+	var i CreateCarrierRegionRow
+	err := row.Scan(
+		&i.ID,
+		&i.CarrierID,
+		&i.RegionID,
+		&i.EstimatedDeliveryDays,
+		&i.PricePerKg,
+		&i.CreatedAt,
+		&i.CubingDivisor,
+		&i.ExcessPricePerKg,
+		&i.MinCharge,
+		&i.OriginRegionID,
+		&i.ValidFrom,
+		&i.ValidTo,
+	)
+	return i, err
+}
+
+const deleteCarrier = `-- name: DeleteCarrier :execrows
+UPDATE carriers
+SET deleted_at = NOW()
+WHERE carriers.id = $1
+  AND carriers.deleted_at IS NULL
+  AND NOT EXISTS (
+      SELECT 1
+      FROM packages p
+      WHERE p.hired_carrier_id = $1
+        AND p.status NOT IN ('entregue', 'extraviado')
+  )
+`
+
+// Soft delete, refused while the carrier has packages in progress
+func (q *Queries) DeleteCarrier(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deleteCarrier, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const endCarrierRegion = `-- name: EndCarrierRegion :exec
+WITH lane AS (
+    SELECT carrier_id, region_id, origin_region_id
+    FROM carrier_regions
+    WHERE carrier_regions.id = $1
+      AND carrier_regions.carrier_id = $2
+), scheduled AS (
+    DELETE FROM carrier_regions cr
+    USING lane
+    WHERE cr.carrier_id = lane.carrier_id
+      AND cr.region_id = lane.region_id
+      AND cr.origin_region_id IS NOT DISTINCT FROM lane.origin_region_id
+      AND cr.valid_from > NOW()
+)
+UPDATE carrier_regions cr
+SET valid_to = NOW()
+FROM lane
+WHERE cr.carrier_id = lane.carrier_id
+  AND cr.region_id = lane.region_id
+  AND cr.origin_region_id IS NOT DISTINCT FROM lane.origin_region_id
+  AND cr.valid_from <= NOW()
+  AND (cr.valid_to IS NULL OR cr.valid_to > NOW())
+`
+
+type EndCarrierRegionParams struct {
+	ID        uuid.UUID
+	CarrierID uuid.UUID
+}
+
+// Drops scheduled versions and ends the current one, which stays in history
+func (q *Queries) EndCarrierRegion(ctx context.Context, arg EndCarrierRegionParams) error {
+	_, err := q.db.ExecContext(ctx, endCarrierRegion, arg.ID, arg.CarrierID)
+	return err
+}
+
+const getCarrierRegionById = `-- name: GetCarrierRegionById :one
+SELECT id, carrier_id, region_id, estimated_delivery_days, price_per_kg, created_at, cubing_divisor, excess_price_per_kg, min_charge, origin_region_id, valid_from, valid_to
+FROM carrier_regions
+WHERE id = $1
+  AND carrier_id = $2
+`
+
+type GetCarrierRegionByIdParams struct {
+	ID        uuid.UUID
+	CarrierID uuid.UUID
+}
+
+func (q *Queries) GetCarrierRegionById(ctx context.Context, arg GetCarrierRegionByIdParams) (CarrierRegion, error) {
+	row := q.db.QueryRowContext(ctx, getCarrierRegionById, arg.ID, arg.CarrierID)
+	var i CarrierRegion
+	err := row.Scan(
+		&i.ID,
+		&i.CarrierID,
+		&i.RegionID,
+		&i.EstimatedDeliveryDays,
+		&i.PricePerKg,
+		&i.CreatedAt,
+		&i.CubingDivisor,
+		&i.ExcessPricePerKg,
+		&i.MinCharge,
+		&i.OriginRegionID,
+		&i.ValidFrom,
+		&i.ValidTo,
+	)
+	return i, err
+}
+
 const getCarrierRegions = `-- name: GetCarrierRegions :many
 SELECT
     cr.id,
     cr.carrier_id,
     cr.region_id,
+    cr.origin_region_id,
     cr.estimated_delivery_days,
     cr.price_per_kg,
-    r.name as region_name
+    r.name as region_name,
+    o.name as origin_region_name,
+    cr.min_charge,
+    cr.excess_price_per_kg,
+    cr.cubing_divisor,
+    cr.valid_from,
+    cr.valid_to
 FROM carrier_regions cr
          JOIN regions r ON cr.region_id = r.id
+         LEFT JOIN regions o ON cr.origin_region_id = o.id
 WHERE cr.carrier_id = $1
+  AND cr.valid_from <= NOW()
+  AND (cr.valid_to IS NULL OR cr.valid_to > NOW())
+ORDER BY r.name, o.name NULLS FIRST
 `
 
 type GetCarrierRegionsRow struct {
 	ID                    uuid.UUID
 	CarrierID             uuid.UUID
 	RegionID              uuid.UUID
+	OriginRegionID        uuid.NullUUID
 	EstimatedDeliveryDays int32
 	PricePerKg            string
 	RegionName            string
+	OriginRegionName      sql.NullString
+	MinCharge             string
+	ExcessPricePerKg      sql.NullString
+	CubingDivisor         int32
+	ValidFrom             time.Time
+	ValidTo               sql.NullTime
 }
 
+// Only the rate version in effect now
 func (q *Queries) GetCarrierRegions(ctx context.Context, carrierID uuid.UUID) ([]GetCarrierRegionsRow, error) {
 	rows, err := q.db.QueryContext(ctx, getCarrierRegions, carrierID)
 	if err != nil {
@@ -46,9 +289,16 @@ func (q *Queries) GetCarrierRegions(ctx context.Context, carrierID uuid.UUID) ([
 			&i.ID,
 			&i.CarrierID,
 			&i.RegionID,
+			&i.OriginRegionID,
 			&i.EstimatedDeliveryDays,
 			&i.PricePerKg,
 			&i.RegionName,
+			&i.OriginRegionName,
+			&i.MinCharge,
+			&i.ExcessPricePerKg,
+			&i.CubingDivisor,
+			&i.ValidFrom,
+			&i.ValidTo,
 		); err != nil {
 			return nil, err
 		}
@@ -82,9 +332,201 @@ func (q *Queries) GetRegionByState(ctx context.Context, code string) (GetRegionB
 	return i, err
 }
 
+const importCarrierRates = `-- name: ImportCarrierRates :many
+WITH version_start AS (
+    SELECT COALESCE($1::TIMESTAMP, NOW()::TIMESTAMP) AS valid_from
+), lanes AS (
+    SELECT (e.item->>'region_id')::UUID AS region_id,
+           (e.item->>'estimated_delivery_days')::INT AS estimated_delivery_days,
+           (e.item->>'price_per_kg')::DECIMAL(10,2) AS price_per_kg,
+           (e.item->>'min_charge')::DECIMAL(10,2) AS min_charge,
+           (e.item->>'excess_price_per_kg')::DECIMAL(10,2) AS excess_price_per_kg,
+           (e.item->>'cubing_divisor')::INT AS cubing_divisor,
+           e.item->'tiers' AS tiers
+    FROM jsonb_array_elements($2::JSONB) AS e(item)
+), previous AS (
+    UPDATE carrier_regions cr
+    SET valid_to = vs.valid_from
+    FROM version_start vs, lanes l
+    WHERE cr.carrier_id = $3
+      AND cr.region_id = l.region_id
+      AND cr.origin_region_id IS NULL
+      AND cr.valid_from < vs.valid_from
+      AND (cr.valid_to IS NULL OR cr.valid_to > vs.valid_from)
+), versions AS (
+    INSERT INTO carrier_regions (carrier_id, region_id, estimated_delivery_days, price_per_kg, min_charge, excess_price_per_kg, cubing_divisor, valid_from, valid_to)
+    SELECT $3, l.region_id, l.estimated_delivery_days, l.price_per_kg, l.min_charge, l.excess_price_per_kg, l.cubing_divisor, vs.valid_from,
+           (SELECT MIN(n.valid_from)
+            FROM carrier_regions n
+            WHERE n.carrier_id = $3
+              AND n.region_id = l.region_id
+              AND n.origin_region_id IS NULL
+              AND n.valid_from > vs.valid_from)
+    FROM lanes l
+             CROSS JOIN version_start vs
+    RETURNING id, region_id
+), tiers AS (
+    INSERT INTO carrier_rate_tiers (carrier_region_id, min_weight_kg, max_weight_kg, price)
+    SELECT v.id, (t.item->>'min_weight_kg')::DECIMAL(10,3), (t.item->>'max_weight_kg')::DECIMAL(10,3), (t.item->>'price')::DECIMAL(10,2)
+    FROM versions v
+             JOIN lanes l ON l.region_id = v.region_id
+             CROSS JOIN jsonb_array_elements(l.tiers) AS t(item)
+)
+SELECT id, region_id
+FROM versions
+`
+
+type ImportCarrierRatesParams struct {
+	ValidFrom sql.NullTime
+	Lanes     json.RawMessage
+	CarrierID uuid.UUID
+}
+
+type ImportCarrierRatesRow struct {
+	ID       uuid.UUID
+	RegionID uuid.UUID
+}
+
+// Adds a version per lane, of any origin, ending the version in effect at valid_from
+func (q *Queries) ImportCarrierRates(ctx context.Context, arg ImportCarrierRatesParams) ([]ImportCarrierRatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, importCarrierRates, arg.ValidFrom, arg.Lanes, arg.CarrierID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ImportCarrierRatesRow{}
+	for rows.Next() {
+		var i ImportCarrierRatesRow
+		if err := rows.Scan(&i.ID, &i.RegionID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCarrierRates = `-- name: ListCarrierRates :many
+SELECT
+    cr.id,
+    cr.carrier_id,
+    cr.region_id,
+    cr.origin_region_id,
+    cr.estimated_delivery_days,
+    cr.price_per_kg,
+    r.name as region_name,
+    o.name as origin_region_name,
+    cr.min_charge,
+    cr.excess_price_per_kg,
+    cr.cubing_divisor,
+    cr.valid_from,
+    cr.valid_to
+FROM carrier_regions cr
+         JOIN regions r ON cr.region_id = r.id
+         LEFT JOIN regions o ON cr.origin_region_id = o.id
+WHERE cr.carrier_id = $1
+  AND ($2::TIMESTAMP IS NULL
+       OR (cr.valid_from <= $2 AND (cr.valid_to IS NULL OR cr.valid_to > $2)))
+ORDER BY r.name, o.name NULLS FIRST, cr.valid_from
+`
+
+type ListCarrierRatesParams struct {
+	CarrierID uuid.UUID
+	At        sql.NullTime
+}
+
+type ListCarrierRatesRow struct {
+	ID                    uuid.UUID
+	CarrierID             uuid.UUID
+	RegionID              uuid.UUID
+	OriginRegionID        uuid.NullUUID
+	EstimatedDeliveryDays int32
+	PricePerKg            string
+	RegionName            string
+	OriginRegionName      sql.NullString
+	MinCharge             string
+	ExcessPricePerKg      sql.NullString
+	CubingDivisor         int32
+	ValidFrom             time.Time
+	ValidTo               sql.NullTime
+}
+
+// Without a date lists every version, including scheduled ones
+func (q *Queries) ListCarrierRates(ctx context.Context, arg ListCarrierRatesParams) ([]ListCarrierRatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listCarrierRates, arg.CarrierID, arg.At)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListCarrierRatesRow{}
+	for rows.Next() {
+		var i ListCarrierRatesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.CarrierID,
+			&i.RegionID,
+			&i.OriginRegionID,
+			&i.EstimatedDeliveryDays,
+			&i.PricePerKg,
+			&i.RegionName,
+			&i.OriginRegionName,
+			&i.MinCharge,
+			&i.ExcessPricePerKg,
+			&i.CubingDivisor,
+			&i.ValidFrom,
+			&i.ValidTo,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCarrierRestrictedCategories = `-- name: ListCarrierRestrictedCategories :many
+SELECT carrier_id, category, created_at
+FROM carrier_restricted_categories
+ORDER BY carrier_id, category
+`
+
+func (q *Queries) ListCarrierRestrictedCategories(ctx context.Context) ([]CarrierRestrictedCategory, error) {
+	rows, err := q.db.QueryContext(ctx, listCarrierRestrictedCategories)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CarrierRestrictedCategory{}
+	for rows.Next() {
+		var i CarrierRestrictedCategory
+		if err := rows.Scan(&i.CarrierID, &i.Category, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listCarriers = `-- name: ListCarriers :many
-SELECT id, name, created_at
+SELECT id, name, created_at, max_weight_kg, max_side_cm, max_dimensions_sum_cm, cutoff_time, tracking_prefix, deleted_at
 FROM carriers
+WHERE deleted_at IS NULL
 ORDER BY name
 `
 
@@ -97,7 +539,17 @@ func (q *Queries) ListCarriers(ctx context.Context) ([]Carrier, error) {
 	items := []Carrier{}
 	for rows.Next() {
 		var i Carrier
-		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.CreatedAt,
+			&i.MaxWeightKg,
+			&i.MaxSideCm,
+			&i.MaxDimensionsSumCm,
+			&i.CutoffTime,
+			&i.TrackingPrefix,
+			&i.DeletedAt,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -110,3 +562,51 @@ func (q *Queries) ListCarriers(ctx context.Context) ([]Carrier, error) {
 	}
 	return items, nil
 }
+
+const updateCarrier = `-- name: UpdateCarrier :one
+UPDATE carriers
+SET name = $2,
+    tracking_prefix = $3,
+    max_weight_kg = $4,
+    max_side_cm = $5,
+    max_dimensions_sum_cm = $6,
+    cutoff_time = $7
+WHERE id = $1
+  AND deleted_at IS NULL
+RETURNING id, name, created_at, max_weight_kg, max_side_cm, max_dimensions_sum_cm, cutoff_time, tracking_prefix, deleted_at
+`
+
+type UpdateCarrierParams struct {
+	ID                 uuid.UUID
+	Name               string
+	TrackingPrefix     string
+	MaxWeightKg        sql.NullFloat64
+	MaxSideCm          sql.NullFloat64
+	MaxDimensionsSumCm sql.NullFloat64
+	CutoffTime         time.Time
+}
+
+func (q *Queries) UpdateCarrier(ctx context.Context, arg UpdateCarrierParams) (Carrier, error) {
+	row := q.db.QueryRowContext(ctx, updateCarrier,
+		arg.ID,
+		arg.Name,
+		arg.TrackingPrefix,
+		arg.MaxWeightKg,
+		arg.MaxSideCm,
+		arg.MaxDimensionsSumCm,
+		arg.CutoffTime,
+	)
+	var i Carrier
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.MaxWeightKg,
+		&i.MaxSideCm,
+		&i.MaxDimensionsSumCm,
+		&i.CutoffTime,
+		&i.TrackingPrefix,
+		&i.DeletedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,33 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: cep_ranges.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const getCepRange = `-- name: GetCepRange :one
+SELECT id, cep_start, cep_end, state_code, city, is_capital, created_at
+FROM cep_ranges
+WHERE $1::CHAR(8) BETWEEN cep_start AND cep_end
+ORDER BY cep_end::INT - cep_start::INT
+LIMIT 1
+`
+
+func (q *Queries) GetCepRange(ctx context.Context, cep string) (CepRange, error) {
+	row := q.db.QueryRowContext(ctx, getCepRange, cep)
+	var i CepRange
+	err := row.Scan(
+		&i.ID,
+		&i.CepStart,
+		&i.CepEnd,
+		&i.StateCode,
+		&i.City,
+		&i.IsCapital,
+		&i.CreatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,48 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: holidays.sql
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+const listHolidaysForState = `-- name: ListHolidaysForState :many
+SELECT id, name, holiday_date, recurring, state_code, created_at
+FROM holidays
+WHERE state_code IS NULL OR state_code = $1
+ORDER BY holiday_date
+`
+
+func (q *Queries) ListHolidaysForState(ctx context.Context, stateCode sql.NullString) ([]Holiday, error) {
+	rows, err := q.db.QueryContext(ctx, listHolidaysForState, stateCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Holiday{}
+	for rows.Next() {
+		var i Holiday
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.HolidayDate,
+			&i.Recurring,
+			&i.StateCode,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
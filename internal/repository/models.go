@@ -6,16 +6,72 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+type ApiKey struct {
+	ID         uuid.UUID
+	Name       string
+	Role       string
+	KeyPrefix  string
+	KeyHash    string
+	CreatedBy  string
+	CreatedAt  time.Time
+	LastUsedAt sql.NullTime
+	RevokedAt  sql.NullTime
+	SellerID   uuid.NullUUID
+}
+
 type Carrier struct {
-	ID        uuid.UUID
-	Name      string
+	ID                 uuid.UUID
+	Name               string
+	CreatedAt          sql.NullTime
+	MaxWeightKg        sql.NullFloat64
+	MaxSideCm          sql.NullFloat64
+	MaxDimensionsSumCm sql.NullFloat64
+	CutoffTime         time.Time
+	TrackingPrefix     string
+	DeletedAt          sql.NullTime
+}
+
+type CarrierApiToken struct {
+	CarrierID uuid.UUID
+	TokenHash string
 	CreatedAt sql.NullTime
 }
 
+type CarrierCepRule struct {
+	ID                uuid.UUID
+	CarrierID         uuid.UUID
+	CepStart          string
+	CepEnd            string
+	Serviceable       bool
+	Surcharge         string
+	ExtraDeliveryDays int32
+	Description       sql.NullString
+	CreatedAt         sql.NullTime
+}
+
+type CarrierEventMapping struct {
+	CarrierID   uuid.UUID
+	CarrierCode string
+	Status      sql.NullString
+	Description string
+	CreatedAt   sql.NullTime
+}
+
+type CarrierRateTier struct {
+	ID              uuid.UUID
+	CarrierRegionID uuid.UUID
+	MinWeightKg     string
+	MaxWeightKg     string
+	Price           string
+	CreatedAt       sql.NullTime
+}
+
 type CarrierRegion struct {
 	ID                    uuid.UUID
 	CarrierID             uuid.UUID
@@ -23,20 +79,131 @@ type CarrierRegion struct {
 	EstimatedDeliveryDays int32
 	PricePerKg            string
 	CreatedAt             sql.NullTime
+	CubingDivisor         int32
+	ExcessPricePerKg      sql.NullString
+	MinCharge             string
+	OriginRegionID        uuid.NullUUID
+	ValidFrom             time.Time
+	ValidTo               sql.NullTime
+}
+
+type CarrierRestrictedCategory struct {
+	CarrierID uuid.UUID
+	Category  string
+	CreatedAt sql.NullTime
+}
+
+type CarrierTrackingEvent struct {
+	ID             uuid.UUID
+	CarrierID      uuid.UUID
+	PackageID      uuid.UUID
+	CarrierEventID string
+	CarrierCode    string
+	MappedStatus   sql.NullString
+	Description    sql.NullString
+	Location       sql.NullString
+	OccurredAt     time.Time
+	Raw            json.RawMessage
+	ReceivedAt     sql.NullTime
+}
+
+type CepRange struct {
+	ID        uuid.UUID
+	CepStart  string
+	CepEnd    string
+	StateCode string
+	City      sql.NullString
+	IsCapital bool
+	CreatedAt sql.NullTime
+}
+
+type Holiday struct {
+	ID          uuid.UUID
+	Name        string
+	HolidayDate time.Time
+	Recurring   bool
+	StateCode   sql.NullString
+	CreatedAt   sql.NullTime
+}
+
+type IdempotencyKey struct {
+	Principal       string
+	IdempotencyKey  string
+	Route           string
+	RequestHash     string
+	StatusCode      sql.NullInt32
+	ContentType     sql.NullString
+	ResponseBody    []byte
+	CreatedAt       time.Time
+	ExpiresAt       time.Time
+	ResponseHeaders json.RawMessage
 }
 
 type Package struct {
-	ID                uuid.UUID
-	TrackingCode      sql.NullString
-	Product           string
-	WeightKg          float64
-	DestinationState  string
-	Status            string
-	HiredCarrierID    uuid.NullUUID
-	HiredPrice        sql.NullString
-	HiredDeliveryDays sql.NullInt32
-	CreatedAt         sql.NullTime
-	UpdatedAt         sql.NullTime
+	ID                    uuid.UUID
+	TrackingCode          sql.NullString
+	Product               string
+	WeightKg              float64
+	DestinationState      string
+	Status                string
+	HiredCarrierID        uuid.NullUUID
+	HiredPrice            sql.NullString
+	HiredDeliveryDays     sql.NullInt32
+	CreatedAt             sql.NullTime
+	UpdatedAt             sql.NullTime
+	HeightCm              sql.NullFloat64
+	WidthCm               sql.NullFloat64
+	LengthCm              sql.NullFloat64
+	ProductCategory       string
+	HiredAt               sql.NullTime
+	EstimatedDeliveryDate sql.NullTime
+	OriginWarehouseID     uuid.NullUUID
+	DestinationCep        sql.NullString
+	DestinationCity       sql.NullString
+	SellerID              uuid.UUID
+	RecipientName         sql.NullString
+	RecipientDocument     sql.NullString
+	RecipientPhone        sql.NullString
+	RecipientStreet       sql.NullString
+	RecipientNumber       sql.NullString
+	RecipientComplement   sql.NullString
+	RecipientNeighborhood sql.NullString
+	SenderName            sql.NullString
+	SenderDocument        sql.NullString
+	SenderPhone           sql.NullString
+	SenderStreet          sql.NullString
+	SenderNumber          sql.NullString
+	SenderComplement      sql.NullString
+	SenderNeighborhood    sql.NullString
+	SenderCity            sql.NullString
+	SenderState           sql.NullString
+	SenderCep             sql.NullString
+	Version               int32
+	DeletedAt             sql.NullTime
+	DeletedBy             sql.NullString
+	DeletedReason         sql.NullString
+}
+
+type PackageStatusEvent struct {
+	ID         uuid.UUID
+	PackageID  uuid.UUID
+	FromStatus string
+	ToStatus   string
+	Actor      string
+	Reason     sql.NullString
+	CreatedAt  time.Time
+}
+
+type Quote struct {
+	ID                    uuid.UUID
+	PackageID             uuid.UUID
+	CarrierID             uuid.UUID
+	Price                 string
+	EstimatedDeliveryDays int32
+	BillableWeightKg      float64
+	ExpiresAt             time.Time
+	UsedAt                sql.NullTime
+	CreatedAt             time.Time
 }
 
 type Region struct {
@@ -45,9 +212,54 @@ type Region struct {
 	CreatedAt sql.NullTime
 }
 
+type Seller struct {
+	ID        uuid.UUID
+	Name      string
+	CreatedAt time.Time
+}
+
+type SellerCarrierRate struct {
+	SellerID      uuid.UUID
+	CarrierID     uuid.UUID
+	PricePerKg    sql.NullString
+	MarkupPercent string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
 type State struct {
 	Code      string
 	Name      string
 	RegionID  uuid.UUID
 	CreatedAt sql.NullTime
 }
+
+type Warehouse struct {
+	ID        uuid.UUID
+	Name      string
+	StateCode string
+	CreatedAt sql.NullTime
+}
+
+type WebhookDelivery struct {
+	ID                 uuid.UUID
+	SubscriptionID     uuid.UUID
+	EventID            uuid.UUID
+	EventType          string
+	Payload            json.RawMessage
+	Status             string
+	Attempts           int32
+	NextAttemptAt      time.Time
+	LastResponseStatus sql.NullInt32
+	LastError          sql.NullString
+	DeliveredAt        sql.NullTime
+	CreatedAt          sql.NullTime
+}
+
+type WebhookSubscription struct {
+	ID         uuid.UUID
+	Url        string
+	Secret     string
+	EventTypes []string
+	CreatedAt  sql.NullTime
+}
@@ -0,0 +1,86 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: package_status_events.sql
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const createPackageStatusEvent = `-- name: CreatePackageStatusEvent :one
+INSERT INTO package_status_events (package_id, from_status, to_status, actor, reason)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id, package_id, from_status, to_status, actor, reason, created_at
+`
+
+type CreatePackageStatusEventParams struct {
+	PackageID  uuid.UUID
+	FromStatus string
+	ToStatus   string
+	Actor      string
+	Reason     sql.NullString
+}
+
+func (q *Queries) CreatePackageStatusEvent(ctx context.Context, arg CreatePackageStatusEventParams) (PackageStatusEvent, error) {
+	row := q.db.QueryRowContext(ctx, createPackageStatusEvent,
+		arg.PackageID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Actor,
+		arg.Reason,
+	)
+	var i PackageStatusEvent
+	err := row.Scan(
+		&i.ID,
+		&i.PackageID,
+		&i.FromStatus,
+		&i.ToStatus,
+		&i.Actor,
+		&i.Reason,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listPackageStatusEvents = `-- name: ListPackageStatusEvents :many
+SELECT id, package_id, from_status, to_status, actor, reason, created_at
+FROM package_status_events
+WHERE package_id = $1
+ORDER BY created_at
+`
+
+func (q *Queries) ListPackageStatusEvents(ctx context.Context, packageID uuid.UUID) ([]PackageStatusEvent, error) {
+	rows, err := q.db.QueryContext(ctx, listPackageStatusEvents, packageID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []PackageStatusEvent{}
+	for rows.Next() {
+		var i PackageStatusEvent
+		if err := rows.Scan(
+			&i.ID,
+			&i.PackageID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Actor,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
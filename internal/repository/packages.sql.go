@@ -8,21 +8,90 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 )
 
+const countPackages = `-- name: CountPackages :one
+SELECT COUNT(*)
+FROM packages
+WHERE ($1::VARCHAR IS NULL OR status = $1)
+  AND ($2::CHAR(2) IS NULL OR destination_state = $2)
+  AND ($3::UUID IS NULL OR hired_carrier_id = $3)
+  AND ($4::TIMESTAMP IS NULL OR created_at >= $4)
+  AND ($5::TIMESTAMP IS NULL OR created_at < $5)
+  AND ($6::TEXT IS NULL OR position(lower($6) IN lower(product)) > 0)
+  AND ($7::UUID IS NULL OR seller_id = $7)
+  AND (deleted_at IS NOT NULL) = $8::BOOLEAN
+`
+
+type CountPackagesParams struct {
+	Status           sql.NullString
+	DestinationState sql.NullString
+	HiredCarrierID   uuid.NullUUID
+	CreatedFrom      sql.NullTime
+	CreatedTo        sql.NullTime
+	Search           sql.NullString
+	SellerID         uuid.NullUUID
+	Deleted          bool
+}
+
+func (q *Queries) CountPackages(ctx context.Context, arg CountPackagesParams) (int64, error) {
+	row := q.db.QueryRowContext(ctx, countPackages,
+		arg.Status,
+		arg.DestinationState,
+		arg.HiredCarrierID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Search,
+		arg.SellerID,
+		arg.Deleted,
+	)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createPackage = `-- name: CreatePackage :one
-INSERT INTO packages (tracking_code, product, weight_kg, destination_state, status)
-VALUES ($1, $2, $3, $4, 'criado')
-RETURNING id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at
+INSERT INTO packages (tracking_code, product, weight_kg, destination_state, status, height_cm, width_cm, length_cm, product_category, origin_warehouse_id, destination_cep, destination_city, seller_id,
+                      recipient_name, recipient_document, recipient_phone, recipient_street, recipient_number, recipient_complement, recipient_neighborhood, sender_name, sender_document, sender_phone, sender_street, sender_number, sender_complement, sender_neighborhood, sender_city, sender_state, sender_cep)
+VALUES ($1, $2, $3, $4, 'criado', $5, $6, $7, COALESCE($8::TEXT, 'geral'), $9, $10, $11, $12,
+        $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26, $27, $28, $29)
+RETURNING id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at, height_cm, width_cm, length_cm, product_category, hired_at, estimated_delivery_date, origin_warehouse_id, destination_cep, destination_city, seller_id, recipient_name, recipient_document, recipient_phone, recipient_street, recipient_number, recipient_complement, recipient_neighborhood, sender_name, sender_document, sender_phone, sender_street, sender_number, sender_complement, sender_neighborhood, sender_city, sender_state, sender_cep, version, deleted_at, deleted_by, deleted_reason
 `
 
 type CreatePackageParams struct {
-	TrackingCode     sql.NullString
-	Product          string
-	WeightKg         float64
-	DestinationState string
+	TrackingCode          sql.NullString
+	Product               string
+	WeightKg              float64
+	DestinationState      string
+	HeightCm              sql.NullFloat64
+	WidthCm               sql.NullFloat64
+	LengthCm              sql.NullFloat64
+	ProductCategory       sql.NullString
+	OriginWarehouseID     uuid.NullUUID
+	DestinationCep        sql.NullString
+	DestinationCity       sql.NullString
+	SellerID              uuid.UUID
+	RecipientName         sql.NullString
+	RecipientDocument     sql.NullString
+	RecipientPhone        sql.NullString
+	RecipientStreet       sql.NullString
+	RecipientNumber       sql.NullString
+	RecipientComplement   sql.NullString
+	RecipientNeighborhood sql.NullString
+	SenderName            sql.NullString
+	SenderDocument        sql.NullString
+	SenderPhone           sql.NullString
+	SenderStreet          sql.NullString
+	SenderNumber          sql.NullString
+	SenderComplement      sql.NullString
+	SenderNeighborhood    sql.NullString
+	SenderCity            sql.NullString
+	SenderState           sql.NullString
+	SenderCep             sql.NullString
 }
 
 func (q *Queries) CreatePackage(ctx context.Context, arg CreatePackageParams) (Package, error) {
@@ -31,6 +100,31 @@ func (q *Queries) CreatePackage(ctx context.Context, arg CreatePackageParams) (P
 		arg.Product,
 		arg.WeightKg,
 		arg.DestinationState,
+		arg.HeightCm,
+		arg.WidthCm,
+		arg.LengthCm,
+		arg.ProductCategory,
+		arg.OriginWarehouseID,
+		arg.DestinationCep,
+		arg.DestinationCity,
+		arg.SellerID,
+		arg.RecipientName,
+		arg.RecipientDocument,
+		arg.RecipientPhone,
+		arg.RecipientStreet,
+		arg.RecipientNumber,
+		arg.RecipientComplement,
+		arg.RecipientNeighborhood,
+		arg.SenderName,
+		arg.SenderDocument,
+		arg.SenderPhone,
+		arg.SenderStreet,
+		arg.SenderNumber,
+		arg.SenderComplement,
+		arg.SenderNeighborhood,
+		arg.SenderCity,
+		arg.SenderState,
+		arg.SenderCep,
 	)
 	var i Package
 	err := row.Scan(
@@ -45,22 +139,180 @@ func (q *Queries) CreatePackage(ctx context.Context, arg CreatePackageParams) (P
 		&i.HiredDeliveryDays,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HeightCm,
+		&i.WidthCm,
+		&i.LengthCm,
+		&i.ProductCategory,
+		&i.HiredAt,
+		&i.EstimatedDeliveryDate,
+		&i.OriginWarehouseID,
+		&i.DestinationCep,
+		&i.DestinationCity,
+		&i.SellerID,
+		&i.RecipientName,
+		&i.RecipientDocument,
+		&i.RecipientPhone,
+		&i.RecipientStreet,
+		&i.RecipientNumber,
+		&i.RecipientComplement,
+		&i.RecipientNeighborhood,
+		&i.SenderName,
+		&i.SenderDocument,
+		&i.SenderPhone,
+		&i.SenderStreet,
+		&i.SenderNumber,
+		&i.SenderComplement,
+		&i.SenderNeighborhood,
+		&i.SenderCity,
+		&i.SenderState,
+		&i.SenderCep,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.DeletedReason,
 	)
 	return i, err
 }
 
-const deletePackage = `-- name: DeletePackage :exec
-DELETE FROM packages
+const createPackagesBatch = `-- name: CreatePackagesBatch :many
+INSERT INTO packages (product, weight_kg, destination_state, status, height_cm, width_cm, length_cm, product_category, origin_warehouse_id, destination_cep, destination_city, seller_id,
+                      recipient_name, recipient_document, recipient_phone, recipient_street, recipient_number, recipient_complement, recipient_neighborhood, sender_name, sender_document, sender_phone, sender_street, sender_number, sender_complement, sender_neighborhood, sender_city, sender_state, sender_cep)
+SELECT e.item->>'product',
+       (e.item->>'weight_kg')::FLOAT,
+       e.item->>'destination_state',
+       'criado',
+       (e.item->>'height_cm')::FLOAT,
+       (e.item->>'width_cm')::FLOAT,
+       (e.item->>'length_cm')::FLOAT,
+       COALESCE(e.item->>'product_category', 'geral'),
+       (e.item->>'origin_warehouse_id')::UUID,
+       e.item->>'destination_cep',
+       e.item->>'destination_city',
+       $1::UUID,
+       e.item->>'recipient_name',
+       e.item->>'recipient_document',
+       e.item->>'recipient_phone',
+       e.item->>'recipient_street',
+       e.item->>'recipient_number',
+       e.item->>'recipient_complement',
+       e.item->>'recipient_neighborhood',
+       e.item->>'sender_name',
+       e.item->>'sender_document',
+       e.item->>'sender_phone',
+       e.item->>'sender_street',
+       e.item->>'sender_number',
+       e.item->>'sender_complement',
+       e.item->>'sender_neighborhood',
+       e.item->>'sender_city',
+       e.item->>'sender_state',
+       e.item->>'sender_cep'
+FROM jsonb_array_elements($2::JSONB) WITH ORDINALITY AS e(item, position)
+ORDER BY e.position
+RETURNING id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at, height_cm, width_cm, length_cm, product_category, hired_at, estimated_delivery_date, origin_warehouse_id, destination_cep, destination_city, seller_id, recipient_name, recipient_document, recipient_phone, recipient_street, recipient_number, recipient_complement, recipient_neighborhood, sender_name, sender_document, sender_phone, sender_street, sender_number, sender_complement, sender_neighborhood, sender_city, sender_state, sender_cep, version, deleted_at, deleted_by, deleted_reason
+`
+
+type CreatePackagesBatchParams struct {
+	SellerID uuid.UUID
+	Packages json.RawMessage
+}
+
+func (q *Queries) CreatePackagesBatch(ctx context.Context, arg CreatePackagesBatchParams) ([]Package, error) {
+	rows, err := q.db.QueryContext(ctx, createPackagesBatch, arg.SellerID, arg.Packages)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Package{}
+	for rows.Next() {
+		var i Package
+		if err := rows.Scan(
+			&i.ID,
+			&i.TrackingCode,
+			&i.Product,
+			&i.WeightKg,
+			&i.DestinationState,
+			&i.Status,
+			&i.HiredCarrierID,
+			&i.HiredPrice,
+			&i.HiredDeliveryDays,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.HeightCm,
+			&i.WidthCm,
+			&i.LengthCm,
+			&i.ProductCategory,
+			&i.HiredAt,
+			&i.EstimatedDeliveryDate,
+			&i.OriginWarehouseID,
+			&i.DestinationCep,
+			&i.DestinationCity,
+			&i.SellerID,
+			&i.RecipientName,
+			&i.RecipientDocument,
+			&i.RecipientPhone,
+			&i.RecipientStreet,
+			&i.RecipientNumber,
+			&i.RecipientComplement,
+			&i.RecipientNeighborhood,
+			&i.SenderName,
+			&i.SenderDocument,
+			&i.SenderPhone,
+			&i.SenderStreet,
+			&i.SenderNumber,
+			&i.SenderComplement,
+			&i.SenderNeighborhood,
+			&i.SenderCity,
+			&i.SenderState,
+			&i.SenderCep,
+			&i.Version,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.DeletedReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const deletePackage = `-- name: DeletePackage :execrows
+UPDATE packages
+SET deleted_at = NOW(), deleted_by = $3, deleted_reason = $4, version = version + 1, updated_at = NOW()
 WHERE id = $1
+  AND version = $2
+  AND deleted_at IS NULL
 `
 
-func (q *Queries) DeletePackage(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.ExecContext(ctx, deletePackage, id)
-	return err
+type DeletePackageParams struct {
+	ID            uuid.UUID
+	Version       int32
+	DeletedBy     sql.NullString
+	DeletedReason sql.NullString
+}
+
+// Soft delete; history and tracking code are kept
+func (q *Queries) DeletePackage(ctx context.Context, arg DeletePackageParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, deletePackage,
+		arg.ID,
+		arg.Version,
+		arg.DeletedBy,
+		arg.DeletedReason,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 const getCarrierById = `-- name: GetCarrierById :one
-SELECT id, name, created_at
+SELECT id, name, created_at, max_weight_kg, max_side_cm, max_dimensions_sum_cm, cutoff_time, tracking_prefix, deleted_at
 FROM carriers
 WHERE id = $1
 `
@@ -68,18 +320,36 @@ WHERE id = $1
 func (q *Queries) GetCarrierById(ctx context.Context, id uuid.UUID) (Carrier, error) {
 	row := q.db.QueryRowContext(ctx, getCarrierById, id)
 	var i Carrier
-	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.CreatedAt,
+		&i.MaxWeightKg,
+		&i.MaxSideCm,
+		&i.MaxDimensionsSumCm,
+		&i.CutoffTime,
+		&i.TrackingPrefix,
+		&i.DeletedAt,
+	)
 	return i, err
 }
 
 const getPackageById = `-- name: GetPackageById :one
-SELECT id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at
+SELECT id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at, height_cm, width_cm, length_cm, product_category, hired_at, estimated_delivery_date, origin_warehouse_id, destination_cep, destination_city, seller_id, recipient_name, recipient_document, recipient_phone, recipient_street, recipient_number, recipient_complement, recipient_neighborhood, sender_name, sender_document, sender_phone, sender_street, sender_number, sender_complement, sender_neighborhood, sender_city, sender_state, sender_cep, version, deleted_at, deleted_by, deleted_reason
 FROM packages
 WHERE id = $1
+  AND ($2::UUID IS NULL OR seller_id = $2)
+  AND ($3::BOOLEAN OR deleted_at IS NULL)
 `
 
-func (q *Queries) GetPackageById(ctx context.Context, id uuid.UUID) (Package, error) {
-	row := q.db.QueryRowContext(ctx, getPackageById, id)
+type GetPackageByIdParams struct {
+	ID             uuid.UUID
+	SellerID       uuid.NullUUID
+	IncludeDeleted bool
+}
+
+func (q *Queries) GetPackageById(ctx context.Context, arg GetPackageByIdParams) (Package, error) {
+	row := q.db.QueryRowContext(ctx, getPackageById, arg.ID, arg.SellerID, arg.IncludeDeleted)
 	var i Package
 	err := row.Scan(
 		&i.ID,
@@ -93,18 +363,56 @@ func (q *Queries) GetPackageById(ctx context.Context, id uuid.UUID) (Package, er
 		&i.HiredDeliveryDays,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HeightCm,
+		&i.WidthCm,
+		&i.LengthCm,
+		&i.ProductCategory,
+		&i.HiredAt,
+		&i.EstimatedDeliveryDate,
+		&i.OriginWarehouseID,
+		&i.DestinationCep,
+		&i.DestinationCity,
+		&i.SellerID,
+		&i.RecipientName,
+		&i.RecipientDocument,
+		&i.RecipientPhone,
+		&i.RecipientStreet,
+		&i.RecipientNumber,
+		&i.RecipientComplement,
+		&i.RecipientNeighborhood,
+		&i.SenderName,
+		&i.SenderDocument,
+		&i.SenderPhone,
+		&i.SenderStreet,
+		&i.SenderNumber,
+		&i.SenderComplement,
+		&i.SenderNeighborhood,
+		&i.SenderCity,
+		&i.SenderState,
+		&i.SenderCep,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.DeletedReason,
 	)
 	return i, err
 }
 
 const getPackageByTrackingCode = `-- name: GetPackageByTrackingCode :one
-SELECT id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at
+SELECT id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at, height_cm, width_cm, length_cm, product_category, hired_at, estimated_delivery_date, origin_warehouse_id, destination_cep, destination_city, seller_id, recipient_name, recipient_document, recipient_phone, recipient_street, recipient_number, recipient_complement, recipient_neighborhood, sender_name, sender_document, sender_phone, sender_street, sender_number, sender_complement, sender_neighborhood, sender_city, sender_state, sender_cep, version, deleted_at, deleted_by, deleted_reason
 FROM packages
 WHERE tracking_code = $1
+  AND ($2::UUID IS NULL OR seller_id = $2)
+  AND deleted_at IS NULL
 `
 
-func (q *Queries) GetPackageByTrackingCode(ctx context.Context, trackingCode sql.NullString) (Package, error) {
-	row := q.db.QueryRowContext(ctx, getPackageByTrackingCode, trackingCode)
+type GetPackageByTrackingCodeParams struct {
+	TrackingCode sql.NullString
+	SellerID     uuid.NullUUID
+}
+
+func (q *Queries) GetPackageByTrackingCode(ctx context.Context, arg GetPackageByTrackingCodeParams) (Package, error) {
+	row := q.db.QueryRowContext(ctx, getPackageByTrackingCode, arg.TrackingCode, arg.SellerID)
 	var i Package
 	err := row.Scan(
 		&i.ID,
@@ -118,34 +426,90 @@ func (q *Queries) GetPackageByTrackingCode(ctx context.Context, trackingCode sql
 		&i.HiredDeliveryDays,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.HeightCm,
+		&i.WidthCm,
+		&i.LengthCm,
+		&i.ProductCategory,
+		&i.HiredAt,
+		&i.EstimatedDeliveryDate,
+		&i.OriginWarehouseID,
+		&i.DestinationCep,
+		&i.DestinationCity,
+		&i.SellerID,
+		&i.RecipientName,
+		&i.RecipientDocument,
+		&i.RecipientPhone,
+		&i.RecipientStreet,
+		&i.RecipientNumber,
+		&i.RecipientComplement,
+		&i.RecipientNeighborhood,
+		&i.SenderName,
+		&i.SenderDocument,
+		&i.SenderPhone,
+		&i.SenderStreet,
+		&i.SenderNumber,
+		&i.SenderComplement,
+		&i.SenderNeighborhood,
+		&i.SenderCity,
+		&i.SenderState,
+		&i.SenderCep,
+		&i.Version,
+		&i.DeletedAt,
+		&i.DeletedBy,
+		&i.DeletedReason,
 	)
 	return i, err
 }
 
 const getQuotesForPackage = `-- name: GetQuotesForPackage :many
-SELECT
-    c.name as carier,
-    (cr.price_per_kg * $1::DECIMAL)::FLOAT as estimated_price,
-    cr.estimated_delivery_days
+SELECT DISTINCT ON (c.id)
+    c.id as carrier_id,
+    c.name as carrier_name,
+    cr.id as carrier_region_id,
+    cr.price_per_kg,
+    cr.excess_price_per_kg,
+    cr.min_charge,
+    cr.cubing_divisor,
+    cr.estimated_delivery_days,
+    c.max_weight_kg,
+    c.max_side_cm,
+    c.max_dimensions_sum_cm,
+    c.cutoff_time
 FROM carriers c
          JOIN carrier_regions cr ON c.id = cr.carrier_id
          JOIN states s ON s.region_id = cr.region_id
-WHERE s.code = $2
+WHERE s.code = $1
+  AND c.deleted_at IS NULL
+  AND cr.valid_from <= NOW()
+  AND (cr.valid_to IS NULL OR cr.valid_to > NOW())
+  AND (cr.origin_region_id IS NULL
+       OR cr.origin_region_id = (SELECT o.region_id FROM states o WHERE o.code = $2))
+ORDER BY c.id, cr.origin_region_id NULLS LAST
 `
 
 type GetQuotesForPackageParams struct {
-	WeightKg  string
-	StateCode string
+	StateCode   string
+	OriginState sql.NullString
 }
 
 type GetQuotesForPackageRow struct {
-	Carier                string
-	EstimatedPrice        float64
+	CarrierID             uuid.UUID
+	CarrierName           string
+	CarrierRegionID       uuid.UUID
+	PricePerKg            string
+	ExcessPricePerKg      sql.NullString
+	MinCharge             string
+	CubingDivisor         int32
 	EstimatedDeliveryDays int32
+	MaxWeightKg           sql.NullFloat64
+	MaxSideCm             sql.NullFloat64
+	MaxDimensionsSumCm    sql.NullFloat64
+	CutoffTime            time.Time
 }
 
+// A lane-specific table wins over the any-origin one
 func (q *Queries) GetQuotesForPackage(ctx context.Context, arg GetQuotesForPackageParams) ([]GetQuotesForPackageRow, error) {
-	rows, err := q.db.QueryContext(ctx, getQuotesForPackage, arg.WeightKg, arg.StateCode)
+	rows, err := q.db.QueryContext(ctx, getQuotesForPackage, arg.StateCode, arg.OriginState)
 	if err != nil {
 		return nil, err
 	}
@@ -153,7 +517,20 @@ func (q *Queries) GetQuotesForPackage(ctx context.Context, arg GetQuotesForPacka
 	items := []GetQuotesForPackageRow{}
 	for rows.Next() {
 		var i GetQuotesForPackageRow
-		if err := rows.Scan(&i.Carier, &i.EstimatedPrice, &i.EstimatedDeliveryDays); err != nil {
+		if err := rows.Scan(
+			&i.CarrierID,
+			&i.CarrierName,
+			&i.CarrierRegionID,
+			&i.PricePerKg,
+			&i.ExcessPricePerKg,
+			&i.MinCharge,
+			&i.CubingDivisor,
+			&i.EstimatedDeliveryDays,
+			&i.MaxWeightKg,
+			&i.MaxSideCm,
+			&i.MaxDimensionsSumCm,
+			&i.CutoffTime,
+		); err != nil {
 			return nil, err
 		}
 		items = append(items, i)
@@ -167,41 +544,109 @@ func (q *Queries) GetQuotesForPackage(ctx context.Context, arg GetQuotesForPacka
 	return items, nil
 }
 
-const hireCarrier = `-- name: HireCarrier :exec
+const hireCarrier = `-- name: HireCarrier :execrows
 UPDATE packages
 SET hired_carrier_id = $2,
     hired_price = $3,
     hired_delivery_days = $4,
+    hired_at = NOW(),
+    estimated_delivery_date = $5,
     status = 'esperando_coleta',
+    tracking_code = COALESCE(tracking_code, $7),
+    version = version + 1,
     updated_at = NOW()
 WHERE id = $1
+  AND version = $6
 `
 
 type HireCarrierParams struct {
-	ID                uuid.UUID
-	HiredCarrierID    uuid.NullUUID
-	HiredPrice        sql.NullString
-	HiredDeliveryDays sql.NullInt32
+	ID                    uuid.UUID
+	HiredCarrierID        uuid.NullUUID
+	HiredPrice            sql.NullString
+	HiredDeliveryDays     sql.NullInt32
+	EstimatedDeliveryDate sql.NullTime
+	Version               int32
+	TrackingCode          sql.NullString
 }
 
-func (q *Queries) HireCarrier(ctx context.Context, arg HireCarrierParams) error {
-	_, err := q.db.ExecContext(ctx, hireCarrier,
+func (q *Queries) HireCarrier(ctx context.Context, arg HireCarrierParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, hireCarrier,
 		arg.ID,
 		arg.HiredCarrierID,
 		arg.HiredPrice,
 		arg.HiredDeliveryDays,
+		arg.EstimatedDeliveryDate,
+		arg.Version,
+		arg.TrackingCode,
 	)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const listPackages = `-- name: ListPackages :many
-SELECT id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at
+const listPackagesPage = `-- name: ListPackagesPage :many
+SELECT id, tracking_code, product, weight_kg, destination_state, status, hired_carrier_id, hired_price, hired_delivery_days, created_at, updated_at, height_cm, width_cm, length_cm, product_category, hired_at, estimated_delivery_date, origin_warehouse_id, destination_cep, destination_city, seller_id, recipient_name, recipient_document, recipient_phone, recipient_street, recipient_number, recipient_complement, recipient_neighborhood, sender_name, sender_document, sender_phone, sender_street, sender_number, sender_complement, sender_neighborhood, sender_city, sender_state, sender_cep, version, deleted_at, deleted_by, deleted_reason
 FROM packages
-ORDER BY created_at DESC
+WHERE ($1::VARCHAR IS NULL OR status = $1)
+  AND ($2::CHAR(2) IS NULL OR destination_state = $2)
+  AND ($3::UUID IS NULL OR hired_carrier_id = $3)
+  AND ($4::TIMESTAMP IS NULL OR created_at >= $4)
+  AND ($5::TIMESTAMP IS NULL OR created_at < $5)
+  AND ($6::TEXT IS NULL OR position(lower($6) IN lower(product)) > 0)
+  AND (
+      $7::UUID IS NULL
+      OR ($8::TEXT = 'created_at' AND $9::BOOLEAN AND (created_at, id) < ($10::TIMESTAMP, $7))
+      OR ($8 = 'created_at' AND NOT $9 AND (created_at, id) > ($10, $7))
+      OR ($8 = 'weight_kg' AND $9 AND (weight_kg, id) < ($11::FLOAT, $7))
+      OR ($8 = 'weight_kg' AND NOT $9 AND (weight_kg, id) > ($11, $7))
+  )
+  AND ($12::UUID IS NULL OR seller_id = $12)
+  AND (deleted_at IS NOT NULL) = $13::BOOLEAN
+ORDER BY
+    CASE WHEN $8 = 'created_at' AND $9 THEN created_at END DESC,
+    CASE WHEN $8 = 'created_at' AND NOT $9 THEN created_at END ASC,
+    CASE WHEN $8 = 'weight_kg' AND $9 THEN weight_kg END DESC,
+    CASE WHEN $8 = 'weight_kg' AND NOT $9 THEN weight_kg END ASC,
+    CASE WHEN $9 THEN id END DESC,
+    CASE WHEN NOT $9 THEN id END ASC
+LIMIT $14
 `
 
-func (q *Queries) ListPackages(ctx context.Context) ([]Package, error) {
-	rows, err := q.db.QueryContext(ctx, listPackages)
+type ListPackagesPageParams struct {
+	Status           sql.NullString
+	DestinationState sql.NullString
+	HiredCarrierID   uuid.NullUUID
+	CreatedFrom      sql.NullTime
+	CreatedTo        sql.NullTime
+	Search           sql.NullString
+	CursorID         uuid.NullUUID
+	SortBy           string
+	SortDesc         bool
+	CursorCreatedAt  sql.NullTime
+	CursorWeightKg   sql.NullFloat64
+	SellerID         uuid.NullUUID
+	Deleted          bool
+	PageLimit        int32
+}
+
+func (q *Queries) ListPackagesPage(ctx context.Context, arg ListPackagesPageParams) ([]Package, error) {
+	rows, err := q.db.QueryContext(ctx, listPackagesPage,
+		arg.Status,
+		arg.DestinationState,
+		arg.HiredCarrierID,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.Search,
+		arg.CursorID,
+		arg.SortBy,
+		arg.SortDesc,
+		arg.CursorCreatedAt,
+		arg.CursorWeightKg,
+		arg.SellerID,
+		arg.Deleted,
+		arg.PageLimit,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -221,6 +666,37 @@ func (q *Queries) ListPackages(ctx context.Context) ([]Package, error) {
 			&i.HiredDeliveryDays,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.HeightCm,
+			&i.WidthCm,
+			&i.LengthCm,
+			&i.ProductCategory,
+			&i.HiredAt,
+			&i.EstimatedDeliveryDate,
+			&i.OriginWarehouseID,
+			&i.DestinationCep,
+			&i.DestinationCity,
+			&i.SellerID,
+			&i.RecipientName,
+			&i.RecipientDocument,
+			&i.RecipientPhone,
+			&i.RecipientStreet,
+			&i.RecipientNumber,
+			&i.RecipientComplement,
+			&i.RecipientNeighborhood,
+			&i.SenderName,
+			&i.SenderDocument,
+			&i.SenderPhone,
+			&i.SenderStreet,
+			&i.SenderNumber,
+			&i.SenderComplement,
+			&i.SenderNeighborhood,
+			&i.SenderCity,
+			&i.SenderState,
+			&i.SenderCep,
+			&i.Version,
+			&i.DeletedAt,
+			&i.DeletedBy,
+			&i.DeletedReason,
 		); err != nil {
 			return nil, err
 		}
@@ -235,6 +711,27 @@ func (q *Queries) ListPackages(ctx context.Context) ([]Package, error) {
 	return items, nil
 }
 
+const restorePackage = `-- name: RestorePackage :execrows
+UPDATE packages
+SET deleted_at = NULL, deleted_by = NULL, deleted_reason = NULL, version = version + 1, updated_at = NOW()
+WHERE id = $1
+  AND version = $2
+  AND deleted_at IS NOT NULL
+`
+
+type RestorePackageParams struct {
+	ID      uuid.UUID
+	Version int32
+}
+
+func (q *Queries) RestorePackage(ctx context.Context, arg RestorePackageParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, restorePackage, arg.ID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const trackingCodeExists = `-- name: TrackingCodeExists :one
 SELECT EXISTS(
     SELECT 1 FROM packages
@@ -249,35 +746,51 @@ func (q *Queries) TrackingCodeExists(ctx context.Context, trackingCode sql.NullS
 	return exists, err
 }
 
-const updatePackageStatus = `-- name: UpdatePackageStatus :exec
+const updatePackageStatus = `-- name: UpdatePackageStatus :execrows
 UPDATE packages
-SET status = $2, updated_at = NOW()
+SET status = $2, version = version + 1, updated_at = NOW()
 WHERE id = $1
+  AND version = $3
 `
 
 type UpdatePackageStatusParams struct {
-	ID     uuid.UUID
-	Status string
+	ID      uuid.UUID
+	Status  string
+	Version int32
 }
 
-func (q *Queries) UpdatePackageStatus(ctx context.Context, arg UpdatePackageStatusParams) error {
-	_, err := q.db.ExecContext(ctx, updatePackageStatus, arg.ID, arg.Status)
-	return err
+// Zero rows means the package changed since it was read
+func (q *Queries) UpdatePackageStatus(ctx context.Context, arg UpdatePackageStatusParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updatePackageStatus, arg.ID, arg.Status, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
-const updatePackageStatusWithTracking = `-- name: UpdatePackageStatusWithTracking :exec
+const updatePackageStatusWithTracking = `-- name: UpdatePackageStatusWithTracking :execrows
 UPDATE packages
-SET status = $2, tracking_code = $3, updated_at = NOW()
+SET status = $2, tracking_code = $3, version = version + 1, updated_at = NOW()
 WHERE id = $1
+  AND version = $4
 `
 
 type UpdatePackageStatusWithTrackingParams struct {
 	ID           uuid.UUID
 	Status       string
 	TrackingCode sql.NullString
+	Version      int32
 }
 
-func (q *Queries) UpdatePackageStatusWithTracking(ctx context.Context, arg UpdatePackageStatusWithTrackingParams) error {
-	_, err := q.db.ExecContext(ctx, updatePackageStatusWithTracking, arg.ID, arg.Status, arg.TrackingCode)
-	return err
+func (q *Queries) UpdatePackageStatusWithTracking(ctx context.Context, arg UpdatePackageStatusWithTrackingParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updatePackageStatusWithTracking,
+		arg.ID,
+		arg.Status,
+		arg.TrackingCode,
+		arg.Version,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
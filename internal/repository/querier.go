@@ -12,23 +12,95 @@ import (
 )
 
 type Querier interface {
+	// Claimed deliveries are retried once the 5-minute claim expires
+	ClaimDueWebhookDeliveries(ctx context.Context, limit int32) ([]ClaimDueWebhookDeliveriesRow, error)
+	CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error
+	CountPackages(ctx context.Context, arg CountPackagesParams) (int64, error)
+	CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error)
+	CreateCarrier(ctx context.Context, arg CreateCarrierParams) (Carrier, error)
+	// Ends the version in effect at valid_from and inherits its weight tiers
+	CreateCarrierRegion(ctx context.Context, arg CreateCarrierRegionParams) (CreateCarrierRegionRow, error)
+	// No row returned means the event is a duplicate
+	CreateCarrierTrackingEvent(ctx context.Context, arg CreateCarrierTrackingEventParams) (CarrierTrackingEvent, error)
 	CreatePackage(ctx context.Context, arg CreatePackageParams) (Package, error)
-	DeletePackage(ctx context.Context, id uuid.UUID) error
+	CreatePackageStatusEvent(ctx context.Context, arg CreatePackageStatusEventParams) (PackageStatusEvent, error)
+	CreatePackagesBatch(ctx context.Context, arg CreatePackagesBatchParams) ([]Package, error)
+	CreateQuote(ctx context.Context, arg CreateQuoteParams) (Quote, error)
+	CreateSeller(ctx context.Context, name string) (Seller, error)
+	CreateWarehouse(ctx context.Context, arg CreateWarehouseParams) (Warehouse, error)
+	CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error)
+	CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error)
+	// Soft delete, refused while the carrier has packages in progress
+	DeleteCarrier(ctx context.Context, id uuid.UUID) (int64, error)
+	DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error)
+	// Soft delete; history and tracking code are kept
+	DeletePackage(ctx context.Context, arg DeletePackageParams) (int64, error)
+	DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) (int64, error)
+	// Drops scheduled versions and ends the current one, which stays in history
+	EndCarrierRegion(ctx context.Context, arg EndCarrierRegionParams) error
+	GetActiveApiKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
+	GetCarrierApiTokenHash(ctx context.Context, carrierID uuid.UUID) (string, error)
 	GetCarrierById(ctx context.Context, id uuid.UUID) (Carrier, error)
+	GetCarrierEventMapping(ctx context.Context, arg GetCarrierEventMappingParams) (CarrierEventMapping, error)
+	GetCarrierRegionById(ctx context.Context, arg GetCarrierRegionByIdParams) (CarrierRegion, error)
+	// Only the rate version in effect now
 	GetCarrierRegions(ctx context.Context, carrierID uuid.UUID) ([]GetCarrierRegionsRow, error)
-	GetPackageById(ctx context.Context, id uuid.UUID) (Package, error)
-	GetPackageByTrackingCode(ctx context.Context, trackingCode sql.NullString) (Package, error)
+	GetCarrierTrackingEvent(ctx context.Context, arg GetCarrierTrackingEventParams) (CarrierTrackingEvent, error)
+	GetCepRange(ctx context.Context, cep string) (CepRange, error)
+	GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error)
+	GetPackageById(ctx context.Context, arg GetPackageByIdParams) (Package, error)
+	GetPackageByTrackingCode(ctx context.Context, arg GetPackageByTrackingCodeParams) (Package, error)
+	GetQuoteById(ctx context.Context, id uuid.UUID) (Quote, error)
+	// A lane-specific table wins over the any-origin one
 	GetQuotesForPackage(ctx context.Context, arg GetQuotesForPackageParams) ([]GetQuotesForPackageRow, error)
 	GetRegionByState(ctx context.Context, code string) (GetRegionByStateRow, error)
+	GetSellerById(ctx context.Context, id uuid.UUID) (Seller, error)
 	GetStateByCode(ctx context.Context, code string) (GetStateByCodeRow, error)
-	HireCarrier(ctx context.Context, arg HireCarrierParams) error
+	GetWarehouseById(ctx context.Context, id uuid.UUID) (Warehouse, error)
+	GetWebhookDeliveryById(ctx context.Context, id uuid.UUID) (WebhookDelivery, error)
+	HireCarrier(ctx context.Context, arg HireCarrierParams) (int64, error)
+	// Adds a version per lane, of any origin, ending the version in effect at valid_from
+	ImportCarrierRates(ctx context.Context, arg ImportCarrierRatesParams) ([]ImportCarrierRatesRow, error)
+	ListApiKeys(ctx context.Context) ([]ApiKey, error)
+	ListCarrierCepRules(ctx context.Context, cep string) ([]CarrierCepRule, error)
+	ListCarrierEventMappings(ctx context.Context, carrierID uuid.UUID) ([]CarrierEventMapping, error)
+	ListCarrierRateTiersByCarrier(ctx context.Context, carrierID uuid.UUID) ([]CarrierRateTier, error)
+	ListCarrierRateTiersByState(ctx context.Context, stateCode string) ([]CarrierRateTier, error)
+	// Without a date lists every version, including scheduled ones
+	ListCarrierRates(ctx context.Context, arg ListCarrierRatesParams) ([]ListCarrierRatesRow, error)
+	ListCarrierRestrictedCategories(ctx context.Context) ([]CarrierRestrictedCategory, error)
+	ListCarrierTrackingEventsByPackage(ctx context.Context, packageID uuid.UUID) ([]CarrierTrackingEvent, error)
 	ListCarriers(ctx context.Context) ([]Carrier, error)
-	ListPackages(ctx context.Context) ([]Package, error)
+	ListHolidaysForState(ctx context.Context, stateCode sql.NullString) ([]Holiday, error)
+	ListPackageStatusEvents(ctx context.Context, packageID uuid.UUID) ([]PackageStatusEvent, error)
+	ListPackagesPage(ctx context.Context, arg ListPackagesPageParams) ([]Package, error)
 	ListRegions(ctx context.Context) ([]Region, error)
+	ListSellerCarrierRates(ctx context.Context, sellerID uuid.UUID) ([]SellerCarrierRate, error)
+	ListSellers(ctx context.Context) ([]Seller, error)
 	ListStates(ctx context.Context) ([]ListStatesRow, error)
+	ListWarehouses(ctx context.Context) ([]Warehouse, error)
+	ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error)
+	ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error)
+	ListWebhookSubscriptionsForEvent(ctx context.Context, eventType string) ([]WebhookSubscription, error)
+	MarkQuoteUsed(ctx context.Context, id uuid.UUID) (int64, error)
+	NextTrackingSerial(ctx context.Context, prefix string) (int64, error)
+	RecordWebhookDeliveryAttempt(ctx context.Context, arg RecordWebhookDeliveryAttemptParams) error
+	ReleaseIdempotencyKey(ctx context.Context, arg ReleaseIdempotencyKeyParams) error
+	ReplayWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error)
+	// Takes over expired keys or ones stuck in progress; otherwise returns no row
+	ReserveIdempotencyKey(ctx context.Context, arg ReserveIdempotencyKeyParams) (IdempotencyKey, error)
+	RestorePackage(ctx context.Context, arg RestorePackageParams) (int64, error)
+	RevokeApiKey(ctx context.Context, id uuid.UUID) (ApiKey, error)
+	// Writes at most once a minute instead of on every request
+	TouchApiKey(ctx context.Context, id uuid.UUID) error
 	TrackingCodeExists(ctx context.Context, trackingCode sql.NullString) (bool, error)
-	UpdatePackageStatus(ctx context.Context, arg UpdatePackageStatusParams) error
-	UpdatePackageStatusWithTracking(ctx context.Context, arg UpdatePackageStatusWithTrackingParams) error
+	UpdateCarrier(ctx context.Context, arg UpdateCarrierParams) (Carrier, error)
+	// Zero rows means the package changed since it was read
+	UpdatePackageStatus(ctx context.Context, arg UpdatePackageStatusParams) (int64, error)
+	UpdatePackageStatusWithTracking(ctx context.Context, arg UpdatePackageStatusWithTrackingParams) (int64, error)
+	UpsertCarrierApiToken(ctx context.Context, arg UpsertCarrierApiTokenParams) error
+	UpsertCarrierEventMapping(ctx context.Context, arg UpsertCarrierEventMappingParams) (CarrierEventMapping, error)
+	UpsertSellerCarrierRate(ctx context.Context, arg UpsertSellerCarrierRateParams) (SellerCarrierRate, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -15,11 +15,38 @@ type QuerierMocked struct {
 	mock.Mock
 }
 
-func (_m *QuerierMocked) UpdatePackageStatusWithTracking(ctx context.Context, arg UpdatePackageStatusWithTrackingParams) error {
+// ClaimDueWebhookDeliveries provides a mock function with given fields: ctx, limit
+func (_m *QuerierMocked) ClaimDueWebhookDeliveries(ctx context.Context, limit int32) ([]ClaimDueWebhookDeliveriesRow, error) {
+	ret := _m.Called(ctx, limit)
+
+	var r0 []ClaimDueWebhookDeliveriesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int32) ([]ClaimDueWebhookDeliveriesRow, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int32) []ClaimDueWebhookDeliveriesRow); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ClaimDueWebhookDeliveriesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int32) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteIdempotencyKey provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) CompleteIdempotencyKey(ctx context.Context, arg CompleteIdempotencyKeyParams) error {
 	ret := _m.Called(ctx, arg)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, UpdatePackageStatusWithTrackingParams) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, CompleteIdempotencyKeyParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
@@ -28,35 +55,46 @@ func (_m *QuerierMocked) UpdatePackageStatusWithTracking(ctx context.Context, ar
 	return r0
 }
 
-// NewQuerierMocked creates a new instance of QuerierMocked. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
-func NewQuerierMocked(t interface {
-	mock.TestingT
-	Cleanup(func())
-}) *QuerierMocked {
-	mock := &QuerierMocked{}
-	mock.Mock.Test(t)
+// CountPackages provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) CountPackages(ctx context.Context, arg CountPackagesParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
 
-	t.Cleanup(func() { mock.AssertExpectations(t) })
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, CountPackagesParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, CountPackagesParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
 
-	return mock
+	if rf, ok := ret.Get(1).(func(context.Context, CountPackagesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// CreatePackage provides a mock function with given fields: ctx, arg
-func (_m *QuerierMocked) CreatePackage(ctx context.Context, arg CreatePackageParams) (Package, error) {
+// CreateApiKey provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) CreateApiKey(ctx context.Context, arg CreateApiKeyParams) (ApiKey, error) {
 	ret := _m.Called(ctx, arg)
 
-	var r0 Package
+	var r0 ApiKey
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, CreatePackageParams) (Package, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, CreateApiKeyParams) (ApiKey, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, CreatePackageParams) Package); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, CreateApiKeyParams) ApiKey); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(Package)
+		r0 = ret.Get(0).(ApiKey)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, CreatePackageParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, CreateApiKeyParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -65,37 +103,47 @@ func (_m *QuerierMocked) CreatePackage(ctx context.Context, arg CreatePackagePar
 	return r0, r1
 }
 
-// DeletePackage provides a mock function with given fields: ctx, id
-func (_m *QuerierMocked) DeletePackage(ctx context.Context, id uuid.UUID) error {
-	ret := _m.Called(ctx, id)
+// CreateCarrier provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) CreateCarrier(ctx context.Context, arg CreateCarrierParams) (Carrier, error) {
+	ret := _m.Called(ctx, arg)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, id)
+	var r0 Carrier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, CreateCarrierParams) (Carrier, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, CreateCarrierParams) Carrier); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(Carrier)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, CreateCarrierParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// GetCarrierById provides a mock function with given fields: ctx, id
-func (_m *QuerierMocked) GetCarrierById(ctx context.Context, id uuid.UUID) (Carrier, error) {
-	ret := _m.Called(ctx, id)
+// CreateCarrierRegion provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) CreateCarrierRegion(ctx context.Context, arg CreateCarrierRegionParams) (CreateCarrierRegionRow, error) {
+	ret := _m.Called(ctx, arg)
 
-	var r0 Carrier
+	var r0 CreateCarrierRegionRow
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (Carrier, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, CreateCarrierRegionParams) (CreateCarrierRegionRow, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) Carrier); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, CreateCarrierRegionParams) CreateCarrierRegionRow); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(Carrier)
+		r0 = ret.Get(0).(CreateCarrierRegionRow)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, CreateCarrierRegionParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -103,25 +151,23 @@ func (_m *QuerierMocked) GetCarrierById(ctx context.Context, id uuid.UUID) (Carr
 	return r0, r1
 }
 
-// GetCarrierRegions provides a mock function with given fields: ctx, carrierID
-func (_m *QuerierMocked) GetCarrierRegions(ctx context.Context, carrierID uuid.UUID) ([]GetCarrierRegionsRow, error) {
-	ret := _m.Called(ctx, carrierID)
+// CreateCarrierTrackingEvent provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) CreateCarrierTrackingEvent(ctx context.Context, arg CreateCarrierTrackingEventParams) (CarrierTrackingEvent, error) {
+	ret := _m.Called(ctx, arg)
 
-	var r0 []GetCarrierRegionsRow
+	var r0 CarrierTrackingEvent
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]GetCarrierRegionsRow, error)); ok {
-		return rf(ctx, carrierID)
+	if rf, ok := ret.Get(0).(func(context.Context, CreateCarrierTrackingEventParams) (CarrierTrackingEvent, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []GetCarrierRegionsRow); ok {
-		r0 = rf(ctx, carrierID)
+	if rf, ok := ret.Get(0).(func(context.Context, CreateCarrierTrackingEventParams) CarrierTrackingEvent); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]GetCarrierRegionsRow)
-		}
+		r0 = ret.Get(0).(CarrierTrackingEvent)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, carrierID)
+	if rf, ok := ret.Get(1).(func(context.Context, CreateCarrierTrackingEventParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -129,23 +175,23 @@ func (_m *QuerierMocked) GetCarrierRegions(ctx context.Context, carrierID uuid.U
 	return r0, r1
 }
 
-// GetPackageById provides a mock function with given fields: ctx, id
-func (_m *QuerierMocked) GetPackageById(ctx context.Context, id uuid.UUID) (Package, error) {
-	ret := _m.Called(ctx, id)
+// CreatePackage provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) CreatePackage(ctx context.Context, arg CreatePackageParams) (Package, error) {
+	ret := _m.Called(ctx, arg)
 
 	var r0 Package
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (Package, error)); ok {
-		return rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, CreatePackageParams) (Package, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) Package); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, CreatePackageParams) Package); ok {
+		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Get(0).(Package)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, id)
+	if rf, ok := ret.Get(1).(func(context.Context, CreatePackageParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -153,23 +199,23 @@ func (_m *QuerierMocked) GetPackageById(ctx context.Context, id uuid.UUID) (Pack
 	return r0, r1
 }
 
-// GetPackageByTrackingCode provides a mock function with given fields: ctx, trackingCode
-func (_m *QuerierMocked) GetPackageByTrackingCode(ctx context.Context, trackingCode sql.NullString) (Package, error) {
-	ret := _m.Called(ctx, trackingCode)
+// CreatePackageStatusEvent provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) CreatePackageStatusEvent(ctx context.Context, arg CreatePackageStatusEventParams) (PackageStatusEvent, error) {
+	ret := _m.Called(ctx, arg)
 
-	var r0 Package
+	var r0 PackageStatusEvent
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (Package, error)); ok {
-		return rf(ctx, trackingCode.String)
+	if rf, ok := ret.Get(0).(func(context.Context, CreatePackageStatusEventParams) (PackageStatusEvent, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) Package); ok {
-		r0 = rf(ctx, trackingCode.String)
+	if rf, ok := ret.Get(0).(func(context.Context, CreatePackageStatusEventParams) PackageStatusEvent); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(Package)
+		r0 = ret.Get(0).(PackageStatusEvent)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, trackingCode.String)
+	if rf, ok := ret.Get(1).(func(context.Context, CreatePackageStatusEventParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -177,24 +223,24 @@ func (_m *QuerierMocked) GetPackageByTrackingCode(ctx context.Context, trackingC
 	return r0, r1
 }
 
-// GetQuotesForPackage provides a mock function with given fields: ctx, arg
-func (_m *QuerierMocked) GetQuotesForPackage(ctx context.Context, arg GetQuotesForPackageParams) ([]GetQuotesForPackageRow, error) {
+// CreatePackagesBatch provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) CreatePackagesBatch(ctx context.Context, arg CreatePackagesBatchParams) ([]Package, error) {
 	ret := _m.Called(ctx, arg)
 
-	var r0 []GetQuotesForPackageRow
+	var r0 []Package
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, GetQuotesForPackageParams) ([]GetQuotesForPackageRow, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, CreatePackagesBatchParams) ([]Package, error)); ok {
 		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, GetQuotesForPackageParams) []GetQuotesForPackageRow); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, CreatePackagesBatchParams) []Package); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]GetQuotesForPackageRow)
+			r0 = ret.Get(0).([]Package)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, GetQuotesForPackageParams) error); ok {
+	if rf, ok := ret.Get(1).(func(context.Context, CreatePackagesBatchParams) error); ok {
 		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
@@ -203,23 +249,23 @@ func (_m *QuerierMocked) GetQuotesForPackage(ctx context.Context, arg GetQuotesF
 	return r0, r1
 }
 
-// GetRegionByState provides a mock function with given fields: ctx, code
-func (_m *QuerierMocked) GetRegionByState(ctx context.Context, code string) (GetRegionByStateRow, error) {
-	ret := _m.Called(ctx, code)
+// CreateQuote provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) CreateQuote(ctx context.Context, arg CreateQuoteParams) (Quote, error) {
+	ret := _m.Called(ctx, arg)
 
-	var r0 GetRegionByStateRow
+	var r0 Quote
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (GetRegionByStateRow, error)); ok {
-		return rf(ctx, code)
+	if rf, ok := ret.Get(0).(func(context.Context, CreateQuoteParams) (Quote, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) GetRegionByStateRow); ok {
-		r0 = rf(ctx, code)
+	if rf, ok := ret.Get(0).(func(context.Context, CreateQuoteParams) Quote); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(GetRegionByStateRow)
+		r0 = ret.Get(0).(Quote)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, code)
+	if rf, ok := ret.Get(1).(func(context.Context, CreateQuoteParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -227,23 +273,23 @@ func (_m *QuerierMocked) GetRegionByState(ctx context.Context, code string) (Get
 	return r0, r1
 }
 
-// GetStateByCode provides a mock function with given fields: ctx, code
-func (_m *QuerierMocked) GetStateByCode(ctx context.Context, code string) (GetStateByCodeRow, error) {
-	ret := _m.Called(ctx, code)
+// CreateSeller provides a mock function with given fields: ctx, name
+func (_m *QuerierMocked) CreateSeller(ctx context.Context, name string) (Seller, error) {
+	ret := _m.Called(ctx, name)
 
-	var r0 GetStateByCodeRow
+	var r0 Seller
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (GetStateByCodeRow, error)); ok {
-		return rf(ctx, code)
+	if rf, ok := ret.Get(0).(func(context.Context, string) (Seller, error)); ok {
+		return rf(ctx, name)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) GetStateByCodeRow); ok {
-		r0 = rf(ctx, code)
+	if rf, ok := ret.Get(0).(func(context.Context, string) Seller); ok {
+		r0 = rf(ctx, name)
 	} else {
-		r0 = ret.Get(0).(GetStateByCodeRow)
+		r0 = ret.Get(0).(Seller)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, code)
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -251,39 +297,47 @@ func (_m *QuerierMocked) GetStateByCode(ctx context.Context, code string) (GetSt
 	return r0, r1
 }
 
-// HireCarrier provides a mock function with given fields: ctx, arg
-func (_m *QuerierMocked) HireCarrier(ctx context.Context, arg HireCarrierParams) error {
+// CreateWarehouse provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) CreateWarehouse(ctx context.Context, arg CreateWarehouseParams) (Warehouse, error) {
 	ret := _m.Called(ctx, arg)
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, HireCarrierParams) error); ok {
+	var r0 Warehouse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, CreateWarehouseParams) (Warehouse, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, CreateWarehouseParams) Warehouse); ok {
 		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Error(0)
+		r0 = ret.Get(0).(Warehouse)
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, CreateWarehouseParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
-// ListCarriers provides a mock function with given fields: ctx
-func (_m *QuerierMocked) ListCarriers(ctx context.Context) ([]Carrier, error) {
-	ret := _m.Called(ctx)
+// CreateWebhookDelivery provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) CreateWebhookDelivery(ctx context.Context, arg CreateWebhookDeliveryParams) (WebhookDelivery, error) {
+	ret := _m.Called(ctx, arg)
 
-	var r0 []Carrier
+	var r0 WebhookDelivery
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]Carrier, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, CreateWebhookDeliveryParams) (WebhookDelivery, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []Carrier); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, CreateWebhookDeliveryParams) WebhookDelivery); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]Carrier)
-		}
+		r0 = ret.Get(0).(WebhookDelivery)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, CreateWebhookDeliveryParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -291,25 +345,23 @@ func (_m *QuerierMocked) ListCarriers(ctx context.Context) ([]Carrier, error) {
 	return r0, r1
 }
 
-// ListPackages provides a mock function with given fields: ctx
-func (_m *QuerierMocked) ListPackages(ctx context.Context) ([]Package, error) {
-	ret := _m.Called(ctx)
+// CreateWebhookSubscription provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) CreateWebhookSubscription(ctx context.Context, arg CreateWebhookSubscriptionParams) (WebhookSubscription, error) {
+	ret := _m.Called(ctx, arg)
 
-	var r0 []Package
+	var r0 WebhookSubscription
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]Package, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, CreateWebhookSubscriptionParams) (WebhookSubscription, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []Package); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, CreateWebhookSubscriptionParams) WebhookSubscription); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]Package)
-		}
+		r0 = ret.Get(0).(WebhookSubscription)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, CreateWebhookSubscriptionParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -317,25 +369,23 @@ func (_m *QuerierMocked) ListPackages(ctx context.Context) ([]Package, error) {
 	return r0, r1
 }
 
-// ListRegions provides a mock function with given fields: ctx
-func (_m *QuerierMocked) ListRegions(ctx context.Context) ([]Region, error) {
-	ret := _m.Called(ctx)
+// DeleteCarrier provides a mock function with given fields: ctx, id
+func (_m *QuerierMocked) DeleteCarrier(ctx context.Context, id uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, id)
 
-	var r0 []Region
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]Region, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, id)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []Region); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, id)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]Region)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -343,21 +393,19 @@ func (_m *QuerierMocked) ListRegions(ctx context.Context) ([]Region, error) {
 	return r0, r1
 }
 
-// ListStates provides a mock function with given fields: ctx
-func (_m *QuerierMocked) ListStates(ctx context.Context) ([]ListStatesRow, error) {
+// DeleteExpiredIdempotencyKeys provides a mock function with given fields: ctx
+func (_m *QuerierMocked) DeleteExpiredIdempotencyKeys(ctx context.Context) (int64, error) {
 	ret := _m.Called(ctx)
 
-	var r0 []ListStatesRow
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]ListStatesRow, error)); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
 		return rf(ctx)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []ListStatesRow); ok {
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
 		r0 = rf(ctx)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]ListStatesRow)
-		}
+		r0 = ret.Get(0).(int64)
 	}
 
 	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
@@ -369,23 +417,23 @@ func (_m *QuerierMocked) ListStates(ctx context.Context) ([]ListStatesRow, error
 	return r0, r1
 }
 
-// TrackingCodeExists provides a mock function with given fields: ctx, trackingCode
-func (_m *QuerierMocked) TrackingCodeExists(ctx context.Context, trackingCode sql.NullString) (bool, error) {
-	ret := _m.Called(ctx, trackingCode)
+// DeletePackage provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) DeletePackage(ctx context.Context, arg DeletePackageParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
 
-	var r0 bool
+	var r0 int64
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) (bool, error)); ok {
-		return rf(ctx, trackingCode.String)
+	if rf, ok := ret.Get(0).(func(context.Context, DeletePackageParams) (int64, error)); ok {
+		return rf(ctx, arg)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
-		r0 = rf(ctx, trackingCode.String)
+	if rf, ok := ret.Get(0).(func(context.Context, DeletePackageParams) int64); ok {
+		r0 = rf(ctx, arg)
 	} else {
-		r0 = ret.Get(0).(bool)
+		r0 = ret.Get(0).(int64)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, trackingCode.String)
+	if rf, ok := ret.Get(1).(func(context.Context, DeletePackageParams) error); ok {
+		r1 = rf(ctx, arg)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -393,12 +441,36 @@ func (_m *QuerierMocked) TrackingCodeExists(ctx context.Context, trackingCode sq
 	return r0, r1
 }
 
-// UpdatePackageStatus provides a mock function with given fields: ctx, arg
-func (_m *QuerierMocked) UpdatePackageStatus(ctx context.Context, arg UpdatePackageStatusParams) error {
+// DeleteWebhookSubscription provides a mock function with given fields: ctx, id
+func (_m *QuerierMocked) DeleteWebhookSubscription(ctx context.Context, id uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EndCarrierRegion provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) EndCarrierRegion(ctx context.Context, arg EndCarrierRegionParams) error {
 	ret := _m.Called(ctx, arg)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, UpdatePackageStatusParams) error); ok {
+	if rf, ok := ret.Get(0).(func(context.Context, EndCarrierRegionParams) error); ok {
 		r0 = rf(ctx, arg)
 	} else {
 		r0 = ret.Error(0)
@@ -406,3 +478,1366 @@ func (_m *QuerierMocked) UpdatePackageStatus(ctx context.Context, arg UpdatePack
 
 	return r0
 }
+
+// GetActiveApiKeyByHash provides a mock function with given fields: ctx, keyHash
+func (_m *QuerierMocked) GetActiveApiKeyByHash(ctx context.Context, keyHash string) (ApiKey, error) {
+	ret := _m.Called(ctx, keyHash)
+
+	var r0 ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (ApiKey, error)); ok {
+		return rf(ctx, keyHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) ApiKey); ok {
+		r0 = rf(ctx, keyHash)
+	} else {
+		r0 = ret.Get(0).(ApiKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, keyHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCarrierApiTokenHash provides a mock function with given fields: ctx, carrierID
+func (_m *QuerierMocked) GetCarrierApiTokenHash(ctx context.Context, carrierID uuid.UUID) (string, error) {
+	ret := _m.Called(ctx, carrierID)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (string, error)); ok {
+		return rf(ctx, carrierID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) string); ok {
+		r0 = rf(ctx, carrierID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, carrierID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCarrierById provides a mock function with given fields: ctx, id
+func (_m *QuerierMocked) GetCarrierById(ctx context.Context, id uuid.UUID) (Carrier, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 Carrier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (Carrier, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) Carrier); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(Carrier)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCarrierEventMapping provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) GetCarrierEventMapping(ctx context.Context, arg GetCarrierEventMappingParams) (CarrierEventMapping, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 CarrierEventMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, GetCarrierEventMappingParams) (CarrierEventMapping, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, GetCarrierEventMappingParams) CarrierEventMapping); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(CarrierEventMapping)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, GetCarrierEventMappingParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCarrierRegionById provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) GetCarrierRegionById(ctx context.Context, arg GetCarrierRegionByIdParams) (CarrierRegion, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 CarrierRegion
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, GetCarrierRegionByIdParams) (CarrierRegion, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, GetCarrierRegionByIdParams) CarrierRegion); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(CarrierRegion)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, GetCarrierRegionByIdParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCarrierRegions provides a mock function with given fields: ctx, carrierID
+func (_m *QuerierMocked) GetCarrierRegions(ctx context.Context, carrierID uuid.UUID) ([]GetCarrierRegionsRow, error) {
+	ret := _m.Called(ctx, carrierID)
+
+	var r0 []GetCarrierRegionsRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]GetCarrierRegionsRow, error)); ok {
+		return rf(ctx, carrierID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []GetCarrierRegionsRow); ok {
+		r0 = rf(ctx, carrierID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]GetCarrierRegionsRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, carrierID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCarrierTrackingEvent provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) GetCarrierTrackingEvent(ctx context.Context, arg GetCarrierTrackingEventParams) (CarrierTrackingEvent, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 CarrierTrackingEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, GetCarrierTrackingEventParams) (CarrierTrackingEvent, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, GetCarrierTrackingEventParams) CarrierTrackingEvent); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(CarrierTrackingEvent)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, GetCarrierTrackingEventParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetCepRange provides a mock function with given fields: ctx, cep
+func (_m *QuerierMocked) GetCepRange(ctx context.Context, cep string) (CepRange, error) {
+	ret := _m.Called(ctx, cep)
+
+	var r0 CepRange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (CepRange, error)); ok {
+		return rf(ctx, cep)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) CepRange); ok {
+		r0 = rf(ctx, cep)
+	} else {
+		r0 = ret.Get(0).(CepRange)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, cep)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetIdempotencyKey provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) GetIdempotencyKey(ctx context.Context, arg GetIdempotencyKeyParams) (IdempotencyKey, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, GetIdempotencyKeyParams) (IdempotencyKey, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, GetIdempotencyKeyParams) IdempotencyKey); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(IdempotencyKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, GetIdempotencyKeyParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPackageById provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) GetPackageById(ctx context.Context, arg GetPackageByIdParams) (Package, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 Package
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, GetPackageByIdParams) (Package, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, GetPackageByIdParams) Package); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(Package)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, GetPackageByIdParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetPackageByTrackingCode provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) GetPackageByTrackingCode(ctx context.Context, arg GetPackageByTrackingCodeParams) (Package, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 Package
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, GetPackageByTrackingCodeParams) (Package, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, GetPackageByTrackingCodeParams) Package); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(Package)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, GetPackageByTrackingCodeParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuoteById provides a mock function with given fields: ctx, id
+func (_m *QuerierMocked) GetQuoteById(ctx context.Context, id uuid.UUID) (Quote, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 Quote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (Quote, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) Quote); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(Quote)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetQuotesForPackage provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) GetQuotesForPackage(ctx context.Context, arg GetQuotesForPackageParams) ([]GetQuotesForPackageRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []GetQuotesForPackageRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, GetQuotesForPackageParams) ([]GetQuotesForPackageRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, GetQuotesForPackageParams) []GetQuotesForPackageRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]GetQuotesForPackageRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, GetQuotesForPackageParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRegionByState provides a mock function with given fields: ctx, code
+func (_m *QuerierMocked) GetRegionByState(ctx context.Context, code string) (GetRegionByStateRow, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 GetRegionByStateRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (GetRegionByStateRow, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) GetRegionByStateRow); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(GetRegionByStateRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetSellerById provides a mock function with given fields: ctx, id
+func (_m *QuerierMocked) GetSellerById(ctx context.Context, id uuid.UUID) (Seller, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 Seller
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (Seller, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) Seller); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(Seller)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetStateByCode provides a mock function with given fields: ctx, code
+func (_m *QuerierMocked) GetStateByCode(ctx context.Context, code string) (GetStateByCodeRow, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 GetStateByCodeRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (GetStateByCodeRow, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) GetStateByCodeRow); ok {
+		r0 = rf(ctx, code)
+	} else {
+		r0 = ret.Get(0).(GetStateByCodeRow)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWarehouseById provides a mock function with given fields: ctx, id
+func (_m *QuerierMocked) GetWarehouseById(ctx context.Context, id uuid.UUID) (Warehouse, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 Warehouse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (Warehouse, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) Warehouse); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(Warehouse)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetWebhookDeliveryById provides a mock function with given fields: ctx, id
+func (_m *QuerierMocked) GetWebhookDeliveryById(ctx context.Context, id uuid.UUID) (WebhookDelivery, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (WebhookDelivery, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) WebhookDelivery); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(WebhookDelivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// HireCarrier provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) HireCarrier(ctx context.Context, arg HireCarrierParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, HireCarrierParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, HireCarrierParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, HireCarrierParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportCarrierRates provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) ImportCarrierRates(ctx context.Context, arg ImportCarrierRatesParams) ([]ImportCarrierRatesRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []ImportCarrierRatesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ImportCarrierRatesParams) ([]ImportCarrierRatesRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ImportCarrierRatesParams) []ImportCarrierRatesRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ImportCarrierRatesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ImportCarrierRatesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListApiKeys provides a mock function with given fields: ctx
+func (_m *QuerierMocked) ListApiKeys(ctx context.Context) ([]ApiKey, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]ApiKey, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []ApiKey); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ApiKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCarrierCepRules provides a mock function with given fields: ctx, cep
+func (_m *QuerierMocked) ListCarrierCepRules(ctx context.Context, cep string) ([]CarrierCepRule, error) {
+	ret := _m.Called(ctx, cep)
+
+	var r0 []CarrierCepRule
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]CarrierCepRule, error)); ok {
+		return rf(ctx, cep)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []CarrierCepRule); ok {
+		r0 = rf(ctx, cep)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]CarrierCepRule)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, cep)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCarrierEventMappings provides a mock function with given fields: ctx, carrierID
+func (_m *QuerierMocked) ListCarrierEventMappings(ctx context.Context, carrierID uuid.UUID) ([]CarrierEventMapping, error) {
+	ret := _m.Called(ctx, carrierID)
+
+	var r0 []CarrierEventMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]CarrierEventMapping, error)); ok {
+		return rf(ctx, carrierID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []CarrierEventMapping); ok {
+		r0 = rf(ctx, carrierID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]CarrierEventMapping)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, carrierID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCarrierRateTiersByCarrier provides a mock function with given fields: ctx, carrierID
+func (_m *QuerierMocked) ListCarrierRateTiersByCarrier(ctx context.Context, carrierID uuid.UUID) ([]CarrierRateTier, error) {
+	ret := _m.Called(ctx, carrierID)
+
+	var r0 []CarrierRateTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]CarrierRateTier, error)); ok {
+		return rf(ctx, carrierID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []CarrierRateTier); ok {
+		r0 = rf(ctx, carrierID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]CarrierRateTier)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, carrierID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCarrierRateTiersByState provides a mock function with given fields: ctx, stateCode
+func (_m *QuerierMocked) ListCarrierRateTiersByState(ctx context.Context, stateCode string) ([]CarrierRateTier, error) {
+	ret := _m.Called(ctx, stateCode)
+
+	var r0 []CarrierRateTier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]CarrierRateTier, error)); ok {
+		return rf(ctx, stateCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []CarrierRateTier); ok {
+		r0 = rf(ctx, stateCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]CarrierRateTier)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, stateCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCarrierRates provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) ListCarrierRates(ctx context.Context, arg ListCarrierRatesParams) ([]ListCarrierRatesRow, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []ListCarrierRatesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ListCarrierRatesParams) ([]ListCarrierRatesRow, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ListCarrierRatesParams) []ListCarrierRatesRow); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ListCarrierRatesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ListCarrierRatesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCarrierRestrictedCategories provides a mock function with given fields: ctx
+func (_m *QuerierMocked) ListCarrierRestrictedCategories(ctx context.Context) ([]CarrierRestrictedCategory, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []CarrierRestrictedCategory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]CarrierRestrictedCategory, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []CarrierRestrictedCategory); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]CarrierRestrictedCategory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCarrierTrackingEventsByPackage provides a mock function with given fields: ctx, packageID
+func (_m *QuerierMocked) ListCarrierTrackingEventsByPackage(ctx context.Context, packageID uuid.UUID) ([]CarrierTrackingEvent, error) {
+	ret := _m.Called(ctx, packageID)
+
+	var r0 []CarrierTrackingEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]CarrierTrackingEvent, error)); ok {
+		return rf(ctx, packageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []CarrierTrackingEvent); ok {
+		r0 = rf(ctx, packageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]CarrierTrackingEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, packageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListCarriers provides a mock function with given fields: ctx
+func (_m *QuerierMocked) ListCarriers(ctx context.Context) ([]Carrier, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []Carrier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]Carrier, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []Carrier); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Carrier)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListHolidaysForState provides a mock function with given fields: ctx, stateCode
+func (_m *QuerierMocked) ListHolidaysForState(ctx context.Context, stateCode sql.NullString) ([]Holiday, error) {
+	ret := _m.Called(ctx, stateCode)
+
+	var r0 []Holiday
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullString) ([]Holiday, error)); ok {
+		return rf(ctx, stateCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullString) []Holiday); ok {
+		r0 = rf(ctx, stateCode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Holiday)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, sql.NullString) error); ok {
+		r1 = rf(ctx, stateCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPackageStatusEvents provides a mock function with given fields: ctx, packageID
+func (_m *QuerierMocked) ListPackageStatusEvents(ctx context.Context, packageID uuid.UUID) ([]PackageStatusEvent, error) {
+	ret := _m.Called(ctx, packageID)
+
+	var r0 []PackageStatusEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]PackageStatusEvent, error)); ok {
+		return rf(ctx, packageID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []PackageStatusEvent); ok {
+		r0 = rf(ctx, packageID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]PackageStatusEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, packageID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListPackagesPage provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) ListPackagesPage(ctx context.Context, arg ListPackagesPageParams) ([]Package, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []Package
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ListPackagesPageParams) ([]Package, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ListPackagesPageParams) []Package); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Package)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ListPackagesPageParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListRegions provides a mock function with given fields: ctx
+func (_m *QuerierMocked) ListRegions(ctx context.Context) ([]Region, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []Region
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]Region, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []Region); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Region)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSellerCarrierRates provides a mock function with given fields: ctx, sellerID
+func (_m *QuerierMocked) ListSellerCarrierRates(ctx context.Context, sellerID uuid.UUID) ([]SellerCarrierRate, error) {
+	ret := _m.Called(ctx, sellerID)
+
+	var r0 []SellerCarrierRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]SellerCarrierRate, error)); ok {
+		return rf(ctx, sellerID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []SellerCarrierRate); ok {
+		r0 = rf(ctx, sellerID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]SellerCarrierRate)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, sellerID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListSellers provides a mock function with given fields: ctx
+func (_m *QuerierMocked) ListSellers(ctx context.Context) ([]Seller, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []Seller
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]Seller, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []Seller); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Seller)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListStates provides a mock function with given fields: ctx
+func (_m *QuerierMocked) ListStates(ctx context.Context) ([]ListStatesRow, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []ListStatesRow
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]ListStatesRow, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []ListStatesRow); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]ListStatesRow)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWarehouses provides a mock function with given fields: ctx
+func (_m *QuerierMocked) ListWarehouses(ctx context.Context) ([]Warehouse, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []Warehouse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]Warehouse, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []Warehouse); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]Warehouse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWebhookDeliveries provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) ListWebhookDeliveries(ctx context.Context, arg ListWebhookDeliveriesParams) ([]WebhookDelivery, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 []WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ListWebhookDeliveriesParams) ([]WebhookDelivery, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ListWebhookDeliveriesParams) []WebhookDelivery); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ListWebhookDeliveriesParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWebhookSubscriptions provides a mock function with given fields: ctx
+func (_m *QuerierMocked) ListWebhookSubscriptions(ctx context.Context) ([]WebhookSubscription, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]WebhookSubscription, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []WebhookSubscription); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListWebhookSubscriptionsForEvent provides a mock function with given fields: ctx, eventType
+func (_m *QuerierMocked) ListWebhookSubscriptionsForEvent(ctx context.Context, eventType string) ([]WebhookSubscription, error) {
+	ret := _m.Called(ctx, eventType)
+
+	var r0 []WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]WebhookSubscription, error)); ok {
+		return rf(ctx, eventType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []WebhookSubscription); ok {
+		r0 = rf(ctx, eventType)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, eventType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkQuoteUsed provides a mock function with given fields: ctx, id
+func (_m *QuerierMocked) MarkQuoteUsed(ctx context.Context, id uuid.UUID) (int64, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (int64, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) int64); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NextTrackingSerial provides a mock function with given fields: ctx, prefix
+func (_m *QuerierMocked) NextTrackingSerial(ctx context.Context, prefix string) (int64, error) {
+	ret := _m.Called(ctx, prefix)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, prefix)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, prefix)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, prefix)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordWebhookDeliveryAttempt provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) RecordWebhookDeliveryAttempt(ctx context.Context, arg RecordWebhookDeliveryAttemptParams) error {
+	ret := _m.Called(ctx, arg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, RecordWebhookDeliveryAttemptParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseIdempotencyKey provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) ReleaseIdempotencyKey(ctx context.Context, arg ReleaseIdempotencyKeyParams) error {
+	ret := _m.Called(ctx, arg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ReleaseIdempotencyKeyParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplayWebhookDelivery provides a mock function with given fields: ctx, id
+func (_m *QuerierMocked) ReplayWebhookDelivery(ctx context.Context, id uuid.UUID) (WebhookDelivery, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (WebhookDelivery, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) WebhookDelivery); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(WebhookDelivery)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReserveIdempotencyKey provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) ReserveIdempotencyKey(ctx context.Context, arg ReserveIdempotencyKeyParams) (IdempotencyKey, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, ReserveIdempotencyKeyParams) (IdempotencyKey, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, ReserveIdempotencyKeyParams) IdempotencyKey); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(IdempotencyKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, ReserveIdempotencyKeyParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestorePackage provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) RestorePackage(ctx context.Context, arg RestorePackageParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, RestorePackageParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, RestorePackageParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, RestorePackageParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeApiKey provides a mock function with given fields: ctx, id
+func (_m *QuerierMocked) RevokeApiKey(ctx context.Context, id uuid.UUID) (ApiKey, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 ApiKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (ApiKey, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ApiKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Get(0).(ApiKey)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// TouchApiKey provides a mock function with given fields: ctx, id
+func (_m *QuerierMocked) TouchApiKey(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TrackingCodeExists provides a mock function with given fields: ctx, trackingCode
+func (_m *QuerierMocked) TrackingCodeExists(ctx context.Context, trackingCode sql.NullString) (bool, error) {
+	ret := _m.Called(ctx, trackingCode)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullString) (bool, error)); ok {
+		return rf(ctx, trackingCode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, sql.NullString) bool); ok {
+		r0 = rf(ctx, trackingCode)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, sql.NullString) error); ok {
+		r1 = rf(ctx, trackingCode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateCarrier provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) UpdateCarrier(ctx context.Context, arg UpdateCarrierParams) (Carrier, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 Carrier
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, UpdateCarrierParams) (Carrier, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, UpdateCarrierParams) Carrier); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(Carrier)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, UpdateCarrierParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePackageStatus provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) UpdatePackageStatus(ctx context.Context, arg UpdatePackageStatusParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, UpdatePackageStatusParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, UpdatePackageStatusParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, UpdatePackageStatusParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdatePackageStatusWithTracking provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) UpdatePackageStatusWithTracking(ctx context.Context, arg UpdatePackageStatusWithTrackingParams) (int64, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, UpdatePackageStatusWithTrackingParams) (int64, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, UpdatePackageStatusWithTrackingParams) int64); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, UpdatePackageStatusWithTrackingParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertCarrierApiToken provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) UpsertCarrierApiToken(ctx context.Context, arg UpsertCarrierApiTokenParams) error {
+	ret := _m.Called(ctx, arg)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, UpsertCarrierApiTokenParams) error); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertCarrierEventMapping provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) UpsertCarrierEventMapping(ctx context.Context, arg UpsertCarrierEventMappingParams) (CarrierEventMapping, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 CarrierEventMapping
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, UpsertCarrierEventMappingParams) (CarrierEventMapping, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, UpsertCarrierEventMappingParams) CarrierEventMapping); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(CarrierEventMapping)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, UpsertCarrierEventMappingParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpsertSellerCarrierRate provides a mock function with given fields: ctx, arg
+func (_m *QuerierMocked) UpsertSellerCarrierRate(ctx context.Context, arg UpsertSellerCarrierRateParams) (SellerCarrierRate, error) {
+	ret := _m.Called(ctx, arg)
+
+	var r0 SellerCarrierRate
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, UpsertSellerCarrierRateParams) (SellerCarrierRate, error)); ok {
+		return rf(ctx, arg)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, UpsertSellerCarrierRateParams) SellerCarrierRate); ok {
+		r0 = rf(ctx, arg)
+	} else {
+		r0 = ret.Get(0).(SellerCarrierRate)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, UpsertSellerCarrierRateParams) error); ok {
+		r1 = rf(ctx, arg)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewQuerierMocked creates a new instance of QuerierMocked. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewQuerierMocked(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *QuerierMocked {
+	mock := &QuerierMocked{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,91 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: quotes.sql
+
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const createQuote = `-- name: CreateQuote :one
+INSERT INTO quotes (package_id, carrier_id, price, estimated_delivery_days, billable_weight_kg, expires_at)
+VALUES ($1, $2, $3, $4, $5,
+        NOW() + make_interval(secs => $6::float8))
+RETURNING id, package_id, carrier_id, price, estimated_delivery_days, billable_weight_kg, expires_at, used_at, created_at
+`
+
+type CreateQuoteParams struct {
+	PackageID             uuid.UUID
+	CarrierID             uuid.UUID
+	Price                 string
+	EstimatedDeliveryDays int32
+	BillableWeightKg      float64
+	TtlSeconds            float64
+}
+
+func (q *Queries) CreateQuote(ctx context.Context, arg CreateQuoteParams) (Quote, error) {
+	row := q.db.QueryRowContext(ctx, createQuote,
+		arg.PackageID,
+		arg.CarrierID,
+		arg.Price,
+		arg.EstimatedDeliveryDays,
+		arg.BillableWeightKg,
+		arg.TtlSeconds,
+	)
+	var i Quote
+	err := row.Scan(
+		&i.ID,
+		&i.PackageID,
+		&i.CarrierID,
+		&i.Price,
+		&i.EstimatedDeliveryDays,
+		&i.BillableWeightKg,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getQuoteById = `-- name: GetQuoteById :one
+SELECT id, package_id, carrier_id, price, estimated_delivery_days, billable_weight_kg, expires_at, used_at, created_at
+FROM quotes
+WHERE id = $1
+`
+
+func (q *Queries) GetQuoteById(ctx context.Context, id uuid.UUID) (Quote, error) {
+	row := q.db.QueryRowContext(ctx, getQuoteById, id)
+	var i Quote
+	err := row.Scan(
+		&i.ID,
+		&i.PackageID,
+		&i.CarrierID,
+		&i.Price,
+		&i.EstimatedDeliveryDays,
+		&i.BillableWeightKg,
+		&i.ExpiresAt,
+		&i.UsedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const markQuoteUsed = `-- name: MarkQuoteUsed :execrows
+UPDATE quotes
+SET used_at = NOW()
+WHERE id = $1
+  AND used_at IS NULL
+  AND expires_at > NOW()
+`
+
+func (q *Queries) MarkQuoteUsed(ctx context.Context, id uuid.UUID) (int64, error) {
+	result, err := q.db.ExecContext(ctx, markQuoteUsed, id)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,141 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: sellers.sql
+
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/google/uuid"
+)
+
+const createSeller = `-- name: CreateSeller :one
+INSERT INTO sellers (name)
+VALUES ($1)
+RETURNING id, name, created_at
+`
+
+func (q *Queries) CreateSeller(ctx context.Context, name string) (Seller, error) {
+	row := q.db.QueryRowContext(ctx, createSeller, name)
+	var i Seller
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const getSellerById = `-- name: GetSellerById :one
+SELECT id, name, created_at
+FROM sellers
+WHERE id = $1
+`
+
+func (q *Queries) GetSellerById(ctx context.Context, id uuid.UUID) (Seller, error) {
+	row := q.db.QueryRowContext(ctx, getSellerById, id)
+	var i Seller
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const listSellerCarrierRates = `-- name: ListSellerCarrierRates :many
+SELECT seller_id, carrier_id, price_per_kg, markup_percent, created_at, updated_at
+FROM seller_carrier_rates
+WHERE seller_id = $1
+ORDER BY carrier_id
+`
+
+func (q *Queries) ListSellerCarrierRates(ctx context.Context, sellerID uuid.UUID) ([]SellerCarrierRate, error) {
+	rows, err := q.db.QueryContext(ctx, listSellerCarrierRates, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []SellerCarrierRate{}
+	for rows.Next() {
+		var i SellerCarrierRate
+		if err := rows.Scan(
+			&i.SellerID,
+			&i.CarrierID,
+			&i.PricePerKg,
+			&i.MarkupPercent,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSellers = `-- name: ListSellers :many
+SELECT id, name, created_at
+FROM sellers
+ORDER BY name
+`
+
+func (q *Queries) ListSellers(ctx context.Context) ([]Seller, error) {
+	rows, err := q.db.QueryContext(ctx, listSellers)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Seller{}
+	for rows.Next() {
+		var i Seller
+		if err := rows.Scan(&i.ID, &i.Name, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertSellerCarrierRate = `-- name: UpsertSellerCarrierRate :one
+INSERT INTO seller_carrier_rates (seller_id, carrier_id, price_per_kg, markup_percent)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (seller_id, carrier_id) DO UPDATE
+SET price_per_kg = EXCLUDED.price_per_kg,
+    markup_percent = EXCLUDED.markup_percent,
+    updated_at = NOW()
+RETURNING seller_id, carrier_id, price_per_kg, markup_percent, created_at, updated_at
+`
+
+type UpsertSellerCarrierRateParams struct {
+	SellerID      uuid.UUID
+	CarrierID     uuid.UUID
+	PricePerKg    sql.NullString
+	MarkupPercent string
+}
+
+func (q *Queries) UpsertSellerCarrierRate(ctx context.Context, arg UpsertSellerCarrierRateParams) (SellerCarrierRate, error) {
+	row := q.db.QueryRowContext(ctx, upsertSellerCarrierRate,
+		arg.SellerID,
+		arg.CarrierID,
+		arg.PricePerKg,
+		arg.MarkupPercent,
+	)
+	var i SellerCarrierRate
+	err := row.Scan(
+		&i.SellerID,
+		&i.CarrierID,
+		&i.PricePerKg,
+		&i.MarkupPercent,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+)
+
+const maxTxAttempts = 3
+
+type Store interface {
+	Querier
+	// WithTx retries serialization failures, so fn may run more than once.
+	WithTx(ctx context.Context, fn func(q Querier) error) error
+}
+
+type SQLStore struct {
+	*Queries
+	db *sql.DB
+}
+
+func NewStore(db *sql.DB) *SQLStore {
+	return &SQLStore{
+		Queries: New(db),
+		db:      db,
+	}
+}
+
+func (s *SQLStore) WithTx(ctx context.Context, fn func(q Querier) error) error {
+	var err error
+	for attempt := 1; attempt <= maxTxAttempts; attempt++ {
+		var conflict bool
+		conflict, err = s.execTx(ctx, fn)
+		if !conflict {
+			return err
+		}
+	}
+	return err
+}
+
+func (s *SQLStore) execTx(ctx context.Context, fn func(q Querier) error) (conflict bool, err error) {
+	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return false, err
+	}
+
+	conn := &txConn{Tx: tx}
+	if err := fn(New(conn)); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			err = errors.Join(err, rbErr)
+		}
+		return conn.conflict || isSerializationFailure(err), err
+	}
+
+	err = tx.Commit()
+	return isSerializationFailure(err), err
+}
+
+// txConn records serialization failures, since services wrap database errors with %v.
+type txConn struct {
+	*sql.Tx
+	conflict bool
+}
+
+func (c *txConn) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	result, err := c.Tx.ExecContext(ctx, query, args...)
+	c.record(err)
+	return result, err
+}
+
+func (c *txConn) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	rows, err := c.Tx.QueryContext(ctx, query, args...)
+	c.record(err)
+	return rows, err
+}
+
+func (c *txConn) QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row {
+	row := c.Tx.QueryRowContext(ctx, query, args...)
+	c.record(row.Err())
+	return row
+}
+
+func (c *txConn) record(err error) {
+	if isSerializationFailure(err) {
+		c.conflict = true
+	}
+}
+
+func isSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && (pqErr.Code == "40001" || pqErr.Code == "40P01")
+}
@@ -0,0 +1,8 @@
+package repository
+
+import "context"
+
+// WithTx runs fn directly against the mock, without a transaction.
+func (_m *QuerierMocked) WithTx(ctx context.Context, fn func(q Querier) error) error {
+	return fn(_m)
+}
@@ -0,0 +1,21 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: tracking.sql
+
+package repository
+
+import (
+	"context"
+)
+
+const nextTrackingSerial = `-- name: NextTrackingSerial :one
+SELECT next_tracking_serial($1::TEXT)::BIGINT AS serial
+`
+
+func (q *Queries) NextTrackingSerial(ctx context.Context, prefix string) (int64, error) {
+	row := q.db.QueryRowContext(ctx, nextTrackingSerial, prefix)
+	var serial int64
+	err := row.Scan(&serial)
+	return serial, err
+}
@@ -0,0 +1,87 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.25.0
+// source: warehouses.sql
+
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const createWarehouse = `-- name: CreateWarehouse :one
+INSERT INTO warehouses (name, state_code)
+VALUES ($1, $2)
+RETURNING id, name, state_code, created_at
+`
+
+type CreateWarehouseParams struct {
+	Name      string
+	StateCode string
+}
+
+func (q *Queries) CreateWarehouse(ctx context.Context, arg CreateWarehouseParams) (Warehouse, error) {
+	row := q.db.QueryRowContext(ctx, createWarehouse, arg.Name, arg.StateCode)
+	var i Warehouse
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.StateCode,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getWarehouseById = `-- name: GetWarehouseById :one
+SELECT id, name, state_code, created_at
+FROM warehouses
+WHERE id = $1
+`
+
+func (q *Queries) GetWarehouseById(ctx context.Context, id uuid.UUID) (Warehouse, error) {
+	row := q.db.QueryRowContext(ctx, getWarehouseById, id)
+	var i Warehouse
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.StateCode,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const listWarehouses = `-- name: ListWarehouses :many
+SELECT id, name, state_code, created_at
+FROM warehouses
+ORDER BY name
+`
+
+func (q *Queries) ListWarehouses(ctx context.Context) ([]Warehouse, error) {
+	rows, err := q.db.QueryContext(ctx, listWarehouses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []Warehouse{}
+	for rows.Next() {
+		var i Warehouse
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.StateCode,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
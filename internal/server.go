@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/gin-contrib/cors"
@@ -9,16 +10,18 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 	"github/moura95/olist-shipping-api/config"
 	"github/moura95/olist-shipping-api/docs"
+	"github/moura95/olist-shipping-api/internal/auth"
 	"github/moura95/olist-shipping-api/internal/handler"
 	"github/moura95/olist-shipping-api/internal/middleware"
 	"github/moura95/olist-shipping-api/internal/repository"
 	"github/moura95/olist-shipping-api/internal/service"
+	"github/moura95/olist-shipping-api/internal/webhook"
 
 	"go.uber.org/zap"
 )
 
 type Server struct {
-	store  *repository.Querier
+	store  repository.Store
 	router *gin.Engine
 	config *config.Config
 	logger *zap.SugaredLogger
@@ -38,10 +41,15 @@ type Server struct {
 
 // @host      localhost:8080
 // @BasePath  /api/v1
-func NewServer(cfg config.Config, store repository.Querier, log *zap.SugaredLogger) *Server {
+
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 "Bearer " seguido de uma API key (olk_...) ou de um JWT
+func NewServer(cfg config.Config, store repository.Store, log *zap.SugaredLogger) *Server {
 
 	server := &Server{
-		store:  &store,
+		store:  store,
 		config: &cfg,
 		logger: log,
 	}
@@ -65,46 +73,116 @@ func NewServer(cfg config.Config, store repository.Querier, log *zap.SugaredLogg
 	corsConfig.AddAllowHeaders("redirect")
 	corsConfig.AddAllowHeaders("Authorization")
 	corsConfig.AddAllowHeaders("Content-Type")
+	corsConfig.AddAllowHeaders("X-API-Key")
+	corsConfig.AddAllowHeaders("Idempotency-Key")
+	corsConfig.AddAllowHeaders("If-Match")
+	corsConfig.AddAllowHeaders("If-None-Match")
+	corsConfig.AddExposeHeaders("ETag")
 	router.Use(cors.New(corsConfig))
 
-	createRoutesV1(&store, server.config, router, log)
+	createRoutesV1(store, server.config, router, log)
 
 	server.router = router
 	return server
 }
 
-func createRoutesV1(store *repository.Querier, cfg *config.Config, router *gin.Engine, log *zap.SugaredLogger) {
-	packageService := service.NewPackageService(*store, *cfg, log)
+func createRoutesV1(store repository.Store, cfg *config.Config, router *gin.Engine, log *zap.SugaredLogger) {
+	webhookService := service.NewWebhookService(store, log)
+	packageService := service.NewPackageService(store, *cfg, log, service.WithEventPublisher(webhookService))
+	authService := service.NewAuthService(store, *cfg, log)
+	idempotencyService := service.NewIdempotencyService(store, *cfg, log)
 
 	packageHandler := handler.NewPackageHandler(packageService, cfg, log)
 	quoteHandler := handler.NewQuoteHandler(packageService, cfg, log)
 	carrierHandler := handler.NewCarrierHandler(packageService, cfg, log)
 	stateHandler := handler.NewStateHandler(packageService, cfg, log)
+	warehouseHandler := handler.NewWarehouseHandler(packageService, cfg, log)
+	webhookHandler := handler.NewWebhookHandler(webhookService, cfg, log)
+	authHandler := handler.NewAuthHandler(authService, cfg, log)
+	sellerHandler := handler.NewSellerHandler(packageService, cfg, log)
+
+	authenticated := middleware.AuthMiddleware(authService)
+	// Everyone reads, sellers ship, operators run operations and only admins reach /admin
+	canRead := middleware.RoleMiddleware(auth.RoleAdmin, auth.RoleOperator, auth.RoleSeller, auth.RoleReadonly)
+	canShip := middleware.RoleMiddleware(auth.RoleAdmin, auth.RoleOperator, auth.RoleSeller)
+	canOperate := middleware.RoleMiddleware(auth.RoleAdmin, auth.RoleOperator)
+	adminOnly := middleware.RoleMiddleware(auth.RoleAdmin)
+	idempotent := middleware.IdempotencyMiddleware(idempotencyService)
 
 	apiV1 := router.Group("/api/v1")
 	{
-		packages := apiV1.Group("/packages")
+		// Public routes; carrier events authenticate with their own token
+		apiV1.GET("/tracking/:tracking_code", packageHandler.Tracking)
+		apiV1.POST("/carriers/:id/events", carrierHandler.Events)
+
+		apiV1.POST("/auth/token", authenticated, authHandler.Token)
+
+		packages := apiV1.Group("/packages", authenticated)
+		{
+			packages.GET("", canRead, packageHandler.List)
+			packages.GET("/:id", canRead, packageHandler.GetByID)
+			packages.POST("", canShip, idempotent, packageHandler.Create)
+			packages.POST("/import", canShip, packageHandler.Import)
+			packages.PATCH("/:id/status", canOperate, packageHandler.UpdateStatus)
+			packages.POST("/:id/quotes", canShip, packageHandler.CreateQuotes)
+			packages.POST("/:id/hire", canShip, idempotent, packageHandler.HireCarrier)
+			packages.GET("/:id/history", canRead, packageHandler.History)
+			packages.GET("/:id/label", canRead, packageHandler.Label)
+			packages.DELETE("/:id", canOperate, packageHandler.Delete)
+			packages.POST("/:id/restore", canOperate, packageHandler.Restore)
+			packages.GET("/tracking/:tracking_code", canRead, packageHandler.GetByTrackingCode)
+		}
+
+		apiV1.GET("/quotes", authenticated, canRead, quoteHandler.GetQuotes)
+
+		carriers := apiV1.Group("/carriers", authenticated)
 		{
-			packages.GET("", packageHandler.List)
-			packages.GET("/:id", packageHandler.GetByID)
-			packages.POST("", packageHandler.Create)
-			packages.PATCH("/:id/status", packageHandler.UpdateStatus)
-			packages.POST("/:id/hire", packageHandler.HireCarrier)
-			packages.DELETE("/:id", packageHandler.Delete)
+			carriers.GET("", canRead, carrierHandler.List)
+			carriers.GET("/:id", canRead, carrierHandler.Get)
+			carriers.POST("", canOperate, carrierHandler.Create)
+			carriers.PUT("/:id", canOperate, carrierHandler.Update)
+			carriers.DELETE("/:id", canOperate, carrierHandler.Delete)
+			carriers.POST("/:id/coverage", canOperate, carrierHandler.AddCoverage)
+			carriers.PUT("/:id/coverage/:coverage_id", canOperate, carrierHandler.UpdateCoverage)
+			carriers.DELETE("/:id/coverage/:coverage_id", canOperate, carrierHandler.DeleteCoverage)
+			carriers.GET("/:id/rates", canRead, carrierHandler.Rates)
+			carriers.POST("/:id/rates/import", canOperate, carrierHandler.ImportRates)
+			carriers.GET("/:id/event-mappings", canRead, carrierHandler.EventMappings)
 		}
 
-		apiV1.GET("/packages/tracking/:tracking_code", packageHandler.GetByTrackingCode)
+		states := apiV1.Group("/states", authenticated)
+		{
+			states.GET("", canRead, stateHandler.List)
+		}
 
-		apiV1.GET("/quotes", quoteHandler.GetQuotes)
+		apiV1.GET("/regions", authenticated, canRead, stateHandler.Regions)
 
-		carriers := apiV1.Group("/carriers")
+		warehouses := apiV1.Group("/warehouses", authenticated)
 		{
-			carriers.GET("", carrierHandler.List)
+			warehouses.GET("", canRead, warehouseHandler.List)
+			warehouses.POST("", canOperate, warehouseHandler.Create)
 		}
 
-		states := apiV1.Group("/states")
+		webhooks := apiV1.Group("/webhooks", authenticated, canOperate)
 		{
-			states.GET("", stateHandler.List)
+			webhooks.GET("", webhookHandler.List)
+			webhooks.POST("", webhookHandler.Create)
+			webhooks.DELETE("/:id", webhookHandler.Delete)
+			webhooks.GET("/:id/deliveries", webhookHandler.Deliveries)
+		}
+
+		admin := apiV1.Group("/admin", authenticated, adminOnly)
+		{
+			admin.GET("/api-keys", authHandler.ListAPIKeys)
+			admin.POST("/api-keys", authHandler.CreateAPIKey)
+			admin.DELETE("/api-keys/:id", authHandler.RevokeAPIKey)
+			admin.GET("/sellers", sellerHandler.List)
+			admin.POST("/sellers", sellerHandler.Create)
+			admin.GET("/sellers/:id/rates", sellerHandler.Rates)
+			admin.PUT("/sellers/:id/rates/:carrier_id", sellerHandler.SaveRate)
+			admin.POST("/webhooks/deliveries/:id/replay", webhookHandler.Replay)
+			admin.POST("/carriers/:id/token", carrierHandler.RotateToken)
+			admin.PUT("/carriers/:id/event-mappings/:code", carrierHandler.SaveEventMapping)
 		}
 	}
 }
@@ -113,8 +191,14 @@ func (s *Server) Start(address string) error {
 	return s.router.Run(address)
 }
 
-func RunGinServer(cfg config.Config, store repository.Querier, log *zap.SugaredLogger) {
+func RunGinServer(cfg config.Config, store repository.Store, log *zap.SugaredLogger) {
 	server := NewServer(cfg, store, log)
 
+	dispatcher := webhook.NewDispatcher(store, cfg, log)
+	go dispatcher.Run(context.Background())
+
+	idempotencyService := service.NewIdempotencyService(store, cfg, log)
+	go idempotencyService.RunCleanup(context.Background())
+
 	_ = server.Start(cfg.HTTPServerAddress)
 }
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+
+	"github/moura95/olist-shipping-api/internal/repository"
+	"github/moura95/olist-shipping-api/pkg/label"
+	customValidator "github/moura95/olist-shipping-api/pkg/validator"
+)
+
+type Address struct {
+	Name         string
+	Document     string
+	Phone        string
+	Street       string
+	Number       string
+	Complement   string
+	Neighborhood string
+	City         string
+	State        string
+	CEP          string
+}
+
+// recipientDestination requires an explicit destination to match the recipient address.
+func recipientDestination(input CreatePackageInput) (string, string, error) {
+	recipient := input.Recipient
+	cep := customValidator.NormalizeCEP(recipient.CEP)
+
+	if input.DestinationState != "" && input.DestinationState != recipient.State {
+		return "", "", fmt.Errorf("%w: destinatário em %s, destino em %s", ErrRecipientMismatch, recipient.State, input.DestinationState)
+	}
+	if input.DestinationCEP != "" && customValidator.NormalizeCEP(input.DestinationCEP) != cep {
+		return "", "", fmt.Errorf("%w: CEP do destinatário %s, CEP de destino %s", ErrRecipientMismatch, recipient.CEP, input.DestinationCEP)
+	}
+
+	return recipient.State, cep, nil
+}
+
+// setRecipient uses the given city only when the CEP range did not set one.
+func setRecipient(arg *repository.CreatePackageParams, recipient Address) {
+	arg.RecipientName = nullString(recipient.Name)
+	arg.RecipientDocument = nullString(customValidator.NormalizeDocument(recipient.Document))
+	arg.RecipientPhone = nullString(customValidator.NormalizePhone(recipient.Phone))
+	arg.RecipientStreet = nullString(recipient.Street)
+	arg.RecipientNumber = nullString(recipient.Number)
+	arg.RecipientComplement = nullString(recipient.Complement)
+	arg.RecipientNeighborhood = nullString(recipient.Neighborhood)
+	if !arg.DestinationCity.Valid {
+		arg.DestinationCity = nullString(recipient.City)
+	}
+}
+
+func setSender(arg *repository.CreatePackageParams, sender Address) {
+	arg.SenderName = nullString(sender.Name)
+	arg.SenderDocument = nullString(customValidator.NormalizeDocument(sender.Document))
+	arg.SenderPhone = nullString(customValidator.NormalizePhone(sender.Phone))
+	arg.SenderStreet = nullString(sender.Street)
+	arg.SenderNumber = nullString(sender.Number)
+	arg.SenderComplement = nullString(sender.Complement)
+	arg.SenderNeighborhood = nullString(sender.Neighborhood)
+	arg.SenderCity = nullString(sender.City)
+	arg.SenderState = nullString(sender.State)
+	arg.SenderCep = nullString(customValidator.NormalizeCEP(sender.CEP))
+}
+
+func packageRecipient(pkg repository.Package) *Address {
+	if !pkg.RecipientName.Valid {
+		return nil
+	}
+	return &Address{
+		Name:         pkg.RecipientName.String,
+		Document:     pkg.RecipientDocument.String,
+		Phone:        pkg.RecipientPhone.String,
+		Street:       pkg.RecipientStreet.String,
+		Number:       pkg.RecipientNumber.String,
+		Complement:   pkg.RecipientComplement.String,
+		Neighborhood: pkg.RecipientNeighborhood.String,
+		City:         pkg.DestinationCity.String,
+		State:        pkg.DestinationState,
+		CEP:          pkg.DestinationCep.String,
+	}
+}
+
+func packageSender(pkg repository.Package) *Address {
+	if !pkg.SenderName.Valid {
+		return nil
+	}
+	return &Address{
+		Name:         pkg.SenderName.String,
+		Document:     pkg.SenderDocument.String,
+		Phone:        pkg.SenderPhone.String,
+		Street:       pkg.SenderStreet.String,
+		Number:       pkg.SenderNumber.String,
+		Complement:   pkg.SenderComplement.String,
+		Neighborhood: pkg.SenderNeighborhood.String,
+		City:         pkg.SenderCity.String,
+		State:        pkg.SenderState.String,
+		CEP:          pkg.SenderCep.String,
+	}
+}
+
+func labelParty(address Address) label.Party {
+	street := address.Street + ", " + address.Number
+	if address.Complement != "" {
+		street += " - " + address.Complement
+	}
+
+	lines := []string{street, address.Neighborhood}
+	if address.City != "" {
+		lines = append(lines, fmt.Sprintf("%s - %s", address.City, address.State))
+	} else {
+		lines = append(lines, address.State)
+	}
+	if address.CEP != "" {
+		lines = append(lines, "CEP "+formatCEP(address.CEP))
+	}
+
+	return label.Party{Name: address.Name, Lines: lines}
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"crypto/subtle"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/config"
+	"github/moura95/olist-shipping-api/internal/auth"
+	"github/moura95/olist-shipping-api/internal/repository"
+	"go.uber.org/zap"
+)
+
+type AuthService struct {
+	repository repository.Querier
+	config     config.Config
+	logger     *zap.SugaredLogger
+}
+
+func NewAuthService(repo repository.Querier, cfg config.Config, log *zap.SugaredLogger) *AuthService {
+	return &AuthService{
+		repository: repo,
+		config:     cfg,
+		logger:     log,
+	}
+}
+
+// Authenticate tries the bootstrap key, then olk_ keys by hash, then JWT.
+func (s *AuthService) Authenticate(ctx context.Context, credential string) (*auth.Principal, error) {
+	if credential == "" {
+		return nil, auth.ErrUnauthenticated
+	}
+
+	if s.config.AuthBootstrapKey != "" &&
+		subtle.ConstantTimeCompare([]byte(credential), []byte(s.config.AuthBootstrapKey)) == 1 {
+		return &auth.Principal{Subject: "bootstrap", Role: auth.RoleAdmin, Method: auth.MethodAPIKey}, nil
+	}
+
+	if auth.IsAPIKey(credential) {
+		key, err := s.repository.GetActiveApiKeyByHash(ctx, auth.HashAPIKey(credential))
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return nil, auth.ErrUnauthenticated
+			}
+			return nil, fmt.Errorf("get api key: %v", err)
+		}
+		if err := s.repository.TouchApiKey(ctx, key.ID); err != nil {
+			s.logger.Errorw("touch api key failed", "error", err, "id", key.ID)
+		}
+		return &auth.Principal{
+			Subject:  "apikey:" + key.Name,
+			Role:     key.Role,
+			Method:   auth.MethodAPIKey,
+			SellerID: key.SellerID,
+			APIKeyID: uuid.NullUUID{UUID: key.ID, Valid: true},
+		}, nil
+	}
+
+	if s.config.AuthJWTSecret == "" {
+		return nil, auth.ErrUnauthenticated
+	}
+	claims, err := auth.ParseToken([]byte(s.config.AuthJWTSecret), credential, time.Now())
+	if err != nil {
+		return nil, fmt.Errorf("parse token: %w: %v", auth.ErrUnauthenticated, err)
+	}
+
+	principal := &auth.Principal{Subject: claims.Subject, Role: claims.Role, Method: auth.MethodJWT}
+	if claims.SellerID != "" {
+		// Already validated by ParseToken
+		principal.SellerID = uuid.NullUUID{UUID: uuid.MustParse(claims.SellerID), Valid: true}
+	}
+
+	return principal, nil
+}
+
+// IssueToken keeps the subject, role and seller of the current credential.
+func (s *AuthService) IssueToken(principal auth.Principal) (string, time.Time, error) {
+	if s.config.AuthJWTSecret == "" {
+		return "", time.Time{}, ErrJWTDisabled
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.config.AuthTokenTTL)
+	claims := auth.Claims{
+		Subject:   principal.Subject,
+		Role:      principal.Role,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: expiresAt.Unix(),
+	}
+	if principal.SellerID.Valid {
+		claims.SellerID = principal.SellerID.UUID.String()
+	}
+	token, err := auth.SignToken([]byte(s.config.AuthJWTSecret), claims)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
+type CreateAPIKeyInput struct {
+	Name      string
+	Role      string
+	SellerID  string
+	CreatedBy string
+}
+
+// CreateAPIKey returns the only plain copy of the key; seller keys require a seller.
+func (s *AuthService) CreateAPIKey(ctx context.Context, input CreateAPIKeyInput) (*repository.ApiKey, string, error) {
+	if input.Role == auth.RoleSeller && input.SellerID == "" {
+		return nil, "", ErrSellerRequired
+	}
+
+	var sellerID uuid.NullUUID
+	if input.SellerID != "" {
+		seller, err := sellerByID(ctx, s.repository, input.SellerID)
+		if err != nil {
+			return nil, "", err
+		}
+		sellerID = uuid.NullUUID{UUID: seller.ID, Valid: true}
+	}
+
+	key, prefix, err := auth.GenerateAPIKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	apiKey, err := s.repository.CreateApiKey(ctx, repository.CreateApiKeyParams{
+		Name:      input.Name,
+		Role:      input.Role,
+		KeyPrefix: prefix,
+		KeyHash:   auth.HashAPIKey(key),
+		CreatedBy: input.CreatedBy,
+		SellerID:  sellerID,
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("create api key: %v", err)
+	}
+
+	return &apiKey, key, nil
+}
+
+func (s *AuthService) ListAPIKeys(ctx context.Context) ([]repository.ApiKey, error) {
+	keys, err := s.repository.ListApiKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list api keys: %v", err)
+	}
+
+	return keys, nil
+}
+
+// RevokeAPIKey keeps the first revocation date when called again.
+func (s *AuthService) RevokeAPIKey(ctx context.Context, id string) (*repository.ApiKey, error) {
+	keyID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("parse api key id: %w", ErrAPIKeyNotFound)
+	}
+
+	apiKey, err := s.repository.RevokeApiKey(ctx, keyID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("revoke api key: %w", ErrAPIKeyNotFound)
+		}
+		return nil, fmt.Errorf("revoke api key: %v", err)
+	}
+
+	return &apiKey, nil
+}
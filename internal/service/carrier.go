@@ -2,11 +2,48 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
+	"github.com/google/uuid"
 	"github/moura95/olist-shipping-api/internal/repository"
 )
 
+const (
+	defaultCutoffTime    = "14:00"
+	defaultCubingDivisor = 6000
+)
+
+// CarrierInput limits are optional; an empty CutoffTime defaults to 14:00.
+type CarrierInput struct {
+	Name               string
+	TrackingPrefix     string
+	MaxWeightKg        *float64
+	MaxSideCm          *float64
+	MaxDimensionsSumCm *float64
+	CutoffTime         string
+}
+
+// CarrierCoverageInput zero values mean any origin, a 6000 cubing divisor and valid from now.
+type CarrierCoverageInput struct {
+	RegionID              string
+	OriginRegionID        string
+	EstimatedDeliveryDays int32
+	PricePerKg            float64
+	MinCharge             float64
+	ExcessPricePerKg      *float64
+	CubingDivisor         int32
+	ValidFrom             *time.Time
+}
+
+type CarrierDetail struct {
+	Carrier  repository.Carrier
+	Coverage []repository.GetCarrierRegionsRow
+}
+
 func (s *PackageService) GetCarriers(ctx context.Context) ([]repository.Carrier, error) {
 	carriers, err := s.repository.ListCarriers(ctx)
 	if err != nil {
@@ -15,3 +52,278 @@ func (s *PackageService) GetCarriers(ctx context.Context) ([]repository.Carrier,
 
 	return carriers, nil
 }
+
+func (s *PackageService) GetCarrier(ctx context.Context, id string) (*CarrierDetail, error) {
+	carrier, err := s.carrierByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	coverage, err := s.repository.GetCarrierRegions(ctx, carrier.ID)
+	if err != nil {
+		return nil, fmt.Errorf("get carrier regions: %v", err)
+	}
+
+	return &CarrierDetail{Carrier: carrier, Coverage: coverage}, nil
+}
+
+func (s *PackageService) CreateCarrier(ctx context.Context, input CarrierInput) (*repository.Carrier, error) {
+	cutoffTime, err := parseCutoffTime(input.CutoffTime)
+	if err != nil {
+		return nil, err
+	}
+
+	carrier, err := s.repository.CreateCarrier(ctx, repository.CreateCarrierParams{
+		Name:               input.Name,
+		TrackingPrefix:     input.TrackingPrefix,
+		MaxWeightKg:        nullFloat64(input.MaxWeightKg),
+		MaxSideCm:          nullFloat64(input.MaxSideCm),
+		MaxDimensionsSumCm: nullFloat64(input.MaxDimensionsSumCm),
+		CutoffTime:         cutoffTime,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("create carrier: %w", ErrTrackingPrefixInUse)
+		}
+		return nil, fmt.Errorf("create carrier: %v", err)
+	}
+
+	return &carrier, nil
+}
+
+func (s *PackageService) UpdateCarrier(ctx context.Context, id string, input CarrierInput) (*repository.Carrier, error) {
+	current, err := s.carrierByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoffTime, err := parseCutoffTime(input.CutoffTime)
+	if err != nil {
+		return nil, err
+	}
+
+	carrier, err := s.repository.UpdateCarrier(ctx, repository.UpdateCarrierParams{
+		ID:                 current.ID,
+		Name:               input.Name,
+		TrackingPrefix:     input.TrackingPrefix,
+		MaxWeightKg:        nullFloat64(input.MaxWeightKg),
+		MaxSideCm:          nullFloat64(input.MaxSideCm),
+		MaxDimensionsSumCm: nullFloat64(input.MaxDimensionsSumCm),
+		CutoffTime:         cutoffTime,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("update carrier: %w", ErrCarrierNotFound)
+		}
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("update carrier: %w", ErrTrackingPrefixInUse)
+		}
+		return nil, fmt.Errorf("update carrier: %v", err)
+	}
+
+	return &carrier, nil
+}
+
+// DeleteCarrier keeps the carrier referenced by the packages it already shipped.
+func (s *PackageService) DeleteCarrier(ctx context.Context, id string) error {
+	carrier, err := s.carrierByID(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := s.repository.DeleteCarrier(ctx, carrier.ID)
+	if err != nil {
+		return fmt.Errorf("delete carrier: %v", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("delete carrier: %w", ErrCarrierInUse)
+	}
+
+	return nil
+}
+
+// AddCarrierCoverage schedules the version when ValidFrom is in the future.
+func (s *PackageService) AddCarrierCoverage(ctx context.Context, carrierID string, input CarrierCoverageInput) (*repository.ListCarrierRatesRow, error) {
+	carrier, err := s.carrierByID(ctx, carrierID)
+	if err != nil {
+		return nil, err
+	}
+
+	regionID, err := uuid.Parse(input.RegionID)
+	if err != nil {
+		return nil, fmt.Errorf("parse region id: %w", ErrRegionNotFound)
+	}
+
+	var originRegionID uuid.NullUUID
+	if input.OriginRegionID != "" {
+		parsed, err := uuid.Parse(input.OriginRegionID)
+		if err != nil {
+			return nil, fmt.Errorf("parse origin region id: %w", ErrRegionNotFound)
+		}
+		originRegionID = uuid.NullUUID{UUID: parsed, Valid: true}
+	}
+
+	return s.createRateVersion(ctx, carrier.ID, regionID, originRegionID, input)
+}
+
+// UpdateCarrierCoverage adds a new version of the same lane; the previous one stays in history.
+func (s *PackageService) UpdateCarrierCoverage(ctx context.Context, carrierID, coverageID string, input CarrierCoverageInput) (*repository.ListCarrierRatesRow, error) {
+	region, err := s.carrierRegionByID(ctx, carrierID, coverageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.createRateVersion(ctx, region.CarrierID, region.RegionID, region.OriginRegionID, input)
+}
+
+func (s *PackageService) DeleteCarrierCoverage(ctx context.Context, carrierID, coverageID string) error {
+	region, err := s.carrierRegionByID(ctx, carrierID, coverageID)
+	if err != nil {
+		return err
+	}
+
+	err = s.repository.EndCarrierRegion(ctx, repository.EndCarrierRegionParams{
+		ID:        region.ID,
+		CarrierID: region.CarrierID,
+	})
+	if err != nil {
+		return fmt.Errorf("end carrier region: %v", err)
+	}
+
+	return nil
+}
+
+// GetCarrierRates without at lists every version, including ended and scheduled ones.
+func (s *PackageService) GetCarrierRates(ctx context.Context, carrierID string, at *time.Time) ([]repository.ListCarrierRatesRow, error) {
+	carrier, err := s.carrierByID(ctx, carrierID)
+	if err != nil {
+		return nil, err
+	}
+
+	var effectiveAt sql.NullTime
+	if at != nil {
+		effectiveAt = sql.NullTime{Time: *at, Valid: true}
+	}
+
+	rates, err := s.repository.ListCarrierRates(ctx, repository.ListCarrierRatesParams{
+		CarrierID: carrier.ID,
+		At:        effectiveAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list carrier rates: %v", err)
+	}
+
+	return rates, nil
+}
+
+func (s *PackageService) createRateVersion(ctx context.Context, carrierID, regionID uuid.UUID, originRegionID uuid.NullUUID, input CarrierCoverageInput) (*repository.ListCarrierRatesRow, error) {
+	var validFrom sql.NullTime
+	if input.ValidFrom != nil {
+		if input.ValidFrom.Before(time.Now()) {
+			return nil, fmt.Errorf("create carrier region: %w", ErrRetroactiveRate)
+		}
+		validFrom = sql.NullTime{Time: *input.ValidFrom, Valid: true}
+	}
+
+	region, err := s.repository.CreateCarrierRegion(ctx, repository.CreateCarrierRegionParams{
+		ValidFrom:             validFrom,
+		CarrierID:             carrierID,
+		RegionID:              regionID,
+		OriginRegionID:        originRegionID,
+		EstimatedDeliveryDays: input.EstimatedDeliveryDays,
+		PricePerKg:            formatPrice(input.PricePerKg),
+		MinCharge:             formatPrice(input.MinCharge),
+		ExcessPricePerKg:      nullPrice(input.ExcessPricePerKg),
+		CubingDivisor:         cubingDivisor(input.CubingDivisor),
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return nil, fmt.Errorf("create carrier region: %w", ErrRateVersionExists)
+		}
+		if isForeignKeyViolation(err) {
+			return nil, fmt.Errorf("create carrier region: %w", ErrRegionNotFound)
+		}
+		return nil, fmt.Errorf("create carrier region: %v", err)
+	}
+
+	return s.carrierCoverage(ctx, carrierID, region.ID)
+}
+
+func (s *PackageService) carrierRegionByID(ctx context.Context, carrierID, coverageID string) (*repository.CarrierRegion, error) {
+	carrier, err := s.carrierByID(ctx, carrierID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.Parse(coverageID)
+	if err != nil {
+		return nil, fmt.Errorf("parse coverage id: %w", ErrCoverageNotFound)
+	}
+
+	region, err := s.repository.GetCarrierRegionById(ctx, repository.GetCarrierRegionByIdParams{
+		ID:        id,
+		CarrierID: carrier.ID,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("get carrier region: %w", ErrCoverageNotFound)
+		}
+		return nil, fmt.Errorf("get carrier region: %v", err)
+	}
+
+	return &region, nil
+}
+
+// carrierCoverage searches the full history because the version may be scheduled.
+func (s *PackageService) carrierCoverage(ctx context.Context, carrierID, coverageID uuid.UUID) (*repository.ListCarrierRatesRow, error) {
+	rates, err := s.repository.ListCarrierRates(ctx, repository.ListCarrierRatesParams{CarrierID: carrierID})
+	if err != nil {
+		return nil, fmt.Errorf("list carrier rates: %v", err)
+	}
+
+	for _, rate := range rates {
+		if rate.ID == coverageID {
+			return &rate, nil
+		}
+	}
+
+	return nil, fmt.Errorf("get carrier region: %w", ErrCoverageNotFound)
+}
+
+func parseCutoffTime(value string) (time.Time, error) {
+	if value == "" {
+		value = defaultCutoffTime
+	}
+
+	cutoffTime, err := time.Parse("15:04", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("parse cutoff time %q: %v", value, err)
+	}
+
+	return cutoffTime, nil
+}
+
+func cubingDivisor(value int32) int32 {
+	if value == 0 {
+		return defaultCubingDivisor
+	}
+	return value
+}
+
+func nullFloat64(value *float64) sql.NullFloat64 {
+	if value == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *value, Valid: true}
+}
+
+func formatPrice(value float64) string {
+	return strconv.FormatFloat(value, 'f', 2, 64)
+}
+
+func nullPrice(value *float64) sql.NullString {
+	if value == nil {
+		return sql.NullString{}
+	}
+	return sql.NullString{String: formatPrice(*value), Valid: true}
+}
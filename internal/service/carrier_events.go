@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+type CarrierEventInput struct {
+	EventID      string
+	Code         string
+	TrackingCode string
+	OccurredAt   time.Time
+	Description  string
+	Location     string
+	Raw          json.RawMessage
+}
+
+type CarrierEventResult struct {
+	EventID        uuid.UUID
+	PackageID      uuid.UUID
+	Duplicate      bool
+	MappedStatus   string
+	PreviousStatus string
+	Status         string
+}
+
+type CarrierEventMappingInput struct {
+	Code        string
+	Status      string
+	Description string
+}
+
+// AuthenticateCarrier compares the token hash in constant time.
+func (s *PackageService) AuthenticateCarrier(ctx context.Context, carrierID, token string) (*repository.Carrier, error) {
+	id, err := uuid.Parse(carrierID)
+	if err != nil || token == "" {
+		return nil, ErrCarrierUnauthorized
+	}
+
+	tokenHash, err := s.repository.GetCarrierApiTokenHash(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrCarrierUnauthorized
+		}
+		return nil, fmt.Errorf("get carrier api token: %v", err)
+	}
+	if subtle.ConstantTimeCompare([]byte(hashCarrierToken(token)), []byte(tokenHash)) != 1 {
+		return nil, ErrCarrierUnauthorized
+	}
+
+	carrier, err := s.repository.GetCarrierById(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get carrier by id: %v", err)
+	}
+	// Deleted carriers can no longer send events, even with their old token
+	if carrier.DeletedAt.Valid {
+		return nil, ErrCarrierUnauthorized
+	}
+
+	return &carrier, nil
+}
+
+// RotateCarrierToken returns the only plain copy of the new token; just its hash is stored.
+func (s *PackageService) RotateCarrierToken(ctx context.Context, carrierID string) (string, error) {
+	carrier, err := s.carrierByID(ctx, carrierID)
+	if err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate carrier token: %v", err)
+	}
+	token := "ctk_" + hex.EncodeToString(buf)
+
+	err = s.repository.UpsertCarrierApiToken(ctx, repository.UpsertCarrierApiTokenParams{
+		CarrierID: carrier.ID,
+		TokenHash: hashCarrierToken(token),
+	})
+	if err != nil {
+		return "", fmt.Errorf("upsert carrier api token: %v", err)
+	}
+
+	return token, nil
+}
+
+// IngestCarrierEvent never moves a package backwards, so duplicate and out-of-order events are harmless.
+func (s *PackageService) IngestCarrierEvent(ctx context.Context, carrier repository.Carrier, input CarrierEventInput) (*CarrierEventResult, error) {
+	var result *CarrierEventResult
+	var changes []EventData
+	err := s.inTx(ctx, func(tx *PackageService) error {
+		var err error
+		result, changes, err = tx.ingestCarrierEvent(ctx, carrier, input)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, data := range changes {
+		s.publish(ctx, EventPackageStatusChanged, data)
+	}
+
+	return result, nil
+}
+
+func (s *PackageService) ingestCarrierEvent(ctx context.Context, carrier repository.Carrier, input CarrierEventInput) (*CarrierEventResult, []EventData, error) {
+	trackingCode := strings.ToUpper(strings.TrimSpace(input.TrackingCode))
+	pkg, err := s.repository.GetPackageByTrackingCode(ctx, repository.GetPackageByTrackingCodeParams{
+		TrackingCode: sql.NullString{String: trackingCode, Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fmt.Errorf("get package by tracking code: %w", ErrPackageNotFound)
+		}
+		return nil, nil, fmt.Errorf("get package by tracking code: %v", err)
+	}
+	// Packages hired with another carrier are reported as not found
+	if !pkg.HiredCarrierID.Valid || pkg.HiredCarrierID.UUID != carrier.ID {
+		return nil, nil, fmt.Errorf("package not hired with carrier: %w", ErrPackageNotFound)
+	}
+
+	mapping, err := s.repository.GetCarrierEventMapping(ctx, repository.GetCarrierEventMappingParams{
+		CarrierID:   carrier.ID,
+		CarrierCode: input.Code,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, fmt.Errorf("%w: %s", ErrUnknownCarrierEvent, input.Code)
+		}
+		return nil, nil, fmt.Errorf("get carrier event mapping: %v", err)
+	}
+
+	event, err := s.repository.CreateCarrierTrackingEvent(ctx, repository.CreateCarrierTrackingEventParams{
+		CarrierID:      carrier.ID,
+		PackageID:      pkg.ID,
+		CarrierEventID: input.EventID,
+		CarrierCode:    input.Code,
+		MappedStatus:   mapping.Status,
+		Description:    nullString(input.Description),
+		Location:       nullString(input.Location),
+		OccurredAt:     input.OccurredAt.UTC(),
+		Raw:            input.Raw,
+	})
+	duplicate := errors.Is(err, sql.ErrNoRows)
+	if duplicate {
+		event, err = s.repository.GetCarrierTrackingEvent(ctx, repository.GetCarrierTrackingEventParams{
+			CarrierID:      carrier.ID,
+			CarrierEventID: input.EventID,
+		})
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("create carrier tracking event: %v", err)
+	}
+
+	result := &CarrierEventResult{
+		EventID:        event.ID,
+		PackageID:      pkg.ID,
+		Duplicate:      duplicate,
+		MappedStatus:   mapping.Status.String,
+		PreviousStatus: pkg.Status,
+		Status:         pkg.Status,
+	}
+
+	actor := "transportadora:" + carrier.Name
+	reason := fmt.Sprintf("evento %s (%s)", input.Code, input.EventID)
+	var changes []EventData
+	for _, next := range StatusPath(pkg.Status, mapping.Status.String) {
+		data, _, err := s.updateStatus(ctx, pkg.ID.String(), uuid.NullUUID{}, StatusChange{Status: next, Actor: actor, Reason: reason})
+		if err != nil {
+			return nil, nil, err
+		}
+		changes = append(changes, data)
+		result.Status = next
+	}
+
+	return result, changes, nil
+}
+
+func (s *PackageService) GetCarrierEventMappings(ctx context.Context, carrierID string) ([]repository.CarrierEventMapping, error) {
+	carrier, err := s.carrierByID(ctx, carrierID)
+	if err != nil {
+		return nil, err
+	}
+
+	mappings, err := s.repository.ListCarrierEventMappings(ctx, carrier.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list carrier event mappings: %v", err)
+	}
+
+	return mappings, nil
+}
+
+// SaveCarrierEventMapping with an empty status makes the code informational only.
+func (s *PackageService) SaveCarrierEventMapping(ctx context.Context, carrierID string, input CarrierEventMappingInput) (*repository.CarrierEventMapping, error) {
+	carrier, err := s.carrierByID(ctx, carrierID)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, err := s.repository.UpsertCarrierEventMapping(ctx, repository.UpsertCarrierEventMappingParams{
+		CarrierID:   carrier.ID,
+		CarrierCode: input.Code,
+		Status:      nullString(input.Status),
+		Description: input.Description,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("upsert carrier event mapping: %v", err)
+	}
+
+	return &mapping, nil
+}
+
+func (s *PackageService) carrierByID(ctx context.Context, id string) (repository.Carrier, error) {
+	carrierID, err := uuid.Parse(id)
+	if err != nil {
+		return repository.Carrier{}, fmt.Errorf("parse carrier id: %w", ErrCarrierNotFound)
+	}
+
+	carrier, err := s.repository.GetCarrierById(ctx, carrierID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.Carrier{}, fmt.Errorf("get carrier by id: %w", ErrCarrierNotFound)
+		}
+		return repository.Carrier{}, fmt.Errorf("get carrier by id: %v", err)
+	}
+	if carrier.DeletedAt.Valid {
+		return repository.Carrier{}, fmt.Errorf("carrier deleted: %w", ErrCarrierNotFound)
+	}
+
+	return carrier, nil
+}
+
+func hashCarrierToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	customValidator "github/moura95/olist-shipping-api/pkg/validator"
+)
+
+type CEPLocation struct {
+	CEP       string
+	StateCode string
+	City      string
+	IsCapital bool
+}
+
+type CEPRule struct {
+	Serviceable       bool
+	Surcharge         float64
+	ExtraDeliveryDays int32
+	Description       string
+}
+
+func (s *PackageService) ResolveCEP(ctx context.Context, cep string) (*CEPLocation, error) {
+	cep = customValidator.NormalizeCEP(cep)
+
+	cepRange, err := s.repository.GetCepRange(ctx, cep)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("%w: %s", ErrCEPNotFound, cep)
+		}
+		return nil, fmt.Errorf("get cep range: %v", err)
+	}
+
+	return &CEPLocation{
+		CEP:       cep,
+		StateCode: cepRange.StateCode,
+		City:      cepRange.City.String,
+		IsCapital: cepRange.IsCapital,
+	}, nil
+}
+
+// resolveDestination requires the given state to match the CEP range when both are set.
+func (s *PackageService) resolveDestination(ctx context.Context, stateCode, cep string) (string, *CEPLocation, error) {
+	if cep == "" {
+		return stateCode, nil, nil
+	}
+
+	location, err := s.ResolveCEP(ctx, cep)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if stateCode != "" && stateCode != location.StateCode {
+		return "", nil, fmt.Errorf("%w: CEP %s pertence a %s", ErrCEPStateMismatch, location.CEP, location.StateCode)
+	}
+
+	return location.StateCode, location, nil
+}
+
+// carrierCEPRules keeps the most specific rule per carrier.
+func (s *PackageService) carrierCEPRules(ctx context.Context, cep string) (map[uuid.UUID]CEPRule, error) {
+	if cep == "" {
+		return nil, nil
+	}
+
+	rows, err := s.repository.ListCarrierCepRules(ctx, cep)
+	if err != nil {
+		return nil, fmt.Errorf("list carrier cep rules: %v", err)
+	}
+
+	rules := make(map[uuid.UUID]CEPRule)
+	for _, row := range rows {
+		if _, ok := rules[row.CarrierID]; ok {
+			continue
+		}
+
+		surcharge, err := strconv.ParseFloat(row.Surcharge, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parse cep surcharge: %v", err)
+		}
+
+		rules[row.CarrierID] = CEPRule{
+			Serviceable:       row.Serviceable,
+			Surcharge:         surcharge,
+			ExtraDeliveryDays: row.ExtraDeliveryDays,
+			Description:       row.Description.String,
+		}
+	}
+	return rules, nil
+}
+
+func cepNotServedReason(cep string) string {
+	return fmt.Sprintf("CEP %s fora da área de atendimento", cep)
+}
@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github/moura95/olist-shipping-api/pkg/calendar"
+)
+
+// deliveryCalendar also loads the next year, since a deadline can cross the new year.
+func (s *PackageService) deliveryCalendar(ctx context.Context, stateCode string, from time.Time) (*calendar.Calendar, error) {
+	rows, err := s.repository.ListHolidaysForState(ctx, sql.NullString{String: stateCode, Valid: true})
+	if err != nil {
+		return nil, fmt.Errorf("list holidays: %v", err)
+	}
+
+	var holidays []calendar.Holiday
+	for year := from.Year(); year <= from.Year()+1; year++ {
+		holidays = append(holidays, calendar.MovableHolidays(year)...)
+		for _, row := range rows {
+			date := row.HolidayDate
+			if row.Recurring {
+				date = time.Date(year, date.Month(), date.Day(), 0, 0, 0, 0, calendar.Location)
+			} else if date.Year() != year {
+				continue
+			}
+			holidays = append(holidays, calendar.Holiday{Date: date, Name: row.Name})
+		}
+	}
+
+	return calendar.New(holidays), nil
+}
+
+func deliveryDate(date time.Time) sql.NullTime {
+	return sql.NullTime{
+		Time:  time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC),
+		Valid: true,
+	}
+}
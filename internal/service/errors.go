@@ -0,0 +1,55 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/lib/pq"
+	"github/moura95/olist-shipping-api/pkg/tracking"
+)
+
+var (
+	ErrPackageNotFound         = errors.New("package not found")
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+	ErrVersionMismatch         = errors.New("package version does not match")
+	ErrPackageModified         = errors.New("package was modified by another request")
+	ErrPackageAlreadyShipped   = errors.New("shipped packages cannot be deleted")
+	ErrPackageNotDeleted       = errors.New("package is not deleted")
+	ErrInvalidListFilter       = errors.New("invalid list filter")
+	ErrQuoteNotFound           = errors.New("quote not found")
+	ErrQuoteExpired            = errors.New("quote expired")
+	ErrQuoteAlreadyUsed        = errors.New("quote already used")
+	ErrCarrierRestricted       = errors.New("carrier does not accept package")
+	ErrWarehouseNotFound       = errors.New("origin warehouse not found")
+	ErrCEPNotFound             = errors.New("cep not found")
+	ErrCEPStateMismatch        = errors.New("cep does not belong to destination state")
+	ErrRecipientMismatch       = errors.New("recipient address does not match destination")
+	ErrInvalidImport           = errors.New("invalid import file")
+	ErrPackageNotHired         = errors.New("package has no hired carrier")
+	ErrTrackingCodeMissing     = errors.New("package has no tracking code")
+	ErrInvalidTrackingCode     = tracking.ErrInvalidCode
+	ErrWebhookNotFound         = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+	ErrCarrierNotFound         = errors.New("carrier not found")
+	ErrCarrierInUse            = errors.New("carrier has packages in progress")
+	ErrTrackingPrefixInUse     = errors.New("tracking prefix already in use")
+	ErrCoverageNotFound        = errors.New("carrier coverage not found")
+	ErrRateVersionExists       = errors.New("a rate version already starts at this date")
+	ErrRetroactiveRate         = errors.New("rate versions cannot start in the past")
+	ErrRegionNotFound          = errors.New("region not found")
+	ErrCarrierUnauthorized     = errors.New("invalid carrier credentials")
+	ErrUnknownCarrierEvent     = errors.New("unknown carrier event code")
+	ErrAPIKeyNotFound          = errors.New("api key not found")
+	ErrJWTDisabled             = errors.New("jwt authentication is not configured")
+	ErrSellerNotFound          = errors.New("seller not found")
+	ErrSellerRequired          = errors.New("seller is required")
+)
+
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+func isForeignKeyViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23503"
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+const (
+	EventPackageCreated       = "package.created"
+	EventPackageStatusChanged = "package.status_changed"
+	EventPackageCarrierHired  = "package.carrier_hired"
+	EventPackageDeleted       = "package.deleted"
+	EventPackageRestored      = "package.restored"
+)
+
+var EventTypes = []string{
+	EventPackageCreated,
+	EventPackageStatusChanged,
+	EventPackageCarrierHired,
+	EventPackageDeleted,
+	EventPackageRestored,
+}
+
+// Event.ID is shared by every delivery and retry of the event.
+type Event struct {
+	ID         uuid.UUID `json:"id"`
+	Type       string    `json:"tipo"`
+	OccurredAt time.Time `json:"ocorrido_em"`
+	Data       EventData `json:"dados"`
+}
+
+type EventData struct {
+	PackageID             uuid.UUID `json:"pacote_id"`
+	TrackingCode          string    `json:"codigo_rastreio,omitempty"`
+	Status                string    `json:"status,omitempty"`
+	PreviousStatus        string    `json:"status_anterior,omitempty"`
+	DestinationState      string    `json:"estado_destino,omitempty"`
+	HiredCarrierID        string    `json:"transportadora_id,omitempty"`
+	HiredPrice            string    `json:"preco_contratado,omitempty"`
+	EstimatedDeliveryDate string    `json:"data_estimada_entrega,omitempty"`
+	Actor                 string    `json:"ator,omitempty"`
+}
+
+type EventPublisher interface {
+	Publish(ctx context.Context, event Event) error
+}
+
+type Option func(*PackageService)
+
+// WithEventPublisher enables events; without a publisher they are dropped.
+func WithEventPublisher(publisher EventPublisher) Option {
+	return func(s *PackageService) {
+		s.events = publisher
+	}
+}
+
+// publish runs after the write is committed, so failures are only logged.
+func (s *PackageService) publish(ctx context.Context, eventType string, data EventData) {
+	if s.events == nil {
+		return
+	}
+
+	event := Event{
+		ID:         uuid.New(),
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+	if err := s.events.Publish(ctx, event); err != nil {
+		s.logger.Errorw("publish event failed", "event", eventType, "package_id", data.PackageID, "error", err)
+	}
+}
+
+func newEventData(pkg repository.Package) EventData {
+	data := EventData{
+		PackageID:        pkg.ID,
+		TrackingCode:     pkg.TrackingCode.String,
+		Status:           pkg.Status,
+		DestinationState: pkg.DestinationState,
+		HiredPrice:       pkg.HiredPrice.String,
+	}
+	if pkg.HiredCarrierID.Valid {
+		data.HiredCarrierID = pkg.HiredCarrierID.UUID.String()
+	}
+	if pkg.EstimatedDeliveryDate.Valid {
+		data.EstimatedDeliveryDate = pkg.EstimatedDeliveryDate.Time.Format("2006-01-02")
+	}
+	return data
+}
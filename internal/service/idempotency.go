@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github/moura95/olist-shipping-api/config"
+	"github/moura95/olist-shipping-api/internal/idempotency"
+	"github/moura95/olist-shipping-api/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	// idempotencyLockTimeout lets a retry take over a key left by a crashed request.
+	idempotencyLockTimeout     = time.Minute
+	idempotencyCleanupInterval = time.Hour
+)
+
+type IdempotencyService struct {
+	repository repository.Querier
+	config     config.Config
+	logger     *zap.SugaredLogger
+}
+
+func NewIdempotencyService(repo repository.Querier, cfg config.Config, log *zap.SugaredLogger) *IdempotencyService {
+	return &IdempotencyService{
+		repository: repo,
+		config:     cfg,
+		logger:     log,
+	}
+}
+
+// Begin returns nil when the request should be processed, or the stored response for a replay.
+func (s *IdempotencyService) Begin(ctx context.Context, key idempotency.Key) (*idempotency.Response, error) {
+	ttl := s.config.IdempotencyTTL
+	if ttl <= 0 {
+		ttl = config.DefaultIdempotencyTTL
+	}
+
+	_, err := s.repository.ReserveIdempotencyKey(ctx, repository.ReserveIdempotencyKeyParams{
+		Principal:          key.Principal,
+		IdempotencyKey:     key.Key,
+		Route:              key.Route,
+		RequestHash:        key.RequestHash,
+		TtlSeconds:         ttl.Seconds(),
+		LockTimeoutSeconds: idempotencyLockTimeout.Seconds(),
+	})
+	if err == nil {
+		return nil, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("reserve idempotency key: %v", err)
+	}
+
+	stored, err := s.repository.GetIdempotencyKey(ctx, repository.GetIdempotencyKeyParams{
+		Principal:      key.Principal,
+		IdempotencyKey: key.Key,
+		Route:          key.Route,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get idempotency key: %v", err)
+	}
+
+	if stored.RequestHash != key.RequestHash {
+		return nil, fmt.Errorf("begin idempotent request: %w", idempotency.ErrKeyReused)
+	}
+	if !stored.StatusCode.Valid {
+		return nil, fmt.Errorf("begin idempotent request: %w", idempotency.ErrInProgress)
+	}
+
+	var headers map[string]string
+	if len(stored.ResponseHeaders) > 0 {
+		if err := json.Unmarshal(stored.ResponseHeaders, &headers); err != nil {
+			return nil, fmt.Errorf("decode idempotency response headers: %v", err)
+		}
+	}
+
+	return &idempotency.Response{
+		StatusCode:  int(stored.StatusCode.Int32),
+		ContentType: stored.ContentType.String,
+		Headers:     headers,
+		Body:        stored.ResponseBody,
+	}, nil
+}
+
+func (s *IdempotencyService) Complete(ctx context.Context, key idempotency.Key, response idempotency.Response) error {
+	headers := response.Headers
+	if headers == nil {
+		headers = map[string]string{}
+	}
+	encodedHeaders, err := json.Marshal(headers)
+	if err != nil {
+		return fmt.Errorf("encode idempotency response headers: %v", err)
+	}
+
+	err = s.repository.CompleteIdempotencyKey(ctx, repository.CompleteIdempotencyKeyParams{
+		StatusCode:      sql.NullInt32{Int32: int32(response.StatusCode), Valid: true},
+		ContentType:     nullString(response.ContentType),
+		ResponseBody:    response.Body,
+		ResponseHeaders: encodedHeaders,
+		Principal:       key.Principal,
+		IdempotencyKey:  key.Key,
+		Route:           key.Route,
+		RequestHash:     key.RequestHash,
+	})
+	if err != nil {
+		return fmt.Errorf("complete idempotency key: %v", err)
+	}
+
+	return nil
+}
+
+func (s *IdempotencyService) Release(ctx context.Context, key idempotency.Key) error {
+	err := s.repository.ReleaseIdempotencyKey(ctx, repository.ReleaseIdempotencyKeyParams{
+		Principal:      key.Principal,
+		IdempotencyKey: key.Key,
+		Route:          key.Route,
+		RequestHash:    key.RequestHash,
+	})
+	if err != nil {
+		return fmt.Errorf("release idempotency key: %v", err)
+	}
+
+	return nil
+}
+
+func (s *IdempotencyService) PurgeExpired(ctx context.Context) (int64, error) {
+	deleted, err := s.repository.DeleteExpiredIdempotencyKeys(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("delete expired idempotency keys: %v", err)
+	}
+
+	return deleted, nil
+}
+
+func (s *IdempotencyService) RunCleanup(ctx context.Context) {
+	ticker := time.NewTicker(idempotencyCleanupInterval)
+	defer ticker.Stop()
+
+	for {
+		deleted, err := s.PurgeExpired(ctx)
+		if err != nil {
+			s.logger.Errorw("purge expired idempotency keys failed", "error", err)
+		} else if deleted > 0 {
+			s.logger.Infow("expired idempotency keys purged", "count", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
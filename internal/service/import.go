@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/internal/repository"
+	"github/moura95/olist-shipping-api/internal/util"
+)
+
+const MaxImportRows = 1000
+
+type ImportMode string
+
+const (
+	ImportModePartial      ImportMode = "parcial"
+	ImportModeAllOrNothing ImportMode = "tudo_ou_nada"
+)
+
+const (
+	ImportStatusImported = "importado"
+	ImportStatusFailed   = "erro"
+	ImportStatusSkipped  = "ignorado"
+)
+
+type ImportRow struct {
+	Line   int
+	Input  CreatePackageInput
+	Errors []string
+}
+
+type ImportRowResult struct {
+	Line      int
+	Status    string
+	PackageID uuid.UUID
+	Errors    []string
+}
+
+type ImportReport struct {
+	Mode     ImportMode
+	Imported int
+	Failed   int
+	Rows     []ImportRowResult
+}
+
+func (r *ImportReport) Rejected() bool {
+	return r.Mode == ImportModeAllOrNothing && r.Failed > 0
+}
+
+type batchPackage struct {
+	Product           string   `json:"product"`
+	WeightKg          float64  `json:"weight_kg"`
+	DestinationState  string   `json:"destination_state"`
+	HeightCm          *float64 `json:"height_cm,omitempty"`
+	WidthCm           *float64 `json:"width_cm,omitempty"`
+	LengthCm          *float64 `json:"length_cm,omitempty"`
+	ProductCategory   *string  `json:"product_category,omitempty"`
+	OriginWarehouseID *string  `json:"origin_warehouse_id,omitempty"`
+	DestinationCep    *string  `json:"destination_cep,omitempty"`
+	DestinationCity   *string  `json:"destination_city,omitempty"`
+
+	RecipientName         *string `json:"recipient_name,omitempty"`
+	RecipientDocument     *string `json:"recipient_document,omitempty"`
+	RecipientPhone        *string `json:"recipient_phone,omitempty"`
+	RecipientStreet       *string `json:"recipient_street,omitempty"`
+	RecipientNumber       *string `json:"recipient_number,omitempty"`
+	RecipientComplement   *string `json:"recipient_complement,omitempty"`
+	RecipientNeighborhood *string `json:"recipient_neighborhood,omitempty"`
+	SenderName            *string `json:"sender_name,omitempty"`
+	SenderDocument        *string `json:"sender_document,omitempty"`
+	SenderPhone           *string `json:"sender_phone,omitempty"`
+	SenderStreet          *string `json:"sender_street,omitempty"`
+	SenderNumber          *string `json:"sender_number,omitempty"`
+	SenderComplement      *string `json:"sender_complement,omitempty"`
+	SenderNeighborhood    *string `json:"sender_neighborhood,omitempty"`
+	SenderCity            *string `json:"sender_city,omitempty"`
+	SenderState           *string `json:"sender_state,omitempty"`
+	SenderCep             *string `json:"sender_cep,omitempty"`
+}
+
+// ImportPackages inserts the valid rows in one atomic statement; in all-or-nothing mode any invalid row aborts.
+func (s *PackageService) ImportPackages(ctx context.Context, sellerID string, rows []ImportRow, mode ImportMode) (*ImportReport, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: nenhuma linha encontrada", ErrInvalidImport)
+	}
+	if len(rows) > MaxImportRows {
+		return nil, fmt.Errorf("%w: máximo de %d linhas por arquivo", ErrInvalidImport, MaxImportRows)
+	}
+
+	seller, err := sellerByID(ctx, s.repository, sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ImportReport{Mode: mode, Rows: make([]ImportRowResult, len(rows))}
+	var batch []batchPackage
+	var batchRows []int
+	for i, row := range rows {
+		result := ImportRowResult{Line: row.Line, Errors: row.Errors}
+		if len(result.Errors) == 0 {
+			arg, err := s.createPackageParams(ctx, row.Input)
+			if err != nil {
+				result.Errors = []string{err.Error()}
+			} else {
+				batch = append(batch, newBatchPackage(arg))
+				batchRows = append(batchRows, i)
+			}
+		}
+
+		if len(result.Errors) > 0 {
+			result.Status = ImportStatusFailed
+			report.Failed++
+		}
+		report.Rows[i] = result
+	}
+
+	if report.Rejected() {
+		for _, i := range batchRows {
+			report.Rows[i].Status = ImportStatusSkipped
+		}
+		return report, nil
+	}
+
+	if len(batch) == 0 {
+		return report, nil
+	}
+
+	payload, err := json.Marshal(batch)
+	if err != nil {
+		return nil, fmt.Errorf("encode import batch: %v", err)
+	}
+
+	created, err := s.repository.CreatePackagesBatch(ctx, repository.CreatePackagesBatchParams{
+		SellerID: seller.ID,
+		Packages: payload,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create packages batch: %v", err)
+	}
+	if len(created) != len(batch) {
+		return nil, fmt.Errorf("create packages batch: expected %d packages, got %d", len(batch), len(created))
+	}
+
+	for k, i := range batchRows {
+		report.Rows[i].Status = ImportStatusImported
+		report.Rows[i].PackageID = created[k].ID
+		s.publish(ctx, EventPackageCreated, newEventData(created[k]))
+	}
+	report.Imported = len(created)
+
+	return report, nil
+}
+
+func newBatchPackage(arg repository.CreatePackageParams) batchPackage {
+	pkg := batchPackage{
+		Product:          arg.Product,
+		WeightKg:         arg.WeightKg,
+		DestinationState: arg.DestinationState,
+		HeightCm:         util.NullFloat64ToPtr(arg.HeightCm),
+		WidthCm:          util.NullFloat64ToPtr(arg.WidthCm),
+		LengthCm:         util.NullFloat64ToPtr(arg.LengthCm),
+		ProductCategory:  util.NullStringToPtr(arg.ProductCategory),
+		DestinationCep:   util.NullStringToPtr(arg.DestinationCep),
+		DestinationCity:  util.NullStringToPtr(arg.DestinationCity),
+
+		RecipientName:         util.NullStringToPtr(arg.RecipientName),
+		RecipientDocument:     util.NullStringToPtr(arg.RecipientDocument),
+		RecipientPhone:        util.NullStringToPtr(arg.RecipientPhone),
+		RecipientStreet:       util.NullStringToPtr(arg.RecipientStreet),
+		RecipientNumber:       util.NullStringToPtr(arg.RecipientNumber),
+		RecipientComplement:   util.NullStringToPtr(arg.RecipientComplement),
+		RecipientNeighborhood: util.NullStringToPtr(arg.RecipientNeighborhood),
+		SenderName:            util.NullStringToPtr(arg.SenderName),
+		SenderDocument:        util.NullStringToPtr(arg.SenderDocument),
+		SenderPhone:           util.NullStringToPtr(arg.SenderPhone),
+		SenderStreet:          util.NullStringToPtr(arg.SenderStreet),
+		SenderNumber:          util.NullStringToPtr(arg.SenderNumber),
+		SenderComplement:      util.NullStringToPtr(arg.SenderComplement),
+		SenderNeighborhood:    util.NullStringToPtr(arg.SenderNeighborhood),
+		SenderCity:            util.NullStringToPtr(arg.SenderCity),
+		SenderState:           util.NullStringToPtr(arg.SenderState),
+		SenderCep:             util.NullStringToPtr(arg.SenderCep),
+	}
+	if arg.OriginWarehouseID.Valid {
+		warehouseID := arg.OriginWarehouseID.UUID.String()
+		pkg.OriginWarehouseID = &warehouseID
+	}
+	return pkg
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/config"
+	"github/moura95/olist-shipping-api/internal/repository"
+	"github/moura95/olist-shipping-api/pkg/label"
+)
+
+// GetLabel only reads the package; the tracking code is issued at hire.
+func (s *PackageService) GetLabel(ctx context.Context, id string, sellerID uuid.NullUUID) (*label.Label, error) {
+	found, err := s.GetByID(ctx, id, sellerID)
+	if err != nil {
+		return nil, err
+	}
+	pkg := *found
+
+	if !pkg.HiredCarrierID.Valid {
+		return nil, ErrPackageNotHired
+	}
+	if !pkg.TrackingCode.Valid {
+		return nil, ErrTrackingCodeMissing
+	}
+
+	carrier, err := s.repository.GetCarrierById(ctx, pkg.HiredCarrierID.UUID)
+	if err != nil {
+		return nil, fmt.Errorf("get carrier by id: %v", err)
+	}
+
+	sender, err := s.labelSender(ctx, pkg)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &label.Label{
+		TrackingCode: pkg.TrackingCode.String,
+		Carrier:      carrier.Name,
+		Sender:       sender,
+		Recipient:    labelRecipient(pkg),
+		Product:      pkg.Product,
+		WeightKg:     pkg.WeightKg,
+		QRData:       fmt.Sprintf("%s|%s|%s", pkg.TrackingCode.String, pkg.ID, labelDestination(pkg)),
+	}
+	if pkg.EstimatedDeliveryDate.Valid {
+		l.EstimatedDelivery = pkg.EstimatedDeliveryDate.Time
+	}
+
+	return l, nil
+}
+
+// labelSender falls back to the configured name and the origin warehouse.
+func (s *PackageService) labelSender(ctx context.Context, pkg repository.Package) (label.Party, error) {
+	if address := packageSender(pkg); address != nil {
+		return labelParty(*address), nil
+	}
+
+	sender := label.Party{Name: s.config.LabelSenderName}
+	if sender.Name == "" {
+		sender.Name = config.DefaultLabelSenderName
+	}
+
+	if pkg.OriginWarehouseID.Valid {
+		warehouse, err := s.repository.GetWarehouseById(ctx, pkg.OriginWarehouseID.UUID)
+		if err != nil {
+			return sender, fmt.Errorf("get warehouse by id: %v", err)
+		}
+		sender.Lines = append(sender.Lines, fmt.Sprintf("%s - %s", warehouse.Name, warehouse.StateCode))
+	}
+
+	return sender, nil
+}
+
+func labelRecipient(pkg repository.Package) label.Party {
+	if address := packageRecipient(pkg); address != nil {
+		return labelParty(*address)
+	}
+
+	var recipient label.Party
+	recipient.Lines = append(recipient.Lines, labelDestination(pkg))
+	if pkg.DestinationCep.Valid {
+		recipient.Lines = append(recipient.Lines, "CEP "+formatCEP(pkg.DestinationCep.String))
+	}
+	return recipient
+}
+
+func labelDestination(pkg repository.Package) string {
+	if pkg.DestinationCity.Valid {
+		return fmt.Sprintf("%s - %s", pkg.DestinationCity.String, pkg.DestinationState)
+	}
+	return pkg.DestinationState
+}
+
+func formatCEP(cep string) string {
+	if len(cep) != 8 {
+		return cep
+	}
+	return cep[:5] + "-" + cep[5:]
+}
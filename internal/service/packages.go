@@ -3,7 +3,10 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github/moura95/olist-shipping-api/config"
@@ -14,164 +17,542 @@ import (
 
 type PackageService struct {
 	repository repository.Querier
+	store      repository.Store
 	config     config.Config
 	logger     *zap.SugaredLogger
+	events     EventPublisher
 }
 
-func NewPackageService(repo repository.Querier, cfg config.Config, log *zap.SugaredLogger) *PackageService {
-	return &PackageService{
-		repository: repo,
+func NewPackageService(store repository.Store, cfg config.Config, log *zap.SugaredLogger, opts ...Option) *PackageService {
+	s := &PackageService{
+		repository: store,
+		store:      store,
 		config:     cfg,
 		logger:     log,
 	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-func (s *PackageService) Create(ctx context.Context, product string, weightKg float64, destinationState string) (*repository.Package, error) {
-	arg := repository.CreatePackageParams{
-		Product:          product,
-		WeightKg:         weightKg,
-		DestinationState: destinationState,
+// inTx may run fn more than once, so events must be published after it returns.
+func (s *PackageService) inTx(ctx context.Context, fn func(tx *PackageService) error) error {
+	return s.store.WithTx(ctx, func(q repository.Querier) error {
+		tx := *s
+		tx.repository = q
+		return fn(&tx)
+	})
+}
+
+type CreatePackageInput struct {
+	Product           string
+	WeightKg          float64
+	DestinationState  string
+	Dimensions        *Dimensions
+	Category          string
+	OriginWarehouseID string
+	DestinationCEP    string
+	SellerID          string
+	Recipient         *Address
+	Sender            *Address
+}
+
+func (s *PackageService) Create(ctx context.Context, input CreatePackageInput) (*repository.Package, error) {
+	seller, err := sellerByID(ctx, s.repository, input.SellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	arg, err := s.createPackageParams(ctx, input)
+	if err != nil {
+		return nil, err
 	}
+	arg.SellerID = seller.ID
 
 	pkg, err := s.repository.CreatePackage(ctx, arg)
 	if err != nil {
 		return nil, fmt.Errorf("create package: %v", err)
 	}
 
+	s.publish(ctx, EventPackageCreated, newEventData(pkg))
+
 	return &pkg, nil
 }
 
-func (s *PackageService) GetByID(ctx context.Context, id string) (*repository.Package, error) {
+// createPackageParams takes the destination from the recipient address when there is one.
+func (s *PackageService) createPackageParams(ctx context.Context, input CreatePackageInput) (repository.CreatePackageParams, error) {
+	warehouseID, err := parseWarehouseID(input.OriginWarehouseID)
+	if err != nil {
+		return repository.CreatePackageParams{}, err
+	}
+	if _, err := s.warehouseState(ctx, warehouseID); err != nil {
+		return repository.CreatePackageParams{}, err
+	}
+
+	destinationState, destinationCEP := input.DestinationState, input.DestinationCEP
+	if input.Recipient != nil {
+		destinationState, destinationCEP, err = recipientDestination(input)
+		if err != nil {
+			return repository.CreatePackageParams{}, err
+		}
+	}
+
+	stateCode, location, err := s.resolveDestination(ctx, destinationState, destinationCEP)
+	if err != nil {
+		return repository.CreatePackageParams{}, err
+	}
+
+	arg := repository.CreatePackageParams{
+		Product:           input.Product,
+		WeightKg:          input.WeightKg,
+		DestinationState:  stateCode,
+		ProductCategory:   nullString(input.Category),
+		OriginWarehouseID: warehouseID,
+	}
+	if location != nil {
+		arg.DestinationCep = sql.NullString{String: location.CEP, Valid: true}
+		arg.DestinationCity = nullString(location.City)
+	}
+	if input.Dimensions != nil {
+		arg.HeightCm = sql.NullFloat64{Float64: input.Dimensions.HeightCm, Valid: true}
+		arg.WidthCm = sql.NullFloat64{Float64: input.Dimensions.WidthCm, Valid: true}
+		arg.LengthCm = sql.NullFloat64{Float64: input.Dimensions.LengthCm, Valid: true}
+	}
+	if input.Recipient != nil {
+		setRecipient(&arg, *input.Recipient)
+	}
+	if input.Sender != nil {
+		setSender(&arg, *input.Sender)
+	}
+
+	return arg, nil
+}
+
+// GetByID reports packages of other sellers as not found.
+func (s *PackageService) GetByID(ctx context.Context, id string, sellerID uuid.NullUUID) (*repository.Package, error) {
 	packageID, err := uuid.Parse(id)
 	if err != nil {
 		return nil, fmt.Errorf("parse package id: %v", err)
 	}
 
-	pkg, err := s.repository.GetPackageById(ctx, packageID)
+	pkg, err := s.repository.GetPackageById(ctx, repository.GetPackageByIdParams{
+		ID:       packageID,
+		SellerID: sellerID,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("get package by id: %v", err)
+		return nil, packageLookupError(err)
 	}
 
 	return &pkg, nil
 }
 
-func (s *PackageService) GetByTrackingCode(ctx context.Context, trackingCode string) (*repository.Package, error) {
-	pkg, err := s.repository.GetPackageByTrackingCode(ctx, sql.NullString{
-		String: trackingCode,
-		Valid:  trackingCode != "",
+func packageWarehouseID(pkg repository.Package) string {
+	if !pkg.OriginWarehouseID.Valid {
+		return ""
+	}
+	return pkg.OriginWarehouseID.UUID.String()
+}
+
+func PackageDimensions(pkg repository.Package) *Dimensions {
+	if !pkg.HeightCm.Valid || !pkg.WidthCm.Valid || !pkg.LengthCm.Valid {
+		return nil
+	}
+	return &Dimensions{
+		HeightCm: pkg.HeightCm.Float64,
+		WidthCm:  pkg.WidthCm.Float64,
+		LengthCm: pkg.LengthCm.Float64,
+	}
+}
+
+// GetByTrackingCode rejects malformed codes without querying the database.
+func (s *PackageService) GetByTrackingCode(ctx context.Context, trackingCode string, sellerID uuid.NullUUID) (*repository.Package, error) {
+	trackingCode = strings.ToUpper(strings.TrimSpace(trackingCode))
+	if err := tracking.ValidForLookup(trackingCode); err != nil {
+		return nil, fmt.Errorf("get package by tracking code: %w", err)
+	}
+
+	pkg, err := s.repository.GetPackageByTrackingCode(ctx, repository.GetPackageByTrackingCodeParams{
+		TrackingCode: sql.NullString{String: trackingCode, Valid: true},
+		SellerID:     sellerID,
 	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("get package by tracking code: %w", ErrPackageNotFound)
+		}
 		return nil, fmt.Errorf("get package by tracking code: %v", err)
 	}
 
 	return &pkg, nil
 }
 
-func (s *PackageService) GetAll(ctx context.Context) ([]repository.Package, error) {
-	packages, err := s.repository.ListPackages(ctx)
+// UpdateStatus returns the package's new version.
+func (s *PackageService) UpdateStatus(ctx context.Context, id string, sellerID uuid.NullUUID, change StatusChange) (int32, error) {
+	var data EventData
+	var newVersion int32
+	err := s.inTx(ctx, func(tx *PackageService) error {
+		var err error
+		data, newVersion, err = tx.updateStatus(ctx, id, sellerID, change)
+		return err
+	})
 	if err != nil {
-		return nil, fmt.Errorf("list packages: %v", err)
+		return 0, err
 	}
 
-	return packages, nil
+	s.publish(ctx, EventPackageStatusChanged, data)
+
+	return newVersion, nil
 }
 
-func (s *PackageService) UpdateStatus(ctx context.Context, id, status string) error {
-	packageID, err := uuid.Parse(id)
+func (s *PackageService) updateStatus(ctx context.Context, id string, sellerID uuid.NullUUID, change StatusChange) (EventData, int32, error) {
+	found, err := s.GetByID(ctx, id, sellerID)
 	if err != nil {
-		return fmt.Errorf("parse package id: %v", err)
+		return EventData{}, 0, err
 	}
+	pkg, packageID := *found, found.ID
 
-	if status == "enviado" {
-		trackingCode := tracking.GenerateUniqueTrackingCode(func(code string) bool {
-			exists, err := s.repository.TrackingCodeExists(ctx, sql.NullString{
-				String: code,
-				Valid:  true,
-			})
-			if err != nil {
-				s.logger.Errorw("failed to check tracking code existence", "error", err, "tracking_code", code)
-				return true
-			}
-			return exists
-		})
+	if err := checkPackageVersion(pkg, change.Version); err != nil {
+		return EventData{}, 0, err
+	}
+
+	if err := ValidateStatusTransition(pkg.Status, change.Status); err != nil {
+		return EventData{}, 0, err
+	}
+
+	if change.Status == StatusAwaitingPickup && !pkg.HiredCarrierID.Valid {
+		return EventData{}, 0, fmt.Errorf("%w: package has no hired carrier", ErrInvalidStatusTransition)
+	}
+
+	previousStatus := pkg.Status
+	var updated int64
+	if change.Status == StatusShipped && !pkg.TrackingCode.Valid {
+		prefix, err := s.trackingPrefix(ctx, pkg)
+		if err != nil {
+			return EventData{}, 0, err
+		}
+
+		trackingCode, err := s.newTrackingCode(ctx, prefix)
+		if err != nil {
+			return EventData{}, 0, err
+		}
 
 		arg := repository.UpdatePackageStatusWithTrackingParams{
 			ID:           packageID,
-			Status:       status,
+			Status:       change.Status,
 			TrackingCode: sql.NullString{String: trackingCode, Valid: true},
+			Version:      pkg.Version,
 		}
 
-		err = s.repository.UpdatePackageStatusWithTracking(ctx, arg)
+		updated, err = s.repository.UpdatePackageStatusWithTracking(ctx, arg)
+		pkg.TrackingCode = arg.TrackingCode
 	} else {
 		arg := repository.UpdatePackageStatusParams{
-			ID:     packageID,
-			Status: status,
+			ID:      packageID,
+			Status:  change.Status,
+			Version: pkg.Version,
 		}
 
-		err = s.repository.UpdatePackageStatus(ctx, arg)
+		updated, err = s.repository.UpdatePackageStatus(ctx, arg)
 	}
 
 	if err != nil {
-		return fmt.Errorf("update package status: %v", err)
+		return EventData{}, 0, fmt.Errorf("update package status: %v", err)
+	}
+	if updated == 0 {
+		return EventData{}, 0, versionConflict("update package status", change.Version)
 	}
 
-	return nil
+	if err := s.recordStatusEvent(ctx, packageID, previousStatus, change.Status, change.Actor, change.Reason); err != nil {
+		return EventData{}, 0, err
+	}
+
+	pkg.Status = change.Status
+	data := newEventData(pkg)
+	data.PreviousStatus = previousStatus
+	data.Actor = change.Actor
+
+	return data, pkg.Version + 1, nil
 }
 
-func (s *PackageService) Delete(ctx context.Context, id string) error {
-	packageID, err := uuid.Parse(id)
+func (s *PackageService) trackingPrefix(ctx context.Context, pkg repository.Package) (string, error) {
+	if !pkg.HiredCarrierID.Valid {
+		return tracking.DefaultPrefix, nil
+	}
+
+	carrier, err := s.repository.GetCarrierById(ctx, pkg.HiredCarrierID.UUID)
 	if err != nil {
-		return fmt.Errorf("parse package id: %v", err)
+		return "", fmt.Errorf("get carrier by id: %v", err)
 	}
+	return carrier.TrackingPrefix, nil
+}
 
-	err = s.repository.DeletePackage(ctx, packageID)
+// newTrackingCode takes the serial from a per-prefix database sequence, so codes never collide.
+func (s *PackageService) newTrackingCode(ctx context.Context, prefix string) (string, error) {
+	serial, err := s.repository.NextTrackingSerial(ctx, prefix)
 	if err != nil {
-		return fmt.Errorf("delete package: %v", err)
+		return "", fmt.Errorf("next tracking serial: %v", err)
 	}
 
+	return tracking.New(prefix, serial)
+}
+
+// PackageDeletion.Version comes from If-Match; zero accepts any version.
+type PackageDeletion struct {
+	Actor   string
+	Reason  string
+	Version int32
+}
+
+// Delete is a soft delete and refuses packages that were already shipped.
+func (s *PackageService) Delete(ctx context.Context, id string, sellerID uuid.NullUUID, deletion PackageDeletion) error {
+	var data EventData
+	err := s.inTx(ctx, func(tx *PackageService) error {
+		pkg, err := tx.GetByID(ctx, id, sellerID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkPackageVersion(*pkg, deletion.Version); err != nil {
+			return err
+		}
+
+		if wasShipped(pkg.Status) {
+			return fmt.Errorf("delete package: %w: status %s", ErrPackageAlreadyShipped, pkg.Status)
+		}
+
+		deleted, err := tx.repository.DeletePackage(ctx, repository.DeletePackageParams{
+			ID:            pkg.ID,
+			Version:       pkg.Version,
+			DeletedBy:     nullString(deletion.Actor),
+			DeletedReason: nullString(deletion.Reason),
+		})
+		if err != nil {
+			return fmt.Errorf("delete package: %v", err)
+		}
+		if deleted == 0 {
+			return versionConflict("delete package", deletion.Version)
+		}
+
+		data = EventData{PackageID: pkg.ID, Actor: deletion.Actor}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publish(ctx, EventPackageDeleted, data)
+
 	return nil
 }
 
-func (s *PackageService) HireCarrier(ctx context.Context, packageID, carrierID string, price string, deliveryDays int32) error {
-	pkg, err := s.GetByID(ctx, packageID)
+// Restore keeps the status the package had when deleted.
+func (s *PackageService) Restore(ctx context.Context, id string, sellerID uuid.NullUUID, actor string, version int32) (*repository.Package, error) {
+	packageID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("parse package id: %v", err)
+	}
+
+	var restored repository.Package
+	err = s.inTx(ctx, func(tx *PackageService) error {
+		pkg, err := tx.repository.GetPackageById(ctx, repository.GetPackageByIdParams{
+			ID:             packageID,
+			SellerID:       sellerID,
+			IncludeDeleted: true,
+		})
+		if err != nil {
+			return packageLookupError(err)
+		}
+
+		if err := checkPackageVersion(pkg, version); err != nil {
+			return err
+		}
+
+		if !pkg.DeletedAt.Valid {
+			return fmt.Errorf("restore package: %w", ErrPackageNotDeleted)
+		}
+
+		updated, err := tx.repository.RestorePackage(ctx, repository.RestorePackageParams{
+			ID:      pkg.ID,
+			Version: pkg.Version,
+		})
+		if err != nil {
+			return fmt.Errorf("restore package: %v", err)
+		}
+		if updated == 0 {
+			return versionConflict("restore package", version)
+		}
+
+		found, err := tx.GetByID(ctx, id, sellerID)
+		if err != nil {
+			return err
+		}
+		restored = *found
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("package not found")
+		return nil, err
+	}
+
+	data := newEventData(restored)
+	data.Actor = actor
+	s.publish(ctx, EventPackageRestored, data)
+
+	return &restored, nil
+}
+
+func wasShipped(status string) bool {
+	switch status {
+	case StatusShipped, StatusDelivered, StatusLost:
+		return true
 	}
+	return false
+}
 
-	if err := s.ValidateCarrierForRegion(ctx, carrierID, pkg.DestinationState); err != nil {
+// HireCarrier accepts any version when version is zero and returns the new one.
+func (s *PackageService) HireCarrier(ctx context.Context, packageID, quoteID string, sellerID uuid.NullUUID, actor string, version int32) (int32, error) {
+	var data EventData
+	var newVersion int32
+	err := s.inTx(ctx, func(tx *PackageService) error {
+		var err error
+		data, newVersion, err = tx.hireCarrier(ctx, packageID, quoteID, sellerID, actor, version)
 		return err
+	})
+	if err != nil {
+		return 0, err
 	}
 
-	pkgUUID, err := uuid.Parse(packageID)
+	s.publish(ctx, EventPackageStatusChanged, data)
+	s.publish(ctx, EventPackageCarrierHired, data)
+
+	return newVersion, nil
+}
+
+func (s *PackageService) hireCarrier(ctx context.Context, packageID, quoteID string, sellerID uuid.NullUUID, actor string, version int32) (EventData, int32, error) {
+	pkg, err := s.GetByID(ctx, packageID, sellerID)
 	if err != nil {
-		return fmt.Errorf("invalid package ID")
+		return EventData{}, 0, err
 	}
 
-	carrierUUID, err := uuid.Parse(carrierID)
+	if err := checkPackageVersion(*pkg, version); err != nil {
+		return EventData{}, 0, err
+	}
+
+	if err := ValidateStatusTransition(pkg.Status, StatusAwaitingPickup); err != nil {
+		return EventData{}, 0, err
+	}
+
+	quoteUUID, err := uuid.Parse(quoteID)
 	if err != nil {
-		return fmt.Errorf("invalid carrier ID")
+		return EventData{}, 0, fmt.Errorf("invalid quote ID")
+	}
+
+	quote, err := s.repository.GetQuoteById(ctx, quoteUUID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return EventData{}, 0, fmt.Errorf("get quote by id: %w", ErrQuoteNotFound)
+		}
+		return EventData{}, 0, fmt.Errorf("get quote by id: %v", err)
+	}
+
+	if quote.PackageID != pkg.ID {
+		return EventData{}, 0, fmt.Errorf("quote does not belong to package: %w", ErrQuoteNotFound)
+	}
+
+	if quote.UsedAt.Valid {
+		return EventData{}, 0, ErrQuoteAlreadyUsed
+	}
+
+	originState, err := s.warehouseState(ctx, pkg.OriginWarehouseID)
+	if err != nil {
+		return EventData{}, 0, err
+	}
+
+	if err := s.ValidateCarrierForRegion(ctx, quote.CarrierID.String(), originState.String, pkg.DestinationState); err != nil {
+		return EventData{}, 0, err
+	}
+
+	carrier, err := s.repository.GetCarrierById(ctx, quote.CarrierID)
+	if err != nil {
+		return EventData{}, 0, fmt.Errorf("carrier not found")
+	}
+	if carrier.DeletedAt.Valid {
+		return EventData{}, 0, fmt.Errorf("hire deleted carrier: %w", ErrCarrierNotFound)
+	}
+
+	if err := s.ValidateCarrierRestrictions(ctx, carrier, *pkg); err != nil {
+		return EventData{}, 0, err
+	}
+
+	deliveryCalendar, err := s.deliveryCalendar(ctx, pkg.DestinationState, time.Now())
+	if err != nil {
+		return EventData{}, 0, err
+	}
+	estimatedDeliveryDate := deliveryCalendar.DeliveryDate(time.Now(), int(quote.EstimatedDeliveryDays), carrier.CutoffTime)
+
+	// Only marks quotes that are unused and not expired
+	marked, err := s.repository.MarkQuoteUsed(ctx, quote.ID)
+	if err != nil {
+		return EventData{}, 0, fmt.Errorf("mark quote used: %v", err)
+	}
+	if marked == 0 {
+		return EventData{}, 0, ErrQuoteExpired
 	}
 
 	arg := repository.HireCarrierParams{
-		ID: pkgUUID,
+		ID: pkg.ID,
 		HiredCarrierID: uuid.NullUUID{
-			UUID:  carrierUUID,
+			UUID:  quote.CarrierID,
 			Valid: true,
 		},
 		HiredPrice: sql.NullString{
-			String: price,
+			String: quote.Price,
 			Valid:  true,
 		},
 		HiredDeliveryDays: sql.NullInt32{
-			Int32: deliveryDays,
+			Int32: quote.EstimatedDeliveryDays,
 			Valid: true,
 		},
+		EstimatedDeliveryDate: deliveryDate(estimatedDeliveryDate),
+		Version:               pkg.Version,
+		TrackingCode:          pkg.TrackingCode,
 	}
 
-	return s.repository.HireCarrier(ctx, arg)
+	// Issue the code at hire so labels and carrier events can find it
+	if !pkg.TrackingCode.Valid {
+		code, err := s.newTrackingCode(ctx, carrier.TrackingPrefix)
+		if err != nil {
+			return EventData{}, 0, err
+		}
+		arg.TrackingCode = sql.NullString{String: code, Valid: true}
+	}
+
+	updated, err := s.repository.HireCarrier(ctx, arg)
+	if err != nil {
+		return EventData{}, 0, fmt.Errorf("hire carrier: %v", err)
+	}
+	if updated == 0 {
+		return EventData{}, 0, versionConflict("hire carrier", version)
+	}
+
+	if err := s.recordStatusEvent(ctx, pkg.ID, pkg.Status, StatusAwaitingPickup, actor, ""); err != nil {
+		return EventData{}, 0, err
+	}
+
+	hired := *pkg
+	hired.Status = StatusAwaitingPickup
+	hired.HiredCarrierID = arg.HiredCarrierID
+	hired.HiredPrice = arg.HiredPrice
+	hired.EstimatedDeliveryDate = arg.EstimatedDeliveryDate
+	hired.TrackingCode = arg.TrackingCode
+	data := newEventData(hired)
+	data.PreviousStatus = pkg.Status
+	data.Actor = actor
+
+	return data, pkg.Version + 1, nil
 }
 
-func (s *PackageService) ValidateCarrierForRegion(ctx context.Context, carrierID, stateCode string) error {
+// ValidateCarrierForRegion with an empty originState accepts only any-origin tables.
+func (s *PackageService) ValidateCarrierForRegion(ctx context.Context, carrierID, originState, stateCode string) error {
 	carrierUUID, err := uuid.Parse(carrierID)
 	if err != nil {
 		return fmt.Errorf("invalid carrier ID")
@@ -182,13 +563,25 @@ func (s *PackageService) ValidateCarrierForRegion(ctx context.Context, carrierID
 		return fmt.Errorf("invalid state code")
 	}
 
+	var originRegionID uuid.NullUUID
+	if originState != "" {
+		originRegion, err := s.repository.GetRegionByState(ctx, originState)
+		if err != nil {
+			return fmt.Errorf("invalid origin state code")
+		}
+		originRegionID = uuid.NullUUID{UUID: originRegion.ID, Valid: true}
+	}
+
 	carrierRegions, err := s.repository.GetCarrierRegions(ctx, carrierUUID)
 	if err != nil {
 		return fmt.Errorf("carrier not found")
 	}
 
 	for _, cr := range carrierRegions {
-		if cr.RegionID == region.ID {
+		if cr.RegionID != region.ID {
+			continue
+		}
+		if !cr.OriginRegionID.Valid || cr.OriginRegionID == originRegionID {
 			return nil
 		}
 	}
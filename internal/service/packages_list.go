@@ -0,0 +1,181 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+const (
+	SortByCreatedAt = "created_at"
+	SortByWeightKg  = "weight_kg"
+
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+type PackageListFilter struct {
+	Status           string
+	DestinationState string
+	HiredCarrierID   string
+	CreatedFrom      *time.Time
+	CreatedTo        *time.Time
+	Search           string
+	SortBy           string
+	SortDesc         bool
+	Cursor           string
+	Limit            int32
+	SellerID         uuid.NullUUID
+	Deleted          bool
+}
+
+type PackagePage struct {
+	Packages   []repository.Package
+	NextCursor string
+	Total      int64
+}
+
+// pageCursor carries its sort order because it is only valid for that order.
+type pageCursor struct {
+	SortBy   string    `json:"s"`
+	SortDesc bool      `json:"d"`
+	Value    string    `json:"v"`
+	ID       uuid.UUID `json:"id"`
+}
+
+func (s *PackageService) List(ctx context.Context, filter PackageListFilter) (*PackagePage, error) {
+	if filter.SortBy == "" {
+		filter.SortBy = SortByCreatedAt
+	}
+	if filter.SortBy != SortByCreatedAt && filter.SortBy != SortByWeightKg {
+		return nil, fmt.Errorf("%w: unsupported sort field %q", ErrInvalidListFilter, filter.SortBy)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultPageLimit
+	}
+	if filter.Limit > maxPageLimit {
+		filter.Limit = maxPageLimit
+	}
+
+	countArg := repository.CountPackagesParams{
+		Status:           nullString(filter.Status),
+		DestinationState: nullString(filter.DestinationState),
+		CreatedFrom:      nullTime(filter.CreatedFrom),
+		CreatedTo:        nullTime(filter.CreatedTo),
+		Search:           nullString(filter.Search),
+		SellerID:         filter.SellerID,
+		Deleted:          filter.Deleted,
+	}
+	if filter.HiredCarrierID != "" {
+		carrierID, err := uuid.Parse(filter.HiredCarrierID)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid carrier id", ErrInvalidListFilter)
+		}
+		countArg.HiredCarrierID = uuid.NullUUID{UUID: carrierID, Valid: true}
+	}
+
+	arg := repository.ListPackagesPageParams{
+		Status:           countArg.Status,
+		DestinationState: countArg.DestinationState,
+		HiredCarrierID:   countArg.HiredCarrierID,
+		CreatedFrom:      countArg.CreatedFrom,
+		CreatedTo:        countArg.CreatedTo,
+		Search:           countArg.Search,
+		SortBy:           filter.SortBy,
+		SortDesc:         filter.SortDesc,
+		SellerID:         countArg.SellerID,
+		Deleted:          countArg.Deleted,
+		PageLimit:        filter.Limit + 1,
+	}
+	if filter.Cursor != "" {
+		if err := applyCursor(&arg, filter.Cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	packages, err := s.repository.ListPackagesPage(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("list packages page: %v", err)
+	}
+
+	total, err := s.repository.CountPackages(ctx, countArg)
+	if err != nil {
+		return nil, fmt.Errorf("count packages: %v", err)
+	}
+
+	page := &PackagePage{
+		Packages: packages,
+		Total:    total,
+	}
+	if len(packages) > int(filter.Limit) {
+		page.Packages = packages[:filter.Limit]
+		page.NextCursor = encodeCursor(page.Packages[len(page.Packages)-1], filter.SortBy, filter.SortDesc)
+	}
+
+	return page, nil
+}
+
+func encodeCursor(pkg repository.Package, sortBy string, sortDesc bool) string {
+	cursor := pageCursor{SortBy: sortBy, SortDesc: sortDesc, ID: pkg.ID}
+	switch sortBy {
+	case SortByWeightKg:
+		cursor.Value = strconv.FormatFloat(pkg.WeightKg, 'f', -1, 64)
+	default:
+		cursor.Value = pkg.CreatedAt.Time.Format(time.RFC3339Nano)
+	}
+
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func applyCursor(arg *repository.ListPackagesPageParams, encoded string) error {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("%w: malformed cursor", ErrInvalidListFilter)
+	}
+
+	var cursor pageCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return fmt.Errorf("%w: malformed cursor", ErrInvalidListFilter)
+	}
+
+	if cursor.SortBy != arg.SortBy || cursor.SortDesc != arg.SortDesc {
+		return fmt.Errorf("%w: cursor was issued for another sort order", ErrInvalidListFilter)
+	}
+
+	switch arg.SortBy {
+	case SortByWeightKg:
+		weight, err := strconv.ParseFloat(cursor.Value, 64)
+		if err != nil {
+			return fmt.Errorf("%w: cursor does not match sort field", ErrInvalidListFilter)
+		}
+		arg.CursorWeightKg = sql.NullFloat64{Float64: weight, Valid: true}
+	default:
+		createdAt, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return fmt.Errorf("%w: cursor does not match sort field", ErrInvalidListFilter)
+		}
+		arg.CursorCreatedAt = sql.NullTime{Time: createdAt, Valid: true}
+	}
+	arg.CursorID = uuid.NullUUID{UUID: cursor.ID, Valid: true}
+
+	return nil
+}
+
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
+
+func nullTime(value *time.Time) sql.NullTime {
+	if value == nil {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: *value, Valid: true}
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+// RateTier covers weights in (MinWeightKg, MaxWeightKg].
+type RateTier struct {
+	MinWeightKg float64
+	MaxWeightKg float64
+	Price       float64
+}
+
+type RateTable struct {
+	PricePerKg       float64
+	ExcessPricePerKg float64
+	MinCharge        float64
+	MarkupPercent    float64
+	Tiers            []RateTier
+}
+
+// SellerRate with a zero PricePerKg keeps the carrier's table price.
+type SellerRate struct {
+	PricePerKg    float64
+	MarkupPercent float64
+}
+
+// Apply replaces the tiers and excess rate with the negotiated price, keeping the minimum charge.
+func (r SellerRate) Apply(table RateTable) RateTable {
+	if r.PricePerKg > 0 {
+		table.PricePerKg = r.PricePerKg
+		table.ExcessPricePerKg = r.PricePerKg
+		table.Tiers = nil
+	}
+	table.MarkupPercent = r.MarkupPercent
+	return table
+}
+
+// Price never goes below the minimum charge, and the markup applies to the final value.
+func (t RateTable) Price(billableWeightKg float64) float64 {
+	price := t.PricePerKg * billableWeightKg
+
+	if len(t.Tiers) > 0 {
+		last := t.Tiers[len(t.Tiers)-1]
+		price = last.Price + (billableWeightKg-last.MaxWeightKg)*t.ExcessPricePerKg
+		for _, tier := range t.Tiers {
+			if billableWeightKg <= tier.MaxWeightKg {
+				price = tier.Price
+				break
+			}
+		}
+	}
+
+	if price < t.MinCharge {
+		price = t.MinCharge
+	}
+	price += price * t.MarkupPercent / 100
+
+	return roundPrice(price)
+}
+
+func newRateTable(rate repository.GetQuotesForPackageRow, tiers []repository.CarrierRateTier) (RateTable, error) {
+	pricePerKg, err := strconv.ParseFloat(rate.PricePerKg, 64)
+	if err != nil {
+		return RateTable{}, fmt.Errorf("parse price per kg: %v", err)
+	}
+
+	minCharge, err := strconv.ParseFloat(rate.MinCharge, 64)
+	if err != nil {
+		return RateTable{}, fmt.Errorf("parse min charge: %v", err)
+	}
+
+	// Without an excess rate, weight above the last tier is charged at the price per kg
+	excessPricePerKg := pricePerKg
+	if rate.ExcessPricePerKg.Valid {
+		excessPricePerKg, err = strconv.ParseFloat(rate.ExcessPricePerKg.String, 64)
+		if err != nil {
+			return RateTable{}, fmt.Errorf("parse excess price per kg: %v", err)
+		}
+	}
+
+	table := RateTable{
+		PricePerKg:       pricePerKg,
+		ExcessPricePerKg: excessPricePerKg,
+		MinCharge:        minCharge,
+	}
+	for _, tier := range tiers {
+		rateTier, err := newRateTier(tier)
+		if err != nil {
+			return RateTable{}, err
+		}
+		table.Tiers = append(table.Tiers, rateTier)
+	}
+
+	return table, nil
+}
+
+func newSellerRate(rate repository.SellerCarrierRate) (SellerRate, error) {
+	markup, err := strconv.ParseFloat(rate.MarkupPercent, 64)
+	if err != nil {
+		return SellerRate{}, fmt.Errorf("parse seller markup: %v", err)
+	}
+
+	sellerRate := SellerRate{MarkupPercent: markup}
+	if rate.PricePerKg.Valid {
+		sellerRate.PricePerKg, err = strconv.ParseFloat(rate.PricePerKg.String, 64)
+		if err != nil {
+			return SellerRate{}, fmt.Errorf("parse seller price per kg: %v", err)
+		}
+	}
+
+	return sellerRate, nil
+}
+
+func newRateTier(tier repository.CarrierRateTier) (RateTier, error) {
+	minWeight, err := strconv.ParseFloat(tier.MinWeightKg, 64)
+	if err != nil {
+		return RateTier{}, fmt.Errorf("parse tier min weight: %v", err)
+	}
+
+	maxWeight, err := strconv.ParseFloat(tier.MaxWeightKg, 64)
+	if err != nil {
+		return RateTier{}, fmt.Errorf("parse tier max weight: %v", err)
+	}
+
+	price, err := strconv.ParseFloat(tier.Price, 64)
+	if err != nil {
+		return RateTier{}, fmt.Errorf("parse tier price: %v", err)
+	}
+
+	return RateTier{MinWeightKg: minWeight, MaxWeightKg: maxWeight, Price: price}, nil
+}
+
+// groupRateTiers keeps the query's max weight order within each region.
+func groupRateTiers(tiers []repository.CarrierRateTier) map[uuid.UUID][]repository.CarrierRateTier {
+	grouped := make(map[uuid.UUID][]repository.CarrierRateTier)
+	for _, tier := range tiers {
+		grouped[tier.CarrierRegionID] = append(grouped[tier.CarrierRegionID], tier)
+	}
+	return grouped
+}
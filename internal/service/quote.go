@@ -5,33 +5,234 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"math"
+	"strconv"
+	"time"
 
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/config"
 	"github/moura95/olist-shipping-api/internal/repository"
 )
 
-func (s *PackageService) GetQuotes(ctx context.Context, stateCode string, weightKg float64) ([]repository.GetQuotesForPackageRow, error) {
-	_, err := s.repository.GetStateByCode(ctx, stateCode)
+type Dimensions struct {
+	HeightCm float64
+	WidthCm  float64
+	LengthCm float64
+}
+
+type QuoteParams struct {
+	StateCode         string
+	DestinationCEP    string
+	OriginWarehouseID string
+	WeightKg          float64
+	Dimensions        *Dimensions
+	Category          string
+	// Without SellerID the carriers' standard tables apply
+	SellerID uuid.NullUUID
+}
+
+type Quote struct {
+	ID                    uuid.UUID
+	ExpiresAt             time.Time
+	CarrierID             uuid.UUID
+	CarrierName           string
+	BillableWeightKg      float64
+	EstimatedPrice        float64
+	EstimatedDeliveryDays int32
+	EstimatedDeliveryDate time.Time
+	CEPSurcharge          float64
+}
+
+type QuoteResult struct {
+	Quotes      []Quote
+	Unavailable []UnavailableCarrier
+}
+
+func (s *PackageService) GetQuotes(ctx context.Context, params QuoteParams) (*QuoteResult, error) {
+	stateCode, location, err := s.resolveDestination(ctx, params.StateCode, params.DestinationCEP)
+	if err != nil {
+		return nil, err
+	}
+	params.StateCode = stateCode
+
+	_, err = s.repository.GetStateByCode(ctx, params.StateCode)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
-			return nil, fmt.Errorf("invalid state code: %s", stateCode)
+			return nil, fmt.Errorf("invalid state code: %s", params.StateCode)
 		}
 		return nil, fmt.Errorf("error validating state: %v", err)
 	}
 
-	// Busca as cotações
-	arg := repository.GetQuotesForPackageParams{
-		StateCode: stateCode,
-		WeightKg:  fmt.Sprintf("%.2f", weightKg),
+	warehouseID, err := parseWarehouseID(params.OriginWarehouseID)
+	if err != nil {
+		return nil, err
+	}
+	originState, err := s.warehouseState(ctx, warehouseID)
+	if err != nil {
+		return nil, err
 	}
 
-	quotes, err := s.repository.GetQuotesForPackage(ctx, arg)
+	rates, err := s.repository.GetQuotesForPackage(ctx, repository.GetQuotesForPackageParams{
+		StateCode:   params.StateCode,
+		OriginState: originState,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("error busca cotações: %v", err)
 	}
 
-	if len(quotes) == 0 {
-		return nil, fmt.Errorf("nehuma transportadora encontrada para o estado %s", stateCode)
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("nehuma transportadora encontrada para o estado %s", params.StateCode)
+	}
+
+	tiers, err := s.repository.ListCarrierRateTiersByState(ctx, params.StateCode)
+	if err != nil {
+		return nil, fmt.Errorf("list rate tiers: %v", err)
+	}
+	tiersByRegion := groupRateTiers(tiers)
+
+	sellerRates, err := s.sellerRates(ctx, params.SellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	restricted, err := s.restrictedCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var cep string
+	if location != nil {
+		cep = location.CEP
+	}
+	cepRules, err := s.carrierCEPRules(ctx, cep)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	deliveryCalendar, err := s.deliveryCalendar(ctx, params.StateCode, now)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &QuoteResult{Quotes: make([]Quote, 0, len(rates))}
+	for _, rate := range rates {
+		capabilities := CarrierCapabilities{
+			MaxWeightKg:          rate.MaxWeightKg.Float64,
+			MaxSideCm:            rate.MaxSideCm.Float64,
+			MaxDimensionsSumCm:   rate.MaxDimensionsSumCm.Float64,
+			RestrictedCategories: restricted[rate.CarrierID],
+		}
+		reasons := capabilities.Check(params.WeightKg, params.Dimensions, params.Category)
+		cepRule, hasCEPRule := cepRules[rate.CarrierID]
+		if hasCEPRule && !cepRule.Serviceable {
+			reasons = append(reasons, cepNotServedReason(cep))
+		}
+		if len(reasons) > 0 {
+			result.Unavailable = append(result.Unavailable, UnavailableCarrier{
+				CarrierID:   rate.CarrierID,
+				CarrierName: rate.CarrierName,
+				Reasons:     reasons,
+			})
+			continue
+		}
+
+		table, err := newRateTable(rate, tiersByRegion[rate.CarrierRegionID])
+		if err != nil {
+			return nil, err
+		}
+		if sellerRate, ok := sellerRates[rate.CarrierID]; ok {
+			table = sellerRate.Apply(table)
+		}
+
+		billableWeight := BillableWeight(params.WeightKg, params.Dimensions, rate.CubingDivisor)
+		deliveryDays := rate.EstimatedDeliveryDays + cepRule.ExtraDeliveryDays
+		result.Quotes = append(result.Quotes, Quote{
+			CarrierID:             rate.CarrierID,
+			CarrierName:           rate.CarrierName,
+			BillableWeightKg:      billableWeight,
+			EstimatedPrice:        roundPrice(table.Price(billableWeight) + cepRule.Surcharge),
+			EstimatedDeliveryDays: deliveryDays,
+			EstimatedDeliveryDate: deliveryCalendar.DeliveryDate(now, int(deliveryDays), rate.CutoffTime),
+			CEPSurcharge:          cepRule.Surcharge,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *PackageService) CreatePackageQuotes(ctx context.Context, packageID string, sellerID uuid.NullUUID) (*QuoteResult, error) {
+	var result *QuoteResult
+	err := s.inTx(ctx, func(tx *PackageService) error {
+		var err error
+		result, err = tx.createPackageQuotes(ctx, packageID, sellerID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (s *PackageService) createPackageQuotes(ctx context.Context, packageID string, sellerID uuid.NullUUID) (*QuoteResult, error) {
+	pkg, err := s.GetByID(ctx, packageID, sellerID)
+	if err != nil {
+		return nil, err
 	}
 
-	return quotes, nil
+	if err := ValidateStatusTransition(pkg.Status, StatusAwaitingPickup); err != nil {
+		return nil, err
+	}
+
+	result, err := s.GetQuotes(ctx, QuoteParams{
+		StateCode:         pkg.DestinationState,
+		DestinationCEP:    pkg.DestinationCep.String,
+		OriginWarehouseID: packageWarehouseID(*pkg),
+		WeightKg:          pkg.WeightKg,
+		Dimensions:        PackageDimensions(*pkg),
+		Category:          pkg.ProductCategory,
+		SellerID:          uuid.NullUUID{UUID: pkg.SellerID, Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := s.config.QuoteTTL
+	if ttl <= 0 {
+		ttl = config.DefaultQuoteTTL
+	}
+
+	for i, quote := range result.Quotes {
+		stored, err := s.repository.CreateQuote(ctx, repository.CreateQuoteParams{
+			PackageID:             pkg.ID,
+			CarrierID:             quote.CarrierID,
+			Price:                 strconv.FormatFloat(quote.EstimatedPrice, 'f', 2, 64),
+			EstimatedDeliveryDays: quote.EstimatedDeliveryDays,
+			BillableWeightKg:      quote.BillableWeightKg,
+			TtlSeconds:            ttl.Seconds(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("create quote: %v", err)
+		}
+
+		result.Quotes[i].ID = stored.ID
+		result.Quotes[i].ExpiresAt = stored.ExpiresAt
+	}
+
+	return result, nil
+}
+
+// BillableWeight is the greater of the actual and the volumetric weight (H x W x L / divisor).
+func BillableWeight(weightKg float64, dimensions *Dimensions, cubingDivisor int32) float64 {
+	if dimensions == nil || cubingDivisor <= 0 {
+		return weightKg
+	}
+
+	volumetricWeight := dimensions.HeightCm * dimensions.WidthCm * dimensions.LengthCm / float64(cubingDivisor)
+	return math.Round(math.Max(weightKg, volumetricWeight)*1000) / 1000
+}
+
+func roundPrice(value float64) float64 {
+	return math.Round(value*100) / 100
 }
@@ -0,0 +1,430 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+const (
+	RateChangeNew       = "nova"
+	RateChangeUpdated   = "alterada"
+	RateChangeUnchanged = "sem_alteracao"
+)
+
+// RateImportRow without MinWeightKg starts where the lane's previous tier ends.
+type RateImportRow struct {
+	Line                  int
+	Region                string
+	State                 string
+	MinWeightKg           *float64
+	MaxWeightKg           float64
+	Price                 float64
+	EstimatedDeliveryDays int32
+	MinCharge             *float64
+	ExcessPricePerKg      *float64
+	Errors                []string
+}
+
+type RateSheet struct {
+	EstimatedDeliveryDays int32
+	MinCharge             float64
+	ExcessPricePerKg      float64
+	Tiers                 []RateTier
+}
+
+// RateChange.Current is nil for a new lane.
+type RateChange struct {
+	RegionID   uuid.UUID
+	RegionName string
+	Status     string
+	Current    *RateSheet
+	Proposed   RateSheet
+	VersionID  uuid.UUID
+}
+
+type RateImportError struct {
+	Line   int
+	Errors []string
+}
+
+type RateImportReport struct {
+	DryRun  bool
+	Applied bool
+	Changes []RateChange
+	Errors  []RateImportError
+}
+
+func (r *RateImportReport) Rejected() bool {
+	return len(r.Errors) > 0
+}
+
+type rateImportLane struct {
+	region repository.Region
+	rows   []RateImportRow
+}
+
+type batchRateLane struct {
+	RegionID              uuid.UUID       `json:"region_id"`
+	EstimatedDeliveryDays int32           `json:"estimated_delivery_days"`
+	PricePerKg            float64         `json:"price_per_kg"`
+	MinCharge             float64         `json:"min_charge"`
+	ExcessPricePerKg      float64         `json:"excess_price_per_kg"`
+	CubingDivisor         int32           `json:"cubing_divisor"`
+	Tiers                 []batchRateTier `json:"tiers"`
+}
+
+type batchRateTier struct {
+	MinWeightKg float64 `json:"min_weight_kg"`
+	MaxWeightKg float64 `json:"max_weight_kg"`
+	Price       float64 `json:"price"`
+}
+
+// ImportCarrierRates applies nothing if any row is invalid; lanes missing from the sheet are kept.
+func (s *PackageService) ImportCarrierRates(ctx context.Context, carrierID string, rows []RateImportRow, validFrom *time.Time, dryRun bool) (*RateImportReport, error) {
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: nenhuma linha encontrada", ErrInvalidImport)
+	}
+	if len(rows) > MaxImportRows {
+		return nil, fmt.Errorf("%w: máximo de %d linhas por arquivo", ErrInvalidImport, MaxImportRows)
+	}
+	if validFrom != nil && validFrom.Before(time.Now()) {
+		return nil, fmt.Errorf("import carrier rates: %w", ErrRetroactiveRate)
+	}
+
+	carrier, err := s.carrierByID(ctx, carrierID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RateImportReport{DryRun: dryRun}
+	lanes, err := s.groupRateImportRows(ctx, rows, report)
+	if err != nil {
+		return nil, err
+	}
+
+	current, err := s.currentRateSheets(ctx, carrier.ID, validFrom)
+	if err != nil {
+		return nil, err
+	}
+
+	var batch []batchRateLane
+	for _, lane := range lanes {
+		change, batchLane, errs := newRateChange(lane, current[lane.region.ID])
+		if len(errs) > 0 {
+			report.Errors = append(report.Errors, RateImportError{Line: lane.rows[0].Line, Errors: errs})
+			continue
+		}
+		report.Changes = append(report.Changes, change)
+		if change.Status != RateChangeUnchanged {
+			batch = append(batch, batchLane)
+		}
+	}
+
+	if report.Rejected() || dryRun {
+		return report, nil
+	}
+
+	if len(batch) > 0 {
+		if err := s.applyRateImport(ctx, carrier.ID, validFrom, batch, report); err != nil {
+			return nil, err
+		}
+	}
+	report.Applied = true
+
+	return report, nil
+}
+
+func (s *PackageService) groupRateImportRows(ctx context.Context, rows []RateImportRow, report *RateImportReport) ([]*rateImportLane, error) {
+	regions, err := s.repository.ListRegions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list regions: %v", err)
+	}
+
+	states, err := s.repository.ListStates(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list states: %v", err)
+	}
+
+	regionsByKey := make(map[string]repository.Region, len(regions)*2)
+	for _, region := range regions {
+		regionsByKey[strings.ToLower(region.Name)] = region
+		regionsByKey[region.ID.String()] = region
+	}
+
+	regionsByState := make(map[string]repository.Region, len(states))
+	for _, state := range states {
+		regionsByState[state.Code] = regionsByKey[strings.ToLower(state.RegionName)]
+	}
+
+	var lanes []*rateImportLane
+	lanesByRegion := make(map[uuid.UUID]*rateImportLane)
+	for _, row := range rows {
+		errs := row.Errors
+		var region repository.Region
+		if len(errs) == 0 {
+			region, errs = resolveRateImportRegion(row, regionsByKey, regionsByState)
+		}
+		if len(errs) > 0 {
+			report.Errors = append(report.Errors, RateImportError{Line: row.Line, Errors: errs})
+			continue
+		}
+
+		lane, ok := lanesByRegion[region.ID]
+		if !ok {
+			lane = &rateImportLane{region: region}
+			lanesByRegion[region.ID] = lane
+			lanes = append(lanes, lane)
+		}
+		lane.rows = append(lane.rows, row)
+	}
+
+	return lanes, nil
+}
+
+func resolveRateImportRegion(row RateImportRow, regionsByKey, regionsByState map[string]repository.Region) (repository.Region, []string) {
+	var region repository.Region
+	if row.Region != "" {
+		found, ok := regionsByKey[strings.ToLower(row.Region)]
+		if !ok {
+			return region, []string{"região desconhecida: " + row.Region}
+		}
+		region = found
+	}
+
+	if row.State != "" {
+		found, ok := regionsByState[row.State]
+		if !ok {
+			return region, []string{"estado desconhecido: " + row.State}
+		}
+		if row.Region != "" && found.ID != region.ID {
+			return region, []string{fmt.Sprintf("estado %s não pertence à região %s", row.State, region.Name)}
+		}
+		region = found
+	}
+
+	if region.ID == uuid.Nil {
+		return region, []string{"região ou estado é obrigatório"}
+	}
+
+	return region, nil
+}
+
+func (s *PackageService) currentRateSheets(ctx context.Context, carrierID uuid.UUID, at *time.Time) (map[uuid.UUID]*currentRateSheet, error) {
+	effectiveAt := sql.NullTime{Time: time.Now(), Valid: true}
+	if at != nil {
+		effectiveAt.Time = *at
+	}
+
+	rates, err := s.repository.ListCarrierRates(ctx, repository.ListCarrierRatesParams{
+		CarrierID: carrierID,
+		At:        effectiveAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list carrier rates: %v", err)
+	}
+
+	tiers, err := s.repository.ListCarrierRateTiersByCarrier(ctx, carrierID)
+	if err != nil {
+		return nil, fmt.Errorf("list carrier rate tiers: %v", err)
+	}
+	tiersByVersion := groupRateTiers(tiers)
+
+	sheets := make(map[uuid.UUID]*currentRateSheet)
+	for _, rate := range rates {
+		if rate.OriginRegionID.Valid {
+			continue
+		}
+		sheet, err := newCurrentRateSheet(rate, tiersByVersion[rate.ID])
+		if err != nil {
+			return nil, err
+		}
+		sheets[rate.RegionID] = sheet
+	}
+
+	return sheets, nil
+}
+
+type currentRateSheet struct {
+	RateSheet
+	pricePerKg    float64
+	cubingDivisor int32
+}
+
+func newCurrentRateSheet(rate repository.ListCarrierRatesRow, tiers []repository.CarrierRateTier) (*currentRateSheet, error) {
+	table, err := newRateTable(repository.GetQuotesForPackageRow{
+		PricePerKg:       rate.PricePerKg,
+		ExcessPricePerKg: rate.ExcessPricePerKg,
+		MinCharge:        rate.MinCharge,
+	}, tiers)
+	if err != nil {
+		return nil, err
+	}
+
+	return &currentRateSheet{
+		RateSheet: RateSheet{
+			EstimatedDeliveryDays: rate.EstimatedDeliveryDays,
+			MinCharge:             table.MinCharge,
+			ExcessPricePerKg:      table.ExcessPricePerKg,
+			Tiers:                 table.Tiers,
+		},
+		pricePerKg:    table.PricePerKg,
+		cubingDivisor: rate.CubingDivisor,
+	}, nil
+}
+
+// newRateChange keeps the current minimum charge and excess when the sheet omits them.
+func newRateChange(lane *rateImportLane, current *currentRateSheet) (RateChange, batchRateLane, []string) {
+	rows := append([]RateImportRow(nil), lane.rows...)
+	sort.SliceStable(rows, func(i, j int) bool { return rows[i].MaxWeightKg < rows[j].MaxWeightKg })
+
+	first := rows[0]
+	proposed := RateSheet{EstimatedDeliveryDays: first.EstimatedDeliveryDays}
+	var minCharge, excess *float64
+	var errs []string
+	previousMax := 0.0
+	for _, row := range rows {
+		minWeight := previousMax
+		if row.MinWeightKg != nil {
+			minWeight = *row.MinWeightKg
+		}
+		if minWeight != previousMax {
+			errs = append(errs, fmt.Sprintf("linha %d: a faixa deve começar em %s kg, onde termina a anterior", row.Line, formatWeight(previousMax)))
+		}
+		if row.MaxWeightKg <= minWeight {
+			errs = append(errs, fmt.Sprintf("linha %d: peso máximo deve ser maior que o mínimo", row.Line))
+		}
+		if row.EstimatedDeliveryDays != first.EstimatedDeliveryDays {
+			errs = append(errs, fmt.Sprintf("linha %d: prazo diferente das outras faixas da rota", row.Line))
+		}
+		if !sameOptional(&minCharge, row.MinCharge) {
+			errs = append(errs, fmt.Sprintf("linha %d: frete mínimo diferente das outras faixas da rota", row.Line))
+		}
+		if !sameOptional(&excess, row.ExcessPricePerKg) {
+			errs = append(errs, fmt.Sprintf("linha %d: excedente diferente das outras faixas da rota", row.Line))
+		}
+
+		proposed.Tiers = append(proposed.Tiers, RateTier{MinWeightKg: minWeight, MaxWeightKg: row.MaxWeightKg, Price: row.Price})
+		previousMax = row.MaxWeightKg
+	}
+
+	change := RateChange{RegionID: lane.region.ID, RegionName: lane.region.Name, Status: RateChangeNew}
+	pricePerKg, cubing := 0.0, int32(defaultCubingDivisor)
+	if current != nil {
+		change.Current = &current.RateSheet
+		proposed.MinCharge = current.MinCharge
+		proposed.ExcessPricePerKg = current.ExcessPricePerKg
+		pricePerKg, cubing = current.pricePerKg, current.cubingDivisor
+	}
+	if minCharge != nil {
+		proposed.MinCharge = *minCharge
+	}
+	if excess != nil {
+		proposed.ExcessPricePerKg = *excess
+	}
+	if current == nil {
+		if excess == nil {
+			errs = append(errs, "excedente_por_kg é obrigatório para rota nova")
+		}
+		pricePerKg = proposed.ExcessPricePerKg
+	}
+	change.Proposed = proposed
+
+	if current != nil && sameRateSheet(current.RateSheet, proposed) {
+		change.Status = RateChangeUnchanged
+	} else if current != nil {
+		change.Status = RateChangeUpdated
+	}
+
+	batchLane := batchRateLane{
+		RegionID:              lane.region.ID,
+		EstimatedDeliveryDays: proposed.EstimatedDeliveryDays,
+		PricePerKg:            pricePerKg,
+		MinCharge:             proposed.MinCharge,
+		ExcessPricePerKg:      proposed.ExcessPricePerKg,
+		CubingDivisor:         cubing,
+	}
+	for _, tier := range proposed.Tiers {
+		batchLane.Tiers = append(batchLane.Tiers, batchRateTier(tier))
+	}
+
+	return change, batchLane, errs
+}
+
+func (s *PackageService) applyRateImport(ctx context.Context, carrierID uuid.UUID, validFrom *time.Time, batch []batchRateLane, report *RateImportReport) error {
+	payload, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("encode rate import batch: %v", err)
+	}
+
+	var start sql.NullTime
+	if validFrom != nil {
+		start = sql.NullTime{Time: *validFrom, Valid: true}
+	}
+
+	versions, err := s.repository.ImportCarrierRates(ctx, repository.ImportCarrierRatesParams{
+		ValidFrom: start,
+		Lanes:     payload,
+		CarrierID: carrierID,
+	})
+	if err != nil {
+		if isUniqueViolation(err) {
+			return fmt.Errorf("import carrier rates: %w", ErrRateVersionExists)
+		}
+		return fmt.Errorf("import carrier rates: %v", err)
+	}
+
+	versionByRegion := make(map[uuid.UUID]uuid.UUID, len(versions))
+	for _, version := range versions {
+		versionByRegion[version.RegionID] = version.ID
+	}
+	for i := range report.Changes {
+		report.Changes[i].VersionID = versionByRegion[report.Changes[i].RegionID]
+	}
+
+	return nil
+}
+
+// sameOptional records the first non-nil value in seen and compares the rest to it.
+func sameOptional(seen **float64, value *float64) bool {
+	if value == nil {
+		return true
+	}
+	if *seen == nil {
+		*seen = value
+		return true
+	}
+	return samePrice(**seen, *value)
+}
+
+func sameRateSheet(a, b RateSheet) bool {
+	if a.EstimatedDeliveryDays != b.EstimatedDeliveryDays || !samePrice(a.MinCharge, b.MinCharge) ||
+		!samePrice(a.ExcessPricePerKg, b.ExcessPricePerKg) || len(a.Tiers) != len(b.Tiers) {
+		return false
+	}
+	for i := range a.Tiers {
+		if !samePrice(a.Tiers[i].MinWeightKg, b.Tiers[i].MinWeightKg) || !samePrice(a.Tiers[i].MaxWeightKg, b.Tiers[i].MaxWeightKg) ||
+			!samePrice(a.Tiers[i].Price, b.Tiers[i].Price) {
+			return false
+		}
+	}
+	return true
+}
+
+// samePrice tolerates values stored with 2 or 3 decimal places.
+func samePrice(a, b float64) bool {
+	return math.Abs(a-b) < 0.0005
+}
+
+func formatWeight(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
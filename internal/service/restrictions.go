@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+const (
+	CategoryGeneral = "geral"
+	CategoryFragile = "fragil"
+	CategoryLiquid  = "liquido"
+	CategoryBattery = "bateria"
+)
+
+// CarrierCapabilities use zero for no limit.
+type CarrierCapabilities struct {
+	MaxWeightKg          float64
+	MaxSideCm            float64
+	MaxDimensionsSumCm   float64
+	RestrictedCategories []string
+}
+
+// UnavailableCarrier serves the region but refuses the package.
+type UnavailableCarrier struct {
+	CarrierID   uuid.UUID
+	CarrierName string
+	Reasons     []string
+}
+
+// Check returns no reasons when the package is accepted.
+func (c CarrierCapabilities) Check(weightKg float64, dimensions *Dimensions, category string) []string {
+	var reasons []string
+
+	if c.MaxWeightKg > 0 && weightKg > c.MaxWeightKg {
+		reasons = append(reasons, fmt.Sprintf("peso de %.2f kg acima do máximo de %.2f kg", weightKg, c.MaxWeightKg))
+	}
+
+	if dimensions != nil {
+		largestSide := math.Max(dimensions.HeightCm, math.Max(dimensions.WidthCm, dimensions.LengthCm))
+		if c.MaxSideCm > 0 && largestSide > c.MaxSideCm {
+			reasons = append(reasons, fmt.Sprintf("lado de %.0f cm acima do máximo de %.0f cm", largestSide, c.MaxSideCm))
+		}
+
+		sum := dimensions.HeightCm + dimensions.WidthCm + dimensions.LengthCm
+		if c.MaxDimensionsSumCm > 0 && sum > c.MaxDimensionsSumCm {
+			reasons = append(reasons, fmt.Sprintf("soma das dimensões de %.0f cm acima do máximo de %.0f cm", sum, c.MaxDimensionsSumCm))
+		}
+	}
+
+	for _, restricted := range c.RestrictedCategories {
+		if restricted == packageCategory(category) {
+			reasons = append(reasons, fmt.Sprintf("categoria %s não aceita", restricted))
+		}
+	}
+
+	return reasons
+}
+
+func (s *PackageService) ValidateCarrierRestrictions(ctx context.Context, carrier repository.Carrier, pkg repository.Package) error {
+	restricted, err := s.restrictedCategories(ctx)
+	if err != nil {
+		return err
+	}
+
+	capabilities := CarrierCapabilities{
+		MaxWeightKg:          carrier.MaxWeightKg.Float64,
+		MaxSideCm:            carrier.MaxSideCm.Float64,
+		MaxDimensionsSumCm:   carrier.MaxDimensionsSumCm.Float64,
+		RestrictedCategories: restricted[carrier.ID],
+	}
+
+	reasons := capabilities.Check(pkg.WeightKg, PackageDimensions(pkg), pkg.ProductCategory)
+
+	cepRules, err := s.carrierCEPRules(ctx, pkg.DestinationCep.String)
+	if err != nil {
+		return err
+	}
+	if rule, ok := cepRules[carrier.ID]; ok && !rule.Serviceable {
+		reasons = append(reasons, cepNotServedReason(pkg.DestinationCep.String))
+	}
+
+	if len(reasons) > 0 {
+		return fmt.Errorf("%w: %s", ErrCarrierRestricted, strings.Join(reasons, "; "))
+	}
+
+	return nil
+}
+
+func (s *PackageService) restrictedCategories(ctx context.Context) (map[uuid.UUID][]string, error) {
+	rows, err := s.repository.ListCarrierRestrictedCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list restricted categories: %v", err)
+	}
+
+	restricted := make(map[uuid.UUID][]string)
+	for _, row := range rows {
+		restricted[row.CarrierID] = append(restricted[row.CarrierID], row.Category)
+	}
+	return restricted, nil
+}
+
+func packageCategory(category string) string {
+	if category == "" {
+		return CategoryGeneral
+	}
+	return category
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+// SaveSellerRateInput with a nil PricePerKg applies only the markup, or a discount when negative.
+type SaveSellerRateInput struct {
+	PricePerKg    *float64
+	MarkupPercent float64
+}
+
+func (s *PackageService) GetSellers(ctx context.Context) ([]repository.Seller, error) {
+	sellers, err := s.repository.ListSellers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list sellers: %v", err)
+	}
+
+	return sellers, nil
+}
+
+func (s *PackageService) CreateSeller(ctx context.Context, name string) (*repository.Seller, error) {
+	seller, err := s.repository.CreateSeller(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("create seller: %v", err)
+	}
+
+	return &seller, nil
+}
+
+func (s *PackageService) GetSellerRates(ctx context.Context, sellerID string) ([]repository.SellerCarrierRate, error) {
+	seller, err := sellerByID(ctx, s.repository, sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	rates, err := s.repository.ListSellerCarrierRates(ctx, seller.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list seller carrier rates: %v", err)
+	}
+
+	return rates, nil
+}
+
+func (s *PackageService) SaveSellerRate(ctx context.Context, sellerID, carrierID string, input SaveSellerRateInput) (*repository.SellerCarrierRate, error) {
+	seller, err := sellerByID(ctx, s.repository, sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	carrier, err := s.carrierByID(ctx, carrierID)
+	if err != nil {
+		return nil, err
+	}
+
+	arg := repository.UpsertSellerCarrierRateParams{
+		SellerID:      seller.ID,
+		CarrierID:     carrier.ID,
+		MarkupPercent: strconv.FormatFloat(input.MarkupPercent, 'f', 2, 64),
+	}
+	if input.PricePerKg != nil {
+		arg.PricePerKg = sql.NullString{String: strconv.FormatFloat(*input.PricePerKg, 'f', 2, 64), Valid: true}
+	}
+
+	rate, err := s.repository.UpsertSellerCarrierRate(ctx, arg)
+	if err != nil {
+		return nil, fmt.Errorf("upsert seller carrier rate: %v", err)
+	}
+
+	return &rate, nil
+}
+
+func (s *PackageService) sellerRates(ctx context.Context, sellerID uuid.NullUUID) (map[uuid.UUID]SellerRate, error) {
+	rates := make(map[uuid.UUID]SellerRate)
+	if !sellerID.Valid {
+		return rates, nil
+	}
+
+	stored, err := s.repository.ListSellerCarrierRates(ctx, sellerID.UUID)
+	if err != nil {
+		return nil, fmt.Errorf("list seller carrier rates: %v", err)
+	}
+
+	for _, rate := range stored {
+		sellerRate, err := newSellerRate(rate)
+		if err != nil {
+			return nil, err
+		}
+		rates[rate.CarrierID] = sellerRate
+	}
+
+	return rates, nil
+}
+
+// sellerByID reports malformed ids as not found.
+func sellerByID(ctx context.Context, repo repository.Querier, id string) (repository.Seller, error) {
+	if id == "" {
+		return repository.Seller{}, ErrSellerRequired
+	}
+
+	sellerID, err := uuid.Parse(id)
+	if err != nil {
+		return repository.Seller{}, fmt.Errorf("parse seller id: %w", ErrSellerNotFound)
+	}
+
+	seller, err := repo.GetSellerById(ctx, sellerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return repository.Seller{}, fmt.Errorf("get seller by id: %w", ErrSellerNotFound)
+		}
+		return repository.Seller{}, fmt.Errorf("get seller by id: %v", err)
+	}
+
+	return seller, nil
+}
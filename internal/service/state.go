@@ -15,3 +15,12 @@ func (s *PackageService) GetStates(ctx context.Context) ([]repository.ListStates
 
 	return states, nil
 }
+
+func (s *PackageService) GetRegions(ctx context.Context) ([]repository.Region, error) {
+	regions, err := s.repository.ListRegions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list regions: %v", err)
+	}
+
+	return regions, nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+const (
+	StatusCreated        = "criado"
+	StatusAwaitingPickup = "esperando_coleta"
+	StatusCollected      = "coletado"
+	StatusShipped        = "enviado"
+	StatusDelivered      = "entregue"
+	StatusLost           = "extraviado"
+)
+
+var statusTransitions = map[string][]string{
+	StatusCreated:        {StatusAwaitingPickup},
+	StatusAwaitingPickup: {StatusCollected},
+	StatusCollected:      {StatusShipped},
+	StatusShipped:        {StatusDelivered, StatusLost},
+}
+
+// StatusChange.Version comes from If-Match; zero accepts any version.
+type StatusChange struct {
+	Status  string
+	Actor   string
+	Reason  string
+	Version int32
+}
+
+func CanTransition(from, to string) bool {
+	for _, next := range statusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func ValidateStatusTransition(from, to string) error {
+	if !CanTransition(from, to) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidStatusTransition, from, to)
+	}
+	return nil
+}
+
+// StatusPath is empty when to is not ahead of from in the normal flow.
+func StatusPath(from, to string) []string {
+	previous := map[string]string{from: ""}
+	queue := []string{from}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if current == to {
+			break
+		}
+		for _, next := range statusTransitions[current] {
+			if _, seen := previous[next]; !seen {
+				previous[next] = current
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	if _, reached := previous[to]; !reached || from == to {
+		return nil
+	}
+	path := []string{}
+	for status := to; status != from; status = previous[status] {
+		path = append([]string{status}, path...)
+	}
+	return path
+}
+
+func (s *PackageService) GetStatusHistory(ctx context.Context, id string, sellerID uuid.NullUUID) ([]repository.PackageStatusEvent, error) {
+	pkg, err := s.GetByID(ctx, id, sellerID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.repository.ListPackageStatusEvents(ctx, pkg.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list package status events: %v", err)
+	}
+
+	return events, nil
+}
+
+func (s *PackageService) recordStatusEvent(ctx context.Context, packageID uuid.UUID, from, to, actor, reason string) error {
+	arg := repository.CreatePackageStatusEventParams{
+		PackageID:  packageID,
+		FromStatus: from,
+		ToStatus:   to,
+		Actor:      actor,
+		Reason: sql.NullString{
+			String: reason,
+			Valid:  reason != "",
+		},
+	}
+
+	if _, err := s.repository.CreatePackageStatusEvent(ctx, arg); err != nil {
+		return fmt.Errorf("create package status event: %v", err)
+	}
+
+	return nil
+}
+
+func packageLookupError(err error) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("get package by id: %w", ErrPackageNotFound)
+	}
+	return fmt.Errorf("get package by id: %v", err)
+}
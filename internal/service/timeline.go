@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+const (
+	TimelineEventStatus = "status"
+	TimelineEventScan   = "rastreio"
+)
+
+var statusDescriptions = map[string]string{
+	StatusCreated:        "Pedido registrado",
+	StatusAwaitingPickup: "Aguardando coleta pela transportadora",
+	StatusCollected:      "Coletado pela transportadora",
+	StatusShipped:        "Em trânsito",
+	StatusDelivered:      "Entregue ao destinatário",
+	StatusLost:           "Extraviado",
+}
+
+type TrackingTimeline struct {
+	Package     repository.Package
+	CarrierName string
+	Events      []TimelineEvent
+}
+
+type TimelineEvent struct {
+	Type        string
+	Status      string
+	Description string
+	Location    string
+	OccurredAt  time.Time
+}
+
+// GetTrackingTimeline merges status changes and carrier scans in chronological order.
+func (s *PackageService) GetTrackingTimeline(ctx context.Context, trackingCode string) (*TrackingTimeline, error) {
+	pkg, err := s.GetByTrackingCode(ctx, trackingCode, uuid.NullUUID{})
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := &TrackingTimeline{Package: *pkg, Events: []TimelineEvent{}}
+	if pkg.HiredCarrierID.Valid {
+		carrier, err := s.repository.GetCarrierById(ctx, pkg.HiredCarrierID.UUID)
+		if err != nil {
+			return nil, fmt.Errorf("get carrier by id: %v", err)
+		}
+		timeline.CarrierName = carrier.Name
+	}
+
+	if pkg.CreatedAt.Valid {
+		timeline.Events = append(timeline.Events, TimelineEvent{
+			Type:        TimelineEventStatus,
+			Status:      StatusCreated,
+			Description: statusDescriptions[StatusCreated],
+			OccurredAt:  pkg.CreatedAt.Time,
+		})
+	}
+
+	statusEvents, err := s.repository.ListPackageStatusEvents(ctx, pkg.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list package status events: %v", err)
+	}
+	for _, event := range statusEvents {
+		timeline.Events = append(timeline.Events, TimelineEvent{
+			Type:        TimelineEventStatus,
+			Status:      event.ToStatus,
+			Description: statusDescriptions[event.ToStatus],
+			OccurredAt:  event.CreatedAt,
+		})
+	}
+
+	scans, err := s.repository.ListCarrierTrackingEventsByPackage(ctx, pkg.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list carrier tracking events: %v", err)
+	}
+	for _, scan := range scans {
+		event := TimelineEvent{
+			Type:        TimelineEventScan,
+			Status:      scan.MappedStatus.String,
+			Description: scan.Description.String,
+			Location:    scan.Location.String,
+			OccurredAt:  scan.OccurredAt,
+		}
+		// Scans without a description use the mapped status text
+		if event.Description == "" {
+			event.Description = statusDescriptions[event.Status]
+		}
+		timeline.Events = append(timeline.Events, event)
+	}
+
+	sort.SliceStable(timeline.Events, func(i, j int) bool {
+		return timeline.Events[i].OccurredAt.Before(timeline.Events[j].OccurredAt)
+	})
+
+	return timeline, nil
+}
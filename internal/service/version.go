@@ -0,0 +1,22 @@
+package service
+
+import (
+	"fmt"
+
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+func checkPackageVersion(pkg repository.Package, expected int32) error {
+	if expected != 0 && pkg.Version != expected {
+		return fmt.Errorf("%w: current version %d, expected %d", ErrVersionMismatch, pkg.Version, expected)
+	}
+	return nil
+}
+
+// versionConflict reports a conditional write that matched no rows.
+func versionConflict(operation string, expected int32) error {
+	if expected != 0 {
+		return fmt.Errorf("%s: %w", operation, ErrVersionMismatch)
+	}
+	return fmt.Errorf("%s: %w", operation, ErrPackageModified)
+}
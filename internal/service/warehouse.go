@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+type CreateWarehouseInput struct {
+	Name      string
+	StateCode string
+}
+
+func (s *PackageService) GetWarehouses(ctx context.Context) ([]repository.Warehouse, error) {
+	warehouses, err := s.repository.ListWarehouses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list warehouses: %v", err)
+	}
+
+	return warehouses, nil
+}
+
+func (s *PackageService) CreateWarehouse(ctx context.Context, input CreateWarehouseInput) (*repository.Warehouse, error) {
+	warehouse, err := s.repository.CreateWarehouse(ctx, repository.CreateWarehouseParams{
+		Name:      input.Name,
+		StateCode: input.StateCode,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create warehouse: %v", err)
+	}
+
+	return &warehouse, nil
+}
+
+// parseWarehouseID treats an empty id as no origin.
+func parseWarehouseID(id string) (uuid.NullUUID, error) {
+	if id == "" {
+		return uuid.NullUUID{}, nil
+	}
+
+	warehouseID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.NullUUID{}, fmt.Errorf("parse warehouse id: %w", ErrWarehouseNotFound)
+	}
+
+	return uuid.NullUUID{UUID: warehouseID, Valid: true}, nil
+}
+
+// warehouseState is empty without a warehouse, so only any-origin tables apply.
+func (s *PackageService) warehouseState(ctx context.Context, warehouseID uuid.NullUUID) (sql.NullString, error) {
+	if !warehouseID.Valid {
+		return sql.NullString{}, nil
+	}
+
+	warehouse, err := s.repository.GetWarehouseById(ctx, warehouseID.UUID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return sql.NullString{}, fmt.Errorf("get warehouse by id: %w", ErrWarehouseNotFound)
+		}
+		return sql.NullString{}, fmt.Errorf("get warehouse by id: %v", err)
+	}
+
+	return sql.NullString{String: warehouse.StateCode, Valid: true}, nil
+}
@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github/moura95/olist-shipping-api/internal/repository"
+	"go.uber.org/zap"
+)
+
+const (
+	DeliveryStatusPending   = "pendente"
+	DeliveryStatusDelivered = "entregue"
+	DeliveryStatusFailed    = "falhou"
+)
+
+const defaultDeliveriesLimit = 50
+
+type WebhookService struct {
+	repository repository.Querier
+	logger     *zap.SugaredLogger
+}
+
+func NewWebhookService(repo repository.Querier, log *zap.SugaredLogger) *WebhookService {
+	return &WebhookService{
+		repository: repo,
+		logger:     log,
+	}
+}
+
+type CreateWebhookInput struct {
+	URL        string
+	Secret     string
+	EventTypes []string
+}
+
+// CreateSubscription generates a random secret when none is given.
+func (s *WebhookService) CreateSubscription(ctx context.Context, input CreateWebhookInput) (*repository.WebhookSubscription, error) {
+	secret := input.Secret
+	if secret == "" {
+		generated, err := newWebhookSecret()
+		if err != nil {
+			return nil, err
+		}
+		secret = generated
+	}
+
+	subscription, err := s.repository.CreateWebhookSubscription(ctx, repository.CreateWebhookSubscriptionParams{
+		Url:        input.URL,
+		Secret:     secret,
+		EventTypes: uniqueStrings(input.EventTypes),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create webhook subscription: %v", err)
+	}
+
+	return &subscription, nil
+}
+
+func (s *WebhookService) ListSubscriptions(ctx context.Context) ([]repository.WebhookSubscription, error) {
+	subscriptions, err := s.repository.ListWebhookSubscriptions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list webhook subscriptions: %v", err)
+	}
+
+	return subscriptions, nil
+}
+
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id string) error {
+	subscriptionID, err := uuid.Parse(id)
+	if err != nil {
+		return fmt.Errorf("parse webhook id: %w", ErrWebhookNotFound)
+	}
+
+	deleted, err := s.repository.DeleteWebhookSubscription(ctx, subscriptionID)
+	if err != nil {
+		return fmt.Errorf("delete webhook subscription: %v", err)
+	}
+	if deleted == 0 {
+		return ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (s *WebhookService) ListDeliveries(ctx context.Context, subscriptionID string, limit int32) ([]repository.WebhookDelivery, error) {
+	id, err := uuid.Parse(subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("parse webhook id: %w", ErrWebhookNotFound)
+	}
+	if limit <= 0 {
+		limit = defaultDeliveriesLimit
+	}
+
+	deliveries, err := s.repository.ListWebhookDeliveries(ctx, repository.ListWebhookDeliveriesParams{
+		SubscriptionID: id,
+		Limit:          limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list webhook deliveries: %v", err)
+	}
+
+	return deliveries, nil
+}
+
+// ReplayDelivery requeues the delivery with zero attempts, even if it was already delivered.
+func (s *WebhookService) ReplayDelivery(ctx context.Context, id string) (*repository.WebhookDelivery, error) {
+	deliveryID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("parse delivery id: %w", ErrWebhookDeliveryNotFound)
+	}
+
+	delivery, err := s.repository.ReplayWebhookDelivery(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("replay webhook delivery: %w", ErrWebhookDeliveryNotFound)
+		}
+		return nil, fmt.Errorf("replay webhook delivery: %v", err)
+	}
+
+	return &delivery, nil
+}
+
+func (s *WebhookService) Publish(ctx context.Context, event Event) error {
+	subscriptions, err := s.repository.ListWebhookSubscriptionsForEvent(ctx, event.Type)
+	if err != nil {
+		return fmt.Errorf("list webhook subscriptions for event: %v", err)
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encode event: %v", err)
+	}
+
+	for _, subscription := range subscriptions {
+		_, err := s.repository.CreateWebhookDelivery(ctx, repository.CreateWebhookDeliveryParams{
+			SubscriptionID: subscription.ID,
+			EventID:        event.ID,
+			EventType:      event.Type,
+			Payload:        payload,
+		})
+		if err != nil {
+			return fmt.Errorf("create webhook delivery: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func newWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate webhook secret: %v", err)
+	}
+	return "whsec_" + hex.EncodeToString(buf), nil
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := []string{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,200 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github/moura95/olist-shipping-api/config"
+	"github/moura95/olist-shipping-api/internal/repository"
+	"github/moura95/olist-shipping-api/internal/service"
+	"go.uber.org/zap"
+)
+
+const (
+	SignatureHeader = "X-Webhook-Signature"
+	TimestampHeader = "X-Webhook-Timestamp"
+	EventHeader     = "X-Webhook-Event"
+	DeliveryHeader  = "X-Webhook-Id"
+)
+
+const (
+	batchSize      = 20
+	baseBackoff    = 30 * time.Second
+	maxBackoff     = 6 * time.Hour
+	maxErrorLength = 500
+)
+
+type Dispatcher struct {
+	repository   repository.Querier
+	client       *http.Client
+	logger       *zap.SugaredLogger
+	pollInterval time.Duration
+	maxAttempts  int32
+}
+
+func NewDispatcher(repo repository.Querier, cfg config.Config, log *zap.SugaredLogger) *Dispatcher {
+	pollInterval := cfg.WebhookPollInterval
+	if pollInterval <= 0 {
+		pollInterval = config.DefaultWebhookPollInterval
+	}
+	timeout := cfg.WebhookTimeout
+	if timeout <= 0 {
+		timeout = config.DefaultWebhookTimeout
+	}
+	maxAttempts := cfg.WebhookMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = config.DefaultWebhookMaxAttempts
+	}
+
+	return &Dispatcher{
+		repository:   repo,
+		client:       &http.Client{Timeout: timeout},
+		logger:       log,
+		pollInterval: pollInterval,
+		maxAttempts:  int32(maxAttempts),
+	}
+}
+
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := d.DispatchDue(ctx); err != nil {
+			d.logger.Errorw("dispatch webhooks failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	deliveries, err := d.repository.ClaimDueWebhookDeliveries(ctx, batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("claim due webhook deliveries: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+		go func(delivery repository.ClaimDueWebhookDeliveriesRow) {
+			defer wg.Done()
+			d.deliver(ctx, delivery)
+		}(delivery)
+	}
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, delivery repository.ClaimDueWebhookDeliveriesRow) {
+	statusCode, sendErr := d.send(ctx, delivery)
+
+	arg := repository.RecordWebhookDeliveryAttemptParams{
+		ID:       delivery.ID,
+		Attempts: delivery.Attempts + 1,
+		LastResponseStatus: sql.NullInt32{
+			Int32: int32(statusCode),
+			Valid: statusCode != 0,
+		},
+	}
+	switch {
+	case sendErr == nil:
+		arg.Status = service.DeliveryStatusDelivered
+	case arg.Attempts >= d.maxAttempts:
+		arg.Status = service.DeliveryStatusFailed
+	default:
+		arg.Status = service.DeliveryStatusPending
+		arg.RetryAfterSeconds = Backoff(int(arg.Attempts)).Seconds()
+	}
+	if sendErr != nil {
+		arg.LastError = sql.NullString{String: truncateError(sendErr.Error()), Valid: true}
+	}
+
+	if err := d.repository.RecordWebhookDeliveryAttempt(ctx, arg); err != nil {
+		d.logger.Errorw("record webhook delivery attempt failed", "delivery_id", delivery.ID, "error", err)
+		return
+	}
+
+	if sendErr != nil {
+		d.logger.Warnw("webhook delivery failed", "delivery_id", delivery.ID, "event", delivery.EventType,
+			"attempt", arg.Attempts, "status", arg.Status, "error", sendErr)
+		return
+	}
+	d.logger.Infow("webhook delivered", "delivery_id", delivery.ID, "event", delivery.EventType, "attempt", arg.Attempts)
+}
+
+func (d *Dispatcher) send(ctx context.Context, delivery repository.ClaimDueWebhookDeliveriesRow) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %v", err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "olist-shipping-webhooks/1.0")
+	req.Header.Set(DeliveryHeader, delivery.ID.String())
+	req.Header.Set(EventHeader, delivery.EventType)
+	req.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(SignatureHeader, Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+// Sign is the HMAC-SHA256 of "<timestamp>.<body>", so receivers can reject stale replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func Verify(secret string, timestamp int64, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+func Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	wait := baseBackoff
+	for i := 1; i < attempt; i++ {
+		wait *= 2
+		if wait >= maxBackoff {
+			return maxBackoff
+		}
+	}
+	return wait
+}
+
+func truncateError(message string) string {
+	if len(message) <= maxErrorLength {
+		return message
+	}
+	return message[:maxErrorLength]
+}
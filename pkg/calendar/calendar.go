@@ -0,0 +1,79 @@
+package calendar
+
+import (
+	"time"
+)
+
+// Location is Brasília time, without daylight saving since 2019.
+var Location = time.FixedZone("America/Sao_Paulo", -3*60*60)
+
+type Holiday struct {
+	Date time.Time
+	Name string
+}
+
+// Calendar treats Monday to Friday, except holidays, as business days.
+type Calendar struct {
+	holidays map[string]string
+}
+
+func New(holidays []Holiday) *Calendar {
+	c := &Calendar{holidays: make(map[string]string, len(holidays))}
+	for _, holiday := range holidays {
+		c.holidays[dateKey(holiday.Date)] = holiday.Name
+	}
+	return c
+}
+
+func (c *Calendar) IsHoliday(t time.Time) (string, bool) {
+	name, ok := c.holidays[dateKey(t)]
+	return name, ok
+}
+
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	if t.Weekday() == time.Saturday || t.Weekday() == time.Sunday {
+		return false
+	}
+	_, holiday := c.IsHoliday(t)
+	return !holiday
+}
+
+func (c *Calendar) NextBusinessDay(t time.Time) time.Time {
+	next := Date(t).AddDate(0, 0, 1)
+	for !c.IsBusinessDay(next) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next
+}
+
+// AddBusinessDays does not count t itself.
+func (c *Calendar) AddBusinessDays(t time.Time, days int) time.Time {
+	result := Date(t)
+	for i := 0; i < days; i++ {
+		result = c.NextBusinessDay(result)
+	}
+	return result
+}
+
+// DeliveryDate counts from the posting day: today if before the cutoff on a business day, else the next one.
+func (c *Calendar) DeliveryDate(from time.Time, deliveryDays int, cutoff time.Time) time.Time {
+	local := from.In(Location)
+
+	dispatch := Date(local)
+	cutoffAt := time.Date(local.Year(), local.Month(), local.Day(), cutoff.Hour(), cutoff.Minute(), cutoff.Second(), 0, Location)
+	if !c.IsBusinessDay(dispatch) || !local.Before(cutoffAt) {
+		dispatch = c.NextBusinessDay(dispatch)
+	}
+
+	return c.AddBusinessDays(dispatch, deliveryDays)
+}
+
+// Date truncates t to midnight in Location.
+func Date(t time.Time) time.Time {
+	local := t.In(Location)
+	return time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, Location)
+}
+
+func dateKey(t time.Time) string {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Format(time.DateOnly)
+}
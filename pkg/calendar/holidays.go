@@ -0,0 +1,34 @@
+package calendar
+
+import "time"
+
+// Easter uses the Meeus/Jones/Butcher algorithm.
+func Easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, Location)
+}
+
+// MovableHolidays are the national holidays that depend on Easter.
+func MovableHolidays(year int) []Holiday {
+	easter := Easter(year)
+	return []Holiday{
+		{Date: easter.AddDate(0, 0, -48), Name: "Carnaval"},
+		{Date: easter.AddDate(0, 0, -47), Name: "Carnaval"},
+		{Date: easter.AddDate(0, 0, -2), Name: "Sexta-feira Santa"},
+		{Date: easter.AddDate(0, 0, 60), Name: "Corpus Christi"},
+	}
+}
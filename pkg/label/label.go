@@ -0,0 +1,192 @@
+package label
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	FormatPDF = "pdf"
+	FormatZPL = "zpl"
+)
+
+type Party struct {
+	Name  string
+	Lines []string
+}
+
+type Label struct {
+	TrackingCode      string
+	Carrier           string
+	Sender            Party
+	Recipient         Party
+	Product           string
+	WeightKg          float64
+	EstimatedDelivery time.Time
+	QRData            string
+}
+
+// 4x6 inch thermal label, in points
+const (
+	pdfWidth  = 288
+	pdfHeight = 432
+	pdfMargin = 16
+)
+
+func PDF(l Label) ([]byte, error) {
+	bars, err := Code128(l.TrackingCode)
+	if err != nil {
+		return nil, err
+	}
+	qr, err := EncodeQR(l.QRData)
+	if err != nil {
+		return nil, err
+	}
+
+	page := newPDFPage(pdfWidth, pdfHeight)
+	page.StrokeRect(8, 8, pdfWidth-16, pdfHeight-16, 1)
+
+	page.Text(pdfMargin, 400, 16, true, truncate(l.Carrier, 28))
+	if !l.EstimatedDelivery.IsZero() {
+		page.Text(pdfMargin, 386, 9, false, "Previsão de entrega: "+l.EstimatedDelivery.Format("02/01/2006"))
+	}
+	page.Line(8, 376, pdfWidth-8, 376, 1)
+
+	y := page.party(362, "REMETENTE", l.Sender, 10, 9)
+	page.Line(8, y, pdfWidth-8, y, 1)
+	y = page.party(y-14, "DESTINATÁRIO", l.Recipient, 12, 11)
+	page.Line(8, y, pdfWidth-8, y, 1)
+
+	modules := 0
+	for _, w := range bars {
+		modules += w
+	}
+	// 10-module quiet zone on each side of the barcode
+	moduleWidth := float64(pdfWidth-16) / float64(modules+20)
+	if moduleWidth > 2 {
+		moduleWidth = 2
+	}
+	x := (pdfWidth - moduleWidth*float64(modules)) / 2
+	barsTop := y - 12
+	for i, w := range bars {
+		if i%2 == 0 {
+			page.FillRect(x, barsTop-64, moduleWidth*float64(w), 64)
+		}
+		x += moduleWidth * float64(w)
+	}
+	page.Text((pdfWidth-textWidth(l.TrackingCode, 12))/2, barsTop-80, 12, true, l.TrackingCode)
+	page.Line(8, barsTop-90, pdfWidth-8, barsTop-90, 1)
+
+	// 4-module quiet zone around the QR code
+	qrModule := 2.5
+	qrSide := qrModule * float64(qr.Size)
+	qrX := pdfWidth - 8 - 4*qrModule - qrSide
+	qrY := 8 + 4*qrModule
+	for row := 0; row < qr.Size; row++ {
+		for col := 0; col < qr.Size; col++ {
+			if qr.Modules[row][col] {
+				page.FillRect(qrX+qrModule*float64(col), qrY+qrSide-qrModule*float64(row+1), qrModule, qrModule)
+			}
+		}
+	}
+
+	infoTop := barsTop - 106
+	page.Text(pdfMargin, infoTop, 7, true, "CONTEÚDO")
+	page.Text(pdfMargin, infoTop-12, 9, false, truncate(l.Product, 24))
+	page.Text(pdfMargin, infoTop-26, 9, false, "Peso: "+formatWeight(l.WeightKg))
+
+	return page.Bytes(), nil
+}
+
+// party returns the vertical position just below the block.
+func (p *pdfPage) party(top float64, title string, party Party, nameSize, lineSize float64) float64 {
+	p.Text(pdfMargin, top, 7, true, title)
+	y := top - nameSize - 4
+	if party.Name != "" {
+		p.Text(pdfMargin, y, nameSize, true, truncate(party.Name, 36))
+		y -= lineSize + 4
+	}
+	for _, line := range party.Lines {
+		p.Text(pdfMargin, y, lineSize, false, truncate(line, 40))
+		y -= lineSize + 4
+	}
+	return y - 2
+}
+
+// ZPL targets 203 dpi Zebra printers, which draw the barcode and QR code themselves.
+func ZPL(l Label) ([]byte, error) {
+	if _, err := Code128(l.TrackingCode); err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	b.WriteString("^XA\n^CI28\n^PW812\n^LL1218\n")
+	b.WriteString("^FO20,20^GB772,1178,3^FS\n")
+
+	field := func(x, y, size int, text string) {
+		fmt.Fprintf(&b, "^FO%d,%d^A0N,%d,%d^FH\\^FD%s^FS\n", x, y, size, size, zplEscape(text))
+	}
+	line := func(y int) {
+		fmt.Fprintf(&b, "^FO20,%d^GB772,3,3^FS\n", y)
+	}
+
+	field(45, 45, 48, l.Carrier)
+	if !l.EstimatedDelivery.IsZero() {
+		field(45, 105, 26, "Previsão de entrega: "+l.EstimatedDelivery.Format("02/01/2006"))
+	}
+	line(145)
+
+	y := 165
+	party := func(title string, party Party, nameSize, lineSize int) {
+		field(45, y, 22, title)
+		y += 32
+		if party.Name != "" {
+			field(45, y, nameSize, party.Name)
+			y += nameSize + 8
+		}
+		for _, text := range party.Lines {
+			field(45, y, lineSize, text)
+			y += lineSize + 8
+		}
+		y += 10
+		line(y)
+		y += 20
+	}
+	party("REMETENTE", l.Sender, 30, 26)
+	party("DESTINATÁRIO", l.Recipient, 38, 32)
+
+	fmt.Fprintf(&b, "^FO70,%d^BY3^BCN,180,Y,N,N^FH\\^FD%s^FS\n", y+10, zplEscape(l.TrackingCode))
+	y += 250
+	line(y)
+
+	fmt.Fprintf(&b, "^FO540,%d^BQN,2,6^FH\\^FDMA,%s^FS\n", y+20, zplEscape(l.QRData))
+	field(45, y+30, 22, "CONTEÚDO")
+	field(45, y+62, 28, l.Product)
+	field(45, y+100, 28, "Peso: "+formatWeight(l.WeightKg))
+
+	b.WriteString("^XZ\n")
+	return []byte(b.String()), nil
+}
+
+// zplEscape hex-encodes (^FH) the ZPL control characters.
+func zplEscape(text string) string {
+	return strings.NewReplacer(`\`, `\5C`, "^", `\5E`, "~", `\7E`).Replace(text)
+}
+
+func formatWeight(kg float64) string {
+	return strings.Replace(fmt.Sprintf("%.2f kg", kg), ".", ",", 1)
+}
+
+// textWidth approximates Helvetica widths, enough to center short codes.
+func textWidth(text string, size float64) float64 {
+	return float64(len([]rune(text))) * size * 0.6
+}
+
+func truncate(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-3]) + "..."
+}
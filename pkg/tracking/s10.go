@@ -0,0 +1,78 @@
+package tracking
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+const (
+	CountryCode = "BR"
+	// DefaultPrefix is used for packages without a hired carrier.
+	DefaultPrefix = "OL"
+	MaxSerial     = 99999999
+)
+
+var ErrInvalidCode = errors.New("invalid tracking code")
+
+var (
+	s10Pattern    = regexp.MustCompile(`^[A-Z]{2}[0-9]{9}[A-Z]{2}$`)
+	legacyPattern = regexp.MustCompile(`^BR[0-9]{8}$`)
+	prefixPattern = regexp.MustCompile(`^[A-Z]{2}$`)
+	s10Weights    = [8]int{8, 6, 4, 2, 3, 5, 9, 7}
+)
+
+// New builds prefix + 8-digit serial + check digit + country.
+func New(prefix string, serial int64) (string, error) {
+	if !ValidPrefix(prefix) {
+		return "", fmt.Errorf("invalid tracking prefix %q", prefix)
+	}
+	if serial < 1 || serial > MaxSerial {
+		return "", fmt.Errorf("tracking serial out of range: %d", serial)
+	}
+
+	digits := fmt.Sprintf("%08d", serial)
+	return fmt.Sprintf("%s%s%d%s", prefix, digits, CheckDigit(digits), CountryCode), nil
+}
+
+func CheckDigit(serial string) int {
+	sum := 0
+	for i, weight := range s10Weights {
+		sum += int(serial[i]-'0') * weight
+	}
+
+	switch check := 11 - sum%11; check {
+	case 10:
+		return 0
+	case 11:
+		return 5
+	default:
+		return check
+	}
+}
+
+func Validate(code string) error {
+	if !s10Pattern.MatchString(code) {
+		return fmt.Errorf("%w: expected format AA000000000BR", ErrInvalidCode)
+	}
+
+	check, _ := strconv.Atoi(code[10:11])
+	if CheckDigit(code[2:10]) != check {
+		return fmt.Errorf("%w: check digit mismatch", ErrInvalidCode)
+	}
+
+	return nil
+}
+
+// ValidForLookup also accepts the legacy BR + 8 digits format.
+func ValidForLookup(code string) error {
+	if legacyPattern.MatchString(code) {
+		return nil
+	}
+	return Validate(code)
+}
+
+func ValidPrefix(prefix string) bool {
+	return prefixPattern.MatchString(prefix)
+}
@@ -0,0 +1,114 @@
+package validator
+
+import (
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidateDocument accepts a CPF or CNPJ, with or without punctuation.
+func ValidateDocument(fl validator.FieldLevel) bool {
+	raw := fl.Field().String()
+	if !formattedDigits(raw, ".-/ ") {
+		return false
+	}
+
+	document := NormalizeDocument(raw)
+	switch len(document) {
+	case 11:
+		return IsValidCPF(document)
+	case 14:
+		return IsValidCNPJ(document)
+	}
+	return false
+}
+
+// ValidatePhone accepts a Brazilian landline (10 digits) or mobile (11 digits, starting with 9) with area code.
+func ValidatePhone(fl validator.FieldLevel) bool {
+	raw := fl.Field().String()
+	if !formattedDigits(raw, " ()-") {
+		return false
+	}
+
+	phone := NormalizePhone(raw)
+	if len(phone) != 10 && len(phone) != 11 {
+		return false
+	}
+	if phone[0] == '0' || phone[1] == '0' {
+		return false
+	}
+	return len(phone) == 10 || phone[2] == '9'
+}
+
+func NormalizeDocument(document string) string {
+	return digits(document)
+}
+
+func NormalizePhone(phone string) string {
+	return digits(phone)
+}
+
+// IsValidCPF rejects repeated digits such as 111.111.111-11, which pass the check digits.
+func IsValidCPF(cpf string) bool {
+	if len(cpf) != 11 || repeated(cpf) {
+		return false
+	}
+	return checkDigit(cpf[:9], 10) == cpf[9] && checkDigit(cpf[:10], 11) == cpf[10]
+}
+
+func IsValidCNPJ(cnpj string) bool {
+	if len(cnpj) != 14 || repeated(cnpj) {
+		return false
+	}
+	return cnpjCheckDigit(cnpj[:12]) == cnpj[12] && cnpjCheckDigit(cnpj[:13]) == cnpj[13]
+}
+
+// checkDigit is the CPF mod-11 digit with weights decreasing from weight.
+func checkDigit(base string, weight int) byte {
+	sum := 0
+	for i := range base {
+		sum += int(base[i]-'0') * (weight - i)
+	}
+	return mod11(sum)
+}
+
+// cnpjCheckDigit is the CNPJ mod-11 digit with weights 2 to 9 cycling from the right.
+func cnpjCheckDigit(base string) byte {
+	sum := 0
+	weight := 2
+	for i := len(base) - 1; i >= 0; i-- {
+		sum += int(base[i]-'0') * weight
+		weight++
+		if weight > 9 {
+			weight = 2
+		}
+	}
+	return mod11(sum)
+}
+
+func mod11(sum int) byte {
+	rest := sum % 11
+	if rest < 2 {
+		return '0'
+	}
+	return byte('0' + 11 - rest)
+}
+
+func repeated(value string) bool {
+	return strings.Count(value, value[:1]) == len(value)
+}
+
+func formattedDigits(value, punctuation string) bool {
+	return strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && !strings.ContainsRune(punctuation, r)
+	}) < 0
+}
+
+func digits(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, value)
+}
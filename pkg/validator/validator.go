@@ -1,6 +1,10 @@
 package validator
 
 import (
+	"regexp"
+	"strings"
+	"time"
+
 	"github.com/go-playground/validator/v10"
 )
 
@@ -18,6 +22,35 @@ func ValidateBrazilianState(fl validator.FieldLevel) bool {
 	return ValidBrazilianStates[state]
 }
 
+var cepPattern = regexp.MustCompile(`^\d{5}-?\d{3}$`)
+
+// ValidateCEP accepts 01310-100 or 01310100.
+func ValidateCEP(fl validator.FieldLevel) bool {
+	return cepPattern.MatchString(fl.Field().String())
+}
+
+func NormalizeCEP(cep string) string {
+	return strings.ReplaceAll(strings.TrimSpace(cep), "-", "")
+}
+
+// ValidateDateOrTime accepts a date (2026-11-01) or an RFC 3339 time.
+func ValidateDateOrTime(fl validator.FieldLevel) bool {
+	_, err := ParseDateOrTime(fl.Field().String())
+	return err == nil
+}
+
+// ParseDateOrTime reads a bare date as midnight.
+func ParseDateOrTime(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.DateOnly, value); err == nil {
+		return parsed, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
 func SetupCustomValidators(v *validator.Validate) {
 	v.RegisterValidation("brazilian_state", ValidateBrazilianState)
+	v.RegisterValidation("cep", ValidateCEP)
+	v.RegisterValidation("date_or_time", ValidateDateOrTime)
+	v.RegisterValidation("cpf_cnpj", ValidateDocument)
+	v.RegisterValidation("br_phone", ValidatePhone)
 }
@@ -0,0 +1,98 @@
+package calendar_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github/moura95/olist-shipping-api/pkg/calendar"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, calendar.Location)
+}
+
+func TestEaster(t *testing.T) {
+	tests := []struct {
+		year     int
+		expected time.Time
+	}{
+		{year: 2024, expected: date(2024, time.March, 31)},
+		{year: 2025, expected: date(2025, time.April, 20)},
+		{year: 2026, expected: date(2026, time.April, 5)},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, calendar.Easter(tt.year), "year %d", tt.year)
+	}
+}
+
+func TestCalendar_DeliveryDate(t *testing.T) {
+	holidays := append(calendar.MovableHolidays(2025),
+		calendar.Holiday{Date: date(2025, time.April, 21), Name: "Tiradentes"},
+	)
+	cal := calendar.New(holidays)
+	cutoff := time.Date(0, 1, 1, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		days     int
+		expected time.Time
+	}{
+		{
+			name:     "Dispatch same day before cutoff",
+			from:     time.Date(2025, time.June, 2, 10, 0, 0, 0, calendar.Location),
+			days:     3,
+			expected: date(2025, time.June, 5),
+		},
+		{
+			name:     "Dispatch next business day after cutoff",
+			from:     time.Date(2025, time.June, 2, 15, 0, 0, 0, calendar.Location),
+			days:     3,
+			expected: date(2025, time.June, 6),
+		},
+		{
+			name:     "Skip weekend",
+			from:     time.Date(2025, time.June, 6, 10, 0, 0, 0, calendar.Location),
+			days:     2,
+			expected: date(2025, time.June, 10),
+		},
+		{
+			name:     "Order on Saturday is dispatched on Monday",
+			from:     time.Date(2025, time.June, 7, 10, 0, 0, 0, calendar.Location),
+			days:     1,
+			expected: date(2025, time.June, 10),
+		},
+		{
+			name:     "Skip Sexta-feira Santa, weekend and Tiradentes",
+			from:     time.Date(2025, time.April, 17, 10, 0, 0, 0, calendar.Location),
+			days:     1,
+			expected: date(2025, time.April, 22),
+		},
+		{
+			name:     "Cutoff is evaluated in Brasília time",
+			from:     time.Date(2025, time.June, 2, 16, 30, 0, 0, time.UTC),
+			days:     1,
+			expected: date(2025, time.June, 3),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cal.DeliveryDate(tt.from, tt.days, cutoff))
+		})
+	}
+}
+
+func TestCalendar_IsBusinessDay(t *testing.T) {
+	cal := calendar.New(calendar.MovableHolidays(2025))
+
+	assert.True(t, cal.IsBusinessDay(date(2025, time.March, 5)))
+	assert.False(t, cal.IsBusinessDay(date(2025, time.March, 4)))
+	assert.False(t, cal.IsBusinessDay(date(2025, time.March, 8)))
+
+	name, ok := cal.IsHoliday(date(2025, time.June, 19))
+	assert.True(t, ok)
+	assert.Equal(t, "Corpus Christi", name)
+}
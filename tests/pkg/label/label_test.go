@@ -0,0 +1,163 @@
+package label_test
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/pkg/label"
+)
+
+func TestCode128(t *testing.T) {
+	widths, err := label.Code128("NB000000014BR")
+	require.NoError(t, err)
+
+	modules := 0
+	for _, w := range widths {
+		modules += w
+	}
+	// start + 13 characters + checksum (11 modules each) + stop (13 modules)
+	assert.Equal(t, 15*11+13, modules)
+	assert.Equal(t, []int{2, 1, 1, 2, 1, 4}, widths[:6], "start B")
+	assert.Equal(t, []int{2, 3, 3, 1, 1, 1, 2}, widths[len(widths)-7:], "stop")
+
+	_, err = label.Code128("")
+	assert.Error(t, err)
+
+	_, err = label.Code128("BRÇ123")
+	assert.Error(t, err)
+}
+
+// Published format bits for level M, masks 0 to 7
+var formatBitsM = []string{
+	"101010000010010", "101000100100101", "101111001111100", "101101101001011",
+	"100010111111001", "100000011001110", "100111110010111", "100101010100000",
+}
+
+func TestEncodeQR(t *testing.T) {
+	tests := []struct {
+		data    string
+		version int
+	}{
+		{data: "NB000000014BR", version: 1},
+		{data: strings.Repeat("a", 14), version: 1},
+		{data: strings.Repeat("a", 15), version: 2},
+		{data: "NB000000014BR|550e8400-e29b-41d4-a716-446655440000|São Paulo - SP", version: 5},
+		{data: strings.Repeat("a", 122), version: 7},
+		{data: strings.Repeat("a", 213), version: 10},
+	}
+
+	for _, tt := range tests {
+		qr, err := label.EncodeQR(tt.data)
+		require.NoError(t, err)
+		assert.Equal(t, tt.version, qr.Version, "data length %d", len(tt.data))
+		assert.Equal(t, tt.version*4+17, qr.Size)
+		require.Len(t, qr.Modules, qr.Size)
+
+		// Finder patterns in three corners
+		for _, corner := range [][2]int{{0, 0}, {qr.Size - 7, 0}, {0, qr.Size - 7}} {
+			x, y := corner[0], corner[1]
+			assert.True(t, qr.Modules[y][x])
+			assert.True(t, qr.Modules[y+6][x+6])
+			assert.False(t, qr.Modules[y+1][x+1])
+			assert.True(t, qr.Modules[y+3][x+3])
+		}
+
+		// Both copies of the format bits match and are valid for level M
+		bit := func(x, y int) string {
+			if qr.Modules[y][x] {
+				return "1"
+			}
+			return "0"
+		}
+		var first, second string
+		for i := 14; i >= 0; i-- {
+			switch {
+			case i <= 5:
+				first += bit(8, i)
+			case i == 6:
+				first += bit(8, 7)
+			case i == 7:
+				first += bit(8, 8)
+			case i == 8:
+				first += bit(7, 8)
+			default:
+				first += bit(14-i, 8)
+			}
+			if i < 8 {
+				second += bit(qr.Size-1-i, 8)
+			} else {
+				second += bit(8, qr.Size-15+i)
+			}
+		}
+		assert.Equal(t, first, second)
+		assert.Contains(t, formatBitsM, first)
+	}
+
+	_, err := label.EncodeQR(strings.Repeat("a", 214))
+	assert.Error(t, err)
+}
+
+func testLabel() label.Label {
+	return label.Label{
+		TrackingCode:      "NB000000014BR",
+		Carrier:           "Nebulix Logística",
+		Sender:            label.Party{Name: "Olist", Lines: []string{"CD Cajamar - SP"}},
+		Recipient:         label.Party{Lines: []string{"São Paulo - SP", "CEP 01310-100"}},
+		Product:           "Camisa (tamanho G)",
+		WeightKg:          0.6,
+		EstimatedDelivery: time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC),
+		QRData:            "NB000000014BR|550e8400-e29b-41d4-a716-446655440000|São Paulo - SP",
+	}
+}
+
+func TestPDF(t *testing.T) {
+	pdf, err := label.PDF(testLabel())
+	require.NoError(t, err)
+
+	assert.True(t, bytes.HasPrefix(pdf, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(pdf, []byte("%%EOF\n")))
+	assert.Contains(t, string(pdf), "/MediaBox [0 0 288 432]")
+	assert.Contains(t, string(pdf), "(NB000000014BR) Tj")
+	assert.Contains(t, string(pdf), `(Camisa \(tamanho G\)) Tj`)
+	assert.Contains(t, string(pdf), "(Previs\xe3o de entrega: 03/11/2026) Tj", "texto em WinAnsi")
+
+	// startxref points to the xref table and each entry to its object
+	match := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(pdf)
+	require.NotNil(t, match)
+	xref, err := strconv.Atoi(string(match[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(pdf[xref:], []byte("xref\n0 7\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n \n`).FindAllSubmatch(pdf[xref:], -1)
+	require.Len(t, entries, 6)
+	for i, entry := range entries {
+		offset, err := strconv.Atoi(string(entry[1]))
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(pdf[offset:], []byte(strconv.Itoa(i+1)+" 0 obj\n")), "object %d", i+1)
+	}
+
+	_, err = label.PDF(label.Label{TrackingCode: "", QRData: "x"})
+	assert.Error(t, err)
+}
+
+func TestZPL(t *testing.T) {
+	l := testLabel()
+	l.Product = "Caneca ^especial~"
+
+	zpl, err := label.ZPL(l)
+	require.NoError(t, err)
+
+	text := string(zpl)
+	assert.True(t, strings.HasPrefix(text, "^XA\n^CI28\n"))
+	assert.True(t, strings.HasSuffix(text, "^XZ\n"))
+	assert.Contains(t, text, "^BCN,180,Y,N,N^FH\\^FDNB000000014BR^FS")
+	assert.Contains(t, text, "^BQN,2,6^FH\\^FDMA,"+l.QRData+"^FS")
+	assert.Contains(t, text, "^FDNebulix Logística^FS")
+	assert.Contains(t, text, `^FDCaneca \5Eespecial\7E^FS`)
+}
@@ -0,0 +1,74 @@
+package tracking_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/pkg/tracking"
+)
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		serial string
+		check  int
+	}{
+		// S10 specification example: AA473124829GB
+		{serial: "47312482", check: 9},
+		// Remainder 1 gives 10, which becomes 0
+		{serial: "00000008", check: 0},
+		// Remainder 0 gives 11, which becomes 5
+		{serial: "00000000", check: 5},
+		{serial: "00000017", check: 8},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.check, tracking.CheckDigit(tt.serial), "serial %s", tt.serial)
+	}
+}
+
+func TestNew(t *testing.T) {
+	code, err := tracking.New("NB", 47312482)
+	require.NoError(t, err)
+	assert.Equal(t, "NB473124829BR", code)
+
+	code, err = tracking.New("OL", 17)
+	require.NoError(t, err)
+	assert.Equal(t, "OL000000178BR", code)
+	assert.NoError(t, tracking.Validate(code))
+
+	_, err = tracking.New("nb", 1)
+	assert.Error(t, err)
+
+	_, err = tracking.New("NB", 0)
+	assert.Error(t, err)
+
+	_, err = tracking.New("NB", tracking.MaxSerial+1)
+	assert.Error(t, err)
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		code      string
+		valid     bool
+		forLookup bool
+	}{
+		{code: "NB473124829BR", valid: true, forLookup: true},
+		{code: "AA473124829GB", valid: true, forLookup: true},
+		{code: "NB473124828BR", valid: false, forLookup: false},
+		{code: "nb473124829br", valid: false, forLookup: false},
+		{code: "NB47312482BR", valid: false, forLookup: false},
+		{code: "BR38897894", valid: false, forLookup: true},
+		{code: "BR3889789", valid: false, forLookup: false},
+		{code: "", valid: false, forLookup: false},
+	}
+
+	for _, tt := range tests {
+		err := tracking.Validate(tt.code)
+		assert.Equal(t, tt.valid, err == nil, "code %q", tt.code)
+		if err != nil {
+			assert.ErrorIs(t, err, tracking.ErrInvalidCode)
+		}
+		assert.Equal(t, tt.forLookup, tracking.ValidForLookup(tt.code) == nil, "lookup code %q", tt.code)
+	}
+}
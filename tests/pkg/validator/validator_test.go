@@ -0,0 +1,119 @@
+package validator_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	customValidator "github/moura95/olist-shipping-api/pkg/validator"
+)
+
+func TestValidateCEP(t *testing.T) {
+	validate := validator.New()
+	customValidator.SetupCustomValidators(validate)
+
+	tests := []struct {
+		cep   string
+		valid bool
+	}{
+		{cep: "01310-100", valid: true},
+		{cep: "01310100", valid: true},
+		{cep: "0131-0100", valid: false},
+		{cep: "01310-10", valid: false},
+		{cep: "0131010a", valid: false},
+		{cep: "", valid: false},
+	}
+
+	for _, tt := range tests {
+		err := validate.Var(tt.cep, "cep")
+		assert.Equal(t, tt.valid, err == nil, "cep %q", tt.cep)
+	}
+}
+
+func TestNormalizeCEP(t *testing.T) {
+	assert.Equal(t, "01310100", customValidator.NormalizeCEP("01310-100"))
+	assert.Equal(t, "01310100", customValidator.NormalizeCEP(" 01310100 "))
+}
+
+func TestValidateDateOrTime(t *testing.T) {
+	validate := validator.New()
+	customValidator.SetupCustomValidators(validate)
+
+	tests := []struct {
+		value string
+		valid bool
+	}{
+		{value: "2026-11-01", valid: true},
+		{value: "2026-11-01T08:00:00-03:00", valid: true},
+		{value: "2026-11-01T08:00:00Z", valid: true},
+		{value: "01/11/2026", valid: false},
+		{value: "2026-13-01", valid: false},
+	}
+
+	for _, tt := range tests {
+		err := validate.Var(tt.value, "date_or_time")
+		assert.Equal(t, tt.valid, err == nil, "value %q", tt.value)
+	}
+}
+
+func TestParseDateOrTime(t *testing.T) {
+	parsed, err := customValidator.ParseDateOrTime("2026-11-01")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC), parsed)
+}
+
+func TestValidateDocument(t *testing.T) {
+	validate := validator.New()
+	customValidator.SetupCustomValidators(validate)
+
+	tests := []struct {
+		document string
+		valid    bool
+	}{
+		{document: "529.982.247-25", valid: true},
+		{document: "52998224725", valid: true},
+		{document: "52998224724", valid: false},
+		{document: "111.111.111-11", valid: false},
+		{document: "11.222.333/0001-81", valid: true},
+		{document: "11222333000181", valid: true},
+		{document: "11222333000180", valid: false},
+		{document: "5299822472a5", valid: false},
+		{document: "1234567890", valid: false},
+		{document: "", valid: false},
+	}
+
+	for _, tt := range tests {
+		err := validate.Var(tt.document, "cpf_cnpj")
+		assert.Equal(t, tt.valid, err == nil, "document %q", tt.document)
+	}
+}
+
+func TestValidatePhone(t *testing.T) {
+	validate := validator.New()
+	customValidator.SetupCustomValidators(validate)
+
+	tests := []struct {
+		phone string
+		valid bool
+	}{
+		{phone: "(11) 98765-4321", valid: true},
+		{phone: "11987654321", valid: true},
+		{phone: "(11) 3456-7890", valid: true},
+		{phone: "11887654321", valid: false},
+		{phone: "01987654321", valid: false},
+		{phone: "+55 11 98765-4321", valid: false},
+		{phone: "98765-4321", valid: false},
+	}
+
+	for _, tt := range tests {
+		err := validate.Var(tt.phone, "br_phone")
+		assert.Equal(t, tt.valid, err == nil, "phone %q", tt.phone)
+	}
+}
+
+func TestNormalizeDocument(t *testing.T) {
+	assert.Equal(t, "52998224725", customValidator.NormalizeDocument("529.982.247-25"))
+	assert.Equal(t, "11222333000181", customValidator.NormalizeDocument("11.222.333/0001-81"))
+	assert.Equal(t, "11987654321", customValidator.NormalizePhone("(11) 98765-4321"))
+}
@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/internal/auth"
+)
+
+var secret = []byte("segredo-jwt-de-teste")
+
+func TestSignAndParseToken(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	claims := auth.Claims{
+		Subject:   "apikey:painel-operacao",
+		Role:      auth.RoleOperator,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(time.Hour).Unix(),
+	}
+
+	token, err := auth.SignToken(secret, claims)
+	require.NoError(t, err)
+	assert.Len(t, strings.Split(token, "."), 3)
+
+	parsed, err := auth.ParseToken(secret, token, now.Add(30*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, claims, parsed)
+
+	_, err = auth.ParseToken(secret, token, now.Add(time.Hour))
+	assert.ErrorIs(t, err, auth.ErrTokenExpired)
+
+	_, err = auth.ParseToken([]byte("outro-segredo"), token, now)
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+}
+
+func TestParseTokenRejectsInvalidTokens(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	valid, err := auth.SignToken(secret, auth.Claims{Subject: "seller-1", Role: auth.RoleSeller, ExpiresAt: now.Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	parts := strings.Split(valid, ".")
+
+	encode := base64.RawURLEncoding.EncodeToString
+	unknownRole, err := auth.SignToken(secret, auth.Claims{Subject: "x", Role: "superuser", ExpiresAt: now.Add(time.Hour).Unix()})
+	require.NoError(t, err)
+	noExpiry, err := auth.SignToken(secret, auth.Claims{Subject: "x", Role: auth.RoleAdmin})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"malformed", "abc.def"},
+		{"tampered payload", parts[0] + "." + encode([]byte(`{"sub":"seller-1","role":"admin","exp":9999999999}`)) + "." + parts[2]},
+		{"alg none", encode([]byte(`{"alg":"none","typ":"JWT"}`)) + "." + parts[1] + "."},
+		{"unknown role", unknownRole},
+		{"missing expiration", noExpiry},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := auth.ParseToken(secret, tt.token, now)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestGenerateAPIKey(t *testing.T) {
+	key, prefix, err := auth.GenerateAPIKey()
+	require.NoError(t, err)
+
+	assert.True(t, auth.IsAPIKey(key))
+	assert.True(t, strings.HasPrefix(key, prefix))
+	assert.Len(t, key, len(auth.APIKeyPrefix)+64)
+	assert.Len(t, auth.HashAPIKey(key), 64)
+	assert.NotContains(t, auth.HashAPIKey(key), key)
+
+	other, _, err := auth.GenerateAPIKey()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestPrincipalHasRole(t *testing.T) {
+	principal := auth.Principal{Subject: "seller-1", Role: auth.RoleSeller}
+
+	assert.True(t, principal.HasRole(auth.RoleAdmin, auth.RoleSeller))
+	assert.False(t, principal.HasRole(auth.RoleAdmin, auth.RoleOperator))
+	assert.True(t, auth.ValidRole(auth.RoleReadonly))
+	assert.False(t, auth.ValidRole("superuser"))
+}
+
+func TestPrincipalScope(t *testing.T) {
+	keyID := uuid.MustParse("770e8400-e29b-41d4-a716-446655440001")
+	sellerID := uuid.MustParse("880e8400-e29b-41d4-a716-446655440001")
+
+	apiKey := auth.Principal{Subject: "apikey:loja", Method: auth.MethodAPIKey, APIKeyID: uuid.NullUUID{UUID: keyID, Valid: true}}
+	rotated := auth.Principal{Subject: "apikey:loja", Method: auth.MethodAPIKey, APIKeyID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+	token := auth.Principal{Subject: "seller-1", Method: auth.MethodJWT, SellerID: uuid.NullUUID{UUID: sellerID, Valid: true}}
+
+	assert.Equal(t, "apikey:"+keyID.String(), apiKey.Scope())
+	assert.NotEqual(t, apiKey.Scope(), rotated.Scope())
+	assert.Equal(t, "jwt:seller-1@"+sellerID.String(), token.Scope())
+}
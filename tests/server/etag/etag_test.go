@@ -0,0 +1,46 @@
+package etag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/internal/etag"
+)
+
+func TestParseIfMatch(t *testing.T) {
+	tests := []struct {
+		header   string
+		expected int32
+	}{
+		{header: "", expected: 0},
+		{header: "*", expected: 0},
+		{header: `"3"`, expected: 3},
+		{header: ` "12" `, expected: 12},
+		{header: etag.Format(7), expected: 7},
+	}
+
+	for _, tt := range tests {
+		version, err := etag.ParseIfMatch(tt.header)
+		require.NoError(t, err, tt.header)
+		assert.Equal(t, tt.expected, version, tt.header)
+	}
+
+	for _, header := range []string{`3`, `W/"3"`, `"abc"`, `"0"`, `"-1"`, `"1", "2"`, `"`} {
+		_, err := etag.ParseIfMatch(header)
+		assert.ErrorIs(t, err, etag.ErrInvalidIfMatch, header)
+	}
+}
+
+func TestNoneMatch(t *testing.T) {
+	current := etag.Format(3)
+
+	assert.True(t, etag.NoneMatch(`"3"`, current))
+	assert.True(t, etag.NoneMatch(`W/"3"`, current))
+	assert.True(t, etag.NoneMatch(`"1", "3"`, current))
+	assert.True(t, etag.NoneMatch(`*`, current))
+
+	assert.False(t, etag.NoneMatch(``, current))
+	assert.False(t, etag.NoneMatch(`"2"`, current))
+	assert.False(t, etag.NoneMatch(`3`, current))
+}
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github/moura95/olist-shipping-api/internal/auth"
+	"github/moura95/olist-shipping-api/internal/middleware"
+)
+
+type fakeAuthenticator map[string]auth.Principal
+
+func (f fakeAuthenticator) Authenticate(_ context.Context, credential string) (*auth.Principal, error) {
+	if credential == "quebrado" {
+		return nil, errors.New("database unavailable")
+	}
+	principal, ok := f[credential]
+	if !ok {
+		return nil, auth.ErrUnauthenticated
+	}
+	return &principal, nil
+}
+
+func newRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	authenticator := fakeAuthenticator{
+		"olk_admin":  {Subject: "apikey:admin", Role: auth.RoleAdmin},
+		"olk_leitor": {Subject: "apikey:leitor", Role: auth.RoleReadonly},
+	}
+
+	router := gin.New()
+	router.DELETE("/packages/:id",
+		middleware.AuthMiddleware(authenticator),
+		middleware.RoleMiddleware(auth.RoleAdmin, auth.RoleOperator),
+		func(ctx *gin.Context) {
+			principal, _ := middleware.GetPrincipalFromContext(ctx)
+			ctx.String(http.StatusOK, principal.Subject)
+		},
+	)
+	return router
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	tests := []struct {
+		name           string
+		headers        map[string]string
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name:           "Missing credentials",
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Unknown key",
+			headers:        map[string]string{"Authorization": "Bearer olk_desconhecida"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Non bearer scheme",
+			headers:        map[string]string{"Authorization": "Basic olk_admin"},
+			expectedStatus: http.StatusUnauthorized,
+		},
+		{
+			name:           "Authenticator failure",
+			headers:        map[string]string{"Authorization": "Bearer quebrado"},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "Role not allowed",
+			headers:        map[string]string{"Authorization": "Bearer olk_leitor"},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "Allowed with bearer",
+			headers:        map[string]string{"Authorization": "Bearer olk_admin"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "apikey:admin",
+		},
+		{
+			name:           "Allowed with X-API-Key",
+			headers:        map[string]string{"X-API-Key": "olk_admin"},
+			expectedStatus: http.StatusOK,
+			expectedBody:   "apikey:admin",
+		},
+	}
+
+	router := newRouter()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodDelete, "/packages/123", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+			rec := httptest.NewRecorder()
+
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expectedStatus, rec.Code)
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, rec.Body.String())
+			}
+			if tt.expectedStatus == http.StatusUnauthorized {
+				assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
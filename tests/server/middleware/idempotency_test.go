@@ -0,0 +1,208 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/internal/auth"
+	"github/moura95/olist-shipping-api/internal/idempotency"
+	"github/moura95/olist-shipping-api/internal/middleware"
+)
+
+type fakeIdempotencyStore struct {
+	hashes    map[string]string
+	responses map[string]*idempotency.Response
+}
+
+func newFakeIdempotencyStore() *fakeIdempotencyStore {
+	return &fakeIdempotencyStore{
+		hashes:    make(map[string]string),
+		responses: make(map[string]*idempotency.Response),
+	}
+}
+
+func storeKey(key idempotency.Key) string {
+	return key.Principal + "|" + key.Key + "|" + key.Route
+}
+
+func (f *fakeIdempotencyStore) Begin(_ context.Context, key idempotency.Key) (*idempotency.Response, error) {
+	hash, exists := f.hashes[storeKey(key)]
+	if !exists {
+		f.hashes[storeKey(key)] = key.RequestHash
+		return nil, nil
+	}
+	if hash != key.RequestHash {
+		return nil, idempotency.ErrKeyReused
+	}
+	response := f.responses[storeKey(key)]
+	if response == nil {
+		return nil, idempotency.ErrInProgress
+	}
+	return response, nil
+}
+
+func (f *fakeIdempotencyStore) Complete(_ context.Context, key idempotency.Key, response idempotency.Response) error {
+	f.responses[storeKey(key)] = &response
+	return nil
+}
+
+func (f *fakeIdempotencyStore) Release(_ context.Context, key idempotency.Key) error {
+	delete(f.hashes, storeKey(key))
+	return nil
+}
+
+var (
+	lojaA     = auth.Principal{Subject: "apikey:loja-a", Role: auth.RoleSeller, APIKeyID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+	lojaANova = auth.Principal{Subject: "apikey:loja-a", Role: auth.RoleSeller, APIKeyID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+	lojaB     = auth.Principal{Subject: "apikey:loja-b", Role: auth.RoleSeller, APIKeyID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+)
+
+// newIdempotentRouter answers 500 for product "falha"; olk_loja_a_nova is a rotated olk_loja_a.
+func newIdempotentRouter(store middleware.IdempotencyStore) (*gin.Engine, *int) {
+	gin.SetMode(gin.TestMode)
+	authenticator := fakeAuthenticator{
+		"olk_loja_a":      lojaA,
+		"olk_loja_a_nova": lojaANova,
+		"olk_loja_b":      lojaB,
+	}
+
+	created := 0
+	router := gin.New()
+	router.POST("/packages",
+		middleware.AuthMiddleware(authenticator),
+		middleware.IdempotencyMiddleware(store),
+		func(ctx *gin.Context) {
+			var body struct {
+				Product string `json:"produto"`
+			}
+			_ = ctx.ShouldBindJSON(&body)
+			if body.Product == "falha" {
+				ctx.JSON(http.StatusInternalServerError, gin.H{"erro": "falha"})
+				return
+			}
+			created++
+			ctx.Header("ETag", fmt.Sprintf(`"%d"`, created))
+			ctx.Header("Location", fmt.Sprintf("/packages/pacote-%d", created))
+			ctx.JSON(http.StatusCreated, gin.H{"id": fmt.Sprintf("pacote-%d", created)})
+		},
+	)
+	return router, &created
+}
+
+func postPackage(router *gin.Engine, credential, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/packages", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+credential)
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(idempotency.Header, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotencyMiddleware(t *testing.T) {
+	t.Run("Replays the first response", func(t *testing.T) {
+		router, created := newIdempotentRouter(newFakeIdempotencyStore())
+
+		first := postPackage(router, "olk_loja_a", "pedido-1", `{"produto": "Camisa"}`)
+		second := postPackage(router, "olk_loja_a", "pedido-1", `{"produto": "Camisa"}`)
+
+		require.Equal(t, http.StatusCreated, first.Code)
+		assert.Equal(t, first.Code, second.Code)
+		assert.Equal(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, first.Header().Get("Content-Type"), second.Header().Get("Content-Type"))
+		assert.Equal(t, `"1"`, second.Header().Get("ETag"))
+		assert.Equal(t, "/packages/pacote-1", second.Header().Get("Location"))
+		assert.Empty(t, first.Header().Get(idempotency.ReplayedHeader))
+		assert.Equal(t, "true", second.Header().Get(idempotency.ReplayedHeader))
+		assert.Equal(t, 1, *created)
+	})
+
+	t.Run("Rejects the same key with a different body", func(t *testing.T) {
+		router, created := newIdempotentRouter(newFakeIdempotencyStore())
+
+		postPackage(router, "olk_loja_a", "pedido-1", `{"produto": "Camisa"}`)
+		w := postPackage(router, "olk_loja_a", "pedido-1", `{"produto": "Tênis"}`)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Equal(t, 1, *created)
+	})
+
+	t.Run("Keys are scoped by principal", func(t *testing.T) {
+		router, created := newIdempotentRouter(newFakeIdempotencyStore())
+
+		first := postPackage(router, "olk_loja_a", "pedido-1", `{"produto": "Camisa"}`)
+		second := postPackage(router, "olk_loja_b", "pedido-1", `{"produto": "Camisa"}`)
+
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.NotEqual(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, 2, *created)
+	})
+
+	t.Run("Keys with the same name do not share responses", func(t *testing.T) {
+		router, created := newIdempotentRouter(newFakeIdempotencyStore())
+
+		first := postPackage(router, "olk_loja_a", "pedido-1", `{"produto": "Camisa"}`)
+		second := postPackage(router, "olk_loja_a_nova", "pedido-1", `{"produto": "Camisa"}`)
+
+		assert.Equal(t, http.StatusCreated, second.Code)
+		assert.Empty(t, second.Header().Get(idempotency.ReplayedHeader))
+		assert.NotEqual(t, first.Body.String(), second.Body.String())
+		assert.Equal(t, 2, *created)
+	})
+
+	t.Run("Server errors are not stored", func(t *testing.T) {
+		store := newFakeIdempotencyStore()
+		router, _ := newIdempotentRouter(store)
+
+		first := postPackage(router, "olk_loja_a", "pedido-1", `{"produto": "falha"}`)
+		second := postPackage(router, "olk_loja_a", "pedido-1", `{"produto": "falha"}`)
+
+		assert.Equal(t, http.StatusInternalServerError, first.Code)
+		assert.Equal(t, http.StatusInternalServerError, second.Code)
+		assert.Empty(t, second.Header().Get(idempotency.ReplayedHeader))
+	})
+
+	t.Run("Request in progress", func(t *testing.T) {
+		store := newFakeIdempotencyStore()
+		router, _ := newIdempotentRouter(store)
+		_, err := store.Begin(context.Background(), idempotency.Key{
+			Principal:   lojaA.Scope(),
+			Key:         "pedido-1",
+			Route:       "POST /packages",
+			RequestHash: idempotency.HashBody([]byte(`{"produto": "Camisa"}`)),
+		})
+		require.NoError(t, err)
+
+		w := postPackage(router, "olk_loja_a", "pedido-1", `{"produto": "Camisa"}`)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+	})
+
+	t.Run("Without the header every request is processed", func(t *testing.T) {
+		router, created := newIdempotentRouter(newFakeIdempotencyStore())
+
+		postPackage(router, "olk_loja_a", "", `{"produto": "Camisa"}`)
+		postPackage(router, "olk_loja_a", "", `{"produto": "Camisa"}`)
+
+		assert.Equal(t, 2, *created)
+	})
+
+	t.Run("Key too long", func(t *testing.T) {
+		router, created := newIdempotentRouter(newFakeIdempotencyStore())
+
+		w := postPackage(router, "olk_loja_a", strings.Repeat("a", idempotency.MaxKeyLength+1), `{"produto": "Camisa"}`)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Equal(t, 0, *created)
+	})
+}
@@ -0,0 +1,71 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/internal/auth"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+func TestApiKeys(t *testing.T) {
+	ctx := context.Background()
+	defer cleanupTestData(t)
+
+	key, prefix, err := auth.GenerateAPIKey()
+	require.NoError(t, err)
+
+	created, err := testQueries.CreateApiKey(ctx, repository.CreateApiKeyParams{
+		Name:      "painel-operacao",
+		Role:      auth.RoleOperator,
+		KeyPrefix: prefix,
+		KeyHash:   auth.HashAPIKey(key),
+		CreatedBy: "bootstrap",
+	})
+	require.NoError(t, err)
+	assert.False(t, created.LastUsedAt.Valid)
+	assert.False(t, created.RevokedAt.Valid)
+
+	found, err := testQueries.GetActiveApiKeyByHash(ctx, auth.HashAPIKey(key))
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, found.ID)
+
+	require.NoError(t, testQueries.TouchApiKey(ctx, created.ID))
+	keys, err := testQueries.ListApiKeys(ctx)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.True(t, keys[0].LastUsedAt.Valid)
+
+	revoked, err := testQueries.RevokeApiKey(ctx, created.ID)
+	require.NoError(t, err)
+	require.True(t, revoked.RevokedAt.Valid)
+
+	// Revoking again keeps the original date
+	again, err := testQueries.RevokeApiKey(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, revoked.RevokedAt.Time, again.RevokedAt.Time)
+
+	_, err = testQueries.GetActiveApiKeyByHash(ctx, auth.HashAPIKey(key))
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.RevokeApiKey(ctx, uuid.New())
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestApiKeyRoleIsChecked(t *testing.T) {
+	ctx := context.Background()
+	defer cleanupTestData(t)
+
+	_, err := testQueries.CreateApiKey(ctx, repository.CreateApiKeyParams{
+		Name:      "papel-invalido",
+		Role:      "superuser",
+		KeyPrefix: "olk_00000000",
+		KeyHash:   auth.HashAPIKey("olk_invalid"),
+		CreatedBy: "bootstrap",
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,171 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+func TestCarrierEventMappings(t *testing.T) {
+	ctx := context.Background()
+	nebulixID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+
+	collected, err := testQueries.GetCarrierEventMapping(ctx, repository.GetCarrierEventMappingParams{
+		CarrierID:   nebulixID,
+		CarrierCode: "COL",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "coletado", collected.Status.String)
+
+	failedAttempt, err := testQueries.GetCarrierEventMapping(ctx, repository.GetCarrierEventMappingParams{
+		CarrierID:   nebulixID,
+		CarrierCode: "TNE",
+	})
+	require.NoError(t, err)
+	assert.False(t, failedAttempt.Status.Valid)
+
+	_, err = testQueries.GetCarrierEventMapping(ctx, repository.GetCarrierEventMappingParams{
+		CarrierID:   nebulixID,
+		CarrierCode: "PICKED_UP",
+	})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	saved, err := testQueries.UpsertCarrierEventMapping(ctx, repository.UpsertCarrierEventMappingParams{
+		CarrierID:   nebulixID,
+		CarrierCode: "DEV",
+		Status:      sql.NullString{String: "extraviado", Valid: true},
+		Description: "Devolvido ao remetente",
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err := testDB.ExecContext(ctx, "DELETE FROM carrier_event_mappings WHERE carrier_id = $1 AND carrier_code = 'DEV'", nebulixID)
+		assert.NoError(t, err)
+	}()
+	assert.Equal(t, "extraviado", saved.Status.String)
+
+	updated, err := testQueries.UpsertCarrierEventMapping(ctx, repository.UpsertCarrierEventMappingParams{
+		CarrierID:   nebulixID,
+		CarrierCode: "DEV",
+		Description: "Em devolução",
+	})
+	require.NoError(t, err)
+	assert.False(t, updated.Status.Valid)
+	assert.Equal(t, "Em devolução", updated.Description)
+
+	mappings, err := testQueries.ListCarrierEventMappings(ctx, nebulixID)
+	require.NoError(t, err)
+	assert.Len(t, mappings, 7)
+}
+
+func TestCarrierApiTokens(t *testing.T) {
+	ctx := context.Background()
+	carrierID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440002")
+	defer func() {
+		_, err := testDB.ExecContext(ctx, "DELETE FROM carrier_api_tokens WHERE carrier_id = $1", carrierID)
+		assert.NoError(t, err)
+	}()
+
+	_, err := testQueries.GetCarrierApiTokenHash(ctx, carrierID)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	first := "8f434346648f6b96df89dda901c5176b10a6d83961dd3c1ac88b59b2dc327aa4"
+	second := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	require.NoError(t, testQueries.UpsertCarrierApiToken(ctx, repository.UpsertCarrierApiTokenParams{CarrierID: carrierID, TokenHash: first}))
+	require.NoError(t, testQueries.UpsertCarrierApiToken(ctx, repository.UpsertCarrierApiTokenParams{CarrierID: carrierID, TokenHash: second}))
+
+	hash, err := testQueries.GetCarrierApiTokenHash(ctx, carrierID)
+	require.NoError(t, err)
+	assert.Equal(t, second, hash)
+}
+
+func TestCarrierTrackingEventsAreDeduplicated(t *testing.T) {
+	ctx := context.Background()
+	defer cleanupTestData(t)
+
+	nebulixID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+	pkg, err := testQueries.CreatePackage(ctx, repository.CreatePackageParams{
+		SellerID:         defaultSellerID,
+		Product:          "Scanned Package",
+		WeightKg:         1.0,
+		DestinationState: "SP",
+	})
+	require.NoError(t, err)
+
+	arg := repository.CreateCarrierTrackingEventParams{
+		CarrierID:      nebulixID,
+		PackageID:      pkg.ID,
+		CarrierEventID: "NB-1",
+		CarrierCode:    "COL",
+		MappedStatus:   sql.NullString{String: "coletado", Valid: true},
+		Location:       sql.NullString{String: "Cajamar/SP", Valid: true},
+		OccurredAt:     time.Date(2026, 10, 17, 12, 30, 0, 0, time.UTC),
+		Raw:            json.RawMessage(`{"evento_id": "NB-1", "codigo": "COL"}`),
+	}
+
+	created, err := testQueries.CreateCarrierTrackingEvent(ctx, arg)
+	require.NoError(t, err)
+	assert.Equal(t, "coletado", created.MappedStatus.String)
+
+	_, err = testQueries.CreateCarrierTrackingEvent(ctx, arg)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	found, err := testQueries.GetCarrierTrackingEvent(ctx, repository.GetCarrierTrackingEventParams{
+		CarrierID:      nebulixID,
+		CarrierEventID: "NB-1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, found.ID)
+	assert.JSONEq(t, string(arg.Raw), string(found.Raw))
+
+	// The same id from another carrier is a different event
+	arg.CarrierID = uuid.MustParse("660e8400-e29b-41d4-a716-446655440003")
+	_, err = testQueries.CreateCarrierTrackingEvent(ctx, arg)
+	require.NoError(t, err)
+}
+
+func TestListCarrierTrackingEventsByPackage(t *testing.T) {
+	ctx := context.Background()
+	defer cleanupTestData(t)
+
+	nebulixID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+	pkg, err := testQueries.CreatePackage(ctx, repository.CreatePackageParams{
+		SellerID:         defaultSellerID,
+		Product:          "Timeline Package",
+		WeightKg:         1.0,
+		DestinationState: "SP",
+	})
+	require.NoError(t, err)
+
+	// Stored out of order; the listing follows the scan time
+	for _, event := range []struct {
+		id         string
+		code       string
+		occurredAt time.Time
+	}{
+		{"NB-2", "TRA", time.Date(2026, 10, 17, 18, 0, 0, 0, time.UTC)},
+		{"NB-1", "COL", time.Date(2026, 10, 17, 12, 30, 0, 0, time.UTC)},
+	} {
+		_, err := testQueries.CreateCarrierTrackingEvent(ctx, repository.CreateCarrierTrackingEventParams{
+			CarrierID:      nebulixID,
+			PackageID:      pkg.ID,
+			CarrierEventID: event.id,
+			CarrierCode:    event.code,
+			OccurredAt:     event.occurredAt,
+			Raw:            json.RawMessage(`{}`),
+		})
+		require.NoError(t, err)
+	}
+
+	events, err := testQueries.ListCarrierTrackingEventsByPackage(ctx, pkg.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, "NB-1", events[0].CarrierEventID)
+	assert.Equal(t, "NB-2", events[1].CarrierEventID)
+}
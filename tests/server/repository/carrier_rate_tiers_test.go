@@ -0,0 +1,43 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+func TestListCarrierRateTiersByState(t *testing.T) {
+	ctx := context.Background()
+
+	moventraID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440003")
+
+	tiers, err := testQueries.ListCarrierRateTiersByState(ctx, "BA")
+	require.NoError(t, err)
+	require.Len(t, tiers, 3)
+
+	for i, tier := range tiers {
+		assert.NotEmpty(t, tier.CarrierRegionID)
+		assert.NotEmpty(t, tier.Price)
+		if i > 0 {
+			assert.Equal(t, tiers[i-1].MaxWeightKg, tier.MinWeightKg)
+		}
+	}
+
+	quotes, err := testQueries.GetQuotesForPackage(ctx, repository.GetQuotesForPackageParams{StateCode: "BA"})
+	require.NoError(t, err)
+	for _, quote := range quotes {
+		if quote.CarrierID == moventraID {
+			assert.Equal(t, tiers[0].CarrierRegionID, quote.CarrierRegionID)
+			assert.True(t, quote.ExcessPricePerKg.Valid)
+			assert.Equal(t, "15.00", quote.MinCharge)
+		}
+	}
+
+	southTiers, err := testQueries.ListCarrierRateTiersByState(ctx, "SP")
+	require.NoError(t, err)
+	assert.Empty(t, southTiers)
+}
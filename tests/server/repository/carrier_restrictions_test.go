@@ -0,0 +1,56 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+func TestCarrierRestrictions(t *testing.T) {
+	defer cleanupTestData(t)
+
+	ctx := context.Background()
+
+	nebulixID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+
+	carrier, err := testQueries.GetCarrierById(ctx, nebulixID)
+	require.NoError(t, err)
+	assert.Equal(t, 30.0, carrier.MaxWeightKg.Float64)
+	assert.True(t, carrier.MaxSideCm.Valid)
+	assert.True(t, carrier.MaxDimensionsSumCm.Valid)
+
+	restricted, err := testQueries.ListCarrierRestrictedCategories(ctx)
+	require.NoError(t, err)
+
+	var nebulixCategories []string
+	for _, row := range restricted {
+		if row.CarrierID == nebulixID {
+			nebulixCategories = append(nebulixCategories, row.Category)
+		}
+	}
+	assert.Equal(t, []string{"bateria", "liquido"}, nebulixCategories)
+
+	pkg, err := testQueries.CreatePackage(ctx, repository.CreatePackageParams{
+		SellerID:         defaultSellerID,
+		Product:          "Fragile Product",
+		WeightKg:         1.0,
+		DestinationState: "SP",
+		ProductCategory:  sql.NullString{String: "fragil", Valid: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "fragil", pkg.ProductCategory)
+
+	_, err = testQueries.CreatePackage(ctx, repository.CreatePackageParams{
+		SellerID:         defaultSellerID,
+		Product:          "Unknown Category",
+		WeightKg:         1.0,
+		DestinationState: "SP",
+		ProductCategory:  sql.NullString{String: "explosivo", Valid: true},
+	})
+	assert.Error(t, err)
+}
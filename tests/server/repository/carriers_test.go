@@ -0,0 +1,330 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+func createTestCarrier(t *testing.T, prefix string) repository.Carrier {
+	ctx := context.Background()
+
+	carrier, err := testQueries.CreateCarrier(ctx, repository.CreateCarrierParams{
+		Name:           "Transportadora " + prefix,
+		TrackingPrefix: prefix,
+		MaxWeightKg:    sql.NullFloat64{Float64: 20, Valid: true},
+		CutoffTime:     time.Date(0, 1, 1, 15, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		cleanupTestData(t)
+		_, err := testDB.ExecContext(ctx, "DELETE FROM carriers WHERE id = $1", carrier.ID)
+		assert.NoError(t, err)
+	})
+
+	return carrier
+}
+
+func TestCarrierCRUD(t *testing.T) {
+	ctx := context.Background()
+
+	carrier := createTestCarrier(t, "VZ")
+	assert.Equal(t, "VZ", carrier.TrackingPrefix)
+	assert.False(t, carrier.DeletedAt.Valid)
+
+	updated, err := testQueries.UpdateCarrier(ctx, repository.UpdateCarrierParams{
+		ID:             carrier.ID,
+		Name:           "Veloz Cargas",
+		TrackingPrefix: "VZ",
+		CutoffTime:     time.Date(0, 1, 1, 17, 30, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Veloz Cargas", updated.Name)
+	assert.False(t, updated.MaxWeightKg.Valid)
+	assert.Equal(t, "17:30", updated.CutoffTime.Format("15:04"))
+
+	// Active carriers cannot share a tracking prefix
+	_, err = testQueries.CreateCarrier(ctx, repository.CreateCarrierParams{
+		Name:           "Nebulix Duplicada",
+		TrackingPrefix: "NB",
+		CutoffTime:     time.Date(0, 1, 1, 14, 0, 0, 0, time.UTC),
+	})
+	assert.Error(t, err)
+
+	rows, err := testQueries.DeleteCarrier(ctx, carrier.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	// Once deleted it leaves the listing but is still found by id
+	carriers, err := testQueries.ListCarriers(ctx)
+	require.NoError(t, err)
+	for _, listed := range carriers {
+		assert.NotEqual(t, carrier.ID, listed.ID)
+	}
+
+	found, err := testQueries.GetCarrierById(ctx, carrier.ID)
+	require.NoError(t, err)
+	assert.True(t, found.DeletedAt.Valid)
+
+	_, err = testQueries.UpdateCarrier(ctx, repository.UpdateCarrierParams{
+		ID:             carrier.ID,
+		Name:           "Veloz Cargas",
+		TrackingPrefix: "VZ",
+		CutoffTime:     time.Date(0, 1, 1, 14, 0, 0, 0, time.UTC),
+	})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	rows, err = testQueries.DeleteCarrier(ctx, carrier.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
+
+func TestDeleteCarrierWithPackagesInProgress(t *testing.T) {
+	ctx := context.Background()
+
+	carrier := createTestCarrier(t, "VY")
+
+	pkg, err := testQueries.CreatePackage(ctx, repository.CreatePackageParams{
+		SellerID:         defaultSellerID,
+		Product:          "Pacote em andamento",
+		WeightKg:         1.0,
+		DestinationState: "SP",
+	})
+	require.NoError(t, err)
+
+	_, err = testQueries.HireCarrier(ctx, repository.HireCarrierParams{
+		ID:             pkg.ID,
+		HiredCarrierID: uuid.NullUUID{UUID: carrier.ID, Valid: true},
+		HiredPrice:     sql.NullString{String: "10.00", Valid: true},
+		Version:        pkg.Version,
+	})
+	require.NoError(t, err)
+
+	rows, err := testQueries.DeleteCarrier(ctx, carrier.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+
+	// Once the package is delivered the deletion succeeds
+	_, err = testQueries.UpdatePackageStatus(ctx, repository.UpdatePackageStatusParams{ID: pkg.ID, Status: "entregue", Version: pkg.Version + 1})
+	require.NoError(t, err)
+
+	rows, err = testQueries.DeleteCarrier(ctx, carrier.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+}
+
+func TestCarrierCoverage(t *testing.T) {
+	ctx := context.Background()
+
+	carrier := createTestCarrier(t, "VX")
+	norte := uuid.MustParse("550e8400-e29b-41d4-a716-446655440005")
+	nordeste := uuid.MustParse("550e8400-e29b-41d4-a716-446655440004")
+
+	route := repository.CreateCarrierRegionParams{
+		CarrierID:             carrier.ID,
+		RegionID:              norte,
+		EstimatedDeliveryDays: 12,
+		PricePerKg:            "9.90",
+		MinCharge:             "25.00",
+		CubingDivisor:         6000,
+	}
+	anyOrigin, err := testQueries.CreateCarrierRegion(ctx, route)
+	require.NoError(t, err)
+	assert.False(t, anyOrigin.ValidTo.Valid)
+
+	route.OriginRegionID = uuid.NullUUID{UUID: nordeste, Valid: true}
+	route.EstimatedDeliveryDays = 6
+	fromNordeste, err := testQueries.CreateCarrierRegion(ctx, route)
+	require.NoError(t, err)
+
+	coverage, err := testQueries.GetCarrierRegions(ctx, carrier.ID)
+	require.NoError(t, err)
+	require.Len(t, coverage, 2)
+	assert.Equal(t, anyOrigin.ID, coverage[0].ID)
+	assert.Equal(t, "Norte", coverage[0].RegionName)
+	assert.False(t, coverage[0].OriginRegionName.Valid)
+	assert.Equal(t, "Nordeste", coverage[1].OriginRegionName.String)
+
+	quotes, err := testQueries.GetQuotesForPackage(ctx, repository.GetQuotesForPackageParams{StateCode: "AM"})
+	require.NoError(t, err)
+	assert.True(t, containsCarrier(quotes, carrier.ID))
+
+	// The lane is only found through its own carrier
+	_, err = testQueries.GetCarrierRegionById(ctx, repository.GetCarrierRegionByIdParams{
+		ID:        fromNordeste.ID,
+		CarrierID: uuid.MustParse("660e8400-e29b-41d4-a716-446655440001"),
+	})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	err = testQueries.EndCarrierRegion(ctx, repository.EndCarrierRegionParams{
+		ID:        fromNordeste.ID,
+		CarrierID: carrier.ID,
+	})
+	require.NoError(t, err)
+
+	coverage, err = testQueries.GetCarrierRegions(ctx, carrier.ID)
+	require.NoError(t, err)
+	assert.Len(t, coverage, 1)
+
+	// The ended version stays in history
+	rates, err := testQueries.ListCarrierRates(ctx, repository.ListCarrierRatesParams{CarrierID: carrier.ID})
+	require.NoError(t, err)
+	assert.Len(t, rates, 2)
+
+	// Deleted carriers are no longer quoted
+	_, err = testQueries.DeleteCarrier(ctx, carrier.ID)
+	require.NoError(t, err)
+
+	quotes, err = testQueries.GetQuotesForPackage(ctx, repository.GetQuotesForPackageParams{StateCode: "AM"})
+	require.NoError(t, err)
+	assert.False(t, containsCarrier(quotes, carrier.ID))
+}
+
+func TestCarrierRateVersions(t *testing.T) {
+	ctx := context.Background()
+
+	carrier := createTestCarrier(t, "VY")
+	norte := uuid.MustParse("550e8400-e29b-41d4-a716-446655440005")
+
+	route := repository.CreateCarrierRegionParams{
+		CarrierID:             carrier.ID,
+		RegionID:              norte,
+		EstimatedDeliveryDays: 12,
+		PricePerKg:            "9.90",
+		MinCharge:             "25.00",
+		CubingDivisor:         6000,
+	}
+	current, err := testQueries.CreateCarrierRegion(ctx, route)
+	require.NoError(t, err)
+
+	_, err = testDB.ExecContext(ctx, `INSERT INTO carrier_rate_tiers (carrier_region_id, min_weight_kg, max_weight_kg, price)
+		VALUES ($1, 0, 1, 15.00)`, current.ID)
+	require.NoError(t, err)
+
+	// Scheduling next month's version ends the current one at its start
+	nextMonth := time.Now().AddDate(0, 1, 0).Truncate(time.Second)
+	route.ValidFrom = sql.NullTime{Time: nextMonth, Valid: true}
+	route.PricePerKg = "11.90"
+	scheduled, err := testQueries.CreateCarrierRegion(ctx, route)
+	require.NoError(t, err)
+	assert.False(t, scheduled.ValidTo.Valid)
+
+	_, err = testQueries.CreateCarrierRegion(ctx, route)
+	assert.Error(t, err, "two versions cannot start at the same time")
+
+	rates, err := testQueries.ListCarrierRates(ctx, repository.ListCarrierRatesParams{CarrierID: carrier.ID})
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	assert.Equal(t, current.ID, rates[0].ID)
+	assert.True(t, rates[0].ValidTo.Valid)
+	assert.Equal(t, scheduled.ID, rates[1].ID)
+
+	// Weight tiers are inherited from the previous version
+	tiers, err := testQueries.ListCarrierRateTiersByState(ctx, "AM")
+	require.NoError(t, err)
+	assert.True(t, containsTier(tiers, scheduled.ID))
+
+	// The current version applies today and the scheduled one next month
+	quotes, err := testQueries.GetQuotesForPackage(ctx, repository.GetQuotesForPackageParams{StateCode: "AM"})
+	require.NoError(t, err)
+	for _, quote := range quotes {
+		if quote.CarrierID == carrier.ID {
+			assert.Equal(t, "9.90", quote.PricePerKg)
+		}
+	}
+
+	rates, err = testQueries.ListCarrierRates(ctx, repository.ListCarrierRatesParams{
+		CarrierID: carrier.ID,
+		At:        sql.NullTime{Time: nextMonth.Add(time.Hour), Valid: true},
+	})
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	assert.Equal(t, "11.90", rates[0].PricePerKg)
+
+	// Ending the lane drops the scheduled version
+	err = testQueries.EndCarrierRegion(ctx, repository.EndCarrierRegionParams{ID: current.ID, CarrierID: carrier.ID})
+	require.NoError(t, err)
+
+	rates, err = testQueries.ListCarrierRates(ctx, repository.ListCarrierRatesParams{CarrierID: carrier.ID})
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	assert.Equal(t, current.ID, rates[0].ID)
+}
+
+func TestImportCarrierRates(t *testing.T) {
+	ctx := context.Background()
+
+	carrier := createTestCarrier(t, "VW")
+	norte := uuid.MustParse("550e8400-e29b-41d4-a716-446655440005")
+	nordeste := uuid.MustParse("550e8400-e29b-41d4-a716-446655440004")
+
+	current, err := testQueries.CreateCarrierRegion(ctx, repository.CreateCarrierRegionParams{
+		CarrierID:             carrier.ID,
+		RegionID:              norte,
+		EstimatedDeliveryDays: 12,
+		PricePerKg:            "9.90",
+		MinCharge:             "25.00",
+		CubingDivisor:         6000,
+	})
+	require.NoError(t, err)
+
+	lanes := fmt.Sprintf(`[
+		{"region_id": %q, "estimated_delivery_days": 10, "price_per_kg": 9.9, "min_charge": 25, "excess_price_per_kg": 7.5, "cubing_divisor": 6000,
+		 "tiers": [{"min_weight_kg": 0, "max_weight_kg": 1, "price": 19.9}, {"min_weight_kg": 1, "max_weight_kg": 5, "price": 44.9}]},
+		{"region_id": %q, "estimated_delivery_days": 6, "price_per_kg": 6.5, "min_charge": 0, "excess_price_per_kg": 6.5, "cubing_divisor": 6000,
+		 "tiers": [{"min_weight_kg": 0, "max_weight_kg": 5, "price": 39.9}]}
+	]`, norte, nordeste)
+	versions, err := testQueries.ImportCarrierRates(ctx, repository.ImportCarrierRatesParams{
+		Lanes:     json.RawMessage(lanes),
+		CarrierID: carrier.ID,
+	})
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+
+	coverage, err := testQueries.GetCarrierRegions(ctx, carrier.ID)
+	require.NoError(t, err)
+	require.Len(t, coverage, 2)
+	for _, region := range coverage {
+		assert.NotEqual(t, current.ID, region.ID)
+		if region.RegionID == norte {
+			assert.Equal(t, int32(10), region.EstimatedDeliveryDays)
+			assert.Equal(t, "7.50", region.ExcessPricePerKg.String)
+		}
+	}
+
+	// The previous version stays in history
+	rates, err := testQueries.ListCarrierRates(ctx, repository.ListCarrierRatesParams{CarrierID: carrier.ID})
+	require.NoError(t, err)
+	assert.Len(t, rates, 3)
+
+	tiers, err := testQueries.ListCarrierRateTiersByCarrier(ctx, carrier.ID)
+	require.NoError(t, err)
+	assert.Len(t, tiers, 3)
+}
+
+func containsTier(tiers []repository.CarrierRateTier, carrierRegionID uuid.UUID) bool {
+	for _, tier := range tiers {
+		if tier.CarrierRegionID == carrierRegionID {
+			return true
+		}
+	}
+	return false
+}
+
+func containsCarrier(quotes []repository.GetQuotesForPackageRow, carrierID uuid.UUID) bool {
+	for _, quote := range quotes {
+		if quote.CarrierID == carrierID {
+			return true
+		}
+	}
+	return false
+}
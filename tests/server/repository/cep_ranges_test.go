@@ -0,0 +1,56 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetCepRange(t *testing.T) {
+	ctx := context.Background()
+
+	capital, err := testQueries.GetCepRange(ctx, "01310100")
+	require.NoError(t, err)
+	assert.Equal(t, "SP", capital.StateCode)
+	assert.Equal(t, "São Paulo", capital.City.String)
+	assert.True(t, capital.IsCapital)
+
+	interior, err := testQueries.GetCepRange(ctx, "13010000")
+	require.NoError(t, err)
+	assert.Equal(t, "SP", interior.StateCode)
+	assert.False(t, interior.City.Valid)
+	assert.False(t, interior.IsCapital)
+
+	noronha, err := testQueries.GetCepRange(ctx, "53990000")
+	require.NoError(t, err)
+	assert.Equal(t, "PE", noronha.StateCode)
+	assert.Equal(t, "Fernando de Noronha", noronha.City.String)
+
+	_, err = testQueries.GetCepRange(ctx, "00000000")
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestListCarrierCepRules(t *testing.T) {
+	ctx := context.Background()
+
+	rotaFacilID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440002")
+	moventraID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440003")
+
+	rules, err := testQueries.ListCarrierCepRules(ctx, "53990000")
+	require.NoError(t, err)
+
+	byCarrier := make(map[uuid.UUID]bool)
+	for _, rule := range rules {
+		byCarrier[rule.CarrierID] = rule.Serviceable
+	}
+	assert.False(t, byCarrier[rotaFacilID])
+	assert.True(t, byCarrier[moventraID])
+
+	capitalRules, err := testQueries.ListCarrierCepRules(ctx, "01310100")
+	require.NoError(t, err)
+	assert.Empty(t, capitalRules)
+}
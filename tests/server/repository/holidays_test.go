@@ -0,0 +1,40 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListHolidaysForState(t *testing.T) {
+	ctx := context.Background()
+
+	spHolidays, err := testQueries.ListHolidaysForState(ctx, sql.NullString{String: "SP", Valid: true})
+	require.NoError(t, err)
+
+	amHolidays, err := testQueries.ListHolidaysForState(ctx, sql.NullString{String: "AM", Valid: true})
+	require.NoError(t, err)
+
+	names := make(map[string]bool)
+	for _, holiday := range spHolidays {
+		names[holiday.Name] = true
+		if holiday.StateCode.Valid {
+			assert.Equal(t, "SP", holiday.StateCode.String)
+		}
+	}
+	assert.True(t, names["Natal"])
+	assert.True(t, names["Revolução Constitucionalista"])
+	assert.Greater(t, len(spHolidays), len(amHolidays))
+
+	for _, holiday := range amHolidays {
+		assert.False(t, holiday.StateCode.Valid)
+		if holiday.Name == "Natal" {
+			assert.Equal(t, time.December, holiday.HolidayDate.Month())
+			assert.Equal(t, 25, holiday.HolidayDate.Day())
+		}
+	}
+}
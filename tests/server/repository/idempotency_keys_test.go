@@ -0,0 +1,103 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+func TestIdempotencyKeys(t *testing.T) {
+	ctx := context.Background()
+	defer cleanupTestData(t)
+
+	reserve := func(hash string, lockTimeout time.Duration) (repository.IdempotencyKey, error) {
+		return testQueries.ReserveIdempotencyKey(ctx, repository.ReserveIdempotencyKeyParams{
+			Principal:          "apikey:loja",
+			IdempotencyKey:     "pedido-1",
+			Route:              "POST /api/v1/packages",
+			RequestHash:        hash,
+			TtlSeconds:         time.Hour.Seconds(),
+			LockTimeoutSeconds: lockTimeout.Seconds(),
+		})
+	}
+	lookup := repository.GetIdempotencyKeyParams{
+		Principal:      "apikey:loja",
+		IdempotencyKey: "pedido-1",
+		Route:          "POST /api/v1/packages",
+	}
+	hashA := "a000000000000000000000000000000000000000000000000000000000000000"
+	hashB := "b000000000000000000000000000000000000000000000000000000000000000"
+
+	reserved, err := reserve(hashA, time.Minute)
+	require.NoError(t, err)
+	assert.False(t, reserved.StatusCode.Valid)
+
+	// A key in use returns no row for either body
+	_, err = reserve(hashA, time.Minute)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	_, err = reserve(hashB, time.Minute)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// A retry with the same body takes over a stuck key
+	_, err = reserve(hashA, 0)
+	require.NoError(t, err)
+
+	err = testQueries.CompleteIdempotencyKey(ctx, repository.CompleteIdempotencyKeyParams{
+		StatusCode:      sql.NullInt32{Int32: 201, Valid: true},
+		ContentType:     sql.NullString{String: "application/json; charset=utf-8", Valid: true},
+		ResponseBody:    []byte(`{"id":"pacote-1"}`),
+		ResponseHeaders: json.RawMessage(`{"ETag": "\"1\""}`),
+		Principal:       lookup.Principal,
+		IdempotencyKey:  lookup.IdempotencyKey,
+		Route:           lookup.Route,
+		RequestHash:     hashA,
+	})
+	require.NoError(t, err)
+
+	stored, err := testQueries.GetIdempotencyKey(ctx, lookup)
+	require.NoError(t, err)
+	assert.Equal(t, int32(201), stored.StatusCode.Int32)
+	assert.Equal(t, `{"id":"pacote-1"}`, string(stored.ResponseBody))
+	assert.JSONEq(t, `{"ETag": "\"1\""}`, string(stored.ResponseHeaders))
+
+	// A completed key is neither released nor taken over before it expires
+	require.NoError(t, testQueries.ReleaseIdempotencyKey(ctx, repository.ReleaseIdempotencyKeyParams{
+		Principal:      lookup.Principal,
+		IdempotencyKey: lookup.IdempotencyKey,
+		Route:          lookup.Route,
+		RequestHash:    hashA,
+	}))
+	_, err = reserve(hashA, 0)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	// An expired key is free for a new request
+	_, err = testDB.ExecContext(ctx, "UPDATE idempotency_keys SET expires_at = NOW() - INTERVAL '1 second'")
+	require.NoError(t, err)
+	reserved, err = reserve(hashB, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, hashB, reserved.RequestHash)
+	assert.False(t, reserved.StatusCode.Valid)
+	assert.Nil(t, reserved.ResponseBody)
+	assert.JSONEq(t, `{}`, string(reserved.ResponseHeaders))
+	assert.WithinDuration(t, reserved.CreatedAt.Add(time.Hour), reserved.ExpiresAt, time.Second)
+
+	// The cleanup removes only expired keys
+	deleted, err := testQueries.DeleteExpiredIdempotencyKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), deleted)
+
+	_, err = testDB.ExecContext(ctx, "UPDATE idempotency_keys SET expires_at = NOW() - INTERVAL '1 second'")
+	require.NoError(t, err)
+	deleted, err = testQueries.DeleteExpiredIdempotencyKeys(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+
+	_, err = testQueries.GetIdempotencyKey(ctx, lookup)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+func TestPackageStatusEvents(t *testing.T) {
+	defer cleanupTestData(t)
+
+	ctx := context.Background()
+
+	pkg, err := testQueries.CreatePackage(ctx, repository.CreatePackageParams{
+		SellerID:         defaultSellerID,
+		Product:          "History Product",
+		WeightKg:         1.0,
+		DestinationState: "SP",
+	})
+	require.NoError(t, err)
+
+	first, err := testQueries.CreatePackageStatusEvent(ctx, repository.CreatePackageStatusEventParams{
+		PackageID:  pkg.ID,
+		FromStatus: "criado",
+		ToStatus:   "esperando_coleta",
+		Actor:      "api",
+	})
+	require.NoError(t, err)
+	assert.NotEmpty(t, first.ID)
+	assert.False(t, first.Reason.Valid)
+
+	_, err = testQueries.CreatePackageStatusEvent(ctx, repository.CreatePackageStatusEventParams{
+		PackageID:  pkg.ID,
+		FromStatus: "esperando_coleta",
+		ToStatus:   "coletado",
+		Actor:      "operador",
+		Reason:     sql.NullString{String: "coleta confirmada", Valid: true},
+	})
+	require.NoError(t, err)
+
+	events, err := testQueries.ListPackageStatusEvents(ctx, pkg.ID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "esperando_coleta", events[0].ToStatus)
+	assert.Equal(t, "coletado", events[1].ToStatus)
+	assert.Equal(t, "coleta confirmada", events[1].Reason.String)
+}
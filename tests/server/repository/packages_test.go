@@ -3,6 +3,7 @@ package repository_test
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"testing"
 
 	"github.com/google/uuid"
@@ -17,6 +18,7 @@ func TestCreatePackage(t *testing.T) {
 	ctx := context.Background()
 
 	arg := repository.CreatePackageParams{
+		SellerID: defaultSellerID,
 		TrackingCode: sql.NullString{
 			String: "BR12345678",
 			Valid:  true,
@@ -44,6 +46,7 @@ func TestGetPackageById(t *testing.T) {
 	ctx := context.Background()
 
 	createArg := repository.CreatePackageParams{
+		SellerID: defaultSellerID,
 		TrackingCode: sql.NullString{
 			String: "BR12345621",
 			Valid:  true,
@@ -55,7 +58,7 @@ func TestGetPackageById(t *testing.T) {
 	createdPkg, err := testQueries.CreatePackage(ctx, createArg)
 	require.NoError(t, err)
 
-	pkg, err := testQueries.GetPackageById(ctx, createdPkg.ID)
+	pkg, err := testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: createdPkg.ID})
 
 	require.NoError(t, err)
 	assert.Equal(t, createdPkg.ID, pkg.ID)
@@ -72,6 +75,7 @@ func TestGetPackageByTrackingCode(t *testing.T) {
 
 	trackingCode := "BR11223344"
 	createArg := repository.CreatePackageParams{
+		SellerID: defaultSellerID,
 		TrackingCode: sql.NullString{
 			String: trackingCode,
 			Valid:  true,
@@ -84,9 +88,8 @@ func TestGetPackageByTrackingCode(t *testing.T) {
 	createdPkg, err := testQueries.CreatePackage(ctx, createArg)
 	require.NoError(t, err)
 
-	pkg, err := testQueries.GetPackageByTrackingCode(ctx, sql.NullString{
-		String: trackingCode,
-		Valid:  true,
+	pkg, err := testQueries.GetPackageByTrackingCode(ctx, repository.GetPackageByTrackingCodeParams{
+		TrackingCode: sql.NullString{String: trackingCode, Valid: true},
 	})
 
 	require.NoError(t, err)
@@ -101,6 +104,7 @@ func TestListPackages(t *testing.T) {
 
 	packages := []repository.CreatePackageParams{
 		{
+			SellerID: defaultSellerID,
 			TrackingCode: sql.NullString{
 				String: "BR1111111",
 				Valid:  true,
@@ -110,6 +114,7 @@ func TestListPackages(t *testing.T) {
 			DestinationState: "SP",
 		},
 		{
+			SellerID: defaultSellerID,
 			TrackingCode: sql.NullString{
 				String: "BR222222",
 				Valid:  true,
@@ -117,6 +122,12 @@ func TestListPackages(t *testing.T) {
 			WeightKg:         2.0,
 			DestinationState: "RJ",
 		},
+		{
+			SellerID:         defaultSellerID,
+			Product:          "Camiseta 100% algodão",
+			WeightKg:         0.3,
+			DestinationState: "SP",
+		},
 	}
 
 	for _, pkg := range packages {
@@ -124,10 +135,27 @@ func TestListPackages(t *testing.T) {
 		require.NoError(t, err)
 	}
 
-	result, err := testQueries.ListPackages(ctx)
+	result, err := testQueries.ListPackagesPage(ctx, repository.ListPackagesPageParams{
+		Search:    sql.NullString{String: "product", Valid: true},
+		SortBy:    "created_at",
+		PageLimit: 10,
+	})
+	require.NoError(t, err)
+	assert.Len(t, result, 2)
+
+	// % and _ in the search are literals, not wildcards
+	result, err = testQueries.ListPackagesPage(ctx, repository.ListPackagesPageParams{
+		Search:    sql.NullString{String: "100%", Valid: true},
+		SortBy:    "created_at",
+		PageLimit: 10,
+	})
+	require.NoError(t, err)
+	require.Len(t, result, 1)
+	assert.Equal(t, "Camiseta 100% algodão", result[0].Product)
 
+	total, err := testQueries.CountPackages(ctx, repository.CountPackagesParams{Search: sql.NullString{String: "Product_", Valid: true}})
 	require.NoError(t, err)
-	assert.GreaterOrEqual(t, len(result), 2)
+	assert.Equal(t, int64(0), total)
 }
 
 func TestUpdatePackageStatus(t *testing.T) {
@@ -136,6 +164,7 @@ func TestUpdatePackageStatus(t *testing.T) {
 	ctx := context.Background()
 
 	createArg := repository.CreatePackageParams{
+		SellerID: defaultSellerID,
 		TrackingCode: sql.NullString{
 			String: "BR9999999",
 			Valid:  true,
@@ -148,16 +177,57 @@ func TestUpdatePackageStatus(t *testing.T) {
 	require.NoError(t, err)
 
 	updateArg := repository.UpdatePackageStatusParams{
-		ID:     createdPkg.ID,
-		Status: "enviado",
+		ID:      createdPkg.ID,
+		Status:  "enviado",
+		Version: createdPkg.Version,
 	}
 
-	err = testQueries.UpdatePackageStatus(ctx, updateArg)
+	rows, err := testQueries.UpdatePackageStatus(ctx, updateArg)
 	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
 
-	updatedPkg, err := testQueries.GetPackageById(ctx, createdPkg.ID)
+	updatedPkg, err := testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: createdPkg.ID})
 	require.NoError(t, err)
 	assert.Equal(t, "enviado", updatedPkg.Status)
+	assert.Equal(t, createdPkg.Version+1, updatedPkg.Version)
+}
+
+func TestPackageVersionCheck(t *testing.T) {
+	defer cleanupTestData(t)
+
+	ctx := context.Background()
+
+	pkg, err := testQueries.CreatePackage(ctx, repository.CreatePackageParams{
+		SellerID:         defaultSellerID,
+		Product:          "Version Test Product",
+		WeightKg:         1.0,
+		DestinationState: "SP",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), pkg.Version)
+
+	// Two writes from the same read: only the first one applies
+	rows, err := testQueries.UpdatePackageStatus(ctx, repository.UpdatePackageStatusParams{ID: pkg.ID, Status: "extraviado", Version: pkg.Version})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	rows, err = testQueries.HireCarrier(ctx, repository.HireCarrierParams{
+		ID:             pkg.ID,
+		HiredCarrierID: uuid.NullUUID{UUID: uuid.MustParse("660e8400-e29b-41d4-a716-446655440001"), Valid: true},
+		Version:        pkg.Version,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+
+	rows, err = testQueries.DeletePackage(ctx, repository.DeletePackageParams{ID: pkg.ID, Version: pkg.Version})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+
+	current, err := testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: pkg.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "extraviado", current.Status)
+	assert.False(t, current.HiredCarrierID.Valid)
+	assert.Equal(t, int32(2), current.Version)
 }
 
 func TestHireCarrier(t *testing.T) {
@@ -166,6 +236,7 @@ func TestHireCarrier(t *testing.T) {
 	ctx := context.Background()
 
 	createArg := repository.CreatePackageParams{
+		SellerID: defaultSellerID,
 		TrackingCode: sql.NullString{
 			String: "BR581717171",
 			Valid:  true,
@@ -193,12 +264,14 @@ func TestHireCarrier(t *testing.T) {
 			Int32: 5,
 			Valid: true,
 		},
+		Version: createdPkg.Version,
 	}
 
-	err = testQueries.HireCarrier(ctx, hireArg)
+	rows, err := testQueries.HireCarrier(ctx, hireArg)
 	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
 
-	updatedPkg, err := testQueries.GetPackageById(ctx, createdPkg.ID)
+	updatedPkg, err := testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: createdPkg.ID})
 	require.NoError(t, err)
 	assert.Equal(t, "esperando_coleta", updatedPkg.Status)
 	assert.True(t, updatedPkg.HiredCarrierID.Valid)
@@ -215,6 +288,7 @@ func TestDeletePackage(t *testing.T) {
 	ctx := context.Background()
 
 	createArg := repository.CreatePackageParams{
+		SellerID: defaultSellerID,
 		TrackingCode: sql.NullString{
 			String: "BR7777777",
 			Valid:  true,
@@ -227,12 +301,138 @@ func TestDeletePackage(t *testing.T) {
 	createdPkg, err := testQueries.CreatePackage(ctx, createArg)
 	require.NoError(t, err)
 
-	err = testQueries.DeletePackage(ctx, createdPkg.ID)
+	rows, err := testQueries.DeletePackage(ctx, repository.DeletePackageParams{
+		ID:            createdPkg.ID,
+		Version:       createdPkg.Version,
+		DeletedBy:     sql.NullString{String: "tester", Valid: true},
+		DeletedReason: sql.NullString{String: "pedido cancelado", Valid: true},
+	})
 	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
 
-	_, err = testQueries.GetPackageById(ctx, createdPkg.ID)
+	_, err = testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: createdPkg.ID})
 	assert.Error(t, err)
 	assert.Equal(t, sql.ErrNoRows, err)
+
+	_, err = testQueries.GetPackageByTrackingCode(ctx, repository.GetPackageByTrackingCodeParams{TrackingCode: createArg.TrackingCode})
+	assert.Equal(t, sql.ErrNoRows, err)
+
+	// The row stays with who deleted it and why
+	deleted, err := testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: createdPkg.ID, IncludeDeleted: true})
+	require.NoError(t, err)
+	assert.True(t, deleted.DeletedAt.Valid)
+	assert.Equal(t, "tester", deleted.DeletedBy.String)
+	assert.Equal(t, "pedido cancelado", deleted.DeletedReason.String)
+	assert.Equal(t, createdPkg.Version+1, deleted.Version)
+
+	total, err := testQueries.CountPackages(ctx, repository.CountPackagesParams{Search: sql.NullString{String: "Delete Test Product", Valid: true}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+
+	total, err = testQueries.CountPackages(ctx, repository.CountPackagesParams{Search: sql.NullString{String: "Delete Test Product", Valid: true}, Deleted: true})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	// Deleting again changes nothing
+	rows, err = testQueries.DeletePackage(ctx, repository.DeletePackageParams{ID: deleted.ID, Version: deleted.Version})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+
+	rows, err = testQueries.RestorePackage(ctx, repository.RestorePackageParams{ID: deleted.ID, Version: deleted.Version})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	restored, err := testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: createdPkg.ID})
+	require.NoError(t, err)
+	assert.False(t, restored.DeletedAt.Valid)
+	assert.False(t, restored.DeletedBy.Valid)
+	assert.Equal(t, createdPkg.TrackingCode, restored.TrackingCode)
+
+	// Only deleted packages can be restored
+	rows, err = testQueries.RestorePackage(ctx, repository.RestorePackageParams{ID: restored.ID, Version: restored.Version})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), rows)
+}
+
+func TestHireCarrierTrackingCode(t *testing.T) {
+	defer cleanupTestData(t)
+
+	ctx := context.Background()
+	carrierID := uuid.NullUUID{UUID: uuid.MustParse("660e8400-e29b-41d4-a716-446655440001"), Valid: true}
+
+	pkg, err := testQueries.CreatePackage(ctx, repository.CreatePackageParams{
+		SellerID:         defaultSellerID,
+		Product:          "Test Product",
+		WeightKg:         1.0,
+		DestinationState: "SP",
+	})
+	require.NoError(t, err)
+
+	rows, err := testQueries.HireCarrier(ctx, repository.HireCarrierParams{
+		ID:             pkg.ID,
+		HiredCarrierID: carrierID,
+		Version:        pkg.Version,
+		TrackingCode:   sql.NullString{String: "NB473124829BR", Valid: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	found, err := testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: pkg.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "NB473124829BR", found.TrackingCode.String)
+	assert.Equal(t, pkg.Version+1, found.Version)
+
+	// A package that already has a code keeps it
+	rows, err = testQueries.HireCarrier(ctx, repository.HireCarrierParams{
+		ID:             pkg.ID,
+		HiredCarrierID: carrierID,
+		Version:        found.Version,
+		TrackingCode:   sql.NullString{String: "NB473124830BR", Valid: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+
+	found, err = testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: pkg.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "NB473124829BR", found.TrackingCode.String)
+}
+
+func TestNextTrackingSerial(t *testing.T) {
+	ctx := context.Background()
+
+	first, err := testQueries.NextTrackingSerial(ctx, "NB")
+	require.NoError(t, err)
+	second, err := testQueries.NextTrackingSerial(ctx, "NB")
+	require.NoError(t, err)
+	assert.Equal(t, first+1, second)
+
+	// A new prefix gets its own sequence
+	serial, err := testQueries.NextTrackingSerial(ctx, "ZZ")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), serial)
+
+	_, err = testQueries.NextTrackingSerial(ctx, "z1")
+	assert.Error(t, err)
+}
+
+func TestTrackingCodeIsUnique(t *testing.T) {
+	defer cleanupTestData(t)
+
+	ctx := context.Background()
+
+	arg := repository.CreatePackageParams{
+		SellerID:         defaultSellerID,
+		TrackingCode:     sql.NullString{String: "NB473124829BR", Valid: true},
+		Product:          "Test Product",
+		WeightKg:         1.0,
+		DestinationState: "SP",
+	}
+
+	_, err := testQueries.CreatePackage(ctx, arg)
+	require.NoError(t, err)
+
+	_, err = testQueries.CreatePackage(ctx, arg)
+	assert.Error(t, err)
 }
 
 func TestTrackingCodeExists(t *testing.T) {
@@ -255,23 +455,132 @@ func TestGetQuotesForPackage(t *testing.T) {
 
 	ctx := context.Background()
 
-	arg := repository.GetQuotesForPackageParams{
-		StateCode: "SP",
-		WeightKg:  "2.0",
-	}
-
-	quotes, err := testQueries.GetQuotesForPackage(ctx, arg)
+	quotes, err := testQueries.GetQuotesForPackage(ctx, repository.GetQuotesForPackageParams{StateCode: "SP"})
 
 	require.NoError(t, err)
 	assert.Greater(t, len(quotes), 0)
 
 	for _, quote := range quotes {
-		assert.NotEmpty(t, quote.Carier)
-		assert.Greater(t, quote.EstimatedPrice, 0.0)
+		assert.NotEmpty(t, quote.CarrierID)
+		assert.NotEmpty(t, quote.CarrierName)
+		assert.NotEmpty(t, quote.PricePerKg)
+		assert.Greater(t, quote.CubingDivisor, int32(0))
 		assert.Greater(t, quote.EstimatedDeliveryDays, int32(0))
 	}
 }
 
+func TestCreatePackageWithDimensions(t *testing.T) {
+	defer cleanupTestData(t)
+
+	ctx := context.Background()
+
+	pkg, err := testQueries.CreatePackage(ctx, repository.CreatePackageParams{
+		SellerID:         defaultSellerID,
+		Product:          "Boxed Product",
+		WeightKg:         1.0,
+		DestinationState: "SP",
+		HeightCm:         sql.NullFloat64{Float64: 20, Valid: true},
+		WidthCm:          sql.NullFloat64{Float64: 30, Valid: true},
+		LengthCm:         sql.NullFloat64{Float64: 40, Valid: true},
+	})
+	require.NoError(t, err)
+
+	found, err := testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: pkg.ID})
+	require.NoError(t, err)
+	assert.Equal(t, 20.0, found.HeightCm.Float64)
+	assert.Equal(t, 30.0, found.WidthCm.Float64)
+	assert.Equal(t, 40.0, found.LengthCm.Float64)
+}
+
+func TestCreatePackageWithAddresses(t *testing.T) {
+	defer cleanupTestData(t)
+
+	ctx := context.Background()
+
+	pkg, err := testQueries.CreatePackage(ctx, repository.CreatePackageParams{
+		SellerID:              defaultSellerID,
+		Product:               "Addressed Product",
+		WeightKg:              1.0,
+		DestinationState:      "SP",
+		DestinationCep:        sql.NullString{String: "01310100", Valid: true},
+		DestinationCity:       sql.NullString{String: "São Paulo", Valid: true},
+		RecipientName:         sql.NullString{String: "Maria Souza", Valid: true},
+		RecipientDocument:     sql.NullString{String: "52998224725", Valid: true},
+		RecipientStreet:       sql.NullString{String: "Avenida Paulista", Valid: true},
+		RecipientNumber:       sql.NullString{String: "1000", Valid: true},
+		RecipientNeighborhood: sql.NullString{String: "Bela Vista", Valid: true},
+		SenderName:            sql.NullString{String: "Loja Olist", Valid: true},
+		SenderDocument:        sql.NullString{String: "11222333000181", Valid: true},
+		SenderState:           sql.NullString{String: "PR", Valid: true},
+		SenderCep:             sql.NullString{String: "80020310", Valid: true},
+	})
+	require.NoError(t, err)
+
+	found, err := testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: pkg.ID})
+	require.NoError(t, err)
+	assert.Equal(t, "Maria Souza", found.RecipientName.String)
+	assert.Equal(t, "52998224725", found.RecipientDocument.String)
+	assert.False(t, found.RecipientPhone.Valid)
+	assert.Equal(t, "Loja Olist", found.SenderName.String)
+	assert.Equal(t, "PR", found.SenderState.String)
+	assert.Equal(t, "80020310", found.SenderCep.String)
+}
+
+func TestCreatePackagesBatch(t *testing.T) {
+	defer cleanupTestData(t)
+
+	ctx := context.Background()
+
+	payload := json.RawMessage(`[
+		{"product": "Produto A", "weight_kg": 1.5, "destination_state": "SP"},
+		{"product": "Produto B", "weight_kg": 3, "destination_state": "PE", "height_cm": 10, "width_cm": 20, "length_cm": 30, "product_category": "fragil", "destination_cep": "50010000", "recipient_name": "Maria Souza", "recipient_document": "52998224725"}
+	]`)
+
+	packages, err := testQueries.CreatePackagesBatch(ctx, repository.CreatePackagesBatchParams{
+		SellerID: defaultSellerID,
+		Packages: payload,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, packages, 2)
+	assert.Equal(t, "Produto A", packages[0].Product)
+	assert.Equal(t, "geral", packages[0].ProductCategory)
+	assert.False(t, packages[0].HeightCm.Valid)
+	assert.Equal(t, "Produto B", packages[1].Product)
+	assert.Equal(t, "fragil", packages[1].ProductCategory)
+	assert.Equal(t, 30.0, packages[1].LengthCm.Float64)
+	assert.Equal(t, "50010000", packages[1].DestinationCep.String)
+	assert.False(t, packages[0].RecipientName.Valid)
+	assert.Equal(t, "Maria Souza", packages[1].RecipientName.String)
+	assert.Equal(t, "52998224725", packages[1].RecipientDocument.String)
+	for _, pkg := range packages {
+		assert.Equal(t, "criado", pkg.Status)
+	}
+}
+
+func TestCreatePackagesBatchIsAtomic(t *testing.T) {
+	defer cleanupTestData(t)
+
+	ctx := context.Background()
+
+	payload := json.RawMessage(`[
+		{"product": "Produto A", "weight_kg": 1.5, "destination_state": "SP"},
+		{"product": "Produto B", "weight_kg": 1.5, "destination_state": "XX"}
+	]`)
+
+	_, err := testQueries.CreatePackagesBatch(ctx, repository.CreatePackagesBatchParams{
+		SellerID: defaultSellerID,
+		Packages: payload,
+	})
+	require.Error(t, err)
+
+	packages, err := testQueries.ListPackagesPage(ctx, repository.ListPackagesPageParams{SortBy: "created_at", PageLimit: 100})
+	require.NoError(t, err)
+	for _, pkg := range packages {
+		assert.NotEqual(t, "Produto A", pkg.Product)
+	}
+}
+
 func TestListCarriers(t *testing.T) {
 	defer cleanupTestData(t)
 
@@ -300,6 +609,7 @@ func TestGetCarrierById(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, carrierID, carrier.ID)
 	assert.Equal(t, "Nebulix Logística", carrier.Name)
+	assert.Equal(t, "NB", carrier.TrackingPrefix)
 }
 
 func TestGetCarrierRegions(t *testing.T) {
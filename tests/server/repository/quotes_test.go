@@ -0,0 +1,66 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+func TestQuotes(t *testing.T) {
+	defer cleanupTestData(t)
+
+	ctx := context.Background()
+
+	pkg, err := testQueries.CreatePackage(ctx, repository.CreatePackageParams{
+		SellerID:         defaultSellerID,
+		Product:          "Quoted Product",
+		WeightKg:         2.0,
+		DestinationState: "SP",
+	})
+	require.NoError(t, err)
+
+	carrierID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+
+	quote, err := testQueries.CreateQuote(ctx, repository.CreateQuoteParams{
+		PackageID:             pkg.ID,
+		CarrierID:             carrierID,
+		Price:                 "11.80",
+		EstimatedDeliveryDays: 4,
+		BillableWeightKg:      2.0,
+		TtlSeconds:            60,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "11.80", quote.Price)
+	assert.False(t, quote.UsedAt.Valid)
+	assert.True(t, quote.ExpiresAt.After(quote.CreatedAt))
+
+	found, err := testQueries.GetQuoteById(ctx, quote.ID)
+	require.NoError(t, err)
+	assert.Equal(t, pkg.ID, found.PackageID)
+
+	marked, err := testQueries.MarkQuoteUsed(ctx, quote.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), marked)
+
+	marked, err = testQueries.MarkQuoteUsed(ctx, quote.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), marked)
+
+	expired, err := testQueries.CreateQuote(ctx, repository.CreateQuoteParams{
+		PackageID:             pkg.ID,
+		CarrierID:             carrierID,
+		Price:                 "11.80",
+		EstimatedDeliveryDays: 4,
+		BillableWeightKg:      2.0,
+		TtlSeconds:            -1,
+	})
+	require.NoError(t, err)
+
+	marked, err = testQueries.MarkQuoteUsed(ctx, expired.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), marked)
+}
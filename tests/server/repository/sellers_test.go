@@ -0,0 +1,163 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/internal/auth"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+func TestSellers(t *testing.T) {
+	ctx := context.Background()
+	defer cleanupTestData(t)
+
+	created, err := testQueries.CreateSeller(ctx, "Loja Azul")
+	require.NoError(t, err)
+	assert.Equal(t, "Loja Azul", created.Name)
+
+	found, err := testQueries.GetSellerById(ctx, created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, found.ID)
+
+	sellers, err := testQueries.ListSellers(ctx)
+	require.NoError(t, err)
+	assert.Len(t, sellers, 2)
+
+	_, err = testQueries.GetSellerById(ctx, uuid.New())
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
+
+func TestPackageQueriesAreScopedBySeller(t *testing.T) {
+	ctx := context.Background()
+	defer cleanupTestData(t)
+
+	other, err := testQueries.CreateSeller(ctx, "Loja Azul")
+	require.NoError(t, err)
+
+	pkg, err := testQueries.CreatePackage(ctx, repository.CreatePackageParams{
+		SellerID:         defaultSellerID,
+		TrackingCode:     sql.NullString{String: "NB000000019BR", Valid: true},
+		Product:          "Produto da Loja Olist",
+		WeightKg:         1.0,
+		DestinationState: "SP",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, defaultSellerID, pkg.SellerID)
+
+	owner := uuid.NullUUID{UUID: defaultSellerID, Valid: true}
+	stranger := uuid.NullUUID{UUID: other.ID, Valid: true}
+
+	found, err := testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: pkg.ID, SellerID: owner})
+	require.NoError(t, err)
+	assert.Equal(t, pkg.ID, found.ID)
+
+	// Without a seller the lookup is not restricted
+	_, err = testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: pkg.ID})
+	require.NoError(t, err)
+
+	_, err = testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: pkg.ID, SellerID: stranger})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	_, err = testQueries.GetPackageByTrackingCode(ctx, repository.GetPackageByTrackingCodeParams{
+		TrackingCode: pkg.TrackingCode,
+		SellerID:     stranger,
+	})
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+
+	page, err := testQueries.ListPackagesPage(ctx, repository.ListPackagesPageParams{
+		SortBy:    "created_at",
+		SellerID:  stranger,
+		PageLimit: 10,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, page)
+
+	total, err := testQueries.CountPackages(ctx, repository.CountPackagesParams{SellerID: owner})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+
+	total, err = testQueries.CountPackages(ctx, repository.CountPackagesParams{SellerID: stranger})
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), total)
+}
+
+func TestCreatePackageRequiresExistingSeller(t *testing.T) {
+	ctx := context.Background()
+	defer cleanupTestData(t)
+
+	_, err := testQueries.CreatePackage(ctx, repository.CreatePackageParams{
+		SellerID:         uuid.New(),
+		Product:          "Produto sem loja",
+		WeightKg:         1.0,
+		DestinationState: "SP",
+	})
+	assert.Error(t, err)
+}
+
+func TestUpsertSellerCarrierRate(t *testing.T) {
+	ctx := context.Background()
+	defer cleanupTestData(t)
+
+	carrierID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+
+	rate, err := testQueries.UpsertSellerCarrierRate(ctx, repository.UpsertSellerCarrierRateParams{
+		SellerID:      defaultSellerID,
+		CarrierID:     carrierID,
+		PricePerKg:    sql.NullString{String: "4.50", Valid: true},
+		MarkupPercent: "10.00",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "4.50", rate.PricePerKg.String)
+
+	// Saving again replaces the rate for the same carrier
+	rate, err = testQueries.UpsertSellerCarrierRate(ctx, repository.UpsertSellerCarrierRateParams{
+		SellerID:      defaultSellerID,
+		CarrierID:     carrierID,
+		MarkupPercent: "-5.00",
+	})
+	require.NoError(t, err)
+	assert.False(t, rate.PricePerKg.Valid)
+	assert.Equal(t, "-5.00", rate.MarkupPercent)
+
+	rates, err := testQueries.ListSellerCarrierRates(ctx, defaultSellerID)
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	assert.Equal(t, carrierID, rates[0].CarrierID)
+
+	_, err = testQueries.UpsertSellerCarrierRate(ctx, repository.UpsertSellerCarrierRateParams{
+		SellerID:      defaultSellerID,
+		CarrierID:     carrierID,
+		MarkupPercent: "-100.00",
+	})
+	assert.Error(t, err)
+}
+
+func TestSellerApiKeyRequiresSeller(t *testing.T) {
+	ctx := context.Background()
+	defer cleanupTestData(t)
+
+	_, err := testQueries.CreateApiKey(ctx, repository.CreateApiKeyParams{
+		Name:      "loja-sem-vendedor",
+		Role:      auth.RoleSeller,
+		KeyPrefix: "olk_00000000",
+		KeyHash:   auth.HashAPIKey("olk_seller"),
+		CreatedBy: "bootstrap",
+	})
+	assert.Error(t, err)
+
+	key, err := testQueries.CreateApiKey(ctx, repository.CreateApiKeyParams{
+		Name:      "loja-olist",
+		Role:      auth.RoleSeller,
+		KeyPrefix: "olk_00000001",
+		KeyHash:   auth.HashAPIKey("olk_seller"),
+		CreatedBy: "bootstrap",
+		SellerID:  uuid.NullUUID{UUID: defaultSellerID, Valid: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, defaultSellerID, key.SellerID.UUID)
+}
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/postgres"
@@ -19,6 +20,9 @@ import (
 	"github/moura95/olist-shipping-api/internal/repository"
 )
 
+// defaultSellerID is the seller created by the sellers migration.
+var defaultSellerID = uuid.MustParse("880e8400-e29b-41d4-a716-446655440001")
+
 var (
 	testDB        *sql.DB
 	testQueries   *repository.Queries
@@ -106,6 +110,9 @@ func cleanupTestData(t *testing.T) {
 
 	tables := []string{
 		"packages",
+		"api_keys",
+		"seller_carrier_rates",
+		"idempotency_keys",
 	}
 
 	for _, table := range tables {
@@ -114,4 +121,8 @@ func cleanupTestData(t *testing.T) {
 			t.Logf("Failed to cleanup table %s: %v", table, err)
 		}
 	}
+
+	if _, err := testDB.ExecContext(ctx, "DELETE FROM sellers WHERE id <> $1", defaultSellerID); err != nil {
+		t.Logf("Failed to cleanup table sellers: %v", err)
+	}
 }
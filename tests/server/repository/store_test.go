@@ -0,0 +1,76 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+func TestStoreWithTx(t *testing.T) {
+	defer cleanupTestData(t)
+
+	ctx := context.Background()
+	store := repository.NewStore(testDB)
+
+	pkg, err := testQueries.CreatePackage(ctx, repository.CreatePackageParams{
+		SellerID:         defaultSellerID,
+		Product:          "Store Test Product",
+		WeightKg:         1.0,
+		DestinationState: "SP",
+	})
+	require.NoError(t, err)
+
+	t.Run("Rolls back when fn fails", func(t *testing.T) {
+		errAbort := errors.New("abort")
+		err := store.WithTx(ctx, func(q repository.Querier) error {
+			_, err := q.UpdatePackageStatus(ctx, repository.UpdatePackageStatusParams{ID: pkg.ID, Status: "extraviado", Version: pkg.Version})
+			require.NoError(t, err)
+			return errAbort
+		})
+		assert.ErrorIs(t, err, errAbort)
+
+		current, err := testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: pkg.ID})
+		require.NoError(t, err)
+		assert.Equal(t, "criado", current.Status)
+		assert.Equal(t, pkg.Version, current.Version)
+	})
+
+	t.Run("Retries after a serialization failure", func(t *testing.T) {
+		attempts := 0
+		err := store.WithTx(ctx, func(q repository.Querier) error {
+			attempts++
+			current, err := q.GetPackageById(ctx, repository.GetPackageByIdParams{ID: pkg.ID})
+			if err != nil {
+				return err
+			}
+
+			// On the first attempt another connection changes the package after the read
+			if attempts == 1 {
+				_, err := testQueries.UpdatePackageStatus(ctx, repository.UpdatePackageStatusParams{ID: pkg.ID, Status: "extraviado", Version: current.Version})
+				require.NoError(t, err)
+			}
+
+			_, err = q.UpdatePackageStatusWithTracking(ctx, repository.UpdatePackageStatusWithTrackingParams{
+				ID:           pkg.ID,
+				Status:       current.Status,
+				TrackingCode: sql.NullString{String: "OL000000178BR", Valid: true},
+				Version:      current.Version,
+			})
+			return err
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+
+		current, err := testQueries.GetPackageById(ctx, repository.GetPackageByIdParams{ID: pkg.ID})
+		require.NoError(t, err)
+		assert.Equal(t, "extraviado", current.Status)
+		assert.Equal(t, "OL000000178BR", current.TrackingCode.String)
+		assert.Equal(t, pkg.Version+2, current.Version)
+	})
+}
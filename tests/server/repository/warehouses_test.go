@@ -0,0 +1,94 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+func TestWarehouses(t *testing.T) {
+	ctx := context.Background()
+
+	warehouse, err := testQueries.CreateWarehouse(ctx, repository.CreateWarehouseParams{
+		Name:      "CD Teste",
+		StateCode: "MG",
+	})
+	require.NoError(t, err)
+	defer func() {
+		cleanupTestData(t)
+		_, err := testDB.ExecContext(ctx, "DELETE FROM warehouses WHERE id = $1", warehouse.ID)
+		assert.NoError(t, err)
+	}()
+
+	found, err := testQueries.GetWarehouseById(ctx, warehouse.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "MG", found.StateCode)
+
+	warehouses, err := testQueries.ListWarehouses(ctx)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, len(warehouses), 3)
+
+	pkg, err := testQueries.CreatePackage(ctx, repository.CreatePackageParams{
+		SellerID:          defaultSellerID,
+		Product:           "Shipped From Warehouse",
+		WeightKg:          1.0,
+		DestinationState:  "SP",
+		OriginWarehouseID: uuid.NullUUID{UUID: warehouse.ID, Valid: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, warehouse.ID, pkg.OriginWarehouseID.UUID)
+}
+
+func TestGetQuotesForPackageByLane(t *testing.T) {
+	ctx := context.Background()
+
+	nebulixID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+	rotaFacilID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440002")
+
+	pricesByCarrier := func(quotes []repository.GetQuotesForPackageRow) map[uuid.UUID]string {
+		prices := make(map[uuid.UUID]string)
+		for _, quote := range quotes {
+			prices[quote.CarrierID] = quote.PricePerKg
+		}
+		return prices
+	}
+
+	anyOrigin, err := testQueries.GetQuotesForPackage(ctx, repository.GetQuotesForPackageParams{StateCode: "BA"})
+	require.NoError(t, err)
+	prices := pricesByCarrier(anyOrigin)
+	assert.Len(t, prices, len(anyOrigin))
+	assert.Equal(t, "8.00", prices[rotaFacilID])
+	assert.NotContains(t, prices, nebulixID)
+
+	fromNordeste, err := testQueries.GetQuotesForPackage(ctx, repository.GetQuotesForPackageParams{
+		StateCode:   "BA",
+		OriginState: sql.NullString{String: "PE", Valid: true},
+	})
+	require.NoError(t, err)
+	prices = pricesByCarrier(fromNordeste)
+	assert.Len(t, prices, len(fromNordeste))
+	assert.Equal(t, "5.10", prices[rotaFacilID])
+	assert.Equal(t, "6.40", prices[nebulixID])
+
+	fromSudeste, err := testQueries.GetQuotesForPackage(ctx, repository.GetQuotesForPackageParams{
+		StateCode:   "BA",
+		OriginState: sql.NullString{String: "SP", Valid: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, pricesByCarrier(anyOrigin), pricesByCarrier(fromSudeste))
+
+	regions, err := testQueries.GetCarrierRegions(ctx, nebulixID)
+	require.NoError(t, err)
+	lanes := 0
+	for _, region := range regions {
+		if region.OriginRegionID.Valid {
+			lanes++
+		}
+	}
+	assert.Equal(t, 1, lanes)
+}
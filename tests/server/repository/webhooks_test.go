@@ -0,0 +1,110 @@
+package repository_test
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/internal/repository"
+)
+
+func TestWebhookDeliveries(t *testing.T) {
+	ctx := context.Background()
+
+	subscription, err := testQueries.CreateWebhookSubscription(ctx, repository.CreateWebhookSubscriptionParams{
+		Url:        "https://loja.example.com/webhooks",
+		Secret:     "segredo-da-loja-123",
+		EventTypes: []string{"package.created", "package.deleted"},
+	})
+	require.NoError(t, err)
+	defer func() {
+		_, err := testQueries.DeleteWebhookSubscription(ctx, subscription.ID)
+		assert.NoError(t, err)
+	}()
+	assert.Equal(t, []string{"package.created", "package.deleted"}, subscription.EventTypes)
+
+	forCreated, err := testQueries.ListWebhookSubscriptionsForEvent(ctx, "package.created")
+	require.NoError(t, err)
+	require.Len(t, forCreated, 1)
+	assert.Equal(t, subscription.ID, forCreated[0].ID)
+
+	forHired, err := testQueries.ListWebhookSubscriptionsForEvent(ctx, "package.carrier_hired")
+	require.NoError(t, err)
+	assert.Empty(t, forHired)
+
+	delivery, err := testQueries.CreateWebhookDelivery(ctx, repository.CreateWebhookDeliveryParams{
+		SubscriptionID: subscription.ID,
+		EventID:        uuid.New(),
+		EventType:      "package.created",
+		Payload:        json.RawMessage(`{"tipo": "package.created"}`),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "pendente", delivery.Status)
+	assert.Equal(t, int32(0), delivery.Attempts)
+
+	claimed, err := testQueries.ClaimDueWebhookDeliveries(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+	assert.Equal(t, delivery.ID, claimed[0].ID)
+	assert.Equal(t, subscription.Url, claimed[0].Url)
+	assert.Equal(t, subscription.Secret, claimed[0].Secret)
+
+	// A claimed delivery is not returned again until the claim expires
+	claimedAgain, err := testQueries.ClaimDueWebhookDeliveries(ctx, 10)
+	require.NoError(t, err)
+	assert.Empty(t, claimedAgain)
+
+	err = testQueries.RecordWebhookDeliveryAttempt(ctx, repository.RecordWebhookDeliveryAttemptParams{
+		ID:                 delivery.ID,
+		Status:             "pendente",
+		Attempts:           1,
+		RetryAfterSeconds:  30,
+		LastResponseStatus: sql.NullInt32{Int32: 500, Valid: true},
+		LastError:          sql.NullString{String: "unexpected response status 500", Valid: true},
+	})
+	require.NoError(t, err)
+
+	retrying, err := testQueries.GetWebhookDeliveryById(ctx, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), retrying.Attempts)
+	assert.Equal(t, int32(500), retrying.LastResponseStatus.Int32)
+	assert.False(t, retrying.DeliveredAt.Valid)
+
+	err = testQueries.RecordWebhookDeliveryAttempt(ctx, repository.RecordWebhookDeliveryAttemptParams{
+		ID:                 delivery.ID,
+		Status:             "entregue",
+		Attempts:           2,
+		LastResponseStatus: sql.NullInt32{Int32: 200, Valid: true},
+	})
+	require.NoError(t, err)
+
+	delivered, err := testQueries.GetWebhookDeliveryById(ctx, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "entregue", delivered.Status)
+	assert.True(t, delivered.DeliveredAt.Valid)
+	assert.False(t, delivered.LastError.Valid)
+
+	replayed, err := testQueries.ReplayWebhookDelivery(ctx, delivery.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "pendente", replayed.Status)
+	assert.Equal(t, int32(0), replayed.Attempts)
+	assert.False(t, replayed.DeliveredAt.Valid)
+
+	claimed, err = testQueries.ClaimDueWebhookDeliveries(ctx, 10)
+	require.NoError(t, err)
+	require.Len(t, claimed, 1)
+
+	deliveries, err := testQueries.ListWebhookDeliveries(ctx, repository.ListWebhookDeliveriesParams{
+		SubscriptionID: subscription.ID,
+		Limit:          10,
+	})
+	require.NoError(t, err)
+	assert.Len(t, deliveries, 1)
+
+	_, err = testQueries.ReplayWebhookDelivery(ctx, uuid.New())
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/config"
+	"github/moura95/olist-shipping-api/internal/auth"
+	"github/moura95/olist-shipping-api/internal/repository"
+	"github/moura95/olist-shipping-api/internal/service"
+	"go.uber.org/zap"
+)
+
+func TestAuthService_Authenticate(t *testing.T) {
+	cfg := config.Config{
+		AuthJWTSecret:    "segredo-jwt-de-teste",
+		AuthTokenTTL:     time.Hour,
+		AuthBootstrapKey: "olk_bootstrap",
+	}
+	keyID := uuid.New()
+	apiKey := "olk_" + "c0ffee"
+
+	seller := uuid.NullUUID{UUID: testSellerID, Valid: true}
+
+	validJWT, err := auth.SignToken([]byte(cfg.AuthJWTSecret), auth.Claims{
+		Subject:   "seller-42",
+		Role:      auth.RoleSeller,
+		SellerID:  testSellerID.String(),
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	})
+	require.NoError(t, err)
+	expiredJWT, err := auth.SignToken([]byte(cfg.AuthJWTSecret), auth.Claims{
+		Subject:   "seller-42",
+		Role:      auth.RoleSeller,
+		SellerID:  testSellerID.String(),
+		ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name              string
+		cfg               config.Config
+		credential        string
+		setupMocked       func(repo *repository.QuerierMocked)
+		expectedPrincipal *auth.Principal
+		expectedError     error
+	}{
+		{
+			name:              "Valid API key",
+			cfg:               cfg,
+			credential:        apiKey,
+			expectedPrincipal: &auth.Principal{Subject: "apikey:painel", Role: auth.RoleOperator, Method: auth.MethodAPIKey, APIKeyID: uuid.NullUUID{UUID: keyID, Valid: true}},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetActiveApiKeyByHash", mock.Anything, auth.HashAPIKey(apiKey)).
+					Return(repository.ApiKey{ID: keyID, Name: "painel", Role: auth.RoleOperator}, nil)
+				repo.On("TouchApiKey", mock.Anything, keyID).Return(nil)
+			},
+		},
+		{
+			name:              "Failing to record usage does not block the request",
+			cfg:               cfg,
+			credential:        apiKey,
+			expectedPrincipal: &auth.Principal{Subject: "apikey:painel", Role: auth.RoleOperator, Method: auth.MethodAPIKey, APIKeyID: uuid.NullUUID{UUID: keyID, Valid: true}},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetActiveApiKeyByHash", mock.Anything, auth.HashAPIKey(apiKey)).
+					Return(repository.ApiKey{ID: keyID, Name: "painel", Role: auth.RoleOperator}, nil)
+				repo.On("TouchApiKey", mock.Anything, keyID).Return(errors.New("database error"))
+			},
+		},
+		{
+			name:       "Seller API key is scoped to its seller",
+			cfg:        cfg,
+			credential: apiKey,
+			expectedPrincipal: &auth.Principal{
+				Subject:  "apikey:loja",
+				Role:     auth.RoleSeller,
+				Method:   auth.MethodAPIKey,
+				SellerID: seller,
+				APIKeyID: uuid.NullUUID{UUID: keyID, Valid: true},
+			},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetActiveApiKeyByHash", mock.Anything, auth.HashAPIKey(apiKey)).
+					Return(repository.ApiKey{ID: keyID, Name: "loja", Role: auth.RoleSeller, SellerID: seller}, nil)
+				repo.On("TouchApiKey", mock.Anything, keyID).Return(nil)
+			},
+		},
+		{
+			name:       "Unknown or revoked API key",
+			cfg:        cfg,
+			credential: apiKey,
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetActiveApiKeyByHash", mock.Anything, auth.HashAPIKey(apiKey)).Return(repository.ApiKey{}, sql.ErrNoRows)
+			},
+			expectedError: auth.ErrUnauthenticated,
+		},
+		{
+			name:              "Bootstrap key",
+			cfg:               cfg,
+			credential:        "olk_bootstrap",
+			setupMocked:       func(repo *repository.QuerierMocked) {},
+			expectedPrincipal: &auth.Principal{Subject: "bootstrap", Role: auth.RoleAdmin, Method: auth.MethodAPIKey},
+		},
+		{
+			name:              "Valid JWT",
+			cfg:               cfg,
+			credential:        validJWT,
+			setupMocked:       func(repo *repository.QuerierMocked) {},
+			expectedPrincipal: &auth.Principal{Subject: "seller-42", Role: auth.RoleSeller, Method: auth.MethodJWT, SellerID: seller},
+		},
+		{
+			name:          "Expired JWT",
+			cfg:           cfg,
+			credential:    expiredJWT,
+			setupMocked:   func(repo *repository.QuerierMocked) {},
+			expectedError: auth.ErrUnauthenticated,
+		},
+		{
+			name:          "JWT without configured secret",
+			cfg:           config.Config{},
+			credential:    validJWT,
+			setupMocked:   func(repo *repository.QuerierMocked) {},
+			expectedError: auth.ErrUnauthenticated,
+		},
+		{
+			name:          "Empty credential",
+			cfg:           cfg,
+			setupMocked:   func(repo *repository.QuerierMocked) {},
+			expectedError: auth.ErrUnauthenticated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMocked := repository.NewQuerierMocked(t)
+			tt.setupMocked(repoMocked)
+
+			authService := service.NewAuthService(repoMocked, tt.cfg, zap.NewNop().Sugar())
+			principal, err := authService.Authenticate(context.Background(), tt.credential)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, principal)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedPrincipal, principal)
+		})
+	}
+}
+
+func TestAuthService_IssueToken(t *testing.T) {
+	cfg := config.Config{AuthJWTSecret: "segredo-jwt-de-teste", AuthTokenTTL: 15 * time.Minute}
+	authService := service.NewAuthService(repository.NewQuerierMocked(t), cfg, zap.NewNop().Sugar())
+
+	principal := auth.Principal{Subject: "apikey:painel", Role: auth.RoleOperator, Method: auth.MethodAPIKey}
+	token, expiresAt, err := authService.IssueToken(principal)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), expiresAt, time.Minute)
+
+	issued, err := authService.Authenticate(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, principal.Subject, issued.Subject)
+	assert.Equal(t, principal.Role, issued.Role)
+	assert.Equal(t, auth.MethodJWT, issued.Method)
+	assert.False(t, issued.SellerID.Valid)
+
+	// A token issued to a seller credential stays restricted to that seller
+	sellerPrincipal := auth.Principal{
+		Subject:  "apikey:loja",
+		Role:     auth.RoleSeller,
+		SellerID: uuid.NullUUID{UUID: testSellerID, Valid: true},
+	}
+	token, _, err = authService.IssueToken(sellerPrincipal)
+	require.NoError(t, err)
+	issued, err = authService.Authenticate(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, sellerPrincipal.SellerID, issued.SellerID)
+
+	disabled := service.NewAuthService(repository.NewQuerierMocked(t), config.Config{}, zap.NewNop().Sugar())
+	_, _, err = disabled.IssueToken(principal)
+	assert.ErrorIs(t, err, service.ErrJWTDisabled)
+}
+
+func TestAuthService_CreateAPIKey(t *testing.T) {
+	repoMocked := repository.NewQuerierMocked(t)
+	var stored repository.CreateApiKeyParams
+	repoMocked.On("CreateApiKey", mock.Anything, mock.AnythingOfType("repository.CreateApiKeyParams")).
+		Run(func(args mock.Arguments) { stored = args.Get(1).(repository.CreateApiKeyParams) }).
+		Return(func(_ context.Context, arg repository.CreateApiKeyParams) repository.ApiKey {
+			return repository.ApiKey{ID: uuid.New(), Name: arg.Name, Role: arg.Role, KeyPrefix: arg.KeyPrefix, KeyHash: arg.KeyHash}
+		}, nil)
+	repoMocked.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID}, nil)
+
+	authService := service.NewAuthService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+	apiKey, key, err := authService.CreateAPIKey(context.Background(), service.CreateAPIKeyInput{
+		Name:      "integracao-erp",
+		Role:      auth.RoleSeller,
+		SellerID:  testSellerID.String(),
+		CreatedBy: "apikey:admin",
+	})
+	require.NoError(t, err)
+
+	assert.True(t, auth.IsAPIKey(key))
+	assert.Equal(t, auth.HashAPIKey(key), stored.KeyHash)
+	assert.NotContains(t, stored.KeyHash, key)
+	assert.Equal(t, key[:len(stored.KeyPrefix)], stored.KeyPrefix)
+	assert.Equal(t, "apikey:admin", stored.CreatedBy)
+	assert.Equal(t, auth.RoleSeller, apiKey.Role)
+	assert.Equal(t, uuid.NullUUID{UUID: testSellerID, Valid: true}, stored.SellerID)
+
+	_, _, err = authService.CreateAPIKey(context.Background(), service.CreateAPIKeyInput{
+		Name:      "loja-sem-vendedor",
+		Role:      auth.RoleSeller,
+		CreatedBy: "apikey:admin",
+	})
+	assert.ErrorIs(t, err, service.ErrSellerRequired)
+}
+
+func TestAuthService_RevokeAPIKey(t *testing.T) {
+	keyID := uuid.New()
+
+	t.Run("Revoke existing key", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("RevokeApiKey", mock.Anything, keyID).Return(repository.ApiKey{
+			ID:        keyID,
+			RevokedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		}, nil)
+
+		authService := service.NewAuthService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+		apiKey, err := authService.RevokeAPIKey(context.Background(), keyID.String())
+		require.NoError(t, err)
+		assert.True(t, apiKey.RevokedAt.Valid)
+	})
+
+	t.Run("Unknown key", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("RevokeApiKey", mock.Anything, keyID).Return(repository.ApiKey{}, sql.ErrNoRows)
+
+		authService := service.NewAuthService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+		_, err := authService.RevokeAPIKey(context.Background(), keyID.String())
+		assert.ErrorIs(t, err, service.ErrAPIKeyNotFound)
+	})
+
+	t.Run("Invalid id", func(t *testing.T) {
+		authService := service.NewAuthService(repository.NewQuerierMocked(t), config.Config{}, zap.NewNop().Sugar())
+		_, err := authService.RevokeAPIKey(context.Background(), "not-a-uuid")
+		assert.ErrorIs(t, err, service.ErrAPIKeyNotFound)
+	})
+}
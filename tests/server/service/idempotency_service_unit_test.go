@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/config"
+	"github/moura95/olist-shipping-api/internal/idempotency"
+	"github/moura95/olist-shipping-api/internal/repository"
+	"github/moura95/olist-shipping-api/internal/service"
+	"go.uber.org/zap"
+)
+
+func TestIdempotencyService_Begin(t *testing.T) {
+	key := idempotency.Key{
+		Principal:   "apikey:loja",
+		Key:         "pedido-1",
+		Route:       "POST /api/v1/packages",
+		RequestHash: idempotency.HashBody([]byte(`{"produto": "Camisa"}`)),
+	}
+	lookup := repository.GetIdempotencyKeyParams{
+		Principal:      key.Principal,
+		IdempotencyKey: key.Key,
+		Route:          key.Route,
+	}
+
+	tests := []struct {
+		name             string
+		setupMocked      func(repo *repository.QuerierMocked)
+		expectedResponse *idempotency.Response
+		expectedError    error
+	}{
+		{
+			name: "New key is reserved",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("ReserveIdempotencyKey", mock.Anything, mock.MatchedBy(func(arg repository.ReserveIdempotencyKeyParams) bool {
+					return arg.RequestHash == key.RequestHash &&
+						arg.TtlSeconds == (2*time.Hour).Seconds() &&
+						arg.LockTimeoutSeconds == time.Minute.Seconds()
+				})).Return(repository.IdempotencyKey{}, nil)
+			},
+		},
+		{
+			name: "Completed request is replayed",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("ReserveIdempotencyKey", mock.Anything, mock.Anything).Return(repository.IdempotencyKey{}, sql.ErrNoRows)
+				repo.On("GetIdempotencyKey", mock.Anything, lookup).Return(repository.IdempotencyKey{
+					RequestHash:     key.RequestHash,
+					StatusCode:      sql.NullInt32{Int32: 201, Valid: true},
+					ContentType:     sql.NullString{String: "application/json; charset=utf-8", Valid: true},
+					ResponseBody:    []byte(`{"id":"pacote-1"}`),
+					ResponseHeaders: json.RawMessage(`{"ETag":"\"1\""}`),
+				}, nil)
+			},
+			expectedResponse: &idempotency.Response{
+				StatusCode:  201,
+				ContentType: "application/json; charset=utf-8",
+				Headers:     map[string]string{"ETag": `"1"`},
+				Body:        []byte(`{"id":"pacote-1"}`),
+			},
+		},
+		{
+			name: "Key reused with another body",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("ReserveIdempotencyKey", mock.Anything, mock.Anything).Return(repository.IdempotencyKey{}, sql.ErrNoRows)
+				repo.On("GetIdempotencyKey", mock.Anything, lookup).Return(repository.IdempotencyKey{
+					RequestHash: idempotency.HashBody([]byte(`{"produto": "Tênis"}`)),
+					StatusCode:  sql.NullInt32{Int32: 201, Valid: true},
+				}, nil)
+			},
+			expectedError: idempotency.ErrKeyReused,
+		},
+		{
+			name: "First request still in progress",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("ReserveIdempotencyKey", mock.Anything, mock.Anything).Return(repository.IdempotencyKey{}, sql.ErrNoRows)
+				repo.On("GetIdempotencyKey", mock.Anything, lookup).Return(repository.IdempotencyKey{RequestHash: key.RequestHash}, nil)
+			},
+			expectedError: idempotency.ErrInProgress,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMocked := repository.NewQuerierMocked(t)
+			tt.setupMocked(repoMocked)
+
+			idempotencyService := service.NewIdempotencyService(repoMocked, config.Config{IdempotencyTTL: 2 * time.Hour}, zap.NewNop().Sugar())
+
+			response, err := idempotencyService.Begin(context.Background(), key)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				assert.Nil(t, response)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedResponse, response)
+		})
+	}
+}
+
+func TestIdempotencyService_Complete(t *testing.T) {
+	key := idempotency.Key{
+		Principal:   "apikey:loja",
+		Key:         "pedido-1",
+		Route:       "POST /api/v1/packages",
+		RequestHash: idempotency.HashBody([]byte(`{"produto": "Camisa"}`)),
+	}
+
+	tests := []struct {
+		name            string
+		headers         map[string]string
+		expectedHeaders string
+	}{
+		{
+			name:            "Response headers are stored",
+			headers:         map[string]string{"ETag": `"1"`, "Location": "/api/v1/packages/pacote-1"},
+			expectedHeaders: `{"ETag":"\"1\"","Location":"/api/v1/packages/pacote-1"}`,
+		},
+		{
+			name:            "Response without headers",
+			expectedHeaders: `{}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMocked := repository.NewQuerierMocked(t)
+			repoMocked.On("CompleteIdempotencyKey", mock.Anything, mock.MatchedBy(func(arg repository.CompleteIdempotencyKeyParams) bool {
+				return arg.StatusCode.Int32 == 201 &&
+					arg.RequestHash == key.RequestHash &&
+					string(arg.ResponseHeaders) == tt.expectedHeaders
+			})).Return(nil)
+
+			idempotencyService := service.NewIdempotencyService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+			err := idempotencyService.Complete(context.Background(), key, idempotency.Response{
+				StatusCode:  201,
+				ContentType: "application/json; charset=utf-8",
+				Headers:     tt.headers,
+				Body:        []byte(`{"id":"pacote-1"}`),
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestIdempotencyService_PurgeExpired(t *testing.T) {
+	repoMocked := repository.NewQuerierMocked(t)
+	repoMocked.On("DeleteExpiredIdempotencyKeys", mock.Anything).Return(int64(3), nil)
+
+	idempotencyService := service.NewIdempotencyService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+	deleted, err := idempotencyService.PurgeExpired(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), deleted)
+}
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	_ "github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -115,23 +116,67 @@ func cleanupIntegrationTestData(t *testing.T) {
 	}
 
 	for _, table := range tables {
-		_, err := integrationTestDB.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE tracking_code LIKE 'TEST%%' OR tracking_code LIKE 'BR%%'", table))
+		_, err := integrationTestDB.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE tracking_code LIKE 'TEST%%' OR tracking_code LIKE 'BR%%' OR tracking_code LIKE '%%BR'", table))
 		if err != nil {
 			t.Logf("Failed to cleanup table %s: %v", table, err)
 		}
 	}
 }
 
+func statusChange(status string) service.StatusChange {
+	return service.StatusChange{
+		Status: status,
+		Actor:  "integration",
+	}
+}
+
+func deletion(reason string) service.PackageDeletion {
+	return service.PackageDeletion{
+		Actor:  "integration",
+		Reason: reason,
+	}
+}
+
+func packageInput(product string, weightKg float64, destinationState string) service.CreatePackageInput {
+	return service.CreatePackageInput{
+		Product:          product,
+		WeightKg:         weightKg,
+		DestinationState: destinationState,
+		SellerID:         testSellerID.String(),
+	}
+}
+
+func quoteParams(stateCode string, weightKg float64) service.QuoteParams {
+	return service.QuoteParams{
+		StateCode: stateCode,
+		WeightKg:  weightKg,
+	}
+}
+
+func dimensions(heightCm, widthCm, lengthCm float64) *service.Dimensions {
+	return &service.Dimensions{HeightCm: heightCm, WidthCm: widthCm, LengthCm: lengthCm}
+}
+
+func quoteForCarrier(t *testing.T, quotes []service.Quote, carrierID string) service.Quote {
+	for _, quote := range quotes {
+		if quote.CarrierID.String() == carrierID {
+			return quote
+		}
+	}
+	t.Fatalf("no quote for carrier %s", carrierID)
+	return service.Quote{}
+}
+
 func TestPackageServiceIntegration_CreateAndGet(t *testing.T) {
 
 	defer cleanupIntegrationTestData(t)
 
 	ctx := context.Background()
 	logger := zap.NewNop().Sugar()
-	store := repository.New(integrationTestDB)
+	store := repository.NewStore(integrationTestDB)
 	service := service.NewPackageService(store, config.Config{}, logger)
 
-	createdPkg, err := service.Create(ctx, "Integration Test Product", 1.5, "SP")
+	createdPkg, err := service.Create(ctx, packageInput("Integration Test Product", 1.5, "SP"))
 	require.NoError(t, err)
 	require.NotNil(t, createdPkg)
 
@@ -141,7 +186,7 @@ func TestPackageServiceIntegration_CreateAndGet(t *testing.T) {
 	assert.Equal(t, "SP", createdPkg.DestinationState)
 	assert.Equal(t, "criado", createdPkg.Status)
 
-	retrievedPkg, err := service.GetByID(ctx, createdPkg.ID.String())
+	retrievedPkg, err := service.GetByID(ctx, createdPkg.ID.String(), uuid.NullUUID{})
 	require.NoError(t, err)
 	require.NotNil(t, retrievedPkg)
 
@@ -154,43 +199,47 @@ func TestPackageServiceIntegration_CreateAndGet(t *testing.T) {
 
 }
 
-func TestPackageServiceIntegration_GetAll(t *testing.T) {
+func TestPackageServiceIntegration_ListPagination(t *testing.T) {
 	defer cleanupIntegrationTestData(t)
 
 	ctx := context.Background()
 	logger := zap.NewNop().Sugar()
-	store := repository.New(integrationTestDB)
-	service := service.NewPackageService(store, config.Config{}, logger)
+	store := repository.NewStore(integrationTestDB)
+	svc := service.NewPackageService(store, config.Config{}, logger)
 
-	initialPackages, err := service.GetAll(ctx)
-	require.NoError(t, err)
-	initialCount := len(initialPackages)
+	var createdIDs []string
+	for i, weight := range []float64{3.0, 1.0, 2.0} {
+		pkg, err := svc.Create(ctx, packageInput(fmt.Sprintf("Pagination Item %d", i), weight, "MG"))
+		require.NoError(t, err)
+		createdIDs = append(createdIDs, pkg.ID.String())
+	}
 
-	pkg1, err := service.Create(ctx, "Test Product 1", 1.0, "SP")
-	require.NoError(t, err)
+	filter := service.PackageListFilter{
+		DestinationState: "MG",
+		Search:           "pagination item",
+		SortBy:           service.SortByWeightKg,
+		Limit:            2,
+	}
 
-	pkg2, err := service.Create(ctx, "Test Product 2", 2.0, "RJ")
+	firstPage, err := svc.List(ctx, filter)
 	require.NoError(t, err)
-
-	allPackages, err := service.GetAll(ctx)
+	require.Len(t, firstPage.Packages, 2)
+	assert.Equal(t, int64(3), firstPage.Total)
+	assert.Equal(t, 1.0, firstPage.Packages[0].WeightKg)
+	assert.Equal(t, 2.0, firstPage.Packages[1].WeightKg)
+	require.NotEmpty(t, firstPage.NextCursor)
+
+	filter.Cursor = firstPage.NextCursor
+	secondPage, err := svc.List(ctx, filter)
 	require.NoError(t, err)
+	require.Len(t, secondPage.Packages, 1)
+	assert.Equal(t, 3.0, secondPage.Packages[0].WeightKg)
+	assert.Empty(t, secondPage.NextCursor)
 
-	assert.GreaterOrEqual(t, len(allPackages), initialCount+2)
-
-	var foundPkg1, foundPkg2 bool
-	for _, pkg := range allPackages {
-		if pkg.ID == pkg1.ID {
-			foundPkg1 = true
-			assert.Equal(t, "Test Product 1", pkg.Product)
-		}
-		if pkg.ID == pkg2.ID {
-			foundPkg2 = true
-			assert.Equal(t, "Test Product 2", pkg.Product)
-		}
-	}
-
-	assert.True(t, foundPkg1, "Package 1 should be found in list")
-	assert.True(t, foundPkg2, "Package 2 should be found in list")
+	newest, err := svc.List(ctx, service.PackageListFilter{Search: "Pagination Item", SortDesc: true, Limit: 1})
+	require.NoError(t, err)
+	require.Len(t, newest.Packages, 1)
+	assert.Equal(t, createdIDs[2], newest.Packages[0].ID.String())
 }
 
 func TestPackageServiceIntegration_UpdateStatus(t *testing.T) {
@@ -199,29 +248,57 @@ func TestPackageServiceIntegration_UpdateStatus(t *testing.T) {
 
 	ctx := context.Background()
 	logger := zap.NewNop().Sugar()
-	store := repository.New(integrationTestDB)
+	store := repository.NewStore(integrationTestDB)
 	service := service.NewPackageService(store, config.Config{}, logger)
 
-	createdPkg, err := service.Create(ctx, "Status Test Product", 1.0, "SP")
+	createdPkg, err := service.Create(ctx, packageInput("Status Test Product", 1.0, "SP"))
 	require.NoError(t, err)
 
 	assert.Equal(t, "criado", createdPkg.Status)
 
-	err = service.UpdateStatus(ctx, createdPkg.ID.String(), "enviado")
+	_, err = service.UpdateStatus(ctx, createdPkg.ID.String(), uuid.NullUUID{}, statusChange("enviado"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid status transition")
+
+	result, err := service.CreatePackageQuotes(ctx, createdPkg.ID.String(), uuid.NullUUID{})
 	require.NoError(t, err)
 
-	updatedPkg, err := service.GetByID(ctx, createdPkg.ID.String())
+	quote := quoteForCarrier(t, result.Quotes, "660e8400-e29b-41d4-a716-446655440001")
+	_, err = service.HireCarrier(ctx, createdPkg.ID.String(), quote.ID.String(), uuid.NullUUID{}, "integration", 0)
 	require.NoError(t, err)
 
-	assert.Equal(t, "enviado", updatedPkg.Status)
+	var version int32
+	for _, status := range []string{"coletado", "enviado", "entregue"} {
+		version, err = service.UpdateStatus(ctx, createdPkg.ID.String(), uuid.NullUUID{}, statusChange(status))
+		require.NoError(t, err)
+	}
 
-	err = service.UpdateStatus(ctx, createdPkg.ID.String(), "entregue")
+	finalPkg, err := service.GetByID(ctx, createdPkg.ID.String(), uuid.NullUUID{})
 	require.NoError(t, err)
 
-	finalPkg, err := service.GetByID(ctx, createdPkg.ID.String())
+	assert.Equal(t, "entregue", finalPkg.Status)
+	assert.Equal(t, finalPkg.Version, version)
+	assert.True(t, finalPkg.TrackingCode.Valid)
+
+	_, err = service.UpdateStatus(ctx, createdPkg.ID.String(), uuid.NullUUID{}, statusChange("criado"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid status transition")
+
+	history, err := service.GetStatusHistory(ctx, createdPkg.ID.String(), uuid.NullUUID{})
 	require.NoError(t, err)
+	require.Len(t, history, 4)
 
-	assert.Equal(t, "entregue", finalPkg.Status)
+	expectedTransitions := [][2]string{
+		{"criado", "esperando_coleta"},
+		{"esperando_coleta", "coletado"},
+		{"coletado", "enviado"},
+		{"enviado", "entregue"},
+	}
+	for i, event := range history {
+		assert.Equal(t, expectedTransitions[i][0], event.FromStatus)
+		assert.Equal(t, expectedTransitions[i][1], event.ToStatus)
+		assert.NotEmpty(t, event.Actor)
+	}
 }
 
 func TestPackageServiceIntegration_Delete(t *testing.T) {
@@ -230,49 +307,148 @@ func TestPackageServiceIntegration_Delete(t *testing.T) {
 
 	ctx := context.Background()
 	logger := zap.NewNop().Sugar()
-	store := repository.New(integrationTestDB)
-	service := service.NewPackageService(store, config.Config{}, logger)
+	store := repository.NewStore(integrationTestDB)
+	svc := service.NewPackageService(store, config.Config{}, logger)
 
-	createdPkg, err := service.Create(ctx, "Delete Test Product", 1.0, "SP")
+	createdPkg, err := svc.Create(ctx, packageInput("Delete Test Product", 1.0, "SP"))
 	require.NoError(t, err)
 
-	retrievedPkg, err := service.GetByID(ctx, createdPkg.ID.String())
+	retrievedPkg, err := svc.GetByID(ctx, createdPkg.ID.String(), uuid.NullUUID{})
 	require.NoError(t, err)
 	assert.NotNil(t, retrievedPkg)
 
-	err = service.Delete(ctx, createdPkg.ID.String())
+	err = svc.Delete(ctx, createdPkg.ID.String(), uuid.NullUUID{}, deletion("pedido cancelado"))
 	require.NoError(t, err)
 
-	deletedPkg, err := service.GetByID(ctx, createdPkg.ID.String())
+	deletedPkg, err := svc.GetByID(ctx, createdPkg.ID.String(), uuid.NullUUID{})
 	assert.Error(t, err)
 	assert.Nil(t, deletedPkg)
 	assert.Contains(t, err.Error(), "get package by id")
+
+	archived, err := svc.List(ctx, service.PackageListFilter{Search: "Delete Test Product", Deleted: true})
+	require.NoError(t, err)
+	require.Len(t, archived.Packages, 1)
+	assert.Equal(t, createdPkg.ID, archived.Packages[0].ID)
+	assert.Equal(t, "integration", archived.Packages[0].DeletedBy.String)
+	assert.Equal(t, "pedido cancelado", archived.Packages[0].DeletedReason.String)
+
+	active, err := svc.List(ctx, service.PackageListFilter{Search: "Delete Test Product"})
+	require.NoError(t, err)
+	assert.Empty(t, active.Packages)
+
+	restoredPkg, err := svc.Restore(ctx, createdPkg.ID.String(), uuid.NullUUID{}, "integration", 0)
+	require.NoError(t, err)
+	assert.False(t, restoredPkg.DeletedAt.Valid)
+	assert.Equal(t, createdPkg.Status, restoredPkg.Status)
+	assert.Equal(t, createdPkg.TrackingCode, restoredPkg.TrackingCode)
 }
 
 func TestPackageServiceIntegration_GetQuotes(t *testing.T) {
 
 	ctx := context.Background()
 	logger := zap.NewNop().Sugar()
-	store := repository.New(integrationTestDB)
+	store := repository.NewStore(integrationTestDB)
 	service := service.NewPackageService(store, config.Config{}, logger)
 
-	quotes, err := service.GetQuotes(ctx, "SP", 2.0)
+	result, err := service.GetQuotes(ctx, quoteParams("SP", 2.0))
 	require.NoError(t, err)
-	require.Greater(t, len(quotes), 0)
+	require.Greater(t, len(result.Quotes), 0)
 
-	for _, quote := range quotes {
-		assert.NotEmpty(t, quote.Carier)
+	for _, quote := range result.Quotes {
+		assert.NotEmpty(t, quote.CarrierName)
+		assert.Equal(t, 2.0, quote.BillableWeightKg)
 		assert.Greater(t, quote.EstimatedPrice, 0.0)
 		assert.Greater(t, quote.EstimatedDeliveryDays, int32(0))
 	}
 
-	quotesRJ, err := service.GetQuotes(ctx, "RJ", 1.5)
+	resultRJ, err := service.GetQuotes(ctx, quoteParams("RJ", 1.5))
+	require.NoError(t, err)
+	require.Greater(t, len(resultRJ.Quotes), 0)
+
+	bulky := quoteParams("SP", 1.0)
+	bulky.Dimensions = dimensions(30, 40, 50)
+	bulkyResult, err := service.GetQuotes(ctx, bulky)
 	require.NoError(t, err)
-	require.Greater(t, len(quotesRJ), 0)
+	for _, quote := range bulkyResult.Quotes {
+		assert.Greater(t, quote.BillableWeightKg, 1.0)
+	}
 
-	quotesNorth, err := service.GetQuotes(ctx, "AM", 3.0)
+	resultNorth, err := service.GetQuotes(ctx, quoteParams("AM", 3.0))
 	//require.NoError(t, err)
-	assert.True(t, len(quotesNorth) >= 0)
+	assert.True(t, resultNorth == nil || len(resultNorth.Quotes) >= 0)
+}
+
+func TestPackageServiceIntegration_SellerIsolation(t *testing.T) {
+	defer cleanupIntegrationTestData(t)
+
+	ctx := context.Background()
+	store := repository.NewStore(integrationTestDB)
+	svc := service.NewPackageService(store, config.Config{}, zap.NewNop().Sugar())
+
+	other, err := svc.CreateSeller(ctx, "Loja Concorrente")
+	require.NoError(t, err)
+	stranger := uuid.NullUUID{UUID: other.ID, Valid: true}
+	owner := uuid.NullUUID{UUID: testSellerID, Valid: true}
+
+	pkg, err := svc.Create(ctx, packageInput("Isolation Product", 1.0, "SP"))
+	require.NoError(t, err)
+	assert.Equal(t, testSellerID, pkg.SellerID)
+
+	_, err = svc.GetByID(ctx, pkg.ID.String(), owner)
+	require.NoError(t, err)
+
+	_, err = svc.GetByID(ctx, pkg.ID.String(), stranger)
+	assert.ErrorIs(t, err, service.ErrPackageNotFound)
+
+	_, err = svc.UpdateStatus(ctx, pkg.ID.String(), stranger, statusChange("esperando_coleta"))
+	assert.ErrorIs(t, err, service.ErrPackageNotFound)
+
+	err = svc.Delete(ctx, pkg.ID.String(), stranger, deletion(""))
+	assert.ErrorIs(t, err, service.ErrPackageNotFound)
+
+	page, err := svc.List(ctx, service.PackageListFilter{Search: "Isolation Product", SellerID: stranger})
+	require.NoError(t, err)
+	assert.Empty(t, page.Packages)
+	assert.Equal(t, int64(0), page.Total)
+
+	page, err = svc.List(ctx, service.PackageListFilter{Search: "Isolation Product", SellerID: owner})
+	require.NoError(t, err)
+	assert.Len(t, page.Packages, 1)
+}
+
+func TestPackageServiceIntegration_SellerNegotiatedRates(t *testing.T) {
+	ctx := context.Background()
+	store := repository.NewStore(integrationTestDB)
+	svc := service.NewPackageService(store, config.Config{}, zap.NewNop().Sugar())
+
+	seller, err := svc.CreateSeller(ctx, "Loja Negociada")
+	require.NoError(t, err)
+
+	nebulixID := "660e8400-e29b-41d4-a716-446655440001"
+	pricePerKg := 1.0
+	_, err = svc.SaveSellerRate(ctx, seller.ID.String(), nebulixID, service.SaveSellerRateInput{
+		PricePerKg:    &pricePerKg,
+		MarkupPercent: 50,
+	})
+	require.NoError(t, err)
+
+	standard, err := svc.GetQuotes(ctx, quoteParams("SP", 2.0))
+	require.NoError(t, err)
+
+	params := quoteParams("SP", 2.0)
+	params.SellerID = uuid.NullUUID{UUID: seller.ID, Valid: true}
+	negotiated, err := svc.GetQuotes(ctx, params)
+	require.NoError(t, err)
+	require.Len(t, negotiated.Quotes, len(standard.Quotes))
+
+	for i, quote := range negotiated.Quotes {
+		if quote.CarrierID.String() == nebulixID {
+			assert.NotEqual(t, standard.Quotes[i].EstimatedPrice, quote.EstimatedPrice)
+			continue
+		}
+		// Carriers without a negotiated rate keep the table price
+		assert.Equal(t, standard.Quotes[i].EstimatedPrice, quote.EstimatedPrice)
+	}
 }
 
 func TestPackageServiceIntegration_HireCarrier(t *testing.T) {
@@ -281,23 +457,30 @@ func TestPackageServiceIntegration_HireCarrier(t *testing.T) {
 
 	ctx := context.Background()
 	logger := zap.NewNop().Sugar()
-	store := repository.New(integrationTestDB)
+	store := repository.NewStore(integrationTestDB)
 	service := service.NewPackageService(store, config.Config{}, logger)
 
-	createdPkg, err := service.Create(ctx, "Hire Carrier Test", 2.0, "SP")
+	createdPkg, err := service.Create(ctx, packageInput("Hire Carrier Test", 2.0, "SP"))
 	require.NoError(t, err)
 
 	assert.Equal(t, "criado", createdPkg.Status)
 	assert.False(t, createdPkg.HiredCarrierID.Valid)
 
 	carrierID := "660e8400-e29b-41d4-a716-446655440001"
-	price := "25.90"
-	deliveryDays := int32(5)
 
-	err = service.HireCarrier(ctx, createdPkg.ID.String(), carrierID, price, deliveryDays)
+	result, err := service.CreatePackageQuotes(ctx, createdPkg.ID.String(), uuid.NullUUID{})
 	require.NoError(t, err)
 
-	updatedPkg, err := service.GetByID(ctx, createdPkg.ID.String())
+	quote := quoteForCarrier(t, result.Quotes, carrierID)
+	assert.NotEmpty(t, quote.ID)
+	assert.False(t, quote.ExpiresAt.IsZero())
+	price := fmt.Sprintf("%.2f", quote.EstimatedPrice)
+	deliveryDays := quote.EstimatedDeliveryDays
+
+	_, err = service.HireCarrier(ctx, createdPkg.ID.String(), quote.ID.String(), uuid.NullUUID{}, "integration", 0)
+	require.NoError(t, err)
+
+	updatedPkg, err := service.GetByID(ctx, createdPkg.ID.String(), uuid.NullUUID{})
 	require.NoError(t, err)
 
 	assert.Equal(t, "esperando_coleta", updatedPkg.Status)
@@ -307,13 +490,81 @@ func TestPackageServiceIntegration_HireCarrier(t *testing.T) {
 	assert.Equal(t, price, updatedPkg.HiredPrice.String)
 	assert.True(t, updatedPkg.HiredDeliveryDays.Valid)
 	assert.Equal(t, deliveryDays, updatedPkg.HiredDeliveryDays.Int32)
+
+	_, err = service.HireCarrier(ctx, createdPkg.ID.String(), quote.ID.String(), uuid.NullUUID{}, "integration", 0)
+	require.Error(t, err)
+}
+
+func TestPackageServiceIntegration_HireCarrierQuoteRules(t *testing.T) {
+	defer cleanupIntegrationTestData(t)
+
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+	store := repository.NewStore(integrationTestDB)
+	svc := service.NewPackageService(store, config.Config{QuoteTTL: time.Second}, logger)
+
+	pkg, err := svc.Create(ctx, packageInput("Quote Rules Product", 1.0, "SP"))
+	require.NoError(t, err)
+
+	otherPkg, err := svc.Create(ctx, packageInput("Other Quote Product", 1.0, "SP"))
+	require.NoError(t, err)
+
+	otherResult, err := svc.CreatePackageQuotes(ctx, otherPkg.ID.String(), uuid.NullUUID{})
+	require.NoError(t, err)
+	require.NotEmpty(t, otherResult.Quotes)
+
+	_, err = svc.HireCarrier(ctx, pkg.ID.String(), otherResult.Quotes[0].ID.String(), uuid.NullUUID{}, "integration", 0)
+	assert.ErrorIs(t, err, service.ErrQuoteNotFound)
+
+	result, err := svc.CreatePackageQuotes(ctx, pkg.ID.String(), uuid.NullUUID{})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.Quotes)
+
+	time.Sleep(1500 * time.Millisecond)
+
+	_, err = svc.HireCarrier(ctx, pkg.ID.String(), result.Quotes[0].ID.String(), uuid.NullUUID{}, "integration", 0)
+	assert.ErrorIs(t, err, service.ErrQuoteExpired)
+}
+
+func TestPackageServiceIntegration_CarrierRestrictions(t *testing.T) {
+	defer cleanupIntegrationTestData(t)
+
+	ctx := context.Background()
+	logger := zap.NewNop().Sugar()
+	store := repository.NewStore(integrationTestDB)
+	svc := service.NewPackageService(store, config.Config{}, logger)
+
+	nebulixID := "660e8400-e29b-41d4-a716-446655440001"
+
+	battery := quoteParams("SP", 1.0)
+	battery.Category = service.CategoryBattery
+	result, err := svc.GetQuotes(ctx, battery)
+	require.NoError(t, err)
+	require.Len(t, result.Unavailable, 1)
+	assert.Equal(t, nebulixID, result.Unavailable[0].CarrierID.String())
+	assert.Contains(t, result.Unavailable[0].Reasons[0], "bateria")
+	for _, quote := range result.Quotes {
+		assert.NotEqual(t, nebulixID, quote.CarrierID.String())
+	}
+
+	heavy, err := svc.Create(ctx, packageInput("Heavy Product", 40.0, "SP"))
+	require.NoError(t, err)
+
+	heavyResult, err := svc.CreatePackageQuotes(ctx, heavy.ID.String(), uuid.NullUUID{})
+	require.NoError(t, err)
+	require.Len(t, heavyResult.Unavailable, 1)
+	assert.Contains(t, heavyResult.Unavailable[0].Reasons[0], "peso")
+	require.NotEmpty(t, heavyResult.Quotes)
+
+	_, err = svc.HireCarrier(ctx, heavy.ID.String(), heavyResult.Quotes[0].ID.String(), uuid.NullUUID{}, "integration", 0)
+	require.NoError(t, err)
 }
 
 func TestPackageServiceIntegration_GetCarriers(t *testing.T) {
 
 	ctx := context.Background()
 	logger := zap.NewNop().Sugar()
-	store := repository.New(integrationTestDB)
+	store := repository.NewStore(integrationTestDB)
 	service := service.NewPackageService(store, config.Config{}, logger)
 
 	carriers, err := service.GetCarriers(ctx)
@@ -344,7 +595,7 @@ func TestPackageServiceIntegration_GetStates(t *testing.T) {
 
 	ctx := context.Background()
 	logger := zap.NewNop().Sugar()
-	store := repository.New(integrationTestDB)
+	store := repository.NewStore(integrationTestDB)
 	service := service.NewPackageService(store, config.Config{}, logger)
 
 	states, err := service.GetStates(ctx)
@@ -384,60 +635,60 @@ func TestPackageServiceIntegration_ErrorCases(t *testing.T) {
 
 	ctx := context.Background()
 	logger := zap.NewNop().Sugar()
-	store := repository.New(integrationTestDB)
+	store := repository.NewStore(integrationTestDB)
 	service := service.NewPackageService(store, config.Config{}, logger)
 
 	t.Run("Get non-existent package", func(t *testing.T) {
-		pkg, err := service.GetByID(ctx, "550e8400-e29b-41d4-a716-446655440999")
+		pkg, err := service.GetByID(ctx, "550e8400-e29b-41d4-a716-446655440999", uuid.NullUUID{})
 		assert.Error(t, err)
 		assert.Nil(t, pkg)
 		assert.Contains(t, err.Error(), "get package by id")
 	})
 
 	t.Run("Get package with invalid UUID", func(t *testing.T) {
-		pkg, err := service.GetByID(ctx, "invalid-uuid")
+		pkg, err := service.GetByID(ctx, "invalid-uuid", uuid.NullUUID{})
 		assert.Error(t, err)
 		assert.Nil(t, pkg)
 		assert.Contains(t, err.Error(), "parse package id")
 	})
 
 	t.Run("Get package by non-existent tracking code", func(t *testing.T) {
-		pkg, err := service.GetByTrackingCode(ctx, "BR99999999")
+		pkg, err := service.GetByTrackingCode(ctx, "BR99999999", uuid.NullUUID{})
 		assert.Error(t, err)
 		assert.Nil(t, pkg)
 		assert.Contains(t, err.Error(), "get package by tracking code")
 	})
 
 	t.Run("Update status with invalid UUID", func(t *testing.T) {
-		err := service.UpdateStatus(ctx, "invalid-uuid", "enviado")
+		_, err := service.UpdateStatus(ctx, "invalid-uuid", uuid.NullUUID{}, statusChange("enviado"))
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "parse package id")
 	})
 
 	t.Run("Delete with invalid UUID", func(t *testing.T) {
-		err := service.Delete(ctx, "invalid-uuid")
+		err := service.Delete(ctx, "invalid-uuid", uuid.NullUUID{}, deletion(""))
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "parse package id")
 	})
 
 	t.Run("Hire carrier with invalid package UUID", func(t *testing.T) {
-		err := service.HireCarrier(ctx, "invalid-uuid", "660e8400-e29b-41d4-a716-446655440001", "25.90", 5)
+		_, err := service.HireCarrier(ctx, "invalid-uuid", "660e8400-e29b-41d4-a716-446655440001", uuid.NullUUID{}, "integration", 0)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "package not found")
+		assert.Contains(t, err.Error(), "parse package id")
 	})
 
-	t.Run("Hire carrier with invalid carrier UUID", func(t *testing.T) {
-		pkg, err := service.Create(ctx, "Error Test Product", 1.0, "SP")
+	t.Run("Hire carrier with invalid quote UUID", func(t *testing.T) {
+		pkg, err := service.Create(ctx, packageInput("Error Test Product", 1.0, "SP"))
 		require.NoError(t, err)
 
-		err = service.HireCarrier(ctx, pkg.ID.String(), "invalid-uuid", "25.90", 5)
+		_, err = service.HireCarrier(ctx, pkg.ID.String(), "invalid-uuid", uuid.NullUUID{}, "integration", 0)
 		assert.Error(t, err)
-		assert.Contains(t, err.Error(), "invalid carrier ID")
+		assert.Contains(t, err.Error(), "invalid quote ID")
 	})
 
 	t.Run("Get quotes for invalid state", func(t *testing.T) {
-		quotes, err := service.GetQuotes(ctx, "XX", 1.0)
+		result, err := service.GetQuotes(ctx, quoteParams("XX", 1.0))
 		require.Error(t, err)
-		assert.Empty(t, quotes)
+		assert.Nil(t, result)
 	})
 }
@@ -2,10 +2,16 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
 	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -15,12 +21,20 @@ import (
 	"go.uber.org/zap"
 )
 
+var testSellerID = uuid.MustParse("880e8400-e29b-41d4-a716-446655440001")
+
 func TestPackageService_Create(t *testing.T) {
 	tests := []struct {
 		name             string
 		product          string
 		weightKg         float64
 		destinationState string
+		dimensions       *service.Dimensions
+		warehouseID      string
+		cep              string
+		sellerID         string
+		recipient        *service.Address
+		sender           *service.Address
 		setupMocked      func(repo *repository.QuerierMocked)
 		expectedError    string
 	}{
@@ -29,7 +43,9 @@ func TestPackageService_Create(t *testing.T) {
 			product:          "Test Product",
 			weightKg:         2.5,
 			destinationState: "SP",
+			sellerID:         testSellerID.String(),
 			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID, Name: "Loja Olist"}, nil)
 				expectedPackage := repository.Package{
 					ID: uuid.New(),
 					TrackingCode: sql.NullString{
@@ -43,12 +59,255 @@ func TestPackageService_Create(t *testing.T) {
 				}
 
 				repo.On("CreatePackage", mock.Anything, mock.MatchedBy(func(arg repository.CreatePackageParams) bool {
-					return arg.Product == "Test Product" &&
+					return arg.SellerID == testSellerID &&
+						arg.Product == "Test Product" &&
 						arg.WeightKg == 2.5 &&
-						arg.DestinationState == "SP"
+						arg.DestinationState == "SP" &&
+						!arg.ProductCategory.Valid &&
+						!arg.HeightCm.Valid
+				})).Return(expectedPackage, nil)
+			},
+		},
+		{
+			name:             "Create package with dimensions",
+			product:          "Boxed Product",
+			weightKg:         1.0,
+			destinationState: "RJ",
+			sellerID:         testSellerID.String(),
+			dimensions:       &service.Dimensions{HeightCm: 20, WidthCm: 30, LengthCm: 40},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID, Name: "Loja Olist"}, nil)
+				expectedPackage := repository.Package{
+					ID:               uuid.New(),
+					Product:          "Boxed Product",
+					WeightKg:         1.0,
+					DestinationState: "RJ",
+					Status:           "criado",
+					HeightCm:         sql.NullFloat64{Float64: 20, Valid: true},
+					WidthCm:          sql.NullFloat64{Float64: 30, Valid: true},
+					LengthCm:         sql.NullFloat64{Float64: 40, Valid: true},
+				}
+
+				repo.On("CreatePackage", mock.Anything, mock.MatchedBy(func(arg repository.CreatePackageParams) bool {
+					return arg.HeightCm.Float64 == 20 &&
+						arg.WidthCm.Float64 == 30 &&
+						arg.LengthCm.Float64 == 40
 				})).Return(expectedPackage, nil)
 			},
 		},
+		{
+			name:             "Create package with origin warehouse",
+			product:          "Test Product",
+			weightKg:         2.5,
+			destinationState: "BA",
+			sellerID:         testSellerID.String(),
+			warehouseID:      "aa0e8400-e29b-41d4-a716-446655440002",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID, Name: "Loja Olist"}, nil)
+				warehouseID := uuid.MustParse("aa0e8400-e29b-41d4-a716-446655440002")
+				repo.On("GetWarehouseById", mock.Anything, warehouseID).Return(repository.Warehouse{ID: warehouseID, StateCode: "PE"}, nil)
+				repo.On("CreatePackage", mock.Anything, mock.MatchedBy(func(arg repository.CreatePackageParams) bool {
+					return arg.OriginWarehouseID == uuid.NullUUID{UUID: warehouseID, Valid: true}
+				})).Return(repository.Package{
+					ID:                uuid.New(),
+					Product:           "Test Product",
+					WeightKg:          2.5,
+					DestinationState:  "BA",
+					Status:            "criado",
+					OriginWarehouseID: uuid.NullUUID{UUID: warehouseID, Valid: true},
+				}, nil)
+			},
+		},
+		{
+			name:             "Create package with unknown warehouse",
+			product:          "Test Product",
+			weightKg:         2.5,
+			destinationState: "BA",
+			sellerID:         testSellerID.String(),
+			warehouseID:      "aa0e8400-e29b-41d4-a716-446655440099",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID, Name: "Loja Olist"}, nil)
+				repo.On("GetWarehouseById", mock.Anything, uuid.MustParse("aa0e8400-e29b-41d4-a716-446655440099")).Return(repository.Warehouse{}, sql.ErrNoRows)
+			},
+			expectedError: service.ErrWarehouseNotFound.Error(),
+		},
+		{
+			name:             "Create package resolving destination from CEP",
+			product:          "Test Product",
+			weightKg:         2.5,
+			destinationState: "SP",
+			sellerID:         testSellerID.String(),
+			cep:              "01310-100",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID, Name: "Loja Olist"}, nil)
+				repo.On("GetCepRange", mock.Anything, "01310100").Return(repository.CepRange{
+					StateCode: "SP",
+					City:      sql.NullString{String: "São Paulo", Valid: true},
+					IsCapital: true,
+				}, nil)
+				repo.On("CreatePackage", mock.Anything, mock.MatchedBy(func(arg repository.CreatePackageParams) bool {
+					return arg.DestinationState == "SP" &&
+						arg.DestinationCep == sql.NullString{String: "01310100", Valid: true} &&
+						arg.DestinationCity == sql.NullString{String: "São Paulo", Valid: true}
+				})).Return(repository.Package{
+					ID:               uuid.New(),
+					Product:          "Test Product",
+					WeightKg:         2.5,
+					DestinationState: "SP",
+					Status:           "criado",
+					DestinationCep:   sql.NullString{String: "01310100", Valid: true},
+					DestinationCity:  sql.NullString{String: "São Paulo", Valid: true},
+				}, nil)
+			},
+		},
+		{
+			name:             "Create package with CEP from another state",
+			product:          "Test Product",
+			weightKg:         2.5,
+			destinationState: "RJ",
+			sellerID:         testSellerID.String(),
+			cep:              "01310-100",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID, Name: "Loja Olist"}, nil)
+				repo.On("GetCepRange", mock.Anything, "01310100").Return(repository.CepRange{StateCode: "SP"}, nil)
+			},
+			expectedError: service.ErrCEPStateMismatch.Error(),
+		},
+		{
+			name:             "Create package with unknown CEP",
+			product:          "Test Product",
+			weightKg:         2.5,
+			destinationState: "SP",
+			sellerID:         testSellerID.String(),
+			cep:              "00000-000",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID, Name: "Loja Olist"}, nil)
+				repo.On("GetCepRange", mock.Anything, "00000000").Return(repository.CepRange{}, sql.ErrNoRows)
+			},
+			expectedError: service.ErrCEPNotFound.Error(),
+		},
+		{
+			name:             "Create package with recipient and sender addresses",
+			product:          "Test Product",
+			weightKg:         2.5,
+			destinationState: "SP",
+			sellerID:         testSellerID.String(),
+			recipient: &service.Address{
+				Name:         "Maria Souza",
+				Document:     "529.982.247-25",
+				Phone:        "(11) 98765-4321",
+				Street:       "Avenida Paulista",
+				Number:       "1000",
+				Complement:   "Apto 12",
+				Neighborhood: "Bela Vista",
+				City:         "São Paulo",
+				State:        "SP",
+				CEP:          "01310-100",
+			},
+			sender: &service.Address{
+				Name:         "Loja Olist",
+				Document:     "11.222.333/0001-81",
+				Street:       "Rua XV de Novembro",
+				Number:       "S/N",
+				Neighborhood: "Centro",
+				City:         "Curitiba",
+				State:        "PR",
+				CEP:          "80020-310",
+			},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID, Name: "Loja Olist"}, nil)
+				repo.On("GetCepRange", mock.Anything, "01310100").Return(repository.CepRange{
+					StateCode: "SP",
+					City:      sql.NullString{String: "São Paulo", Valid: true},
+				}, nil)
+				repo.On("CreatePackage", mock.Anything, mock.MatchedBy(func(arg repository.CreatePackageParams) bool {
+					return arg.DestinationState == "SP" &&
+						arg.DestinationCep.String == "01310100" &&
+						arg.RecipientName.String == "Maria Souza" &&
+						arg.RecipientDocument.String == "52998224725" &&
+						arg.RecipientPhone.String == "11987654321" &&
+						arg.RecipientComplement.String == "Apto 12" &&
+						arg.SenderDocument.String == "11222333000181" &&
+						!arg.SenderPhone.Valid &&
+						!arg.SenderComplement.Valid &&
+						arg.SenderState.String == "PR" &&
+						arg.SenderCep.String == "80020310"
+				})).Return(repository.Package{
+					ID:               uuid.New(),
+					Product:          "Test Product",
+					WeightKg:         2.5,
+					DestinationState: "SP",
+					Status:           "criado",
+					DestinationCep:   sql.NullString{String: "01310100", Valid: true},
+					RecipientName:    sql.NullString{String: "Maria Souza", Valid: true},
+				}, nil)
+			},
+		},
+		{
+			name:      "Create package with destination taken from recipient",
+			product:   "Test Product",
+			weightKg:  2.5,
+			sellerID:  testSellerID.String(),
+			recipient: &service.Address{Name: "Maria Souza", Document: "52998224725", Street: "Rua A", Number: "10", Neighborhood: "Centro", City: "Salvador", State: "BA", CEP: "40020-000"},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID, Name: "Loja Olist"}, nil)
+				repo.On("GetCepRange", mock.Anything, "40020000").Return(repository.CepRange{StateCode: "BA"}, nil)
+				repo.On("CreatePackage", mock.Anything, mock.MatchedBy(func(arg repository.CreatePackageParams) bool {
+					return arg.DestinationState == "BA" &&
+						arg.DestinationCep.String == "40020000" &&
+						arg.DestinationCity == sql.NullString{String: "Salvador", Valid: true}
+				})).Return(repository.Package{
+					ID:       uuid.New(),
+					Product:  "Test Product",
+					WeightKg: 2.5,
+					Status:   "criado",
+				}, nil)
+			},
+		},
+		{
+			name:             "Create package with recipient in another state",
+			product:          "Test Product",
+			weightKg:         2.5,
+			destinationState: "RJ",
+			sellerID:         testSellerID.String(),
+			recipient:        &service.Address{Name: "Maria Souza", Document: "52998224725", Street: "Rua A", Number: "10", Neighborhood: "Centro", City: "Salvador", State: "BA", CEP: "40020-000"},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID, Name: "Loja Olist"}, nil)
+			},
+			expectedError: service.ErrRecipientMismatch.Error(),
+		},
+		{
+			name:             "Create package with recipient CEP different from destination CEP",
+			product:          "Test Product",
+			weightKg:         2.5,
+			destinationState: "BA",
+			cep:              "40010-000",
+			sellerID:         testSellerID.String(),
+			recipient:        &service.Address{Name: "Maria Souza", Document: "52998224725", Street: "Rua A", Number: "10", Neighborhood: "Centro", City: "Salvador", State: "BA", CEP: "40020-000"},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID, Name: "Loja Olist"}, nil)
+			},
+			expectedError: service.ErrRecipientMismatch.Error(),
+		},
+		{
+			name:             "Create package without seller",
+			product:          "Test Product",
+			weightKg:         2.5,
+			destinationState: "SP",
+			setupMocked:      func(repo *repository.QuerierMocked) {},
+			expectedError:    service.ErrSellerRequired.Error(),
+		},
+		{
+			name:             "Create package for unknown seller",
+			product:          "Test Product",
+			weightKg:         2.5,
+			destinationState: "SP",
+			sellerID:         "880e8400-e29b-41d4-a716-446655440099",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetSellerById", mock.Anything, uuid.MustParse("880e8400-e29b-41d4-a716-446655440099")).Return(repository.Seller{}, sql.ErrNoRows)
+			},
+			expectedError: service.ErrSellerNotFound.Error(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -59,7 +318,19 @@ func TestPackageService_Create(t *testing.T) {
 			logger := zap.NewNop().Sugar()
 			packageService := service.NewPackageService(repoMocked, config.Config{}, logger)
 
-			result, err := packageService.Create(context.Background(), tt.product, tt.weightKg, tt.destinationState)
+			input := service.CreatePackageInput{
+				Product:           tt.product,
+				WeightKg:          tt.weightKg,
+				DestinationState:  tt.destinationState,
+				Dimensions:        tt.dimensions,
+				OriginWarehouseID: tt.warehouseID,
+				DestinationCEP:    tt.cep,
+				SellerID:          tt.sellerID,
+				Recipient:         tt.recipient,
+				Sender:            tt.sender,
+			}
+
+			result, err := packageService.Create(context.Background(), input)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -73,6 +344,7 @@ func TestPackageService_Create(t *testing.T) {
 				assert.Equal(t, tt.destinationState, result.DestinationState)
 				assert.False(t, result.TrackingCode.Valid)
 				assert.Equal(t, "criado", result.Status)
+				assert.Equal(t, tt.dimensions, service.PackageDimensions(*result))
 			}
 		})
 	}
@@ -99,7 +371,7 @@ func TestPackageService_GetByID(t *testing.T) {
 					Status:           "criado",
 				}
 
-				repo.On("GetPackageById", mock.Anything, expectedUUID).Return(expectedPackage, nil)
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: expectedUUID}).Return(expectedPackage, nil)
 			},
 		},
 		{
@@ -113,7 +385,7 @@ func TestPackageService_GetByID(t *testing.T) {
 			packageID: "550e8400-e29b-41d4-a716-446655440000",
 			setupMocked: func(repo *repository.QuerierMocked) {
 				expectedUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
-				repo.On("GetPackageById", mock.Anything, expectedUUID).Return(repository.Package{}, sql.ErrNoRows)
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: expectedUUID}).Return(repository.Package{}, sql.ErrNoRows)
 			},
 			expectedError: "get package by id",
 		},
@@ -127,7 +399,7 @@ func TestPackageService_GetByID(t *testing.T) {
 			logger := zap.NewNop().Sugar()
 			packageService := service.NewPackageService(repoMocked, config.Config{}, logger)
 
-			result, err := packageService.GetByID(context.Background(), tt.packageID)
+			result, err := packageService.GetByID(context.Background(), tt.packageID, uuid.NullUUID{})
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -146,6 +418,65 @@ func TestPackageService_GetByID(t *testing.T) {
 	}
 }
 
+// Another seller's package is not found by any id operation and is never changed.
+func TestPackageService_SellerIsolation(t *testing.T) {
+	packageID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	stranger := uuid.NullUUID{UUID: uuid.MustParse("880e8400-e29b-41d4-a716-446655440002"), Valid: true}
+	id := packageID.String()
+
+	repoMocked := repository.NewQuerierMocked(t)
+	repoMocked.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID, SellerID: stranger}).
+		Return(repository.Package{}, sql.ErrNoRows)
+	repoMocked.On("GetPackageByTrackingCode", mock.Anything, repository.GetPackageByTrackingCodeParams{
+		TrackingCode: sql.NullString{String: "NB473124829BR", Valid: true},
+		SellerID:     stranger,
+	}).Return(repository.Package{}, sql.ErrNoRows)
+	packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+	ctx := context.Background()
+
+	_, err := packageService.GetByID(ctx, id, stranger)
+	assert.ErrorIs(t, err, service.ErrPackageNotFound)
+
+	_, err = packageService.GetByTrackingCode(ctx, "NB473124829BR", stranger)
+	assert.ErrorIs(t, err, service.ErrPackageNotFound)
+
+	_, err = packageService.UpdateStatus(ctx, id, stranger, service.StatusChange{Status: "esperando_coleta", Actor: "tester"})
+	assert.ErrorIs(t, err, service.ErrPackageNotFound)
+
+	err = packageService.Delete(ctx, id, stranger, service.PackageDeletion{})
+	assert.ErrorIs(t, err, service.ErrPackageNotFound)
+
+	_, err = packageService.HireCarrier(ctx, id, uuid.NewString(), stranger, "tester", 0)
+	assert.ErrorIs(t, err, service.ErrPackageNotFound)
+
+	_, err = packageService.CreatePackageQuotes(ctx, id, stranger)
+	assert.ErrorIs(t, err, service.ErrPackageNotFound)
+
+	_, err = packageService.GetStatusHistory(ctx, id, stranger)
+	assert.ErrorIs(t, err, service.ErrPackageNotFound)
+
+	_, err = packageService.GetLabel(ctx, id, stranger)
+	assert.ErrorIs(t, err, service.ErrPackageNotFound)
+}
+
+func TestPackageService_ListIsScopedBySeller(t *testing.T) {
+	seller := uuid.NullUUID{UUID: testSellerID, Valid: true}
+
+	repoMocked := repository.NewQuerierMocked(t)
+	repoMocked.On("ListPackagesPage", mock.Anything, mock.MatchedBy(func(arg repository.ListPackagesPageParams) bool {
+		return arg.SellerID == seller
+	})).Return([]repository.Package{{ID: uuid.New(), SellerID: testSellerID}}, nil)
+	repoMocked.On("CountPackages", mock.Anything, mock.MatchedBy(func(arg repository.CountPackagesParams) bool {
+		return arg.SellerID == seller
+	})).Return(int64(1), nil)
+	packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+	page, err := packageService.List(context.Background(), service.PackageListFilter{SellerID: seller})
+	require.NoError(t, err)
+	assert.Len(t, page.Packages, 1)
+	assert.Equal(t, int64(1), page.Total)
+}
+
 func TestPackageService_GetByTrackingCode(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -166,7 +497,7 @@ func TestPackageService_GetByTrackingCode(t *testing.T) {
 					Status:           "criado",
 				}
 
-				repo.On("GetPackageByTrackingCode", mock.Anything, sql.NullString{String: "BR12345678", Valid: true}).Return(expectedPackage, nil)
+				repo.On("GetPackageByTrackingCode", mock.Anything, repository.GetPackageByTrackingCodeParams{TrackingCode: sql.NullString{String: "BR12345678", Valid: true}}).Return(expectedPackage, nil)
 
 			},
 		},
@@ -174,11 +505,34 @@ func TestPackageService_GetByTrackingCode(t *testing.T) {
 			name:         "Get package by tracking code not found",
 			trackingCode: "BR99999999",
 			setupMocked: func(repo *repository.QuerierMocked) {
-				repo.On("GetPackageByTrackingCode", mock.Anything, sql.NullString{String: "BR99999999", Valid: true}).Return(repository.Package{}, sql.ErrNoRows)
+				repo.On("GetPackageByTrackingCode", mock.Anything, repository.GetPackageByTrackingCodeParams{TrackingCode: sql.NullString{String: "BR99999999", Valid: true}}).Return(repository.Package{}, sql.ErrNoRows)
 
 			},
 			expectedError: "get package by tracking code",
 		},
+		{
+			name:         "Get package by S10 tracking code",
+			trackingCode: " nb473124829br",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageByTrackingCode", mock.Anything, repository.GetPackageByTrackingCodeParams{TrackingCode: sql.NullString{String: "NB473124829BR", Valid: true}}).Return(repository.Package{
+					ID:           uuid.New(),
+					TrackingCode: sql.NullString{String: "NB473124829BR", Valid: true},
+					Product:      "Test Product",
+				}, nil)
+			},
+		},
+		{
+			name:          "Reject tracking code with wrong check digit",
+			trackingCode:  "NB473124828BR",
+			setupMocked:   func(repo *repository.QuerierMocked) {},
+			expectedError: service.ErrInvalidTrackingCode.Error(),
+		},
+		{
+			name:          "Reject malformed tracking code",
+			trackingCode:  "XYZ",
+			setupMocked:   func(repo *repository.QuerierMocked) {},
+			expectedError: service.ErrInvalidTrackingCode.Error(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -189,7 +543,7 @@ func TestPackageService_GetByTrackingCode(t *testing.T) {
 			logger := zap.NewNop().Sugar()
 			packageService := service.NewPackageService(repoMocked, config.Config{}, logger)
 
-			result, err := packageService.GetByTrackingCode(context.Background(), tt.trackingCode)
+			result, err := packageService.GetByTrackingCode(context.Background(), tt.trackingCode, uuid.NullUUID{})
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -199,7 +553,7 @@ func TestPackageService_GetByTrackingCode(t *testing.T) {
 				assert.NoError(t, err)
 				assert.NotNil(t, result)
 				if result.TrackingCode.Valid {
-					assert.Equal(t, "BR12345678", result.TrackingCode.String)
+					assert.Equal(t, strings.ToUpper(strings.TrimSpace(tt.trackingCode)), result.TrackingCode.String)
 				}
 				assert.Equal(t, "Test Product", result.Product)
 			}
@@ -207,48 +561,69 @@ func TestPackageService_GetByTrackingCode(t *testing.T) {
 	}
 }
 
-func TestPackageService_GetAll(t *testing.T) {
+func TestPackageService_List(t *testing.T) {
+	firstID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440001")
+	secondID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+	thirdID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440003")
+	baseTime := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	pagePackages := []repository.Package{
+		{ID: firstID, Product: "Product 1", WeightKg: 1.0, DestinationState: "SP", Status: "criado", CreatedAt: sql.NullTime{Time: baseTime, Valid: true}},
+		{ID: secondID, Product: "Product 2", WeightKg: 2.0, DestinationState: "SP", Status: "criado", CreatedAt: sql.NullTime{Time: baseTime.Add(-time.Hour), Valid: true}},
+		{ID: thirdID, Product: "Product 3", WeightKg: 3.0, DestinationState: "SP", Status: "criado", CreatedAt: sql.NullTime{Time: baseTime.Add(-2 * time.Hour), Valid: true}},
+	}
+
 	tests := []struct {
-		name          string
-		setupMocked   func(repo *repository.QuerierMocked)
-		expectedCount int
-		expectedError string
+		name               string
+		filter             service.PackageListFilter
+		setupMocked        func(repo *repository.QuerierMocked)
+		expectedCount      int
+		expectedNextCursor bool
+		expectedTotal      int64
+		expectedError      string
 	}{
 		{
-			name: "Get all packages successfully",
+			name:   "First page with next cursor",
+			filter: service.PackageListFilter{DestinationState: "SP", SortDesc: true, Limit: 2},
 			setupMocked: func(repo *repository.QuerierMocked) {
-				expectedPackages := []repository.Package{
-					{
-						ID:               uuid.New(),
-						TrackingCode:     sql.NullString{Valid: false},
-						Product:          "Product 1",
-						WeightKg:         1.0,
-						DestinationState: "SP",
-						Status:           "criado",
-					},
-					{
-						ID: uuid.New(),
-						TrackingCode: sql.NullString{
-							String: "BR87654321",
-							Valid:  true,
-						},
-						Product:          "Product 2",
-						WeightKg:         2.0,
-						DestinationState: "RJ",
-						Status:           "enviado",
-					},
-				}
-
-				repo.On("ListPackages", mock.Anything).Return(expectedPackages, nil)
+				repo.On("ListPackagesPage", mock.Anything, mock.MatchedBy(func(arg repository.ListPackagesPageParams) bool {
+					return arg.DestinationState.String == "SP" &&
+						arg.SortBy == service.SortByCreatedAt &&
+						arg.SortDesc &&
+						arg.PageLimit == 3 &&
+						!arg.CursorID.Valid
+				})).Return(pagePackages, nil)
+				repo.On("CountPackages", mock.Anything, mock.MatchedBy(func(arg repository.CountPackagesParams) bool {
+					return arg.DestinationState.String == "SP" && !arg.Status.Valid
+				})).Return(int64(3), nil)
 			},
-			expectedCount: 2,
+			expectedCount:      2,
+			expectedNextCursor: true,
+			expectedTotal:      3,
 		},
 		{
-			name: "Get all packages with empty result",
+			name:   "Last page without next cursor",
+			filter: service.PackageListFilter{SortBy: service.SortByWeightKg, Limit: 5},
 			setupMocked: func(repo *repository.QuerierMocked) {
-				repo.On("ListPackages", mock.Anything).Return([]repository.Package{}, nil)
+				repo.On("ListPackagesPage", mock.Anything, mock.MatchedBy(func(arg repository.ListPackagesPageParams) bool {
+					return arg.SortBy == service.SortByWeightKg && arg.PageLimit == 6
+				})).Return(pagePackages, nil)
+				repo.On("CountPackages", mock.Anything, mock.Anything).Return(int64(3), nil)
 			},
-			expectedCount: 0,
+			expectedCount: 3,
+			expectedTotal: 3,
+		},
+		{
+			name:          "Reject malformed cursor",
+			filter:        service.PackageListFilter{Cursor: "not-a-cursor"},
+			setupMocked:   func(repo *repository.QuerierMocked) {},
+			expectedError: "invalid list filter",
+		},
+		{
+			name:          "Reject invalid carrier filter",
+			filter:        service.PackageListFilter{HiredCarrierID: "invalid-uuid"},
+			setupMocked:   func(repo *repository.QuerierMocked) {},
+			expectedError: "invalid list filter",
 		},
 	}
 
@@ -260,19 +635,64 @@ func TestPackageService_GetAll(t *testing.T) {
 			logger := zap.NewNop().Sugar()
 			packageService := service.NewPackageService(repoMocked, config.Config{}, logger)
 
-			result, err := packageService.GetAll(context.Background())
+			result, err := packageService.List(context.Background(), tt.filter)
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedError)
+				assert.Nil(t, result)
 			} else {
-				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedCount, len(result))
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectedCount, len(result.Packages))
+				assert.Equal(t, tt.expectedTotal, result.Total)
+				assert.Equal(t, tt.expectedNextCursor, result.NextCursor != "")
 			}
 		})
 	}
 }
 
+func TestPackageService_ListFollowsCursor(t *testing.T) {
+	repoMocked := repository.NewQuerierMocked(t)
+	lastID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+	lastCreatedAt := time.Date(2025, 1, 10, 11, 0, 0, 123456000, time.UTC)
+
+	repoMocked.On("ListPackagesPage", mock.Anything, mock.MatchedBy(func(arg repository.ListPackagesPageParams) bool {
+		return !arg.CursorID.Valid
+	})).Return([]repository.Package{
+		{ID: uuid.New(), CreatedAt: sql.NullTime{Time: lastCreatedAt.Add(time.Hour), Valid: true}},
+		{ID: lastID, CreatedAt: sql.NullTime{Time: lastCreatedAt, Valid: true}},
+		{ID: uuid.New(), CreatedAt: sql.NullTime{Time: lastCreatedAt.Add(-time.Hour), Valid: true}},
+	}, nil).Once()
+	repoMocked.On("ListPackagesPage", mock.Anything, mock.MatchedBy(func(arg repository.ListPackagesPageParams) bool {
+		return arg.CursorID.Valid &&
+			arg.CursorID.UUID == lastID &&
+			arg.CursorCreatedAt.Valid &&
+			arg.CursorCreatedAt.Time.Equal(lastCreatedAt)
+	})).Return([]repository.Package{}, nil).Once()
+	repoMocked.On("CountPackages", mock.Anything, mock.Anything).Return(int64(3), nil)
+
+	logger := zap.NewNop().Sugar()
+	packageService := service.NewPackageService(repoMocked, config.Config{}, logger)
+
+	firstPage, err := packageService.List(context.Background(), service.PackageListFilter{SortDesc: true, Limit: 2})
+	require.NoError(t, err)
+	require.NotEmpty(t, firstPage.NextCursor)
+
+	secondPage, err := packageService.List(context.Background(), service.PackageListFilter{SortDesc: true, Limit: 2, Cursor: firstPage.NextCursor})
+	require.NoError(t, err)
+	assert.Empty(t, secondPage.Packages)
+	assert.Empty(t, secondPage.NextCursor)
+
+	// A cursor is only valid for the sort order it was issued with
+	for _, filter := range []service.PackageListFilter{
+		{SortDesc: false, Limit: 2, Cursor: firstPage.NextCursor},
+		{SortBy: service.SortByWeightKg, SortDesc: true, Limit: 2, Cursor: firstPage.NextCursor},
+	} {
+		_, err = packageService.List(context.Background(), filter)
+		assert.ErrorIs(t, err, service.ErrInvalidListFilter)
+	}
+}
+
 func TestPackageService_UpdateStatus(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -287,12 +707,24 @@ func TestPackageService_UpdateStatus(t *testing.T) {
 			status:    "coletado",
 			setupMocked: func(repo *repository.QuerierMocked) {
 				expectedUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: expectedUUID}).Return(repository.Package{
+					ID:             expectedUUID,
+					Status:         "esperando_coleta",
+					HiredCarrierID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+				}, nil)
+
 				expectedParams := repository.UpdatePackageStatusParams{
 					ID:     expectedUUID,
 					Status: "coletado",
 				}
 
-				repo.On("UpdatePackageStatus", mock.Anything, expectedParams).Return(nil)
+				repo.On("UpdatePackageStatus", mock.Anything, expectedParams).Return(int64(1), nil)
+				repo.On("CreatePackageStatusEvent", mock.Anything, mock.MatchedBy(func(arg repository.CreatePackageStatusEventParams) bool {
+					return arg.PackageID == expectedUUID &&
+						arg.FromStatus == "esperando_coleta" &&
+						arg.ToStatus == "coletado" &&
+						arg.Actor == "tester"
+				})).Return(repository.PackageStatusEvent{}, nil)
 			},
 		},
 		{
@@ -301,16 +733,93 @@ func TestPackageService_UpdateStatus(t *testing.T) {
 			status:    "enviado",
 			setupMocked: func(repo *repository.QuerierMocked) {
 				expectedUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: expectedUUID}).Return(repository.Package{
+					ID:     expectedUUID,
+					Status: "coletado",
+				}, nil)
 
-				repo.On("TrackingCodeExists", mock.Anything, mock.AnythingOfType("sql.NullString")).Return(false, nil)
+				repo.On("NextTrackingSerial", mock.Anything, "OL").Return(int64(17), nil)
 
 				repo.On("UpdatePackageStatusWithTracking", mock.Anything, mock.MatchedBy(func(arg repository.UpdatePackageStatusWithTrackingParams) bool {
 					return arg.ID == expectedUUID &&
 						arg.Status == "enviado" &&
-						arg.TrackingCode.Valid &&
-						len(arg.TrackingCode.String) > 0
-				})).Return(nil)
+						arg.TrackingCode == sql.NullString{String: "OL000000178BR", Valid: true}
+				})).Return(int64(1), nil)
+				repo.On("CreatePackageStatusEvent", mock.Anything, mock.AnythingOfType("repository.CreatePackageStatusEventParams")).Return(repository.PackageStatusEvent{}, nil)
+			},
+		},
+		{
+			name:      "Update package status to enviado uses carrier tracking prefix",
+			packageID: "550e8400-e29b-41d4-a716-446655440000",
+			status:    "enviado",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				expectedUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+				carrierID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: expectedUUID}).Return(repository.Package{
+					ID:             expectedUUID,
+					Status:         "coletado",
+					HiredCarrierID: uuid.NullUUID{UUID: carrierID, Valid: true},
+				}, nil)
+				repo.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID, TrackingPrefix: "NB"}, nil)
+				repo.On("NextTrackingSerial", mock.Anything, "NB").Return(int64(47312482), nil)
+
+				repo.On("UpdatePackageStatusWithTracking", mock.Anything, mock.MatchedBy(func(arg repository.UpdatePackageStatusWithTrackingParams) bool {
+					return arg.TrackingCode == sql.NullString{String: "NB473124829BR", Valid: true}
+				})).Return(int64(1), nil)
+				repo.On("CreatePackageStatusEvent", mock.Anything, mock.AnythingOfType("repository.CreatePackageStatusEventParams")).Return(repository.PackageStatusEvent{}, nil)
+			},
+		},
+		{
+			name:      "Update package status to enviado keeps label tracking code",
+			packageID: "550e8400-e29b-41d4-a716-446655440000",
+			status:    "enviado",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				expectedUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: expectedUUID}).Return(repository.Package{
+					ID:           expectedUUID,
+					Status:       "coletado",
+					TrackingCode: sql.NullString{String: "NB473124829BR", Valid: true},
+				}, nil)
+
+				repo.On("UpdatePackageStatus", mock.Anything, repository.UpdatePackageStatusParams{ID: expectedUUID, Status: "enviado"}).Return(int64(1), nil)
+				repo.On("CreatePackageStatusEvent", mock.Anything, mock.AnythingOfType("repository.CreatePackageStatusEventParams")).Return(repository.PackageStatusEvent{}, nil)
+			},
+		},
+		{
+			name:      "Reject transition back from entregue",
+			packageID: "550e8400-e29b-41d4-a716-446655440000",
+			status:    "criado",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				expectedUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: expectedUUID}).Return(repository.Package{
+					ID:     expectedUUID,
+					Status: "entregue",
+				}, nil)
 			},
+			expectedError: "invalid status transition",
+		},
+		{
+			name:      "Reject esperando_coleta without hired carrier",
+			packageID: "550e8400-e29b-41d4-a716-446655440000",
+			status:    "esperando_coleta",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				expectedUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: expectedUUID}).Return(repository.Package{
+					ID:     expectedUUID,
+					Status: "criado",
+				}, nil)
+			},
+			expectedError: "package has no hired carrier",
+		},
+		{
+			name:      "Update status of missing package",
+			packageID: "550e8400-e29b-41d4-a716-446655440000",
+			status:    "coletado",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				expectedUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: expectedUUID}).Return(repository.Package{}, sql.ErrNoRows)
+			},
+			expectedError: "package not found",
 		},
 		{
 			name:          "Update package with invalid UUID",
@@ -329,7 +838,10 @@ func TestPackageService_UpdateStatus(t *testing.T) {
 			logger := zap.NewNop().Sugar()
 			packageService := service.NewPackageService(repoMocked, config.Config{}, logger)
 
-			err := packageService.UpdateStatus(context.Background(), tt.packageID, tt.status)
+			_, err := packageService.UpdateStatus(context.Background(), tt.packageID, uuid.NullUUID{}, service.StatusChange{
+				Status: tt.status,
+				Actor:  "tester",
+			})
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
@@ -341,26 +853,37 @@ func TestPackageService_UpdateStatus(t *testing.T) {
 	}
 }
 
-func TestPackageService_Delete(t *testing.T) {
+func TestPackageService_GetStatusHistory(t *testing.T) {
 	tests := []struct {
 		name          string
 		packageID     string
 		setupMocked   func(repo *repository.QuerierMocked)
+		expectedCount int
 		expectedError string
 	}{
 		{
-			name:      "Delete package successfully",
+			name:      "Get status history successfully",
 			packageID: "550e8400-e29b-41d4-a716-446655440000",
 			setupMocked: func(repo *repository.QuerierMocked) {
 				expectedUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
-				repo.On("DeletePackage", mock.Anything, expectedUUID).Return(nil)
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: expectedUUID}).Return(repository.Package{ID: expectedUUID, Status: "coletado"}, nil)
+
+				expectedEvents := []repository.PackageStatusEvent{
+					{ID: uuid.New(), PackageID: expectedUUID, FromStatus: "criado", ToStatus: "esperando_coleta", Actor: "api"},
+					{ID: uuid.New(), PackageID: expectedUUID, FromStatus: "esperando_coleta", ToStatus: "coletado", Actor: "api"},
+				}
+				repo.On("ListPackageStatusEvents", mock.Anything, expectedUUID).Return(expectedEvents, nil)
 			},
+			expectedCount: 2,
 		},
 		{
-			name:          "Delete package with invalid UUID",
-			packageID:     "invalid-uuid",
-			setupMocked:   func(repo *repository.QuerierMocked) {},
-			expectedError: "parse package id",
+			name:      "Get status history of missing package",
+			packageID: "550e8400-e29b-41d4-a716-446655440000",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				expectedUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: expectedUUID}).Return(repository.Package{}, sql.ErrNoRows)
+			},
+			expectedError: "package not found",
 		},
 	}
 
@@ -372,69 +895,49 @@ func TestPackageService_Delete(t *testing.T) {
 			logger := zap.NewNop().Sugar()
 			packageService := service.NewPackageService(repoMocked, config.Config{}, logger)
 
-			err := packageService.Delete(context.Background(), tt.packageID)
+			result, err := packageService.GetStatusHistory(context.Background(), tt.packageID, uuid.NullUUID{})
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedError)
 			} else {
 				assert.NoError(t, err)
+				assert.Equal(t, tt.expectedCount, len(result))
 			}
 		})
 	}
 }
 
-func TestPackageService_GetQuotes(t *testing.T) {
+func TestPackageService_Delete(t *testing.T) {
 	tests := []struct {
 		name          string
-		stateCode     string
-		weightKg      float64
+		packageID     string
 		setupMocked   func(repo *repository.QuerierMocked)
-		expectedCount int
 		expectedError string
 	}{
 		{
-			name:      "Get quotes successfully",
-			stateCode: "SP",
-			weightKg:  2.5,
+			name:      "Delete package successfully",
+			packageID: "550e8400-e29b-41d4-a716-446655440000",
 			setupMocked: func(repo *repository.QuerierMocked) {
-				mockState := repository.GetStateByCodeRow{
-					Code:       "SP",
-					Name:       "São Paulo",
-					RegionName: "Sudeste",
-				}
-				repo.On("GetStateByCode", mock.Anything, "SP").Return(mockState, nil)
-
-				expectedParams := repository.GetQuotesForPackageParams{
-					StateCode: "SP",
-					WeightKg:  "2.50",
-				}
-
-				expectedQuotes := []repository.GetQuotesForPackageRow{
-					{
-						Carier:                "Nebulix Logística",
-						EstimatedPrice:        14.75,
-						EstimatedDeliveryDays: 4,
-					},
-					{
-						Carier:                "RotaFácil Transportes",
-						EstimatedPrice:        10.88,
-						EstimatedDeliveryDays: 7,
-					},
-				}
-
-				repo.On("GetQuotesForPackage", mock.Anything, expectedParams).Return(expectedQuotes, nil)
+				expectedUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: expectedUUID}).Return(repository.Package{ID: expectedUUID}, nil)
+				repo.On("DeletePackage", mock.Anything, repository.DeletePackageParams{ID: expectedUUID}).Return(int64(1), nil)
 			},
-			expectedCount: 2,
 		},
 		{
-			name:      "Get quotes for invalid state",
-			stateCode: "XX",
-			weightKg:  2.5,
+			name:      "Delete package not found",
+			packageID: "550e8400-e29b-41d4-a716-446655440000",
 			setupMocked: func(repo *repository.QuerierMocked) {
-				repo.On("GetStateByCode", mock.Anything, "XX").Return(repository.GetStateByCodeRow{}, sql.ErrNoRows)
+				expectedUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: expectedUUID}).Return(repository.Package{}, sql.ErrNoRows)
 			},
-			expectedError: "invalid state code",
+			expectedError: service.ErrPackageNotFound.Error(),
+		},
+		{
+			name:          "Delete package with invalid UUID",
+			packageID:     "invalid-uuid",
+			setupMocked:   func(repo *repository.QuerierMocked) {},
+			expectedError: "parse package id",
 		},
 	}
 
@@ -446,153 +949,1395 @@ func TestPackageService_GetQuotes(t *testing.T) {
 			logger := zap.NewNop().Sugar()
 			packageService := service.NewPackageService(repoMocked, config.Config{}, logger)
 
-			result, err := packageService.GetQuotes(context.Background(), tt.stateCode, tt.weightKg)
+			err := packageService.Delete(context.Background(), tt.packageID, uuid.NullUUID{}, service.PackageDeletion{})
 
 			if tt.expectedError != "" {
 				assert.Error(t, err)
 				assert.Contains(t, err.Error(), tt.expectedError)
 			} else {
 				assert.NoError(t, err)
-				assert.Equal(t, tt.expectedCount, len(result))
-
-				if len(result) > 0 {
-					assert.NotEmpty(t, result[0].Carier)
-					assert.Greater(t, result[0].EstimatedPrice, 0.0)
-					assert.Greater(t, result[0].EstimatedDeliveryDays, int32(0))
-				}
 			}
 		})
 	}
 }
 
-func TestPackageService_HireCarrier(t *testing.T) {
-	tests := []struct {
-		name          string
-		packageID     string
-		carrierID     string
-		price         string
-		deliveryDays  int32
-		setupMocked   func(repo *repository.QuerierMocked)
-		expectedError string
+func TestPackageService_SoftDelete(t *testing.T) {
+	packageID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	logger := zap.NewNop().Sugar()
+
+	t.Run("Shipped packages cannot be deleted", func(t *testing.T) {
+		for _, status := range []string{"enviado", "entregue", "extraviado"} {
+			repo := repository.NewQuerierMocked(t)
+			repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).
+				Return(repository.Package{ID: packageID, Status: status, Version: 1}, nil)
+			packageService := service.NewPackageService(repo, config.Config{}, logger)
+
+			err := packageService.Delete(context.Background(), packageID.String(), uuid.NullUUID{}, service.PackageDeletion{})
+			assert.ErrorIs(t, err, service.ErrPackageAlreadyShipped, status)
+		}
+	})
+
+	t.Run("Delete records who deleted and why", func(t *testing.T) {
+		repo := repository.NewQuerierMocked(t)
+		repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).
+			Return(repository.Package{ID: packageID, Status: "coletado", Version: 2}, nil)
+		repo.On("DeletePackage", mock.Anything, repository.DeletePackageParams{
+			ID:            packageID,
+			Version:       2,
+			DeletedBy:     sql.NullString{String: "apikey:loja", Valid: true},
+			DeletedReason: sql.NullString{String: "pedido cancelado", Valid: true},
+		}).Return(int64(1), nil)
+		packageService := service.NewPackageService(repo, config.Config{}, logger)
+
+		err := packageService.Delete(context.Background(), packageID.String(), uuid.NullUUID{},
+			service.PackageDeletion{Actor: "apikey:loja", Reason: "pedido cancelado"})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Restore a package that is not deleted", func(t *testing.T) {
+		repo := repository.NewQuerierMocked(t)
+		repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID, IncludeDeleted: true}).
+			Return(repository.Package{ID: packageID, Status: "criado", Version: 1}, nil)
+		packageService := service.NewPackageService(repo, config.Config{}, logger)
+
+		_, err := packageService.Restore(context.Background(), packageID.String(), uuid.NullUUID{}, "tester", 0)
+		assert.ErrorIs(t, err, service.ErrPackageNotDeleted)
+	})
+
+	t.Run("Restore a deleted package", func(t *testing.T) {
+		deleted := repository.Package{ID: packageID, Status: "coletado", Version: 3,
+			DeletedAt: sql.NullTime{Time: time.Now(), Valid: true}}
+		restored := repository.Package{ID: packageID, Status: "coletado", Version: 4}
+
+		repo := repository.NewQuerierMocked(t)
+		repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID, IncludeDeleted: true}).Return(deleted, nil)
+		repo.On("RestorePackage", mock.Anything, repository.RestorePackageParams{ID: packageID, Version: 3}).Return(int64(1), nil)
+		repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(restored, nil)
+		packageService := service.NewPackageService(repo, config.Config{}, logger)
+
+		pkg, err := packageService.Restore(context.Background(), packageID.String(), uuid.NullUUID{}, "tester", 3)
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), pkg.Version)
+		assert.False(t, pkg.DeletedAt.Valid)
+	})
+
+	t.Run("Restore a package that does not exist", func(t *testing.T) {
+		repo := repository.NewQuerierMocked(t)
+		repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID, IncludeDeleted: true}).
+			Return(repository.Package{}, sql.ErrNoRows)
+		packageService := service.NewPackageService(repo, config.Config{}, logger)
+
+		_, err := packageService.Restore(context.Background(), packageID.String(), uuid.NullUUID{}, "tester", 0)
+		assert.ErrorIs(t, err, service.ErrPackageNotFound)
+	})
+}
+
+func TestPackageService_VersionCheck(t *testing.T) {
+	packageID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	current := repository.Package{ID: packageID, Status: "esperando_coleta", Version: 3,
+		HiredCarrierID: uuid.NullUUID{UUID: uuid.New(), Valid: true}}
+	logger := zap.NewNop().Sugar()
+
+	t.Run("Stale If-Match is rejected before writing", func(t *testing.T) {
+		repo := repository.NewQuerierMocked(t)
+		repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(current, nil)
+		packageService := service.NewPackageService(repo, config.Config{}, logger)
+
+		_, err := packageService.UpdateStatus(context.Background(), packageID.String(), uuid.NullUUID{},
+			service.StatusChange{Status: "coletado", Version: 2})
+		assert.ErrorIs(t, err, service.ErrVersionMismatch)
+
+		err = packageService.Delete(context.Background(), packageID.String(), uuid.NullUUID{}, service.PackageDeletion{Version: 2})
+		assert.ErrorIs(t, err, service.ErrVersionMismatch)
+
+		_, err = packageService.HireCarrier(context.Background(), packageID.String(), uuid.NewString(), uuid.NullUUID{}, "tester", 2)
+		assert.ErrorIs(t, err, service.ErrVersionMismatch)
+	})
+
+	t.Run("Matching If-Match writes the version read", func(t *testing.T) {
+		repo := repository.NewQuerierMocked(t)
+		repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(current, nil)
+		repo.On("UpdatePackageStatus", mock.Anything, repository.UpdatePackageStatusParams{ID: packageID, Status: "coletado", Version: 3}).Return(int64(1), nil)
+		repo.On("CreatePackageStatusEvent", mock.Anything, mock.Anything).Return(repository.PackageStatusEvent{}, nil)
+		packageService := service.NewPackageService(repo, config.Config{}, logger)
+
+		_, err := packageService.UpdateStatus(context.Background(), packageID.String(), uuid.NullUUID{},
+			service.StatusChange{Status: "coletado", Version: 3})
+		assert.NoError(t, err)
+	})
+
+	t.Run("Concurrent change with If-Match", func(t *testing.T) {
+		repo := repository.NewQuerierMocked(t)
+		repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(current, nil)
+		repo.On("UpdatePackageStatus", mock.Anything, mock.Anything).Return(int64(0), nil)
+		packageService := service.NewPackageService(repo, config.Config{}, logger)
+
+		_, err := packageService.UpdateStatus(context.Background(), packageID.String(), uuid.NullUUID{},
+			service.StatusChange{Status: "coletado", Version: 3})
+		assert.ErrorIs(t, err, service.ErrVersionMismatch)
+	})
+
+	t.Run("Concurrent change without If-Match", func(t *testing.T) {
+		repo := repository.NewQuerierMocked(t)
+		repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(current, nil)
+		repo.On("UpdatePackageStatus", mock.Anything, mock.Anything).Return(int64(0), nil)
+		repo.On("DeletePackage", mock.Anything, repository.DeletePackageParams{ID: packageID, Version: 3}).Return(int64(0), nil)
+		packageService := service.NewPackageService(repo, config.Config{}, logger)
+
+		_, err := packageService.UpdateStatus(context.Background(), packageID.String(), uuid.NullUUID{},
+			service.StatusChange{Status: "coletado"})
+		assert.ErrorIs(t, err, service.ErrPackageModified)
+
+		err = packageService.Delete(context.Background(), packageID.String(), uuid.NullUUID{}, service.PackageDeletion{})
+		assert.ErrorIs(t, err, service.ErrPackageModified)
+	})
+}
+
+func TestSellerRate_Apply(t *testing.T) {
+	table := service.RateTable{
+		PricePerKg:       5.90,
+		ExcessPricePerKg: 2.5,
+		MinCharge:        12,
+		Tiers:            []service.RateTier{{MinWeightKg: 0, MaxWeightKg: 5, Price: 20}},
+	}
+
+	// The negotiated price replaces tiers and excess, but the minimum charge still applies
+	negotiated := service.SellerRate{PricePerKg: 3, MarkupPercent: 5}.Apply(table)
+	assert.Empty(t, negotiated.Tiers)
+	assert.Equal(t, 3.0, negotiated.ExcessPricePerKg)
+	assert.Equal(t, 12.0, negotiated.MinCharge)
+	assert.Equal(t, 12.6, negotiated.Price(2))
+	assert.Equal(t, 31.5, negotiated.Price(10))
+
+	// A markup alone keeps the carrier's table
+	markupOnly := service.SellerRate{MarkupPercent: 10}.Apply(table)
+	assert.Len(t, markupOnly.Tiers, 1)
+	assert.Equal(t, 22.0, markupOnly.Price(3))
+}
+
+func TestPackageService_GetQuotes(t *testing.T) {
+	nebulixID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+	rotaFacilID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440002")
+	rates := []repository.GetQuotesForPackageRow{
+		{
+			CarrierID:             nebulixID,
+			CarrierName:           "Nebulix Logística",
+			PricePerKg:            "5.90",
+			MinCharge:             "0.00",
+			CubingDivisor:         6000,
+			EstimatedDeliveryDays: 4,
+		},
+		{
+			CarrierID:             rotaFacilID,
+			CarrierName:           "RotaFácil Transportes",
+			PricePerKg:            "4.35",
+			MinCharge:             "0.00",
+			CubingDivisor:         5000,
+			EstimatedDeliveryDays: 7,
+		},
+	}
+
+	tests := []struct {
+		name             string
+		params           service.QuoteParams
+		setupMocked      func(repo *repository.QuerierMocked)
+		expectedPrices   []float64
+		expectedBillable []float64
+		expectedReasons  []string
+		expectedError    string
+	}{
+		{
+			name:   "Get quotes successfully",
+			params: service.QuoteParams{StateCode: "SP", WeightKg: 2.5},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				mockState := repository.GetStateByCodeRow{
+					Code:       "SP",
+					Name:       "São Paulo",
+					RegionName: "Sudeste",
+				}
+				repo.On("GetStateByCode", mock.Anything, "SP").Return(mockState, nil)
+				repo.On("GetQuotesForPackage", mock.Anything, repository.GetQuotesForPackageParams{StateCode: "SP"}).Return(rates, nil)
+				repo.On("ListCarrierRateTiersByState", mock.Anything, "SP").Return([]repository.CarrierRateTier{}, nil)
+				repo.On("ListCarrierRestrictedCategories", mock.Anything).Return([]repository.CarrierRestrictedCategory{}, nil)
+				repo.On("ListHolidaysForState", mock.Anything, mock.Anything).Return([]repository.Holiday{}, nil)
+			},
+			expectedPrices:   []float64{14.75, 10.88},
+			expectedBillable: []float64{2.5, 2.5},
+		},
+		{
+			name: "Get quotes using volumetric weight",
+			params: service.QuoteParams{
+				StateCode:  "SP",
+				WeightKg:   1.0,
+				Dimensions: &service.Dimensions{HeightCm: 30, WidthCm: 40, LengthCm: 50},
+			},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetStateByCode", mock.Anything, "SP").Return(repository.GetStateByCodeRow{Code: "SP"}, nil)
+				repo.On("GetQuotesForPackage", mock.Anything, repository.GetQuotesForPackageParams{StateCode: "SP"}).Return(rates, nil)
+				repo.On("ListCarrierRateTiersByState", mock.Anything, "SP").Return([]repository.CarrierRateTier{}, nil)
+				repo.On("ListCarrierRestrictedCategories", mock.Anything).Return([]repository.CarrierRestrictedCategory{}, nil)
+				repo.On("ListHolidaysForState", mock.Anything, mock.Anything).Return([]repository.Holiday{}, nil)
+			},
+			expectedPrices:   []float64{59.0, 52.2},
+			expectedBillable: []float64{10, 12},
+		},
+		{
+			name: "Get quotes keeps real weight when heavier than volumetric",
+			params: service.QuoteParams{
+				StateCode:  "SP",
+				WeightKg:   8.0,
+				Dimensions: &service.Dimensions{HeightCm: 10, WidthCm: 10, LengthCm: 10},
+			},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetStateByCode", mock.Anything, "SP").Return(repository.GetStateByCodeRow{Code: "SP"}, nil)
+				repo.On("GetQuotesForPackage", mock.Anything, repository.GetQuotesForPackageParams{StateCode: "SP"}).Return(rates, nil)
+				repo.On("ListCarrierRateTiersByState", mock.Anything, "SP").Return([]repository.CarrierRateTier{}, nil)
+				repo.On("ListCarrierRestrictedCategories", mock.Anything).Return([]repository.CarrierRestrictedCategory{}, nil)
+				repo.On("ListHolidaysForState", mock.Anything, mock.Anything).Return([]repository.Holiday{}, nil)
+			},
+			expectedPrices:   []float64{47.2, 34.8},
+			expectedBillable: []float64{8, 8},
+		},
+		{
+			name:   "Get quotes using weight tiers",
+			params: service.QuoteParams{StateCode: "SP", WeightKg: 3.0},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				tieredRates := []repository.GetQuotesForPackageRow{rates[0], rates[1]}
+				tieredRates[0].CarrierRegionID = uuid.MustParse("880e8400-e29b-41d4-a716-446655440001")
+				tieredRates[0].MinCharge = "30.00"
+				tieredRates[1].CarrierRegionID = uuid.MustParse("880e8400-e29b-41d4-a716-446655440002")
+
+				tiers := []repository.CarrierRateTier{
+					{CarrierRegionID: tieredRates[0].CarrierRegionID, MinWeightKg: "0.000", MaxWeightKg: "1.000", Price: "12.00"},
+					{CarrierRegionID: tieredRates[0].CarrierRegionID, MinWeightKg: "1.000", MaxWeightKg: "5.000", Price: "25.00"},
+					{CarrierRegionID: tieredRates[1].CarrierRegionID, MinWeightKg: "0.000", MaxWeightKg: "1.000", Price: "9.00"},
+					{CarrierRegionID: tieredRates[1].CarrierRegionID, MinWeightKg: "1.000", MaxWeightKg: "2.000", Price: "15.00"},
+				}
+
+				repo.On("GetStateByCode", mock.Anything, "SP").Return(repository.GetStateByCodeRow{Code: "SP"}, nil)
+				repo.On("GetQuotesForPackage", mock.Anything, repository.GetQuotesForPackageParams{StateCode: "SP"}).Return(tieredRates, nil)
+				repo.On("ListCarrierRateTiersByState", mock.Anything, "SP").Return(tiers, nil)
+				repo.On("ListCarrierRestrictedCategories", mock.Anything).Return([]repository.CarrierRestrictedCategory{}, nil)
+				repo.On("ListHolidaysForState", mock.Anything, mock.Anything).Return([]repository.Holiday{}, nil)
+			},
+			expectedPrices:   []float64{30.0, 19.35},
+			expectedBillable: []float64{3, 3},
+		},
+		{
+			name:   "Get quotes skips carriers that refuse the package",
+			params: service.QuoteParams{StateCode: "SP", WeightKg: 2.5, Category: service.CategoryBattery},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				restrictedRates := []repository.GetQuotesForPackageRow{rates[0], rates[1]}
+				restrictedRates[1].MaxWeightKg = sql.NullFloat64{Float64: 2, Valid: true}
+
+				repo.On("GetStateByCode", mock.Anything, "SP").Return(repository.GetStateByCodeRow{Code: "SP"}, nil)
+				repo.On("GetQuotesForPackage", mock.Anything, repository.GetQuotesForPackageParams{StateCode: "SP"}).Return(restrictedRates, nil)
+				repo.On("ListCarrierRateTiersByState", mock.Anything, "SP").Return([]repository.CarrierRateTier{}, nil)
+				repo.On("ListCarrierRestrictedCategories", mock.Anything).Return([]repository.CarrierRestrictedCategory{
+					{CarrierID: rotaFacilID, Category: service.CategoryBattery},
+				}, nil)
+				repo.On("ListHolidaysForState", mock.Anything, mock.Anything).Return([]repository.Holiday{}, nil)
+			},
+			expectedPrices:   []float64{14.75},
+			expectedBillable: []float64{2.5},
+			expectedReasons:  []string{"peso de 2.50 kg acima do máximo de 2.00 kg", "categoria bateria não aceita"},
+		},
+		{
+			name:   "Get quotes for the origin warehouse lane",
+			params: service.QuoteParams{StateCode: "BA", OriginWarehouseID: "aa0e8400-e29b-41d4-a716-446655440002", WeightKg: 2.5},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				warehouseID := uuid.MustParse("aa0e8400-e29b-41d4-a716-446655440002")
+				laneRates := []repository.GetQuotesForPackageRow{rates[0], rates[1]}
+				laneRates[1].PricePerKg = "5.10"
+
+				repo.On("GetStateByCode", mock.Anything, "BA").Return(repository.GetStateByCodeRow{Code: "BA"}, nil)
+				repo.On("GetWarehouseById", mock.Anything, warehouseID).Return(repository.Warehouse{ID: warehouseID, StateCode: "PE"}, nil)
+				repo.On("GetQuotesForPackage", mock.Anything, repository.GetQuotesForPackageParams{
+					StateCode:   "BA",
+					OriginState: sql.NullString{String: "PE", Valid: true},
+				}).Return(laneRates, nil)
+				repo.On("ListCarrierRateTiersByState", mock.Anything, "BA").Return([]repository.CarrierRateTier{}, nil)
+				repo.On("ListCarrierRestrictedCategories", mock.Anything).Return([]repository.CarrierRestrictedCategory{}, nil)
+				repo.On("ListHolidaysForState", mock.Anything, mock.Anything).Return([]repository.Holiday{}, nil)
+			},
+			expectedPrices:   []float64{14.75, 12.75},
+			expectedBillable: []float64{2.5, 2.5},
+		},
+		{
+			name:   "Get quotes applying CEP surcharge and coverage",
+			params: service.QuoteParams{DestinationCEP: "13010-000", WeightKg: 2.5},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetCepRange", mock.Anything, "13010000").Return(repository.CepRange{StateCode: "SP"}, nil)
+				repo.On("GetStateByCode", mock.Anything, "SP").Return(repository.GetStateByCodeRow{Code: "SP"}, nil)
+				repo.On("GetQuotesForPackage", mock.Anything, repository.GetQuotesForPackageParams{StateCode: "SP"}).Return(rates, nil)
+				repo.On("ListCarrierRateTiersByState", mock.Anything, "SP").Return([]repository.CarrierRateTier{}, nil)
+				repo.On("ListCarrierRestrictedCategories", mock.Anything).Return([]repository.CarrierRestrictedCategory{}, nil)
+				repo.On("ListCarrierCepRules", mock.Anything, "13010000").Return([]repository.CarrierCepRule{
+					{CarrierID: nebulixID, Serviceable: true, Surcharge: "3.50", ExtraDeliveryDays: 1},
+					{CarrierID: nebulixID, Serviceable: false, Surcharge: "0.00"},
+					{CarrierID: rotaFacilID, Serviceable: false, Surcharge: "0.00"},
+				}, nil)
+				repo.On("ListHolidaysForState", mock.Anything, mock.Anything).Return([]repository.Holiday{}, nil)
+			},
+			expectedPrices:   []float64{18.25},
+			expectedBillable: []float64{2.5},
+			expectedReasons:  []string{"CEP 13010000 fora da área de atendimento"},
+		},
+		{
+			name:   "Get quotes for unknown origin warehouse",
+			params: service.QuoteParams{StateCode: "BA", OriginWarehouseID: "not-a-uuid", WeightKg: 2.5},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetStateByCode", mock.Anything, "BA").Return(repository.GetStateByCodeRow{Code: "BA"}, nil)
+			},
+			expectedError: service.ErrWarehouseNotFound.Error(),
+		},
+		{
+			name: "Apply seller negotiated rates",
+			params: service.QuoteParams{
+				StateCode: "SP",
+				WeightKg:  2.5,
+				SellerID:  uuid.NullUUID{UUID: testSellerID, Valid: true},
+			},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetStateByCode", mock.Anything, "SP").Return(repository.GetStateByCodeRow{Code: "SP"}, nil)
+				repo.On("GetQuotesForPackage", mock.Anything, repository.GetQuotesForPackageParams{StateCode: "SP"}).Return(rates, nil)
+				repo.On("ListCarrierRateTiersByState", mock.Anything, "SP").Return([]repository.CarrierRateTier{}, nil)
+				repo.On("ListSellerCarrierRates", mock.Anything, testSellerID).Return([]repository.SellerCarrierRate{
+					{SellerID: testSellerID, CarrierID: nebulixID, PricePerKg: sql.NullString{String: "4.00", Valid: true}, MarkupPercent: "10.00"},
+					{SellerID: testSellerID, CarrierID: rotaFacilID, MarkupPercent: "-10.00"},
+				}, nil)
+				repo.On("ListCarrierRestrictedCategories", mock.Anything).Return([]repository.CarrierRestrictedCategory{}, nil)
+				repo.On("ListHolidaysForState", mock.Anything, mock.Anything).Return([]repository.Holiday{}, nil)
+			},
+			expectedPrices:   []float64{11, 9.79},
+			expectedBillable: []float64{2.5, 2.5},
+		},
+		{
+			name:   "Get quotes for invalid state",
+			params: service.QuoteParams{StateCode: "XX", WeightKg: 2.5},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetStateByCode", mock.Anything, "XX").Return(repository.GetStateByCodeRow{}, sql.ErrNoRows)
+			},
+			expectedError: "invalid state code",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMocked := repository.NewQuerierMocked(t)
+			tt.setupMocked(repoMocked)
+
+			logger := zap.NewNop().Sugar()
+			packageService := service.NewPackageService(repoMocked, config.Config{}, logger)
+
+			result, err := packageService.GetQuotes(context.Background(), tt.params)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				require.Len(t, result.Quotes, len(tt.expectedPrices))
+
+				for i, quote := range result.Quotes {
+					assert.NotEmpty(t, quote.CarrierName)
+					assert.Equal(t, rates[i].CarrierID, quote.CarrierID)
+					assert.Equal(t, tt.expectedBillable[i], quote.BillableWeightKg)
+					assert.Equal(t, tt.expectedPrices[i], quote.EstimatedPrice)
+					assert.Greater(t, quote.EstimatedDeliveryDays, int32(0))
+					assert.True(t, quote.EstimatedDeliveryDate.After(time.Now()))
+				}
+
+				if tt.expectedReasons != nil {
+					require.Len(t, result.Unavailable, 1)
+					assert.Equal(t, rotaFacilID, result.Unavailable[0].CarrierID)
+					assert.Equal(t, tt.expectedReasons, result.Unavailable[0].Reasons)
+				} else {
+					assert.Empty(t, result.Unavailable)
+				}
+			}
+		})
+	}
+}
+
+func TestCarrierCapabilities_Check(t *testing.T) {
+	capabilities := service.CarrierCapabilities{
+		MaxWeightKg:          30,
+		MaxSideCm:            100,
+		MaxDimensionsSumCm:   200,
+		RestrictedCategories: []string{service.CategoryLiquid},
+	}
+
+	tests := []struct {
+		name            string
+		capabilities    service.CarrierCapabilities
+		weightKg        float64
+		dimensions      *service.Dimensions
+		category        string
+		expectedReasons []string
+	}{
+		{
+			name:         "Package accepted",
+			capabilities: capabilities,
+			weightKg:     10,
+			dimensions:   &service.Dimensions{HeightCm: 50, WidthCm: 50, LengthCm: 100},
+			category:     service.CategoryFragile,
+		},
+		{
+			name:       "Carrier without limits accepts anything",
+			weightKg:   500,
+			dimensions: &service.Dimensions{HeightCm: 300, WidthCm: 300, LengthCm: 300},
+			category:   service.CategoryBattery,
+		},
+		{
+			name:            "Weight above limit",
+			capabilities:    capabilities,
+			weightKg:        31,
+			expectedReasons: []string{"peso de 31.00 kg acima do máximo de 30.00 kg"},
+		},
+		{
+			name:         "Side and sum above limits",
+			capabilities: capabilities,
+			weightKg:     1,
+			dimensions:   &service.Dimensions{HeightCm: 60, WidthCm: 40, LengthCm: 120},
+			expectedReasons: []string{
+				"lado de 120 cm acima do máximo de 100 cm",
+				"soma das dimensões de 220 cm acima do máximo de 200 cm",
+			},
+		},
+		{
+			name:            "Restricted category",
+			capabilities:    capabilities,
+			weightKg:        1,
+			category:        service.CategoryLiquid,
+			expectedReasons: []string{"categoria liquido não aceita"},
+		},
+		{
+			name:            "Empty category is general",
+			capabilities:    service.CarrierCapabilities{RestrictedCategories: []string{service.CategoryGeneral}},
+			weightKg:        1,
+			expectedReasons: []string{"categoria geral não aceita"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons := tt.capabilities.Check(tt.weightKg, tt.dimensions, tt.category)
+			assert.Equal(t, tt.expectedReasons, reasons)
+		})
+	}
+}
+
+func TestRateTable_Price(t *testing.T) {
+	tiers := []service.RateTier{
+		{MinWeightKg: 0, MaxWeightKg: 1, Price: 10},
+		{MinWeightKg: 1, MaxWeightKg: 5, Price: 20},
+		{MinWeightKg: 5, MaxWeightKg: 30, Price: 60},
+	}
+
+	tests := []struct {
+		name          string
+		table         service.RateTable
+		weightKg      float64
+		expectedPrice float64
+	}{
+		{
+			name:          "Flat rate without tiers",
+			table:         service.RateTable{PricePerKg: 5.90},
+			weightKg:      2.5,
+			expectedPrice: 14.75,
+		},
+		{
+			name:          "Flat rate below minimum charge",
+			table:         service.RateTable{PricePerKg: 5.90, MinCharge: 12},
+			weightKg:      0.5,
+			expectedPrice: 12,
+		},
+		{
+			name:          "First tier",
+			table:         service.RateTable{Tiers: tiers},
+			weightKg:      0.3,
+			expectedPrice: 10,
+		},
+		{
+			name:          "Weight on tier upper bound",
+			table:         service.RateTable{Tiers: tiers},
+			weightKg:      5,
+			expectedPrice: 20,
+		},
+		{
+			name:          "Middle tier",
+			table:         service.RateTable{Tiers: tiers},
+			weightKg:      12.4,
+			expectedPrice: 60,
+		},
+		{
+			name:          "Excess above last tier",
+			table:         service.RateTable{Tiers: tiers, ExcessPricePerKg: 2.5},
+			weightKg:      32,
+			expectedPrice: 65,
+		},
+		{
+			name:          "Tier below minimum charge",
+			table:         service.RateTable{Tiers: tiers, MinCharge: 15},
+			weightKg:      0.8,
+			expectedPrice: 15,
+		},
+		{
+			name:          "Markup applied over the minimum charge",
+			table:         service.RateTable{PricePerKg: 5.90, MinCharge: 12, MarkupPercent: 10},
+			weightKg:      0.5,
+			expectedPrice: 13.2,
+		},
+		{
+			name:          "Negative markup is a discount",
+			table:         service.RateTable{Tiers: tiers, MarkupPercent: -25},
+			weightKg:      3,
+			expectedPrice: 15,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expectedPrice, tt.table.Price(tt.weightKg))
+		})
+	}
+}
+
+func TestPackageService_HireCarrier(t *testing.T) {
+	pkgUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	carrierUUID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+	quoteUUID := uuid.MustParse("990e8400-e29b-41d4-a716-446655440001")
+	regionUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+
+	newPackage := func(status, state string) repository.Package {
+		return repository.Package{
+			ID:               pkgUUID,
+			TrackingCode:     sql.NullString{Valid: false},
+			Product:          "Test Product",
+			WeightKg:         2.5,
+			DestinationState: state,
+			Status:           status,
+		}
+	}
+	newQuote := func() repository.Quote {
+		return repository.Quote{
+			ID:                    quoteUUID,
+			PackageID:             pkgUUID,
+			CarrierID:             carrierUUID,
+			Price:                 "14.75",
+			EstimatedDeliveryDays: 4,
+			BillableWeightKg:      2.5,
+			ExpiresAt:             time.Now().Add(time.Minute),
+		}
+	}
+	acceptedByCarrier := func(repo *repository.QuerierMocked) {
+		repo.On("GetCarrierById", mock.Anything, carrierUUID).Return(repository.Carrier{
+			ID:             carrierUUID,
+			Name:           "Nebulix Logística",
+			TrackingPrefix: "NB",
+			MaxWeightKg:    sql.NullFloat64{Float64: 30, Valid: true},
+		}, nil)
+		repo.On("ListCarrierRestrictedCategories", mock.Anything).Return([]repository.CarrierRestrictedCategory{
+			{CarrierID: carrierUUID, Category: service.CategoryBattery},
+		}, nil)
+	}
+	coveredRegion := func(repo *repository.QuerierMocked) {
+		repo.On("GetRegionByState", mock.Anything, "SP").Return(repository.GetRegionByStateRow{ID: regionUUID, Name: "Sudeste"}, nil)
+		repo.On("GetCarrierRegions", mock.Anything, carrierUUID).Return([]repository.GetCarrierRegionsRow{
+			{
+				ID:                    uuid.New(),
+				CarrierID:             carrierUUID,
+				RegionID:              regionUUID,
+				EstimatedDeliveryDays: 4,
+				PricePerKg:            "5.90",
+				RegionName:            "Sudeste",
+			},
+		}, nil)
+	}
+
+	tests := []struct {
+		name          string
+		packageID     string
+		quoteID       string
+		setupMocked   func(repo *repository.QuerierMocked)
+		expectedError error
+		errorContains string
+	}{
+		{
+			name:      "Hire carrier successfully",
+			packageID: pkgUUID.String(),
+			quoteID:   quoteUUID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: pkgUUID}).Return(newPackage("criado", "SP"), nil)
+				repo.On("GetQuoteById", mock.Anything, quoteUUID).Return(newQuote(), nil)
+				coveredRegion(repo)
+				acceptedByCarrier(repo)
+				repo.On("ListHolidaysForState", mock.Anything, sql.NullString{String: "SP", Valid: true}).Return([]repository.Holiday{}, nil)
+				repo.On("MarkQuoteUsed", mock.Anything, quoteUUID).Return(int64(1), nil)
+				repo.On("NextTrackingSerial", mock.Anything, "NB").Return(int64(47312482), nil)
+
+				repo.On("HireCarrier", mock.Anything, mock.MatchedBy(func(arg repository.HireCarrierParams) bool {
+					return arg.ID == pkgUUID &&
+						arg.TrackingCode == sql.NullString{String: "NB473124829BR", Valid: true} &&
+						arg.HiredCarrierID == uuid.NullUUID{UUID: carrierUUID, Valid: true} &&
+						arg.HiredPrice == sql.NullString{String: "14.75", Valid: true} &&
+						arg.HiredDeliveryDays == sql.NullInt32{Int32: 4, Valid: true} &&
+						arg.EstimatedDeliveryDate.Valid &&
+						arg.EstimatedDeliveryDate.Time.After(time.Now())
+				})).Return(int64(1), nil)
+				repo.On("CreatePackageStatusEvent", mock.Anything, mock.MatchedBy(func(arg repository.CreatePackageStatusEventParams) bool {
+					return arg.PackageID == pkgUUID &&
+						arg.FromStatus == "criado" &&
+						arg.ToStatus == "esperando_coleta"
+				})).Return(repository.PackageStatusEvent{}, nil)
+			},
+		},
+		{
+			name:      "Hire carrier for package already hired",
+			packageID: pkgUUID.String(),
+			quoteID:   quoteUUID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: pkgUUID}).Return(newPackage("coletado", "SP"), nil)
+			},
+			expectedError: service.ErrInvalidStatusTransition,
+		},
+		{
+			name:          "Hire carrier with invalid package UUID",
+			packageID:     "invalid-uuid",
+			quoteID:       quoteUUID.String(),
+			setupMocked:   func(repo *repository.QuerierMocked) {},
+			errorContains: "parse package id",
+		},
+		{
+			name:      "Hire carrier with unknown package",
+			packageID: pkgUUID.String(),
+			quoteID:   quoteUUID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: pkgUUID}).Return(repository.Package{}, sql.ErrNoRows)
+			},
+			expectedError: service.ErrPackageNotFound,
+		},
+		{
+			name:      "Hire carrier keeps database errors",
+			packageID: pkgUUID.String(),
+			quoteID:   quoteUUID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: pkgUUID}).Return(repository.Package{}, sql.ErrConnDone)
+			},
+			errorContains: "get package by id: sql: connection is already closed",
+		},
+		{
+			name:      "Hire carrier with invalid quote UUID",
+			packageID: pkgUUID.String(),
+			quoteID:   "invalid-uuid",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: pkgUUID}).Return(newPackage("criado", "SP"), nil)
+			},
+			errorContains: "invalid quote ID",
+		},
+		{
+			name:      "Hire carrier with unknown quote",
+			packageID: pkgUUID.String(),
+			quoteID:   quoteUUID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: pkgUUID}).Return(newPackage("criado", "SP"), nil)
+				repo.On("GetQuoteById", mock.Anything, quoteUUID).Return(repository.Quote{}, sql.ErrNoRows)
+			},
+			expectedError: service.ErrQuoteNotFound,
+		},
+		{
+			name:      "Hire carrier with quote of another package",
+			packageID: pkgUUID.String(),
+			quoteID:   quoteUUID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: pkgUUID}).Return(newPackage("criado", "SP"), nil)
+				quote := newQuote()
+				quote.PackageID = uuid.New()
+				repo.On("GetQuoteById", mock.Anything, quoteUUID).Return(quote, nil)
+			},
+			expectedError: service.ErrQuoteNotFound,
+		},
+		{
+			name:      "Hire carrier with used quote",
+			packageID: pkgUUID.String(),
+			quoteID:   quoteUUID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: pkgUUID}).Return(newPackage("criado", "SP"), nil)
+				quote := newQuote()
+				quote.UsedAt = sql.NullTime{Time: time.Now(), Valid: true}
+				repo.On("GetQuoteById", mock.Anything, quoteUUID).Return(quote, nil)
+			},
+			expectedError: service.ErrQuoteAlreadyUsed,
+		},
+		{
+			name:      "Hire carrier with expired quote",
+			packageID: pkgUUID.String(),
+			quoteID:   quoteUUID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: pkgUUID}).Return(newPackage("criado", "SP"), nil)
+				repo.On("GetQuoteById", mock.Anything, quoteUUID).Return(newQuote(), nil)
+				coveredRegion(repo)
+				acceptedByCarrier(repo)
+				repo.On("ListHolidaysForState", mock.Anything, sql.NullString{String: "SP", Valid: true}).Return([]repository.Holiday{}, nil)
+				repo.On("MarkQuoteUsed", mock.Anything, quoteUUID).Return(int64(0), nil)
+			},
+			expectedError: service.ErrQuoteExpired,
+		},
+		{
+			name:      "Hire carrier that refuses the package category",
+			packageID: pkgUUID.String(),
+			quoteID:   quoteUUID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				pkg := newPackage("criado", "SP")
+				pkg.ProductCategory = service.CategoryBattery
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: pkgUUID}).Return(pkg, nil)
+				repo.On("GetQuoteById", mock.Anything, quoteUUID).Return(newQuote(), nil)
+				coveredRegion(repo)
+				acceptedByCarrier(repo)
+			},
+			expectedError: service.ErrCarrierRestricted,
+		},
+		{
+			name:      "Hire carrier that does not cover the destination CEP",
+			packageID: pkgUUID.String(),
+			quoteID:   quoteUUID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				pkg := newPackage("criado", "SP")
+				pkg.DestinationCep = sql.NullString{String: "13010000", Valid: true}
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: pkgUUID}).Return(pkg, nil)
+				repo.On("GetQuoteById", mock.Anything, quoteUUID).Return(newQuote(), nil)
+				coveredRegion(repo)
+				acceptedByCarrier(repo)
+				repo.On("ListCarrierCepRules", mock.Anything, "13010000").Return([]repository.CarrierCepRule{
+					{CarrierID: carrierUUID, Serviceable: false, Surcharge: "0.00"},
+				}, nil)
+			},
+			expectedError: service.ErrCarrierRestricted,
+		},
+		{
+			name:      "Hire carrier on a lane served only from another origin",
+			packageID: pkgUUID.String(),
+			quoteID:   quoteUUID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: pkgUUID}).Return(newPackage("criado", "BA"), nil)
+				repo.On("GetQuoteById", mock.Anything, quoteUUID).Return(newQuote(), nil)
+
+				nordesteID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440004")
+				repo.On("GetRegionByState", mock.Anything, "BA").Return(repository.GetRegionByStateRow{ID: nordesteID, Name: "Nordeste"}, nil)
+				repo.On("GetCarrierRegions", mock.Anything, carrierUUID).Return([]repository.GetCarrierRegionsRow{
+					{
+						ID:             uuid.New(),
+						CarrierID:      carrierUUID,
+						RegionID:       nordesteID,
+						OriginRegionID: uuid.NullUUID{UUID: nordesteID, Valid: true},
+					},
+				}, nil)
+			},
+			errorContains: "carrier does not serve this region",
+		},
+		{
+			name:      "Hire carrier that does not serve region",
+			packageID: pkgUUID.String(),
+			quoteID:   quoteUUID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: pkgUUID}).Return(newPackage("criado", "AM"), nil)
+				repo.On("GetQuoteById", mock.Anything, quoteUUID).Return(newQuote(), nil)
+
+				mockRegion := repository.GetRegionByStateRow{
+					ID:   uuid.MustParse("550e8400-e29b-41d4-a716-446655440005"),
+					Name: "Norte",
+				}
+				repo.On("GetRegionByState", mock.Anything, "AM").Return(mockRegion, nil)
+				repo.On("GetCarrierRegions", mock.Anything, carrierUUID).Return([]repository.GetCarrierRegionsRow{}, nil)
+			},
+			errorContains: "carrier does not serve this region",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMocked := repository.NewQuerierMocked(t)
+			tt.setupMocked(repoMocked)
+
+			logger := zap.NewNop().Sugar()
+			packageService := service.NewPackageService(repoMocked, config.Config{}, logger)
+
+			_, err := packageService.HireCarrier(context.Background(), tt.packageID, tt.quoteID, uuid.NullUUID{}, "tester", 0)
+
+			switch {
+			case tt.expectedError != nil:
+				assert.ErrorIs(t, err, tt.expectedError)
+			case tt.errorContains != "":
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+			default:
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPackageService_CreatePackageQuotes(t *testing.T) {
+	pkgUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	carrierUUID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+
+	tests := []struct {
+		name          string
+		status        string
+		quoteTTL      time.Duration
+		setupMocked   func(repo *repository.QuerierMocked)
+		expectedCount int
+		expectedError error
 	}{
 		{
-			name:         "Hire carrier successfully",
-			packageID:    "550e8400-e29b-41d4-a716-446655440000",
-			carrierID:    "660e8400-e29b-41d4-a716-446655440001",
-			price:        "25.90",
-			deliveryDays: 5,
+			name:     "Persist quotes with configured TTL",
+			status:   "criado",
+			quoteTTL: 10 * time.Minute,
 			setupMocked: func(repo *repository.QuerierMocked) {
-				expectedPkgUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
-				expectedCarrierUUID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
-				regionUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+				repo.On("GetStateByCode", mock.Anything, "SP").Return(repository.GetStateByCodeRow{Code: "SP"}, nil)
+				repo.On("GetQuotesForPackage", mock.Anything, repository.GetQuotesForPackageParams{StateCode: "SP"}).Return([]repository.GetQuotesForPackageRow{
+					{
+						CarrierID:             carrierUUID,
+						CarrierName:           "Nebulix Logística",
+						PricePerKg:            "5.90",
+						MinCharge:             "0.00",
+						CubingDivisor:         6000,
+						EstimatedDeliveryDays: 4,
+					},
+				}, nil)
+				repo.On("ListCarrierRateTiersByState", mock.Anything, "SP").Return([]repository.CarrierRateTier{}, nil)
+				repo.On("ListSellerCarrierRates", mock.Anything, testSellerID).Return([]repository.SellerCarrierRate{}, nil)
+				repo.On("ListCarrierRestrictedCategories", mock.Anything).Return([]repository.CarrierRestrictedCategory{}, nil)
+				repo.On("ListHolidaysForState", mock.Anything, mock.Anything).Return([]repository.Holiday{}, nil)
+				repo.On("CreateQuote", mock.Anything, repository.CreateQuoteParams{
+					PackageID:             pkgUUID,
+					CarrierID:             carrierUUID,
+					Price:                 "14.75",
+					EstimatedDeliveryDays: 4,
+					BillableWeightKg:      2.5,
+					TtlSeconds:            600,
+				}).Return(repository.Quote{ID: uuid.New(), ExpiresAt: time.Now().Add(10 * time.Minute)}, nil)
+			},
+			expectedCount: 1,
+		},
+		{
+			name:          "Reject quotes for package already hired",
+			status:        "esperando_coleta",
+			setupMocked:   func(repo *repository.QuerierMocked) {},
+			expectedError: service.ErrInvalidStatusTransition,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMocked := repository.NewQuerierMocked(t)
+			repoMocked.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: pkgUUID}).Return(repository.Package{
+				ID:               pkgUUID,
+				SellerID:         testSellerID,
+				Product:          "Test Product",
+				WeightKg:         2.5,
+				DestinationState: "SP",
+				Status:           tt.status,
+			}, nil)
+			tt.setupMocked(repoMocked)
+
+			logger := zap.NewNop().Sugar()
+			packageService := service.NewPackageService(repoMocked, config.Config{QuoteTTL: tt.quoteTTL}, logger)
+
+			result, err := packageService.CreatePackageQuotes(context.Background(), pkgUUID.String(), uuid.NullUUID{})
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, result.Quotes, tt.expectedCount)
+			assert.NotEqual(t, uuid.Nil, result.Quotes[0].ID)
+			assert.False(t, result.Quotes[0].ExpiresAt.IsZero())
+		})
+	}
+}
+
+func TestPackageService_ImportPackages(t *testing.T) {
+	validRow := func(line int, product string) service.ImportRow {
+		return service.ImportRow{
+			Line:  line,
+			Input: service.CreatePackageInput{Product: product, WeightKg: 1.5, DestinationState: "SP"},
+		}
+	}
+	invalidRow := service.ImportRow{Line: 3, Errors: []string{"peso_kg deve ser numérico"}}
+
+	tests := []struct {
+		name             string
+		rows             []service.ImportRow
+		mode             service.ImportMode
+		setupMocked      func(repo *repository.QuerierMocked)
+		expectedStatuses []string
+		expectedImported int
+		expectedFailed   int
+		expectedRejected bool
+		expectedError    string
+	}{
+		{
+			name: "Partial import inserts valid rows and reports invalid ones",
+			rows: []service.ImportRow{validRow(2, "Produto A"), invalidRow, validRow(4, "Produto B")},
+			mode: service.ImportModePartial,
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID, Name: "Loja Olist"}, nil)
+				repo.On("CreatePackagesBatch", mock.Anything, mock.MatchedBy(func(arg repository.CreatePackagesBatchParams) bool {
+					var batch []map[string]interface{}
+					if arg.SellerID != testSellerID || json.Unmarshal(arg.Packages, &batch) != nil {
+						return false
+					}
+					return len(batch) == 2 && batch[0]["product"] == "Produto A" && batch[1]["product"] == "Produto B"
+				})).Return([]repository.Package{{ID: uuid.New()}, {ID: uuid.New()}}, nil)
+			},
+			expectedStatuses: []string{service.ImportStatusImported, service.ImportStatusFailed, service.ImportStatusImported},
+			expectedImported: 2,
+			expectedFailed:   1,
+		},
+		{
+			name: "All or nothing import is rejected when a row has errors",
+			rows: []service.ImportRow{validRow(2, "Produto A"), invalidRow},
+			mode: service.ImportModeAllOrNothing,
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID, Name: "Loja Olist"}, nil)
+			},
+			expectedStatuses: []string{service.ImportStatusSkipped, service.ImportStatusFailed},
+			expectedFailed:   1,
+			expectedRejected: true,
+		},
+		{
+			name: "Row with unknown warehouse is reported as failed",
+			rows: []service.ImportRow{{
+				Line: 2,
+				Input: service.CreatePackageInput{
+					Product:           "Produto A",
+					WeightKg:          1.5,
+					DestinationState:  "SP",
+					OriginWarehouseID: "aa0e8400-e29b-41d4-a716-446655440099",
+				},
+			}},
+			mode: service.ImportModePartial,
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID, Name: "Loja Olist"}, nil)
+				repo.On("GetWarehouseById", mock.Anything, uuid.MustParse("aa0e8400-e29b-41d4-a716-446655440099")).Return(repository.Warehouse{}, sql.ErrNoRows)
+			},
+			expectedStatuses: []string{service.ImportStatusFailed},
+			expectedFailed:   1,
+		},
+		{
+			name:          "Empty file",
+			rows:          nil,
+			mode:          service.ImportModePartial,
+			setupMocked:   func(repo *repository.QuerierMocked) {},
+			expectedError: service.ErrInvalidImport.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMocked := repository.NewQuerierMocked(t)
+			tt.setupMocked(repoMocked)
+
+			logger := zap.NewNop().Sugar()
+			packageService := service.NewPackageService(repoMocked, config.Config{}, logger)
+
+			report, err := packageService.ImportPackages(context.Background(), testSellerID.String(), tt.rows, tt.mode)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, report.Rows, len(tt.expectedStatuses))
+			for i, status := range tt.expectedStatuses {
+				assert.Equal(t, status, report.Rows[i].Status)
+				assert.Equal(t, tt.rows[i].Line, report.Rows[i].Line)
+				if status == service.ImportStatusImported {
+					assert.NotEqual(t, uuid.Nil, report.Rows[i].PackageID)
+				}
+			}
+			assert.Equal(t, tt.expectedImported, report.Imported)
+			assert.Equal(t, tt.expectedFailed, report.Failed)
+			assert.Equal(t, tt.expectedRejected, report.Rejected())
+		})
+	}
+}
+
+func TestPackageService_GetLabel(t *testing.T) {
+	packageID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	carrierID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+	warehouseID := uuid.MustParse("aa0e8400-e29b-41d4-a716-446655440001")
+
+	hiredPackage := func() repository.Package {
+		return repository.Package{
+			ID:                    packageID,
+			TrackingCode:          sql.NullString{String: "BR12345678", Valid: true},
+			Product:               "Camisa",
+			WeightKg:              0.6,
+			DestinationState:      "SP",
+			Status:                "esperando_coleta",
+			HiredCarrierID:        uuid.NullUUID{UUID: carrierID, Valid: true},
+			EstimatedDeliveryDate: sql.NullTime{Time: time.Date(2026, 11, 3, 0, 0, 0, 0, time.UTC), Valid: true},
+			OriginWarehouseID:     uuid.NullUUID{UUID: warehouseID, Valid: true},
+			DestinationCep:        sql.NullString{String: "01310100", Valid: true},
+			DestinationCity:       sql.NullString{String: "São Paulo", Valid: true},
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		packageID            string
+		setupMocked          func(repo *repository.QuerierMocked)
+		expectedTrackingCode string
+		expectedRecipient    []string
+		expectedSender       []string
+		expectedError        string
+	}{
+		{
+			name:      "Label for hired package",
+			packageID: packageID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(hiredPackage(), nil)
+				repo.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID, Name: "Nebulix Logística"}, nil)
+				repo.On("GetWarehouseById", mock.Anything, warehouseID).Return(repository.Warehouse{ID: warehouseID, Name: "CD Cajamar", StateCode: "SP"}, nil)
+			},
+			expectedTrackingCode: "BR12345678",
+			expectedRecipient:    []string{"São Paulo - SP", "CEP 01310-100"},
+			expectedSender:       []string{"CD Cajamar - SP"},
+		},
+		{
+			name:      "Label without origin warehouse or destination city",
+			packageID: packageID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				pkg := hiredPackage()
+				pkg.OriginWarehouseID = uuid.NullUUID{}
+				pkg.DestinationCep = sql.NullString{}
+				pkg.DestinationCity = sql.NullString{}
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(pkg, nil)
+				repo.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID, Name: "Nebulix Logística"}, nil)
+			},
+			expectedTrackingCode: "BR12345678",
+			expectedRecipient:    []string{"SP"},
+		},
+		{
+			// The label only reads the package, so it never issues a code
+			name:      "Label for hired package without tracking code",
+			packageID: packageID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				pkg := hiredPackage()
+				pkg.TrackingCode = sql.NullString{}
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(pkg, nil)
+			},
+			expectedError: service.ErrTrackingCodeMissing.Error(),
+		},
+		{
+			name:      "Label for package without hired carrier",
+			packageID: packageID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				pkg := hiredPackage()
+				pkg.Status = "criado"
+				pkg.HiredCarrierID = uuid.NullUUID{}
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(pkg, nil)
+			},
+			expectedError: service.ErrPackageNotHired.Error(),
+		},
+		{
+			name:      "Label for unknown package",
+			packageID: packageID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(repository.Package{}, sql.ErrNoRows)
+			},
+			expectedError: service.ErrPackageNotFound.Error(),
+		},
+		{
+			name:          "Label with invalid package id",
+			packageID:     "invalid-uuid",
+			setupMocked:   func(repo *repository.QuerierMocked) {},
+			expectedError: "parse package id",
+		},
+	}
 
-				mockPackage := repository.Package{
-					ID:               expectedPkgUUID,
-					TrackingCode:     sql.NullString{Valid: false},
-					Product:          "Test Product",
-					WeightKg:         2.5,
-					DestinationState: "SP",
-					Status:           "criado",
-				}
-				repo.On("GetPackageById", mock.Anything, expectedPkgUUID).Return(mockPackage, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMocked := repository.NewQuerierMocked(t)
+			tt.setupMocked(repoMocked)
 
-				mockRegion := repository.GetRegionByStateRow{
-					ID:   regionUUID,
-					Name: "Sudeste",
-				}
-				repo.On("GetRegionByState", mock.Anything, "SP").Return(mockRegion, nil)
+			logger := zap.NewNop().Sugar()
+			packageService := service.NewPackageService(repoMocked, config.Config{LabelSenderName: "Olist"}, logger)
 
-				mockCarrierRegions := []repository.GetCarrierRegionsRow{
-					{
-						ID:                    uuid.New(),
-						CarrierID:             expectedCarrierUUID,
-						RegionID:              regionUUID,
-						EstimatedDeliveryDays: 4,
-						PricePerKg:            "5.90",
-						RegionName:            "Sudeste",
-					},
-				}
-				repo.On("GetCarrierRegions", mock.Anything, expectedCarrierUUID).Return(mockCarrierRegions, nil)
+			shippingLabel, err := packageService.GetLabel(context.Background(), tt.packageID, uuid.NullUUID{})
 
-				expectedParams := repository.HireCarrierParams{
-					ID: expectedPkgUUID,
-					HiredCarrierID: uuid.NullUUID{
-						UUID:  expectedCarrierUUID,
-						Valid: true,
-					},
-					HiredPrice: sql.NullString{
-						String: "25.90",
-						Valid:  true,
-					},
-					HiredDeliveryDays: sql.NullInt32{
-						Int32: 5,
-						Valid: true,
-					},
-				}
-				repo.On("HireCarrier", mock.Anything, expectedParams).Return(nil)
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			if tt.expectedTrackingCode != "" {
+				assert.Equal(t, tt.expectedTrackingCode, shippingLabel.TrackingCode)
+			} else {
+				assert.NotEmpty(t, shippingLabel.TrackingCode)
+			}
+			assert.Equal(t, "Nebulix Logística", shippingLabel.Carrier)
+			assert.Equal(t, "Olist", shippingLabel.Sender.Name)
+			assert.Equal(t, tt.expectedSender, shippingLabel.Sender.Lines)
+			assert.Equal(t, tt.expectedRecipient, shippingLabel.Recipient.Lines)
+			assert.Contains(t, shippingLabel.QRData, shippingLabel.TrackingCode)
+			assert.Contains(t, shippingLabel.QRData, packageID.String())
+		})
+	}
+}
+
+func TestStatusPath(t *testing.T) {
+	assert.Equal(t, []string{"coletado"}, service.StatusPath("esperando_coleta", "coletado"))
+	assert.Equal(t, []string{"coletado", "enviado", "entregue"}, service.StatusPath("esperando_coleta", "entregue"))
+	assert.Equal(t, []string{"extraviado"}, service.StatusPath("enviado", "extraviado"))
+	assert.Empty(t, service.StatusPath("enviado", "enviado"))
+	assert.Empty(t, service.StatusPath("entregue", "coletado"))
+	assert.Empty(t, service.StatusPath("extraviado", "entregue"))
+}
+
+func TestPackageService_AuthenticateCarrier(t *testing.T) {
+	carrierID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+	tokenHash := sha256Hex("ctk_valid-token")
+
+	tests := []struct {
+		name          string
+		carrierID     string
+		token         string
+		setupMocked   func(repo *repository.QuerierMocked)
+		expectedError error
+	}{
+		{
+			name:      "Valid token",
+			carrierID: carrierID.String(),
+			token:     "ctk_valid-token",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetCarrierApiTokenHash", mock.Anything, carrierID).Return(tokenHash, nil)
+				repo.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID, Name: "Nebulix Logística"}, nil)
 			},
 		},
 		{
-			name:          "Hire carrier with invalid package UUID",
-			packageID:     "invalid-uuid",
-			carrierID:     "660e8400-e29b-41d4-a716-446655440001",
-			price:         "25.90",
-			deliveryDays:  5,
-			setupMocked:   func(repo *repository.QuerierMocked) {},
-			expectedError: "package not found",
+			name:      "Wrong token",
+			carrierID: carrierID.String(),
+			token:     "ctk_other-token",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetCarrierApiTokenHash", mock.Anything, carrierID).Return(tokenHash, nil)
+			},
+			expectedError: service.ErrCarrierUnauthorized,
 		},
 		{
-			name:         "Hire carrier with invalid carrier UUID",
-			packageID:    "550e8400-e29b-41d4-a716-446655440000",
-			carrierID:    "invalid-uuid",
-			price:        "25.90",
-			deliveryDays: 5,
+			name:      "Carrier without token",
+			carrierID: carrierID.String(),
+			token:     "ctk_valid-token",
 			setupMocked: func(repo *repository.QuerierMocked) {
-				expectedPkgUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
-
-				mockPackage := repository.Package{
-					ID:               expectedPkgUUID,
-					TrackingCode:     sql.NullString{Valid: false},
-					Product:          "Test Product",
-					WeightKg:         2.5,
-					DestinationState: "SP",
-					Status:           "criado",
-				}
-				repo.On("GetPackageById", mock.Anything, expectedPkgUUID).Return(mockPackage, nil)
+				repo.On("GetCarrierApiTokenHash", mock.Anything, carrierID).Return("", sql.ErrNoRows)
 			},
-			expectedError: "invalid carrier ID",
+			expectedError: service.ErrCarrierUnauthorized,
 		},
 		{
-			name:         "Hire carrier that does not serve region",
-			packageID:    "550e8400-e29b-41d4-a716-446655440000",
-			carrierID:    "660e8400-e29b-41d4-a716-446655440001",
-			price:        "25.90",
-			deliveryDays: 5,
+			name:      "Deleted carrier",
+			carrierID: carrierID.String(),
+			token:     "ctk_valid-token",
 			setupMocked: func(repo *repository.QuerierMocked) {
-				expectedPkgUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
-				expectedCarrierUUID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
-				regionUUID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440005")
+				repo.On("GetCarrierApiTokenHash", mock.Anything, carrierID).Return(tokenHash, nil)
+				repo.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{
+					ID:        carrierID,
+					DeletedAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+			},
+			expectedError: service.ErrCarrierUnauthorized,
+		},
+		{
+			name:          "Missing token",
+			carrierID:     carrierID.String(),
+			setupMocked:   func(repo *repository.QuerierMocked) {},
+			expectedError: service.ErrCarrierUnauthorized,
+		},
+	}
 
-				mockPackage := repository.Package{
-					ID:               expectedPkgUUID,
-					TrackingCode:     sql.NullString{Valid: false},
-					Product:          "Test Product",
-					WeightKg:         2.5,
-					DestinationState: "AM",
-					Status:           "criado",
-				}
-				repo.On("GetPackageById", mock.Anything, expectedPkgUUID).Return(mockPackage, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMocked := repository.NewQuerierMocked(t)
+			tt.setupMocked(repoMocked)
 
-				mockRegion := repository.GetRegionByStateRow{
-					ID:   regionUUID,
-					Name: "Norte",
-				}
-				repo.On("GetRegionByState", mock.Anything, "AM").Return(mockRegion, nil)
+			packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+			carrier, err := packageService.AuthenticateCarrier(context.Background(), tt.carrierID, tt.token)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, carrierID, carrier.ID)
+			}
+		})
+	}
+}
+
+func TestPackageService_RotateCarrierToken(t *testing.T) {
+	carrierID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+
+	repoMocked := repository.NewQuerierMocked(t)
+	repoMocked.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID}, nil)
+
+	var storedHash string
+	repoMocked.On("UpsertCarrierApiToken", mock.Anything, mock.MatchedBy(func(arg repository.UpsertCarrierApiTokenParams) bool {
+		storedHash = arg.TokenHash
+		return arg.CarrierID == carrierID
+	})).Return(nil)
+
+	packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+	token, err := packageService.RotateCarrierToken(context.Background(), carrierID.String())
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, "ctk_"))
+	assert.Equal(t, sha256Hex(token), storedHash, "only the token hash is stored")
+}
+
+func TestPackageService_IngestCarrierEvent(t *testing.T) {
+	carrier := repository.Carrier{
+		ID:   uuid.MustParse("660e8400-e29b-41d4-a716-446655440001"),
+		Name: "Nebulix Logística",
+	}
+	packageID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	trackingCode := sql.NullString{String: "NB000000014BR", Valid: true}
+	occurredAt := time.Date(2026, 10, 17, 9, 30, 0, 0, time.FixedZone("BRT", -3*60*60))
+
+	hiredPackage := func(status string) repository.Package {
+		return repository.Package{
+			ID:             packageID,
+			Status:         status,
+			TrackingCode:   trackingCode,
+			HiredCarrierID: uuid.NullUUID{UUID: carrier.ID, Valid: true},
+		}
+	}
+	mapping := func(code, status string) repository.CarrierEventMapping {
+		return repository.CarrierEventMapping{
+			CarrierID:   carrier.ID,
+			CarrierCode: code,
+			Status:      sql.NullString{String: status, Valid: status != ""},
+		}
+	}
 
-				mockCarrierRegions := []repository.GetCarrierRegionsRow{}
-				repo.On("GetCarrierRegions", mock.Anything, expectedCarrierUUID).Return(mockCarrierRegions, nil)
+	tests := []struct {
+		name             string
+		code             string
+		setupMocked      func(repo *repository.QuerierMocked)
+		expectedStatus   string
+		expectedDup      bool
+		expectedError    error
+		expectedErrorMsg string
+	}{
+		{
+			name: "Collection scan moves package to coletado",
+			code: "COL",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageByTrackingCode", mock.Anything, repository.GetPackageByTrackingCodeParams{TrackingCode: trackingCode}).Return(hiredPackage("esperando_coleta"), nil)
+				repo.On("GetCarrierEventMapping", mock.Anything, repository.GetCarrierEventMappingParams{CarrierID: carrier.ID, CarrierCode: "COL"}).
+					Return(mapping("COL", "coletado"), nil)
+				repo.On("CreateCarrierTrackingEvent", mock.Anything, mock.MatchedBy(func(arg repository.CreateCarrierTrackingEventParams) bool {
+					return arg.CarrierEventID == "NB-1" &&
+						arg.PackageID == packageID &&
+						arg.MappedStatus.String == "coletado" &&
+						arg.OccurredAt.Equal(occurredAt) &&
+						arg.OccurredAt.Location() == time.UTC
+				})).Return(repository.CarrierTrackingEvent{ID: uuid.New()}, nil)
+
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(hiredPackage("esperando_coleta"), nil).Once()
+				repo.On("UpdatePackageStatus", mock.Anything, repository.UpdatePackageStatusParams{ID: packageID, Status: "coletado"}).Return(int64(1), nil)
+				repo.On("CreatePackageStatusEvent", mock.Anything, mock.MatchedBy(func(arg repository.CreatePackageStatusEventParams) bool {
+					return arg.ToStatus == "coletado" &&
+						arg.Actor == "transportadora:Nebulix Logística" &&
+						arg.Reason.String == "evento COL (NB-1)"
+				})).Return(repository.PackageStatusEvent{}, nil)
+			},
+			expectedStatus: "coletado",
+		},
+		{
+			name: "Delivered scan walks through missing statuses",
+			code: "ENT",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageByTrackingCode", mock.Anything, repository.GetPackageByTrackingCodeParams{TrackingCode: trackingCode}).Return(hiredPackage("coletado"), nil)
+				repo.On("GetCarrierEventMapping", mock.Anything, mock.Anything).Return(mapping("ENT", "entregue"), nil)
+				repo.On("CreateCarrierTrackingEvent", mock.Anything, mock.Anything).Return(repository.CarrierTrackingEvent{ID: uuid.New()}, nil)
+
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(hiredPackage("coletado"), nil).Once()
+				repo.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(hiredPackage("enviado"), nil).Once()
+				repo.On("UpdatePackageStatus", mock.Anything, repository.UpdatePackageStatusParams{ID: packageID, Status: "enviado"}).Return(int64(1), nil).Once()
+				repo.On("UpdatePackageStatus", mock.Anything, repository.UpdatePackageStatusParams{ID: packageID, Status: "entregue"}).Return(int64(1), nil).Once()
+				repo.On("CreatePackageStatusEvent", mock.Anything, mock.Anything).Return(repository.PackageStatusEvent{}, nil).Twice()
+			},
+			expectedStatus: "entregue",
+		},
+		{
+			name: "Duplicate event is acknowledged without changes",
+			code: "COL",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageByTrackingCode", mock.Anything, repository.GetPackageByTrackingCodeParams{TrackingCode: trackingCode}).Return(hiredPackage("coletado"), nil)
+				repo.On("GetCarrierEventMapping", mock.Anything, mock.Anything).Return(mapping("COL", "coletado"), nil)
+				repo.On("CreateCarrierTrackingEvent", mock.Anything, mock.Anything).Return(repository.CarrierTrackingEvent{}, sql.ErrNoRows)
+				repo.On("GetCarrierTrackingEvent", mock.Anything, repository.GetCarrierTrackingEventParams{CarrierID: carrier.ID, CarrierEventID: "NB-1"}).
+					Return(repository.CarrierTrackingEvent{ID: uuid.New()}, nil)
+			},
+			expectedStatus: "coletado",
+			expectedDup:    true,
+		},
+		{
+			name: "Out of order scan does not move package back",
+			code: "COL",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageByTrackingCode", mock.Anything, repository.GetPackageByTrackingCodeParams{TrackingCode: trackingCode}).Return(hiredPackage("enviado"), nil)
+				repo.On("GetCarrierEventMapping", mock.Anything, mock.Anything).Return(mapping("COL", "coletado"), nil)
+				repo.On("CreateCarrierTrackingEvent", mock.Anything, mock.Anything).Return(repository.CarrierTrackingEvent{ID: uuid.New()}, nil)
+			},
+			expectedStatus: "enviado",
+		},
+		{
+			name: "Informational scan is stored only",
+			code: "TNE",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageByTrackingCode", mock.Anything, repository.GetPackageByTrackingCodeParams{TrackingCode: trackingCode}).Return(hiredPackage("enviado"), nil)
+				repo.On("GetCarrierEventMapping", mock.Anything, mock.Anything).Return(mapping("TNE", ""), nil)
+				repo.On("CreateCarrierTrackingEvent", mock.Anything, mock.MatchedBy(func(arg repository.CreateCarrierTrackingEventParams) bool {
+					return !arg.MappedStatus.Valid
+				})).Return(repository.CarrierTrackingEvent{ID: uuid.New()}, nil)
+			},
+			expectedStatus: "enviado",
+		},
+		{
+			name: "Unknown code is rejected",
+			code: "XYZ",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageByTrackingCode", mock.Anything, repository.GetPackageByTrackingCodeParams{TrackingCode: trackingCode}).Return(hiredPackage("enviado"), nil)
+				repo.On("GetCarrierEventMapping", mock.Anything, mock.Anything).Return(repository.CarrierEventMapping{}, sql.ErrNoRows)
+			},
+			expectedError: service.ErrUnknownCarrierEvent,
+		},
+		{
+			name: "Package hired with another carrier is not found",
+			code: "COL",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				pkg := hiredPackage("esperando_coleta")
+				pkg.HiredCarrierID = uuid.NullUUID{UUID: uuid.New(), Valid: true}
+				repo.On("GetPackageByTrackingCode", mock.Anything, repository.GetPackageByTrackingCodeParams{TrackingCode: trackingCode}).Return(pkg, nil)
+			},
+			expectedError: service.ErrPackageNotFound,
+		},
+		{
+			name: "Unknown tracking code",
+			code: "COL",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetPackageByTrackingCode", mock.Anything, repository.GetPackageByTrackingCodeParams{TrackingCode: trackingCode}).Return(repository.Package{}, sql.ErrNoRows)
 			},
-			expectedError: "carrier does not serve this region",
+			expectedError: service.ErrPackageNotFound,
 		},
 	}
 
@@ -601,21 +2346,126 @@ func TestPackageService_HireCarrier(t *testing.T) {
 			repoMocked := repository.NewQuerierMocked(t)
 			tt.setupMocked(repoMocked)
 
-			logger := zap.NewNop().Sugar()
-			packageService := service.NewPackageService(repoMocked, config.Config{}, logger)
+			packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
 
-			err := packageService.HireCarrier(context.Background(), tt.packageID, tt.carrierID, tt.price, tt.deliveryDays)
+			result, err := packageService.IngestCarrierEvent(context.Background(), carrier, service.CarrierEventInput{
+				EventID:      "NB-1",
+				Code:         tt.code,
+				TrackingCode: "nb000000014br",
+				OccurredAt:   occurredAt,
+				Raw:          json.RawMessage(`{}`),
+			})
 
-			if tt.expectedError != "" {
-				assert.Error(t, err)
-				assert.Contains(t, err.Error(), tt.expectedError)
-			} else {
-				assert.NoError(t, err)
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+				return
 			}
+			require.NoError(t, err)
+			assert.Equal(t, packageID, result.PackageID)
+			assert.Equal(t, tt.expectedStatus, result.Status)
+			assert.Equal(t, tt.expectedDup, result.Duplicate)
 		})
 	}
 }
 
+func sha256Hex(value string) string {
+	sum := sha256.Sum256([]byte(value))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestPackageService_GetTrackingTimeline(t *testing.T) {
+	carrierID := uuid.New()
+	packageID := uuid.New()
+	created := time.Date(2026, 10, 16, 14, 0, 0, 0, time.UTC)
+	trackingCode := sql.NullString{String: "NB473124829BR", Valid: true}
+
+	t.Run("Merge status changes and carrier scans in chronological order", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetPackageByTrackingCode", mock.Anything, repository.GetPackageByTrackingCodeParams{TrackingCode: trackingCode}).Return(repository.Package{
+			ID:             packageID,
+			TrackingCode:   trackingCode,
+			Status:         service.StatusCollected,
+			HiredCarrierID: uuid.NullUUID{UUID: carrierID, Valid: true},
+			HiredPrice:     sql.NullString{String: "42.50", Valid: true},
+			CreatedAt:      sql.NullTime{Time: created, Valid: true},
+		}, nil)
+		repoMocked.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID, Name: "Nebulix Logística"}, nil)
+		repoMocked.On("ListPackageStatusEvents", mock.Anything, packageID).Return([]repository.PackageStatusEvent{
+			{ToStatus: service.StatusAwaitingPickup, Actor: "api", CreatedAt: created.Add(time.Hour)},
+			{ToStatus: service.StatusCollected, Actor: "transportadora:Nebulix Logística", CreatedAt: created.Add(5 * time.Hour)},
+		}, nil)
+		repoMocked.On("ListCarrierTrackingEventsByPackage", mock.Anything, packageID).Return([]repository.CarrierTrackingEvent{
+			{
+				CarrierCode:  "COL",
+				MappedStatus: sql.NullString{String: service.StatusCollected, Valid: true},
+				Description:  sql.NullString{String: "Objeto coletado", Valid: true},
+				Location:     sql.NullString{String: "Cajamar/SP", Valid: true},
+				OccurredAt:   created.Add(4 * time.Hour),
+			},
+			{
+				CarrierCode: "TNE",
+				OccurredAt:  created.Add(6 * time.Hour),
+			},
+		}, nil)
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+		timeline, err := packageService.GetTrackingTimeline(context.Background(), "nb473124829br")
+		require.NoError(t, err)
+
+		assert.Equal(t, "Nebulix Logística", timeline.CarrierName)
+		require.Len(t, timeline.Events, 5)
+
+		types := []string{}
+		statuses := []string{}
+		for i, event := range timeline.Events {
+			if i > 0 {
+				assert.False(t, event.OccurredAt.Before(timeline.Events[i-1].OccurredAt))
+			}
+			types = append(types, event.Type)
+			statuses = append(statuses, event.Status)
+		}
+		assert.Equal(t, []string{
+			service.TimelineEventStatus, service.TimelineEventStatus, service.TimelineEventScan,
+			service.TimelineEventStatus, service.TimelineEventScan,
+		}, types)
+		assert.Equal(t, []string{
+			service.StatusCreated, service.StatusAwaitingPickup, service.StatusCollected, service.StatusCollected, "",
+		}, statuses)
+		assert.Equal(t, "Objeto coletado", timeline.Events[2].Description)
+		assert.Equal(t, "Cajamar/SP", timeline.Events[2].Location)
+		assert.Equal(t, "Coletado pela transportadora", timeline.Events[3].Description)
+	})
+
+	t.Run("Package without carrier has only its own status history", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetPackageByTrackingCode", mock.Anything, repository.GetPackageByTrackingCodeParams{TrackingCode: trackingCode}).Return(repository.Package{
+			ID:           packageID,
+			TrackingCode: trackingCode,
+			Status:       service.StatusCreated,
+			CreatedAt:    sql.NullTime{Time: created, Valid: true},
+		}, nil)
+		repoMocked.On("ListPackageStatusEvents", mock.Anything, packageID).Return([]repository.PackageStatusEvent{}, nil)
+		repoMocked.On("ListCarrierTrackingEventsByPackage", mock.Anything, packageID).Return([]repository.CarrierTrackingEvent{}, nil)
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+		timeline, err := packageService.GetTrackingTimeline(context.Background(), "NB473124829BR")
+		require.NoError(t, err)
+
+		assert.Empty(t, timeline.CarrierName)
+		require.Len(t, timeline.Events, 1)
+		assert.Equal(t, "Pedido registrado", timeline.Events[0].Description)
+	})
+
+	t.Run("Unknown tracking code", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetPackageByTrackingCode", mock.Anything, repository.GetPackageByTrackingCodeParams{TrackingCode: trackingCode}).Return(repository.Package{}, sql.ErrNoRows)
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+		_, err := packageService.GetTrackingTimeline(context.Background(), "NB473124829BR")
+		assert.ErrorIs(t, err, service.ErrPackageNotFound)
+	})
+}
+
 func TestPackageService_GetCarriers(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -669,6 +2519,495 @@ func TestPackageService_GetCarriers(t *testing.T) {
 	}
 }
 
+func TestPackageService_CreateCarrier(t *testing.T) {
+	maxWeight := 30.0
+
+	tests := []struct {
+		name          string
+		input         service.CarrierInput
+		setupMocked   func(repo *repository.QuerierMocked)
+		expectedError error
+	}{
+		{
+			name: "Create carrier with default cutoff time",
+			input: service.CarrierInput{
+				Name:           "Veloz Cargas",
+				TrackingPrefix: "VZ",
+				MaxWeightKg:    &maxWeight,
+			},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("CreateCarrier", mock.Anything, mock.MatchedBy(func(arg repository.CreateCarrierParams) bool {
+					return arg.TrackingPrefix == "VZ" &&
+						arg.MaxWeightKg == sql.NullFloat64{Float64: 30, Valid: true} &&
+						!arg.MaxSideCm.Valid &&
+						arg.CutoffTime.Format("15:04") == "14:00"
+				})).Return(repository.Carrier{ID: uuid.New(), Name: "Veloz Cargas", TrackingPrefix: "VZ"}, nil)
+			},
+		},
+		{
+			name: "Tracking prefix already in use",
+			input: service.CarrierInput{
+				Name:           "Nebulix Express",
+				TrackingPrefix: "NB",
+				CutoffTime:     "16:30",
+			},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("CreateCarrier", mock.Anything, mock.Anything).Return(repository.Carrier{}, &pq.Error{Code: "23505"})
+			},
+			expectedError: service.ErrTrackingPrefixInUse,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMocked := repository.NewQuerierMocked(t)
+			tt.setupMocked(repoMocked)
+
+			packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+			carrier, err := packageService.CreateCarrier(context.Background(), tt.input)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.input.TrackingPrefix, carrier.TrackingPrefix)
+			}
+		})
+	}
+}
+
+func TestPackageService_UpdateCarrier(t *testing.T) {
+	carrierID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+	input := service.CarrierInput{Name: "Nebulix Logística", TrackingPrefix: "NB", CutoffTime: "17:00"}
+
+	tests := []struct {
+		name          string
+		setupMocked   func(repo *repository.QuerierMocked)
+		expectedError error
+	}{
+		{
+			name: "Update carrier",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID}, nil)
+				repo.On("UpdateCarrier", mock.Anything, mock.MatchedBy(func(arg repository.UpdateCarrierParams) bool {
+					return arg.ID == carrierID && arg.CutoffTime.Format("15:04") == "17:00"
+				})).Return(repository.Carrier{ID: carrierID, Name: "Nebulix Logística"}, nil)
+			},
+		},
+		{
+			name: "Deleted carrier",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{
+					ID:        carrierID,
+					DeletedAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+			},
+			expectedError: service.ErrCarrierNotFound,
+		},
+		{
+			name: "Carrier not found",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{}, sql.ErrNoRows)
+			},
+			expectedError: service.ErrCarrierNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMocked := repository.NewQuerierMocked(t)
+			tt.setupMocked(repoMocked)
+
+			packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+			_, err := packageService.UpdateCarrier(context.Background(), carrierID.String(), input)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPackageService_DeleteCarrier(t *testing.T) {
+	carrierID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+
+	tests := []struct {
+		name          string
+		carrierID     string
+		setupMocked   func(repo *repository.QuerierMocked)
+		expectedError error
+	}{
+		{
+			name:      "Delete carrier without packages in progress",
+			carrierID: carrierID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID}, nil)
+				repo.On("DeleteCarrier", mock.Anything, carrierID).Return(int64(1), nil)
+			},
+		},
+		{
+			name:      "Carrier with packages in progress",
+			carrierID: carrierID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID}, nil)
+				repo.On("DeleteCarrier", mock.Anything, carrierID).Return(int64(0), nil)
+			},
+			expectedError: service.ErrCarrierInUse,
+		},
+		{
+			name:      "Carrier already deleted",
+			carrierID: carrierID.String(),
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{
+					ID:        carrierID,
+					DeletedAt: sql.NullTime{Time: time.Now(), Valid: true},
+				}, nil)
+			},
+			expectedError: service.ErrCarrierNotFound,
+		},
+		{
+			name:          "Invalid carrier id",
+			carrierID:     "invalid",
+			setupMocked:   func(repo *repository.QuerierMocked) {},
+			expectedError: service.ErrCarrierNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMocked := repository.NewQuerierMocked(t)
+			tt.setupMocked(repoMocked)
+
+			packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+			err := packageService.DeleteCarrier(context.Background(), tt.carrierID)
+
+			if tt.expectedError != nil {
+				assert.ErrorIs(t, err, tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPackageService_CarrierCoverage(t *testing.T) {
+	carrierID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440001")
+	regionID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440005")
+	coverageID := uuid.New()
+	excess := 3.5
+
+	stored := repository.ListCarrierRatesRow{
+		ID:                    coverageID,
+		CarrierID:             carrierID,
+		RegionID:              regionID,
+		RegionName:            "Norte",
+		EstimatedDeliveryDays: 12,
+		PricePerKg:            "9.90",
+		MinCharge:             "25.00",
+		ExcessPricePerKg:      sql.NullString{String: "3.50", Valid: true},
+		CubingDivisor:         6000,
+		ValidFrom:             time.Now(),
+	}
+	input := service.CarrierCoverageInput{
+		RegionID:              regionID.String(),
+		EstimatedDeliveryDays: 12,
+		PricePerKg:            9.9,
+		MinCharge:             25,
+		ExcessPricePerKg:      &excess,
+	}
+
+	t.Run("Add route with default cubing divisor", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID}, nil)
+		repoMocked.On("CreateCarrierRegion", mock.Anything, repository.CreateCarrierRegionParams{
+			CarrierID:             carrierID,
+			RegionID:              regionID,
+			EstimatedDeliveryDays: 12,
+			PricePerKg:            "9.90",
+			MinCharge:             "25.00",
+			ExcessPricePerKg:      sql.NullString{String: "3.50", Valid: true},
+			CubingDivisor:         6000,
+		}).Return(repository.CreateCarrierRegionRow{ID: coverageID, CarrierID: carrierID, RegionID: regionID}, nil)
+		repoMocked.On("ListCarrierRates", mock.Anything, repository.ListCarrierRatesParams{CarrierID: carrierID}).
+			Return([]repository.ListCarrierRatesRow{stored}, nil)
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+		coverage, err := packageService.AddCarrierCoverage(context.Background(), carrierID.String(), input)
+		require.NoError(t, err)
+		assert.Equal(t, "Norte", coverage.RegionName)
+	})
+
+	t.Run("Schedule a future version", func(t *testing.T) {
+		validFrom := time.Now().AddDate(0, 1, 0)
+		scheduled := input
+		scheduled.ValidFrom = &validFrom
+
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID}, nil)
+		repoMocked.On("CreateCarrierRegion", mock.Anything, mock.MatchedBy(func(params repository.CreateCarrierRegionParams) bool {
+			return params.ValidFrom.Valid && params.ValidFrom.Time.Equal(validFrom)
+		})).Return(repository.CreateCarrierRegionRow{ID: coverageID}, nil)
+		repoMocked.On("ListCarrierRates", mock.Anything, mock.Anything).Return([]repository.ListCarrierRatesRow{stored}, nil)
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+		_, err := packageService.AddCarrierCoverage(context.Background(), carrierID.String(), scheduled)
+		assert.NoError(t, err)
+	})
+
+	t.Run("Version in the past", func(t *testing.T) {
+		validFrom := time.Now().AddDate(0, 0, -1)
+		retroactive := input
+		retroactive.ValidFrom = &validFrom
+
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID}, nil)
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+		_, err := packageService.AddCarrierCoverage(context.Background(), carrierID.String(), retroactive)
+		assert.ErrorIs(t, err, service.ErrRetroactiveRate)
+	})
+
+	t.Run("Version already starts at this date", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID}, nil)
+		repoMocked.On("CreateCarrierRegion", mock.Anything, mock.Anything).Return(repository.CreateCarrierRegionRow{}, &pq.Error{Code: "23505"})
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+		_, err := packageService.AddCarrierCoverage(context.Background(), carrierID.String(), input)
+		assert.ErrorIs(t, err, service.ErrRateVersionExists)
+	})
+
+	t.Run("Unknown region", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID}, nil)
+		repoMocked.On("CreateCarrierRegion", mock.Anything, mock.Anything).Return(repository.CreateCarrierRegionRow{}, &pq.Error{Code: "23503"})
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+		_, err := packageService.AddCarrierCoverage(context.Background(), carrierID.String(), input)
+		assert.ErrorIs(t, err, service.ErrRegionNotFound)
+	})
+
+	t.Run("Update creates a new version of the same route", func(t *testing.T) {
+		originRegionID := uuid.NullUUID{UUID: uuid.MustParse("550e8400-e29b-41d4-a716-446655440004"), Valid: true}
+		newVersionID := uuid.New()
+
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID}, nil)
+		repoMocked.On("GetCarrierRegionById", mock.Anything, repository.GetCarrierRegionByIdParams{
+			ID:        coverageID,
+			CarrierID: carrierID,
+		}).Return(repository.CarrierRegion{ID: coverageID, CarrierID: carrierID, RegionID: regionID, OriginRegionID: originRegionID}, nil)
+		repoMocked.On("CreateCarrierRegion", mock.Anything, mock.MatchedBy(func(params repository.CreateCarrierRegionParams) bool {
+			return params.RegionID == regionID && params.OriginRegionID == originRegionID && !params.ValidFrom.Valid
+		})).Return(repository.CreateCarrierRegionRow{ID: newVersionID}, nil)
+		repoMocked.On("ListCarrierRates", mock.Anything, mock.Anything).
+			Return([]repository.ListCarrierRatesRow{stored, {ID: newVersionID, RegionName: "Norte"}}, nil)
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+		coverage, err := packageService.UpdateCarrierCoverage(context.Background(), carrierID.String(), coverageID.String(), input)
+		require.NoError(t, err)
+		assert.Equal(t, newVersionID, coverage.ID)
+	})
+
+	t.Run("Update route of another carrier", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID}, nil)
+		repoMocked.On("GetCarrierRegionById", mock.Anything, mock.Anything).Return(repository.CarrierRegion{}, sql.ErrNoRows)
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+		_, err := packageService.UpdateCarrierCoverage(context.Background(), carrierID.String(), coverageID.String(), input)
+		assert.ErrorIs(t, err, service.ErrCoverageNotFound)
+	})
+
+	t.Run("Delete route", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID}, nil)
+		repoMocked.On("GetCarrierRegionById", mock.Anything, mock.Anything).
+			Return(repository.CarrierRegion{ID: coverageID, CarrierID: carrierID, RegionID: regionID}, nil)
+		repoMocked.On("EndCarrierRegion", mock.Anything, repository.EndCarrierRegionParams{
+			ID:        coverageID,
+			CarrierID: carrierID,
+		}).Return(nil)
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+		err := packageService.DeleteCarrierCoverage(context.Background(), carrierID.String(), coverageID.String())
+		assert.NoError(t, err)
+	})
+
+	t.Run("Delete missing route", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID}, nil)
+		repoMocked.On("GetCarrierRegionById", mock.Anything, mock.Anything).Return(repository.CarrierRegion{}, sql.ErrNoRows)
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+		err := packageService.DeleteCarrierCoverage(context.Background(), carrierID.String(), coverageID.String())
+		assert.ErrorIs(t, err, service.ErrCoverageNotFound)
+	})
+
+	t.Run("Rates effective at a date", func(t *testing.T) {
+		at := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID}, nil)
+		repoMocked.On("ListCarrierRates", mock.Anything, repository.ListCarrierRatesParams{
+			CarrierID: carrierID,
+			At:        sql.NullTime{Time: at, Valid: true},
+		}).Return([]repository.ListCarrierRatesRow{stored}, nil)
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+		rates, err := packageService.GetCarrierRates(context.Background(), carrierID.String(), &at)
+		require.NoError(t, err)
+		assert.Len(t, rates, 1)
+	})
+}
+
+func TestPackageService_ImportCarrierRates(t *testing.T) {
+	carrierID := uuid.MustParse("660e8400-e29b-41d4-a716-446655440003")
+	norte := repository.Region{ID: uuid.MustParse("550e8400-e29b-41d4-a716-446655440005"), Name: "Norte"}
+	nordeste := repository.Region{ID: uuid.MustParse("550e8400-e29b-41d4-a716-446655440004"), Name: "Nordeste"}
+	currentID := uuid.New()
+	excess := 8.5
+
+	setupLookups := func(repo *repository.QuerierMocked) {
+		repo.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID}, nil)
+		repo.On("ListRegions", mock.Anything).Return([]repository.Region{nordeste, norte}, nil)
+		repo.On("ListStates", mock.Anything).Return([]repository.ListStatesRow{
+			{Code: "BA", Name: "Bahia", RegionName: "Nordeste"},
+			{Code: "AM", Name: "Amazonas", RegionName: "Norte"},
+		}, nil)
+		repo.On("ListCarrierRates", mock.Anything, mock.Anything).Return([]repository.ListCarrierRatesRow{{
+			ID:                    currentID,
+			CarrierID:             carrierID,
+			RegionID:              nordeste.ID,
+			RegionName:            "Nordeste",
+			EstimatedDeliveryDays: 4,
+			PricePerKg:            "8.50",
+			MinCharge:             "15.00",
+			ExcessPricePerKg:      sql.NullString{String: "8.50", Valid: true},
+			CubingDivisor:         6000,
+		}}, nil)
+		repo.On("ListCarrierRateTiersByCarrier", mock.Anything, carrierID).Return([]repository.CarrierRateTier{
+			{CarrierRegionID: currentID, MinWeightKg: "0.000", MaxWeightKg: "1.000", Price: "22.90"},
+			{CarrierRegionID: currentID, MinWeightKg: "1.000", MaxWeightKg: "5.000", Price: "49.90"},
+		}, nil)
+	}
+
+	sheet := []service.RateImportRow{
+		{Line: 2, State: "BA", MaxWeightKg: 1, Price: 22.9, EstimatedDeliveryDays: 4},
+		{Line: 3, Region: "nordeste", MaxWeightKg: 5, Price: 54.9, EstimatedDeliveryDays: 4},
+		{Line: 4, Region: "Norte", MaxWeightKg: 5, Price: 59.9, EstimatedDeliveryDays: 8, ExcessPricePerKg: &excess},
+	}
+
+	t.Run("Dry run compares the sheet with the current rates", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		setupLookups(repoMocked)
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+		report, err := packageService.ImportCarrierRates(context.Background(), carrierID.String(), sheet, nil, true)
+		require.NoError(t, err)
+		assert.False(t, report.Rejected())
+		assert.False(t, report.Applied)
+		require.Len(t, report.Changes, 2)
+
+		assert.Equal(t, service.RateChangeUpdated, report.Changes[0].Status)
+		require.NotNil(t, report.Changes[0].Current)
+		assert.Equal(t, 49.9, report.Changes[0].Current.Tiers[1].Price)
+		assert.Equal(t, 54.9, report.Changes[0].Proposed.Tiers[1].Price)
+		assert.Equal(t, 1.0, report.Changes[0].Proposed.Tiers[1].MinWeightKg)
+		assert.Equal(t, 15.0, report.Changes[0].Proposed.MinCharge)
+
+		assert.Equal(t, service.RateChangeNew, report.Changes[1].Status)
+		assert.Nil(t, report.Changes[1].Current)
+	})
+
+	t.Run("Apply sends only new and changed routes", func(t *testing.T) {
+		unchanged := []service.RateImportRow{
+			{Line: 2, State: "BA", MaxWeightKg: 1, Price: 22.9, EstimatedDeliveryDays: 4},
+			{Line: 3, State: "BA", MaxWeightKg: 5, Price: 49.9, EstimatedDeliveryDays: 4},
+			sheet[2],
+		}
+		versionID := uuid.New()
+
+		repoMocked := repository.NewQuerierMocked(t)
+		setupLookups(repoMocked)
+		repoMocked.On("ImportCarrierRates", mock.Anything, mock.MatchedBy(func(arg repository.ImportCarrierRatesParams) bool {
+			var lanes []map[string]interface{}
+			if arg.CarrierID != carrierID || arg.ValidFrom.Valid || json.Unmarshal(arg.Lanes, &lanes) != nil {
+				return false
+			}
+			return len(lanes) == 1 && lanes[0]["region_id"] == norte.ID.String() && lanes[0]["price_per_kg"] == 8.5
+		})).Return([]repository.ImportCarrierRatesRow{{ID: versionID, RegionID: norte.ID}}, nil)
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+		report, err := packageService.ImportCarrierRates(context.Background(), carrierID.String(), unchanged, nil, false)
+		require.NoError(t, err)
+		assert.True(t, report.Applied)
+		require.Len(t, report.Changes, 2)
+		assert.Equal(t, service.RateChangeUnchanged, report.Changes[0].Status)
+		assert.Equal(t, uuid.Nil, report.Changes[0].VersionID)
+		assert.Equal(t, versionID, report.Changes[1].VersionID)
+	})
+
+	t.Run("Invalid sheet is rejected without changes", func(t *testing.T) {
+		invalid := []service.RateImportRow{
+			{Line: 2, State: "BA", MaxWeightKg: 1, Price: 22.9, EstimatedDeliveryDays: 4},
+			{Line: 3, State: "BA", MaxWeightKg: 5, Price: 54.9, EstimatedDeliveryDays: 6},
+			{Line: 4, Region: "Centro", MaxWeightKg: 5, Price: 59.9, EstimatedDeliveryDays: 8},
+			{Line: 5, Region: "Norte", MaxWeightKg: 5, Price: 59.9, EstimatedDeliveryDays: 8},
+			{Line: 6, Region: "Norte", State: "BA", MaxWeightKg: 5, Price: 59.9, EstimatedDeliveryDays: 8},
+		}
+
+		repoMocked := repository.NewQuerierMocked(t)
+		setupLookups(repoMocked)
+
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+		report, err := packageService.ImportCarrierRates(context.Background(), carrierID.String(), invalid, nil, false)
+		require.NoError(t, err)
+		assert.True(t, report.Rejected())
+		assert.False(t, report.Applied)
+
+		lines := make([]int, 0, len(report.Errors))
+		for _, rowError := range report.Errors {
+			lines = append(lines, rowError.Line)
+		}
+		// Unknown region, BA outside Norte, a diverging line 3 deadline and a new Norte lane without excess
+		assert.ElementsMatch(t, []int{4, 6, 2, 5}, lines)
+	})
+
+	t.Run("Empty sheet", func(t *testing.T) {
+		packageService := service.NewPackageService(repository.NewQuerierMocked(t), config.Config{}, zap.NewNop().Sugar())
+
+		_, err := packageService.ImportCarrierRates(context.Background(), carrierID.String(), nil, nil, false)
+		assert.ErrorIs(t, err, service.ErrInvalidImport)
+	})
+
+	t.Run("Versions cannot start in the past", func(t *testing.T) {
+		yesterday := time.Now().AddDate(0, 0, -1)
+		packageService := service.NewPackageService(repository.NewQuerierMocked(t), config.Config{}, zap.NewNop().Sugar())
+
+		_, err := packageService.ImportCarrierRates(context.Background(), carrierID.String(), sheet, &yesterday, false)
+		assert.ErrorIs(t, err, service.ErrRetroactiveRate)
+	})
+}
+
 func TestPackageService_GetStates(t *testing.T) {
 	tests := []struct {
 		name          string
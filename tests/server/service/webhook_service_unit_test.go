@@ -0,0 +1,403 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/config"
+	"github/moura95/olist-shipping-api/internal/repository"
+	"github/moura95/olist-shipping-api/internal/service"
+	"go.uber.org/zap"
+)
+
+type recordingPublisher struct {
+	events []service.Event
+	err    error
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, event service.Event) error {
+	p.events = append(p.events, event)
+	return p.err
+}
+
+// failingCommitStore fails on commit, like a transaction aborted by the database.
+type failingCommitStore struct {
+	*repository.QuerierMocked
+	err error
+}
+
+func (s *failingCommitStore) WithTx(_ context.Context, fn func(q repository.Querier) error) error {
+	if err := fn(s.QuerierMocked); err != nil {
+		return err
+	}
+	return s.err
+}
+
+func TestWebhookService_CreateSubscription(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         service.CreateWebhookInput
+		setupMocked   func(repo *repository.QuerierMocked)
+		expectedError string
+	}{
+		{
+			name: "Create subscription with informed secret",
+			input: service.CreateWebhookInput{
+				URL:        "https://loja.example.com/webhooks",
+				Secret:     "segredo-da-loja-123",
+				EventTypes: []string{service.EventPackageCreated, service.EventPackageStatusChanged, service.EventPackageCreated},
+			},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("CreateWebhookSubscription", mock.Anything, repository.CreateWebhookSubscriptionParams{
+					Url:        "https://loja.example.com/webhooks",
+					Secret:     "segredo-da-loja-123",
+					EventTypes: []string{service.EventPackageCreated, service.EventPackageStatusChanged},
+				}).Return(repository.WebhookSubscription{ID: uuid.New()}, nil)
+			},
+		},
+		{
+			name: "Create subscription generates secret when omitted",
+			input: service.CreateWebhookInput{
+				URL:        "https://loja.example.com/webhooks",
+				EventTypes: []string{service.EventPackageDeleted},
+			},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("CreateWebhookSubscription", mock.Anything, mock.MatchedBy(func(arg repository.CreateWebhookSubscriptionParams) bool {
+					return strings.HasPrefix(arg.Secret, "whsec_") && len(arg.Secret) == len("whsec_")+64
+				})).Return(repository.WebhookSubscription{ID: uuid.New()}, nil)
+			},
+		},
+		{
+			name: "Create subscription database error",
+			input: service.CreateWebhookInput{
+				URL:        "https://loja.example.com/webhooks",
+				EventTypes: []string{service.EventPackageDeleted},
+			},
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("CreateWebhookSubscription", mock.Anything, mock.Anything).Return(repository.WebhookSubscription{}, sql.ErrConnDone)
+			},
+			expectedError: "create webhook subscription",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMocked := repository.NewQuerierMocked(t)
+			tt.setupMocked(repoMocked)
+
+			webhookService := service.NewWebhookService(repoMocked, zap.NewNop().Sugar())
+
+			result, err := webhookService.CreateSubscription(context.Background(), tt.input)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+				assert.NotNil(t, result)
+			}
+		})
+	}
+}
+
+func TestWebhookService_Publish(t *testing.T) {
+	event := service.Event{
+		ID:   uuid.New(),
+		Type: service.EventPackageStatusChanged,
+		Data: service.EventData{PackageID: uuid.New(), Status: "coletado", PreviousStatus: "esperando_coleta"},
+	}
+	subscriptions := []repository.WebhookSubscription{{ID: uuid.New()}, {ID: uuid.New()}}
+
+	tests := []struct {
+		name          string
+		setupMocked   func(repo *repository.QuerierMocked)
+		expectedError string
+	}{
+		{
+			name: "Publish creates one delivery per subscription",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("ListWebhookSubscriptionsForEvent", mock.Anything, service.EventPackageStatusChanged).Return(subscriptions, nil)
+				for _, subscription := range subscriptions {
+					subscriptionID := subscription.ID
+					repo.On("CreateWebhookDelivery", mock.Anything, mock.MatchedBy(func(arg repository.CreateWebhookDeliveryParams) bool {
+						var payload map[string]interface{}
+						if err := json.Unmarshal(arg.Payload, &payload); err != nil {
+							return false
+						}
+						dados := payload["dados"].(map[string]interface{})
+						return arg.SubscriptionID == subscriptionID &&
+							arg.EventID == event.ID &&
+							arg.EventType == service.EventPackageStatusChanged &&
+							payload["tipo"] == service.EventPackageStatusChanged &&
+							dados["status_anterior"] == "esperando_coleta"
+					})).Return(repository.WebhookDelivery{}, nil).Once()
+				}
+			},
+		},
+		{
+			name: "Publish without subscriptions records nothing",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("ListWebhookSubscriptionsForEvent", mock.Anything, service.EventPackageStatusChanged).Return([]repository.WebhookSubscription{}, nil)
+			},
+		},
+		{
+			name: "Publish delivery error",
+			setupMocked: func(repo *repository.QuerierMocked) {
+				repo.On("ListWebhookSubscriptionsForEvent", mock.Anything, service.EventPackageStatusChanged).Return(subscriptions[:1], nil)
+				repo.On("CreateWebhookDelivery", mock.Anything, mock.Anything).Return(repository.WebhookDelivery{}, sql.ErrConnDone)
+			},
+			expectedError: "create webhook delivery",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repoMocked := repository.NewQuerierMocked(t)
+			tt.setupMocked(repoMocked)
+
+			webhookService := service.NewWebhookService(repoMocked, zap.NewNop().Sugar())
+
+			err := webhookService.Publish(context.Background(), event)
+
+			if tt.expectedError != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectedError)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWebhookService_NotFound(t *testing.T) {
+	repoMocked := repository.NewQuerierMocked(t)
+	webhookService := service.NewWebhookService(repoMocked, zap.NewNop().Sugar())
+
+	subscriptionID := uuid.New()
+	repoMocked.On("DeleteWebhookSubscription", mock.Anything, subscriptionID).Return(int64(0), nil)
+	err := webhookService.DeleteSubscription(context.Background(), subscriptionID.String())
+	assert.ErrorIs(t, err, service.ErrWebhookNotFound)
+
+	deliveryID := uuid.New()
+	repoMocked.On("ReplayWebhookDelivery", mock.Anything, deliveryID).Return(repository.WebhookDelivery{}, sql.ErrNoRows)
+	_, err = webhookService.ReplayDelivery(context.Background(), deliveryID.String())
+	assert.ErrorIs(t, err, service.ErrWebhookDeliveryNotFound)
+
+	_, err = webhookService.ReplayDelivery(context.Background(), "invalid-uuid")
+	assert.ErrorIs(t, err, service.ErrWebhookDeliveryNotFound)
+}
+
+func TestPackageService_PublishesEvents(t *testing.T) {
+	packageID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+	regionID := uuid.MustParse("550e8400-e29b-41d4-a716-446655440002")
+
+	t.Run("Create publishes package.created", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetSellerById", mock.Anything, testSellerID).Return(repository.Seller{ID: testSellerID}, nil)
+		repoMocked.On("CreatePackage", mock.Anything, mock.Anything).Return(repository.Package{
+			ID:               packageID,
+			Status:           "criado",
+			DestinationState: "SP",
+		}, nil)
+
+		publisher := &recordingPublisher{}
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar(), service.WithEventPublisher(publisher))
+
+		_, err := packageService.Create(context.Background(), service.CreatePackageInput{
+			Product:          "Camiseta",
+			WeightKg:         0.3,
+			DestinationState: "SP",
+			SellerID:         testSellerID.String(),
+		})
+		require.NoError(t, err)
+
+		require.Len(t, publisher.events, 1)
+		assert.Equal(t, service.EventPackageCreated, publisher.events[0].Type)
+		assert.Equal(t, packageID, publisher.events[0].Data.PackageID)
+		assert.Equal(t, "criado", publisher.events[0].Data.Status)
+		assert.NotEqual(t, uuid.Nil, publisher.events[0].ID)
+	})
+
+	t.Run("UpdateStatus publishes package.status_changed", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(repository.Package{
+			ID:             packageID,
+			Status:         "esperando_coleta",
+			HiredCarrierID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		}, nil)
+		repoMocked.On("UpdatePackageStatus", mock.Anything, mock.Anything).Return(int64(1), nil)
+		repoMocked.On("CreatePackageStatusEvent", mock.Anything, mock.Anything).Return(repository.PackageStatusEvent{}, nil)
+
+		publisher := &recordingPublisher{}
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar(), service.WithEventPublisher(publisher))
+
+		_, err := packageService.UpdateStatus(context.Background(), packageID.String(), uuid.NullUUID{}, service.StatusChange{Status: "coletado", Actor: "tester"})
+		require.NoError(t, err)
+
+		require.Len(t, publisher.events, 1)
+		data := publisher.events[0].Data
+		assert.Equal(t, service.EventPackageStatusChanged, publisher.events[0].Type)
+		assert.Equal(t, "coletado", data.Status)
+		assert.Equal(t, "esperando_coleta", data.PreviousStatus)
+		assert.Equal(t, "tester", data.Actor)
+	})
+
+	t.Run("HireCarrier publishes status_changed and carrier_hired once", func(t *testing.T) {
+		carrierID := uuid.New()
+		quoteID := uuid.New()
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(repository.Package{
+			ID:               packageID,
+			Status:           "criado",
+			WeightKg:         1,
+			DestinationState: "SP",
+			Version:          3,
+		}, nil)
+		repoMocked.On("GetQuoteById", mock.Anything, quoteID).Return(repository.Quote{
+			ID:                    quoteID,
+			PackageID:             packageID,
+			CarrierID:             carrierID,
+			Price:                 "14.75",
+			EstimatedDeliveryDays: 4,
+			ExpiresAt:             time.Now().Add(time.Minute),
+		}, nil)
+		repoMocked.On("GetRegionByState", mock.Anything, "SP").Return(repository.GetRegionByStateRow{ID: regionID}, nil)
+		repoMocked.On("GetCarrierRegions", mock.Anything, carrierID).Return([]repository.GetCarrierRegionsRow{
+			{CarrierID: carrierID, RegionID: regionID, EstimatedDeliveryDays: 4, PricePerKg: "5.90"},
+		}, nil)
+		repoMocked.On("GetCarrierById", mock.Anything, carrierID).Return(repository.Carrier{ID: carrierID, TrackingPrefix: "NB"}, nil)
+		repoMocked.On("ListCarrierRestrictedCategories", mock.Anything).Return([]repository.CarrierRestrictedCategory{}, nil)
+		repoMocked.On("ListHolidaysForState", mock.Anything, mock.Anything).Return([]repository.Holiday{}, nil)
+		repoMocked.On("MarkQuoteUsed", mock.Anything, quoteID).Return(int64(1), nil)
+		repoMocked.On("NextTrackingSerial", mock.Anything, "NB").Return(int64(47312482), nil)
+		repoMocked.On("HireCarrier", mock.Anything, mock.Anything).Return(int64(1), nil)
+		repoMocked.On("CreatePackageStatusEvent", mock.Anything, mock.Anything).Return(repository.PackageStatusEvent{}, nil)
+
+		publisher := &recordingPublisher{}
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar(), service.WithEventPublisher(publisher))
+
+		version, err := packageService.HireCarrier(context.Background(), packageID.String(), quoteID.String(), uuid.NullUUID{}, "tester", 0)
+		require.NoError(t, err)
+		assert.Equal(t, int32(4), version)
+
+		require.Len(t, publisher.events, 2)
+		assert.Equal(t, service.EventPackageStatusChanged, publisher.events[0].Type)
+		assert.Equal(t, service.EventPackageCarrierHired, publisher.events[1].Type)
+		for _, event := range publisher.events {
+			assert.Equal(t, packageID, event.Data.PackageID)
+			assert.Equal(t, "esperando_coleta", event.Data.Status)
+			assert.Equal(t, "criado", event.Data.PreviousStatus)
+			assert.Equal(t, "tester", event.Data.Actor)
+			assert.Equal(t, "NB473124829BR", event.Data.TrackingCode)
+		}
+	})
+
+	t.Run("Failed status update publishes nothing", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(repository.Package{
+			ID:     packageID,
+			Status: "criado",
+		}, nil)
+
+		publisher := &recordingPublisher{}
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar(), service.WithEventPublisher(publisher))
+
+		_, err := packageService.UpdateStatus(context.Background(), packageID.String(), uuid.NullUUID{}, service.StatusChange{Status: "entregue"})
+		assert.ErrorIs(t, err, service.ErrInvalidStatusTransition)
+		assert.Empty(t, publisher.events)
+	})
+
+	t.Run("Aborted transaction publishes nothing", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(repository.Package{
+			ID:             packageID,
+			Status:         "esperando_coleta",
+			HiredCarrierID: uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		}, nil)
+		repoMocked.On("UpdatePackageStatus", mock.Anything, mock.Anything).Return(int64(1), nil)
+		repoMocked.On("CreatePackageStatusEvent", mock.Anything, mock.Anything).Return(repository.PackageStatusEvent{}, nil)
+		store := &failingCommitStore{QuerierMocked: repoMocked, err: errors.New("could not serialize access")}
+
+		publisher := &recordingPublisher{}
+		packageService := service.NewPackageService(store, config.Config{}, zap.NewNop().Sugar(), service.WithEventPublisher(publisher))
+
+		_, err := packageService.UpdateStatus(context.Background(), packageID.String(), uuid.NullUUID{}, service.StatusChange{Status: "coletado"})
+		assert.Error(t, err)
+		assert.Empty(t, publisher.events)
+	})
+
+	carrierEvent := func(repoMocked *repository.QuerierMocked) (repository.Carrier, service.CarrierEventInput) {
+		carrier := repository.Carrier{ID: uuid.New(), Name: "Nebulix Logística"}
+		trackingCode := sql.NullString{String: "NB000000014BR", Valid: true}
+		pkg := func(status string) repository.Package {
+			return repository.Package{ID: packageID, Status: status, TrackingCode: trackingCode, HiredCarrierID: uuid.NullUUID{UUID: carrier.ID, Valid: true}}
+		}
+		repoMocked.On("GetPackageByTrackingCode", mock.Anything, mock.Anything).Return(pkg("coletado"), nil)
+		repoMocked.On("GetCarrierEventMapping", mock.Anything, mock.Anything).Return(repository.CarrierEventMapping{
+			CarrierID:   carrier.ID,
+			CarrierCode: "ENT",
+			Status:      sql.NullString{String: "entregue", Valid: true},
+		}, nil)
+		repoMocked.On("CreateCarrierTrackingEvent", mock.Anything, mock.Anything).Return(repository.CarrierTrackingEvent{ID: uuid.New()}, nil)
+		repoMocked.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(pkg("coletado"), nil).Once()
+		repoMocked.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(pkg("enviado"), nil).Once()
+		repoMocked.On("UpdatePackageStatus", mock.Anything, mock.Anything).Return(int64(1), nil).Twice()
+		repoMocked.On("CreatePackageStatusEvent", mock.Anything, mock.Anything).Return(repository.PackageStatusEvent{}, nil).Twice()
+
+		return carrier, service.CarrierEventInput{EventID: "NB-9", Code: "ENT", TrackingCode: trackingCode.String, OccurredAt: time.Now()}
+	}
+
+	t.Run("Carrier event publishes each status change after commit", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		carrier, input := carrierEvent(repoMocked)
+
+		publisher := &recordingPublisher{}
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar(), service.WithEventPublisher(publisher))
+
+		result, err := packageService.IngestCarrierEvent(context.Background(), carrier, input)
+		require.NoError(t, err)
+		assert.Equal(t, "entregue", result.Status)
+
+		require.Len(t, publisher.events, 2)
+		assert.Equal(t, "enviado", publisher.events[0].Data.Status)
+		assert.Equal(t, "entregue", publisher.events[1].Data.Status)
+	})
+
+	t.Run("Aborted carrier event ingestion publishes nothing", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		carrier, input := carrierEvent(repoMocked)
+		store := &failingCommitStore{QuerierMocked: repoMocked, err: errors.New("could not serialize access")}
+
+		publisher := &recordingPublisher{}
+		packageService := service.NewPackageService(store, config.Config{}, zap.NewNop().Sugar(), service.WithEventPublisher(publisher))
+
+		_, err := packageService.IngestCarrierEvent(context.Background(), carrier, input)
+		assert.Error(t, err)
+		assert.Empty(t, publisher.events)
+	})
+
+	t.Run("Publisher error does not fail the operation", func(t *testing.T) {
+		repoMocked := repository.NewQuerierMocked(t)
+		repoMocked.On("GetPackageById", mock.Anything, repository.GetPackageByIdParams{ID: packageID}).Return(repository.Package{ID: packageID}, nil)
+		repoMocked.On("DeletePackage", mock.Anything, repository.DeletePackageParams{ID: packageID}).Return(int64(1), nil)
+
+		publisher := &recordingPublisher{err: errors.New("database unavailable")}
+		packageService := service.NewPackageService(repoMocked, config.Config{}, zap.NewNop().Sugar(), service.WithEventPublisher(publisher))
+
+		err := packageService.Delete(context.Background(), packageID.String(), uuid.NullUUID{}, service.PackageDeletion{})
+		require.NoError(t, err)
+
+		require.Len(t, publisher.events, 1)
+		assert.Equal(t, service.EventPackageDeleted, publisher.events[0].Type)
+		assert.Equal(t, packageID, publisher.events[0].Data.PackageID)
+	})
+}
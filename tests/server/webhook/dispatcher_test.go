@@ -0,0 +1,176 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github/moura95/olist-shipping-api/config"
+	"github/moura95/olist-shipping-api/internal/repository"
+	"github/moura95/olist-shipping-api/internal/service"
+	"github/moura95/olist-shipping-api/internal/webhook"
+	"go.uber.org/zap"
+)
+
+const testSecret = "segredo-da-loja-123"
+
+// receiver checks the signature and answers with the configured status.
+type receiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []*http.Request
+	bodies   [][]byte
+	valid    []bool
+}
+
+func (r *receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	timestamp, _ := strconv.ParseInt(req.Header.Get(webhook.TimestampHeader), 10, 64)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, req)
+	r.bodies = append(r.bodies, body)
+	r.valid = append(r.valid, webhook.Verify(testSecret, timestamp, body, req.Header.Get(webhook.SignatureHeader)))
+	w.WriteHeader(r.status)
+}
+
+func newDelivery(url string, attempts int32) repository.ClaimDueWebhookDeliveriesRow {
+	payload, _ := json.Marshal(service.Event{
+		ID:   uuid.New(),
+		Type: service.EventPackageCreated,
+		Data: service.EventData{PackageID: uuid.New(), Status: "criado"},
+	})
+	return repository.ClaimDueWebhookDeliveriesRow{
+		ID:        uuid.New(),
+		EventID:   uuid.New(),
+		EventType: service.EventPackageCreated,
+		Payload:   payload,
+		Attempts:  attempts,
+		Url:       url,
+		Secret:    testSecret,
+	}
+}
+
+func TestDispatcher_DispatchDue(t *testing.T) {
+	tests := []struct {
+		name           string
+		responseStatus int
+		attempts       int32
+		expectedStatus string
+		expectedRetry  time.Duration
+		expectError    bool
+	}{
+		{
+			name:           "Successful delivery is marked as delivered",
+			responseStatus: http.StatusNoContent,
+			expectedStatus: service.DeliveryStatusDelivered,
+		},
+		{
+			name:           "Server error is retried with backoff",
+			responseStatus: http.StatusInternalServerError,
+			attempts:       2,
+			expectedStatus: service.DeliveryStatusPending,
+			expectedRetry:  2 * time.Minute,
+			expectError:    true,
+		},
+		{
+			name:           "Last attempt failure gives up",
+			responseStatus: http.StatusBadGateway,
+			attempts:       config.DefaultWebhookMaxAttempts - 1,
+			expectedStatus: service.DeliveryStatusFailed,
+			expectError:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recv := &receiver{status: tt.responseStatus}
+			server := httptest.NewServer(recv)
+			defer server.Close()
+
+			delivery := newDelivery(server.URL, tt.attempts)
+
+			repoMocked := repository.NewQuerierMocked(t)
+			repoMocked.On("ClaimDueWebhookDeliveries", mock.Anything, mock.Anything).
+				Return([]repository.ClaimDueWebhookDeliveriesRow{delivery}, nil)
+			repoMocked.On("RecordWebhookDeliveryAttempt", mock.Anything, mock.MatchedBy(func(arg repository.RecordWebhookDeliveryAttemptParams) bool {
+				return arg.ID == delivery.ID &&
+					arg.Status == tt.expectedStatus &&
+					arg.Attempts == tt.attempts+1 &&
+					arg.RetryAfterSeconds == tt.expectedRetry.Seconds() &&
+					arg.LastResponseStatus.Int32 == int32(tt.responseStatus) &&
+					arg.LastError.Valid == tt.expectError
+			})).Return(nil)
+
+			dispatcher := webhook.NewDispatcher(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+			processed, err := dispatcher.DispatchDue(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, 1, processed)
+
+			require.Len(t, recv.requests, 1)
+			req := recv.requests[0]
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+			assert.Equal(t, delivery.ID.String(), req.Header.Get(webhook.DeliveryHeader))
+			assert.Equal(t, service.EventPackageCreated, req.Header.Get(webhook.EventHeader))
+			assert.JSONEq(t, string(delivery.Payload), string(recv.bodies[0]))
+			assert.True(t, recv.valid[0], "signature should verify with the subscription secret")
+		})
+	}
+}
+
+func TestDispatcher_UnreachableEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	delivery := newDelivery(url, 0)
+
+	repoMocked := repository.NewQuerierMocked(t)
+	repoMocked.On("ClaimDueWebhookDeliveries", mock.Anything, mock.Anything).
+		Return([]repository.ClaimDueWebhookDeliveriesRow{delivery}, nil)
+	repoMocked.On("RecordWebhookDeliveryAttempt", mock.Anything, mock.MatchedBy(func(arg repository.RecordWebhookDeliveryAttemptParams) bool {
+		return arg.Status == service.DeliveryStatusPending &&
+			arg.Attempts == 1 &&
+			arg.RetryAfterSeconds == 30 &&
+			!arg.LastResponseStatus.Valid &&
+			arg.LastError.Valid
+	})).Return(nil)
+
+	dispatcher := webhook.NewDispatcher(repoMocked, config.Config{}, zap.NewNop().Sugar())
+
+	_, err := dispatcher.DispatchDue(context.Background())
+	require.NoError(t, err)
+}
+
+func TestSignAndVerify(t *testing.T) {
+	body := []byte(`{"tipo":"package.created"}`)
+
+	signature := webhook.Sign(testSecret, 1700000000, body)
+	// HMAC-SHA256 of "1700000000.<body>", computed outside Go
+	assert.Equal(t, "sha256=b5fb77a89012c873d2ebe20ef295ac3bd6a911ed0e5ca5d27d5fe49dec4a51d9", signature)
+
+	assert.True(t, webhook.Verify(testSecret, 1700000000, body, signature))
+	assert.False(t, webhook.Verify("outro-segredo", 1700000000, body, signature))
+	assert.False(t, webhook.Verify(testSecret, 1700000001, body, signature))
+	assert.False(t, webhook.Verify(testSecret, 1700000000, []byte(`{"tipo":"package.deleted"}`), signature))
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 30*time.Second, webhook.Backoff(1))
+	assert.Equal(t, time.Minute, webhook.Backoff(2))
+	assert.Equal(t, 8*time.Minute, webhook.Backoff(5))
+	assert.Equal(t, 6*time.Hour, webhook.Backoff(20))
+}
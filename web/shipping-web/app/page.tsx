@@ -8,8 +8,7 @@ import { QuotesSection } from "@/components/quotes-section"
 import { TrackingSection } from "@/components/tracking-section"
 import { HireCarrierSection } from "@/components/hire-carrier-section"
 import type { Package, Carrier, State } from "@/types"
-
-const API_BASE_URL = process.env.NEXT_PUBLIC_API_BASE_URL || "http://localhost:8080"
+import { apiFetch } from "@/lib/api"
 
 export default function Home() {
   const [packages, setPackages] = useState<Package[]>([])
@@ -24,8 +23,8 @@ export default function Home() {
   const loadInitialData = async () => {
     try {
       const [carriersRes, statesRes] = await Promise.all([
-        fetch(`${API_BASE_URL}/api/v1/carriers`),
-        fetch(`${API_BASE_URL}/api/v1/states`),
+        apiFetch(`/api/v1/carriers`),
+        apiFetch(`/api/v1/states`),
       ])
 
       if (carriersRes.ok) {
@@ -46,7 +45,7 @@ export default function Home() {
 
   const loadPackages = async () => {
     try {
-      const response = await fetch(`${API_BASE_URL}/api/v1/packages`)
+      const response = await apiFetch(`/api/v1/packages`)
       if (response.ok) {
         const data = await response.json()
         setPackages(data.data || [])
@@ -117,7 +116,7 @@ export default function Home() {
               </CardDescription>
             </CardHeader>
             <CardContent>
-              <TrackingSection carriers={carriers} />
+              <TrackingSection />
             </CardContent>
           </Card>
         </TabsContent>
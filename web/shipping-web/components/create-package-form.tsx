@@ -9,10 +9,9 @@ import { Label } from "@/components/ui/label"
 import { Select, SelectContent, SelectItem, SelectTrigger, SelectValue } from "@/components/ui/select"
 import { useToast } from "@/hooks/use-toast"
 import type { State } from "@/types"
+import { apiFetch } from "@/lib/api"
 
 
-const API_BASE_URL = process.env.NEXT_PUBLIC_API_BASE_URL || "http://localhost:8080"
-
 interface CreatePackageFormProps {
   states: State[]
   onPackageCreated: () => void
@@ -32,7 +31,7 @@ export function CreatePackageForm({ states, onPackageCreated }: CreatePackageFor
     setLoading(true)
 
     try {
-      const response = await fetch(`${API_BASE_URL}/api/v1/packages`, {
+      const response = await apiFetch(`/api/v1/packages`, {
         method: "POST",
         headers: {
           "Content-Type": "application/json",
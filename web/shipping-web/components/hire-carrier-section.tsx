@@ -11,8 +11,7 @@ import { Badge } from "@/components/ui/badge"
 import { useToast } from "@/hooks/use-toast"
 import type { Package, Carrier } from "@/types"
 import { Truck, PackageIcon, DollarSign, Calendar, MapPin, AlertCircle } from "lucide-react"
-
-const API_BASE_URL = process.env.NEXT_PUBLIC_API_BASE_URL || "http://localhost:8080"
+import { apiFetch } from "@/lib/api"
 
 interface HireCarrierSectionProps {
   packages: Package[]
@@ -61,18 +60,14 @@ export function HireCarrierSection({ packages, carriers, onPackageUpdated, onLoa
 
     setLoadingQuote(true)
     try {
-      const params = new URLSearchParams({
-        estado_destino: packageData.estado_destino,
-        peso_kg: packageData.peso_kg.toString(),
+      // Cotações salvas do pacote: o id da cotação é usado na contratação
+      const response = await apiFetch(`/api/v1/packages/${packageData.id}/quotes`, {
+        method: "POST",
       })
-
-      const response = await fetch(`${API_BASE_URL}/api/v1/quotes?${params}`)
       if (response.ok) {
         const data = await response.json()
-        const quotes = data.data || []
-        const carrierQuote = quotes.find((q: any) =>
-          carriers.find((c) => c.id === carrierId && c.nome === q.transportadora),
-        )
+        const quotes = data.data?.cotacoes || []
+        const carrierQuote = quotes.find((q: any) => q.transportadora_id === carrierId)
 
         if (carrierQuote) {
           setAutoQuote(carrierQuote)
@@ -108,15 +103,13 @@ export function HireCarrierSection({ packages, carriers, onPackageUpdated, onLoa
     setLoading(true)
 
     try {
-      const response = await fetch(`${API_BASE_URL}/api/v1/packages/${selectedPackageId}/hire`, {
+      const response = await apiFetch(`/api/v1/packages/${selectedPackageId}/hire`, {
         method: "POST",
         headers: {
           "Content-Type": "application/json",
         },
         body: JSON.stringify({
-          transportadora_id: selectedCarrierId,
-          preco: autoQuote.preco_estimado.toString(),
-          prazo_dias: autoQuote.prazo_estimado_dias,
+          cotacao_id: autoQuote.id,
         }),
       })
 
@@ -13,8 +13,7 @@ import { useToast } from "@/hooks/use-toast"
 import type { Package, Carrier, State } from "@/types"
 import { Truck, PackageIcon, Calendar, DollarSign, Plus } from "lucide-react"
 import { CreatePackageForm } from "@/components/create-package-form"
-
-const API_BASE_URL = process.env.NEXT_PUBLIC_API_BASE_URL || "http://localhost:8080"
+import { apiFetch } from "@/lib/api"
 
 interface PackagesListProps {
   packages: Package[]
@@ -72,18 +71,14 @@ export function PackagesList({
 
     setLoadingQuote(true)
     try {
-      const params = new URLSearchParams({
-        estado_destino: packageData.estado_destino,
-        peso_kg: packageData.peso_kg.toString(),
+      // Cotações salvas do pacote: o id da cotação é usado na contratação
+      const response = await apiFetch(`/api/v1/packages/${packageData.id}/quotes`, {
+        method: "POST",
       })
-
-      const response = await fetch(`${API_BASE_URL}/api/v1/quotes?${params}`)
       if (response.ok) {
         const data = await response.json()
-        const quotes = data.data || []
-        const carrierQuote = quotes.find((q: any) =>
-          carriers.find((c) => c.id === carrierId && c.nome === q.transportadora),
-        )
+        const quotes = data.data?.cotacoes || []
+        const carrierQuote = quotes.find((q: any) => q.transportadora_id === carrierId)
 
         if (carrierQuote) {
           setHireData({ ...hireData, autoQuote: carrierQuote })
@@ -99,7 +94,7 @@ export function PackagesList({
   const updatePackageStatus = async (packageId: string, status: string) => {
     setLoading(true)
     try {
-      const response = await fetch(`${API_BASE_URL}/api/v1/packages/${packageId}/status`, {
+      const response = await apiFetch(`/api/v1/packages/${packageId}/status`, {
         method: "PATCH",
         headers: {
           "Content-Type": "application/json",
@@ -144,15 +139,13 @@ export function PackagesList({
 
     setLoading(true)
     try {
-      const response = await fetch(`${API_BASE_URL}/api/v1/packages/${packageId}/hire`, {
+      const response = await apiFetch(`/api/v1/packages/${packageId}/hire`, {
         method: "POST",
         headers: {
           "Content-Type": "application/json",
         },
         body: JSON.stringify({
-          transportadora_id: hireData.carrier_id,
-          preco: hireData.autoQuote.preco_estimado.toString(),
-          prazo_dias: hireData.autoQuote.prazo_estimado_dias,
+          cotacao_id: hireData.autoQuote.id,
         }),
       })
 
@@ -184,7 +177,7 @@ export function PackagesList({
 
   const getPackageDetails = async (packageId: string) => {
     try {
-      const response = await fetch(`${API_BASE_URL}/api/v1/packages/${packageId}`)
+      const response = await apiFetch(`/api/v1/packages/${packageId}`)
       if (response.ok) {
         const data = await response.json()
         setDetailsPackage(data.data)
@@ -11,8 +11,7 @@ import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card"
 import { useToast } from "@/hooks/use-toast"
 import type { State, Quote } from "@/types"
 import { Truck, Clock, DollarSign } from "lucide-react"
-
-const API_BASE_URL = process.env.NEXT_PUBLIC_API_BASE_URL || "http://localhost:8080"
+import { apiFetch } from "@/lib/api"
 
 interface QuotesSectionProps {
   states: State[]
@@ -37,13 +36,14 @@ export function QuotesSection({ states }: QuotesSectionProps) {
         peso_kg: formData.peso_kg,
       })
 
-      const response = await fetch(`${API_BASE_URL}/api/v1/quotes?${params}`)
+      const response = await apiFetch(`/api/v1/quotes?${params}`)
 
       if (response.ok) {
         const data = await response.json()
-        setQuotes(data.data || [])
+        const cotacoes = data.data?.cotacoes || []
+        setQuotes(cotacoes)
 
-        if (!data.data || data.data.length === 0) {
+        if (cotacoes.length === 0) {
           toast({
             title: "Aviso",
             description: "Nenhuma cotação encontrada para os parâmetros informados.",
@@ -129,7 +129,11 @@ export function QuotesSection({ states }: QuotesSectionProps) {
                   </div>
                   <div className="flex items-center gap-2 text-blue-600">
                     <Clock className="h-4 w-4" />
-                    <span>{quote.prazo_estimado_dias} dias</span>
+                    <span>
+                      {quote.prazo_estimado_dias} dias úteis
+                      {quote.data_estimada_entrega &&
+                        ` (até ${quote.data_estimada_entrega.split("-").reverse().join("/")})`}
+                    </span>
                   </div>
                 </CardContent>
               </Card>
@@ -9,14 +9,9 @@ import { Label } from "@/components/ui/label"
 import { Card, CardContent, CardHeader, CardTitle } from "@/components/ui/card"
 import { Badge } from "@/components/ui/badge"
 import { useToast } from "@/hooks/use-toast"
-import type { Package, Carrier } from "@/types"
-import { Search, PackageIcon, Truck, Calendar, DollarSign, MapPin } from "lucide-react"
-
-const API_BASE_URL = process.env.NEXT_PUBLIC_API_BASE_URL || "http://localhost:8080"
-
-interface TrackingSectionProps {
-  carriers: Carrier[]
-}
+import type { Tracking } from "@/types"
+import { Search, PackageIcon, Truck, Calendar, MapPin, Clock } from "lucide-react"
+import { apiFetch } from "@/lib/api"
 
 const statusColors = {
   criado: "bg-blue-100 text-blue-800",
@@ -36,9 +31,9 @@ const statusLabels = {
   extraviado: "Extraviado",
 }
 
-export function TrackingSection({ carriers }: TrackingSectionProps) {
+export function TrackingSection() {
   const [trackingCode, setTrackingCode] = useState("")
-  const [packageData, setPackageData] = useState<Package | null>(null)
+  const [packageData, setPackageData] = useState<Tracking | null>(null)
   const [loading, setLoading] = useState(false)
   const [searched, setSearched] = useState(false)
   const { toast } = useToast()
@@ -58,7 +53,7 @@ export function TrackingSection({ carriers }: TrackingSectionProps) {
     setSearched(true)
 
     try {
-      const response = await fetch(`${API_BASE_URL}/api/v1/packages/tracking/${trackingCode}`)
+      const response = await apiFetch(`/api/v1/tracking/${encodeURIComponent(trackingCode.trim())}`)
 
       if (response.ok) {
         const data = await response.json()
@@ -91,11 +86,6 @@ export function TrackingSection({ carriers }: TrackingSectionProps) {
     }
   }
 
-  const getCarrierName = (carrierId: string) => {
-    const carrier = carriers.find((c) => c.id === carrierId)
-    return carrier?.nome || "Transportadora não identificada"
-  }
-
   return (
     <div className="space-y-6">
       <form onSubmit={searchPackage} className="space-y-4 max-w-md">
@@ -106,7 +96,7 @@ export function TrackingSection({ carriers }: TrackingSectionProps) {
               id="tracking-code"
               value={trackingCode}
               onChange={(e) => setTrackingCode(e.target.value)}
-              placeholder="Ex: PKG123456789"
+              placeholder="Ex: NB473124829BR"
               className="flex-1"
             />
             <Button type="submit" disabled={loading}>
@@ -143,11 +133,6 @@ export function TrackingSection({ carriers }: TrackingSectionProps) {
           <CardContent className="space-y-6">
             <div className="grid grid-cols-1 md:grid-cols-2 gap-6">
               <div className="space-y-4">
-                <div>
-                  <Label className="text-sm font-medium text-muted-foreground">Produto</Label>
-                  <p className="text-lg font-semibold">{packageData.produto}</p>
-                </div>
-
                 <div>
                   <Label className="text-sm font-medium text-muted-foreground">Código de Rastreamento</Label>
                   <p className="text-sm font-mono bg-muted px-2 py-1 rounded inline-block">
@@ -155,18 +140,14 @@ export function TrackingSection({ carriers }: TrackingSectionProps) {
                   </p>
                 </div>
 
-                <div className="flex gap-4">
-                  <div>
-                    <Label className="text-sm font-medium text-muted-foreground">Peso</Label>
-                    <p className="text-sm">{packageData.peso_kg}kg</p>
-                  </div>
-                  <div>
-                    <Label className="text-sm font-medium text-muted-foreground">Destino</Label>
-                    <p className="text-sm flex items-center gap-1">
-                      <MapPin className="h-3 w-3" />
-                      {packageData.estado_destino}
-                    </p>
-                  </div>
+                <div>
+                  <Label className="text-sm font-medium text-muted-foreground">Destino</Label>
+                  <p className="text-sm flex items-center gap-1">
+                    <MapPin className="h-3 w-3" />
+                    {packageData.cidade_destino
+                      ? `${packageData.cidade_destino} - ${packageData.estado_destino}`
+                      : packageData.estado_destino}
+                  </p>
                 </div>
               </div>
 
@@ -184,52 +165,45 @@ export function TrackingSection({ carriers }: TrackingSectionProps) {
                   </div>
                 </div>
 
-                <div>
-                  <Label className="text-sm font-medium text-muted-foreground">Criado em</Label>
-                  <p className="text-sm">
-                    {packageData.criado_em ? new Date(packageData.criado_em).toLocaleString("pt-BR") : "N/A"}
-                  </p>
-                </div>
+                {packageData.transportadora && (
+                  <div>
+                    <Label className="text-sm font-medium text-muted-foreground">Transportadora</Label>
+                    <p className="text-sm flex items-center gap-1">
+                      <Truck className="h-3 w-3" />
+                      {packageData.transportadora}
+                    </p>
+                  </div>
+                )}
 
-                <div>
-                  <Label className="text-sm font-medium text-muted-foreground">Última Atualização</Label>
-                  <p className="text-sm">
-                    {packageData.atualizado_em ? new Date(packageData.atualizado_em).toLocaleString("pt-BR") : "N/A"}
-                  </p>
-                </div>
+                {packageData.data_estimada_entrega && (
+                  <div>
+                    <Label className="text-sm font-medium text-muted-foreground">Previsão de Entrega</Label>
+                    <p className="text-sm flex items-center gap-1">
+                      <Calendar className="h-3 w-3" />
+                      {packageData.data_estimada_entrega.split("-").reverse().join("/")}
+                    </p>
+                  </div>
+                )}
               </div>
             </div>
 
-            {packageData.transportadora_id && (
+            {packageData.eventos && packageData.eventos.length > 0 && (
               <div className="border-t pt-4">
-                <h4 className="font-semibold mb-3 flex items-center gap-2 text-green-700">
-                  <Truck className="h-4 w-4" />
-                  Transportadora Contratada
+                <h4 className="font-semibold mb-3 flex items-center gap-2">
+                  <Clock className="h-4 w-4" />
+                  Histórico
                 </h4>
-                <div className="grid grid-cols-1 md:grid-cols-3 gap-4 bg-green-50 p-4 rounded-lg">
-                  <div>
-                    <Label className="text-sm font-medium text-muted-foreground">Transportadora</Label>
-                    <p className="text-sm font-semibold">{getCarrierName(packageData.transportadora_id)}</p>
-                  </div>
-                  {packageData.preco_contratado && (
-                    <div>
-                      <Label className="text-sm font-medium text-muted-foreground">Preço Contratado</Label>
-                      <p className="text-sm font-semibold text-green-600 flex items-center gap-1">
-                        <DollarSign className="h-3 w-3" />
-                        R$ {packageData.preco_contratado}
+                <ol className="space-y-3">
+                  {packageData.eventos.map((event, index) => (
+                    <li key={index} className="border-l-2 pl-4">
+                      <p className="text-sm font-medium">{event.descricao}</p>
+                      <p className="text-xs text-muted-foreground">
+                        {event.ocorrido_em ? new Date(event.ocorrido_em).toLocaleString("pt-BR") : "N/A"}
+                        {event.local && ` · ${event.local}`}
                       </p>
-                    </div>
-                  )}
-                  {packageData.prazo_contratado_dias && (
-                    <div>
-                      <Label className="text-sm font-medium text-muted-foreground">Prazo de Entrega</Label>
-                      <p className="text-sm flex items-center gap-1">
-                        <Calendar className="h-3 w-3" />
-                        {packageData.prazo_contratado_dias} dias
-                      </p>
-                    </div>
-                  )}
-                </div>
+                    </li>
+                  ))}
+                </ol>
               </div>
             )}
           </CardContent>
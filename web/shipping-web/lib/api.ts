@@ -0,0 +1,10 @@
+const API_BASE_URL = process.env.NEXT_PUBLIC_API_BASE_URL || "http://localhost:8080"
+const API_KEY = process.env.NEXT_PUBLIC_API_KEY
+
+export function apiFetch(path: string, init: RequestInit = {}) {
+  const headers = new Headers(init.headers)
+  if (API_KEY && !headers.has("Authorization")) {
+    headers.set("Authorization", `Bearer ${API_KEY}`)
+  }
+  return fetch(`${API_BASE_URL}${path}`, { ...init, headers })
+}
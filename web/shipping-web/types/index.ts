@@ -8,6 +8,11 @@ export interface Package {
   transportadora_id?: string
   preco_contratado?: string
   prazo_contratado_dias?: number
+  contratado_em?: string
+  data_estimada_entrega?: string
+  armazem_origem_id?: string
+  cep_destino?: string
+  cidade_destino?: string
   criado_em?: string
   atualizado_em?: string
 }
@@ -16,6 +21,8 @@ export interface Quote {
   transportadora?: string
   preco_estimado?: number
   prazo_estimado_dias?: number
+  data_estimada_entrega?: string
+  sobretaxa_cep?: number
 }
 
 export interface Carrier {
@@ -24,8 +31,33 @@ export interface Carrier {
   criado_em?: string
 }
 
+export interface Warehouse {
+  id?: string
+  nome?: string
+  estado?: string
+  criado_em?: string
+}
+
 export interface State {
   codigo?: string
   nome?: string
   nome_regiao?: string
 }
+
+export interface TrackingEvent {
+  tipo?: string
+  status?: string
+  descricao?: string
+  local?: string
+  ocorrido_em?: string
+}
+
+export interface Tracking {
+  codigo_rastreio?: string
+  status?: string
+  transportadora?: string
+  estado_destino?: string
+  cidade_destino?: string
+  data_estimada_entrega?: string
+  eventos?: TrackingEvent[]
+}